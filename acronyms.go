@@ -0,0 +1,137 @@
+package dymean
+
+import (
+	"strings"
+	"unicode"
+)
+
+// defaultAcronyms contains common acronyms and abbreviations with their expansions.
+// An empty expansion means the acronym is accepted but has no suggested expansion.
+var defaultAcronyms = map[string]string{
+	// Acronyms
+	"AI":    "artificial intelligence",
+	"API":   "application programming interface",
+	"ASAP":  "as soon as possible",
+	"ATM":   "automated teller machine",
+	"CEO":   "chief executive officer",
+	"CPU":   "central processing unit",
+	"CSS":   "cascading style sheets",
+	"DIY":   "do it yourself",
+	"DNA":   "deoxyribonucleic acid",
+	"DNS":   "domain name system",
+	"ETA":   "estimated time of arrival",
+	"EU":    "European Union",
+	"FAQ":   "frequently asked questions",
+	"FBI":   "Federal Bureau of Investigation",
+	"FYI":   "for your information",
+	"GDP":   "gross domestic product",
+	"GPS":   "global positioning system",
+	"GPU":   "graphics processing unit",
+	"HTML":  "hypertext markup language",
+	"HTTP":  "hypertext transfer protocol",
+	"HTTPS": "hypertext transfer protocol secure",
+	"ID":    "identifier",
+	"IP":    "internet protocol",
+	"JSON":  "JavaScript object notation",
+	"ML":    "machine learning",
+	"NASA":  "National Aeronautics and Space Administration",
+	"NATO":  "North Atlantic Treaty Organization",
+	"OS":    "operating system",
+	"PDF":   "portable document format",
+	"PIN":   "personal identification number",
+	"RAM":   "random access memory",
+	"ROM":   "read-only memory",
+	"RSVP":  "please respond",
+	"SQL":   "structured query language",
+	"TBD":   "to be determined",
+	"UK":    "United Kingdom",
+	"UN":    "United Nations",
+	"URL":   "uniform resource locator",
+	"USA":   "United States of America",
+	"USB":   "universal serial bus",
+	"XML":   "extensible markup language",
+
+	// Abbreviations
+	"approx.": "approximately",
+	"dept.":   "department",
+	"e.g.":    "for example",
+	"etc.":    "et cetera",
+	"govt.":   "government",
+	"i.e.":    "that is",
+	"misc.":   "miscellaneous",
+	"no.":     "number",
+	"vs.":     "versus",
+}
+
+// AddAcronyms adds acronyms or abbreviations with their expansions to the acronym layer.
+// Use an empty expansion for acronyms that should only be accepted.
+func (dym *DidYouMean) AddAcronyms(acronyms map[string]string) {
+	dym.mu.Lock()
+	defer dym.mu.Unlock()
+	for acronym, expansion := range acronyms {
+		dym.acronyms[acronymKey(acronym)] = expansion
+	}
+}
+
+// SetAcronymAwareness enables or disables accepting known acronyms as correct words
+func (dym *DidYouMean) SetAcronymAwareness(enabled bool) {
+	dym.acronymsEnabled = enabled
+}
+
+// SetExpandAbbreviations enables or disables suggesting expansions for known abbreviations
+func (dym *DidYouMean) SetExpandAbbreviations(enabled bool) {
+	dym.expandAbbreviations = enabled
+}
+
+// IsAcronym reports whether a token is a known acronym or abbreviation.
+// Only ALL-CAPS tokens and tokens containing a period are considered, so
+// lowercase words are still checked against the regular dictionary.
+func (dym *DidYouMean) IsAcronym(word string) bool {
+	word = strings.TrimSpace(word)
+	if !looksLikeAcronym(word) {
+		return false
+	}
+	dym.mu.RLock()
+	defer dym.mu.RUnlock()
+	_, exists := dym.acronyms[acronymKey(word)]
+	return exists
+}
+
+// ExpandAbbreviation returns the expansion of a known acronym or abbreviation
+func (dym *DidYouMean) ExpandAbbreviation(word string) (string, bool) {
+	word = strings.TrimSpace(word)
+	if !looksLikeAcronym(word) {
+		return "", false
+	}
+	dym.mu.RLock()
+	expansion, exists := dym.acronyms[acronymKey(word)]
+	dym.mu.RUnlock()
+	if !exists || expansion == "" {
+		return "", false
+	}
+	return expansion, true
+}
+
+// acronymKey returns the lookup key for an acronym or abbreviation
+func acronymKey(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
+
+// looksLikeAcronym checks if a token is written in ALL-CAPS or as a dotted abbreviation
+func looksLikeAcronym(word string) bool {
+	if strings.Contains(word, ".") {
+		return true
+	}
+
+	letters := 0
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			if !unicode.IsUpper(r) {
+				return false
+			}
+			letters++
+		}
+	}
+
+	return letters >= 2
+}
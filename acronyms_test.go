@@ -0,0 +1,90 @@
+package dymean_test
+
+import (
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"sync"
+	"testing"
+)
+
+// TestAcronymAwareness tests that known acronyms are not flagged
+func TestAcronymAwareness(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.LoadDefaultDictionary(dymean.English)
+
+	for _, word := range []string{"NASA", "CEO", "FYI"} {
+		if !dym.IsCorrect(word) {
+			t.Errorf("Expected acronym '%s' to be correct", word)
+		}
+	}
+
+	// Lowercase forms are still checked against the dictionary
+	if dym.IsCorrect("nasa") {
+		t.Error("Expected lowercase 'nasa' to be checked against the dictionary")
+	}
+
+	// Unknown ALL-CAPS tokens are still flagged
+	if dym.IsCorrect("QZXW") {
+		t.Error("Expected unknown acronym 'QZXW' to be incorrect")
+	}
+
+	dym.AddAcronyms(map[string]string{"QZXW": ""})
+	if !dym.IsCorrect("QZXW") {
+		t.Error("Expected custom acronym 'QZXW' to be correct")
+	}
+
+	dym.SetAcronymAwareness(false)
+	if dym.IsCorrect("NASA") {
+		t.Error("Expected 'NASA' to be incorrect with acronym awareness disabled")
+	}
+}
+
+// TestAbbreviationExpansion tests suggesting expansions for abbreviations
+func TestAbbreviationExpansion(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.LoadDefaultDictionary(dymean.English)
+
+	expansion, ok := dym.ExpandAbbreviation("approx.")
+	if !ok || expansion != "approximately" {
+		t.Errorf("Expected 'approx.' to expand to 'approximately', got '%s'", expansion)
+	}
+
+	// Known abbreviations are accepted as-is unless expansion is enabled
+	if suggestions := dym.GetSuggestions("approx.", 3, 2); len(suggestions) != 1 || suggestions[0].Word != "approx." {
+		t.Errorf("Expected 'approx.' to be returned unchanged by default, got %v", suggestions)
+	}
+
+	dym.SetExpandAbbreviations(true)
+	suggestions := dym.GetSuggestions("approx.", 3, 2)
+	if len(suggestions) == 0 || suggestions[0].Word != "approximately" {
+		t.Errorf("Expected 'approximately' suggestion for 'approx.', got %v", suggestions)
+	}
+}
+
+// TestAddAcronymsConcurrent tests adding acronyms while texts are checked
+func TestAddAcronymsConcurrent(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithAutomatonSearch())
+	dym.AddWords([]string{"the", "team"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				dym.AddAcronyms(map[string]string{fmt.Sprintf("QX%dZ%d", i, j): ""})
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				dym.CheckText("the QXZ team, e.g. the SRE team")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !dym.IsAcronym("QX3Z99") {
+		t.Error("Expected every added acronym to be known")
+	}
+}
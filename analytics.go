@@ -0,0 +1,148 @@
+package dymean
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Misspelling is what the analytics collector knows of a misspelled input
+type Misspelling struct {
+	Input      string   `json:"input"`      // Normalized input
+	Language   Language `json:"language"`   // Language it was looked up in
+	Suggestion string   `json:"suggestion"` // Latest top suggestion
+	Chosen     string   `json:"chosen"`     // Latest correction accepted by a user, if any
+	Count      int      `json:"count"`      // Lookups, an upper bound once entries were evicted
+	Accepted   int      `json:"accepted"`   // Suggestions accepted by users
+	Rejected   int      `json:"rejected"`   // Suggestions rejected by users
+}
+
+// analytics collects misspellings in bounded memory. When full, the least
+// frequent entry makes room for a new input, which inherits its count (the
+// Space-Saving algorithm), so frequent misspellings are kept and their counts
+// overestimate by at most the evicted count.
+type analytics struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[harvestKey]*Misspelling
+}
+
+// WithAnalytics collects the misspelled inputs of suggestion lookups with
+// their top suggestion, and the suggestions users accepted or rejected
+// through RecordFeedback and LearnCorrection, keeping up to capacity inputs.
+// TopMisspellings and ExportAnalytics show what users mistype most.
+func WithAnalytics(capacity int) Option {
+	return func(dym *DidYouMean) {
+		if capacity <= 0 {
+			dym.analytics = nil
+			return
+		}
+		dym.analytics = &analytics{capacity: capacity, entries: make(map[harvestKey]*Misspelling)}
+	}
+}
+
+// entry returns the entry of an input, making room for it when new. The
+// caller must hold the lock.
+func (a *analytics) entry(input string, lang Language) *Misspelling {
+	key := harvestKey{word: input, lang: lang}
+	if entry, ok := a.entries[key]; ok {
+		return entry
+	}
+
+	entry := &Misspelling{Input: input, Language: lang}
+	if len(a.entries) >= a.capacity {
+		var evicted harvestKey
+		var least *Misspelling
+		for key, candidate := range a.entries {
+			if least == nil || candidate.Count < least.Count ||
+				(candidate.Count == least.Count && (key.lang < evicted.lang || (key.lang == evicted.lang && key.word < evicted.word))) {
+				evicted, least = key, candidate
+			}
+		}
+		delete(a.entries, evicted)
+		entry.Count = least.Count
+	}
+	a.entries[key] = entry
+	return entry
+}
+
+// recordLookup records the suggestions of a lookup of a misspelled input
+func (dym *DidYouMean) recordLookup(input string, lang Language, suggestions []Suggestion) {
+	if dym.analytics == nil || len(suggestions) == 0 || suggestions[0].Word == input {
+		return
+	}
+	dym.analytics.mu.Lock()
+	defer dym.analytics.mu.Unlock()
+	entry := dym.analytics.entry(input, lang)
+	entry.Count++
+	entry.Suggestion = suggestions[0].Word
+}
+
+// recordChoice records a suggestion accepted or rejected for an input
+func (dym *DidYouMean) recordChoice(input, suggestion string, accepted bool, lang Language) {
+	if dym.analytics == nil {
+		return
+	}
+	dym.analytics.mu.Lock()
+	defer dym.analytics.mu.Unlock()
+	entry := dym.analytics.entry(input, lang)
+	if accepted {
+		entry.Accepted++
+		entry.Chosen = suggestion
+	} else {
+		entry.Rejected++
+	}
+}
+
+// TopMisspellings returns the n most frequent misspelled inputs collected
+// with WithAnalytics, most frequent first, or all of them for n <= 0
+func (dym *DidYouMean) TopMisspellings(n int) []Misspelling {
+	misspellings := make([]Misspelling, 0)
+	if dym.analytics == nil {
+		return misspellings
+	}
+
+	dym.analytics.mu.Lock()
+	for _, entry := range dym.analytics.entries {
+		misspellings = append(misspellings, *entry)
+	}
+	dym.analytics.mu.Unlock()
+
+	sort.Slice(misspellings, func(i, j int) bool {
+		if misspellings[i].Count != misspellings[j].Count {
+			return misspellings[i].Count > misspellings[j].Count
+		}
+		if misspellings[i].Language != misspellings[j].Language {
+			return misspellings[i].Language < misspellings[j].Language
+		}
+		return misspellings[i].Input < misspellings[j].Input
+	})
+	if n > 0 && len(misspellings) > n {
+		misspellings = misspellings[:n]
+	}
+	return misspellings
+}
+
+// ExportAnalytics writes the collected misspellings as JSON lines, most
+// frequent first, for a dashboard or a spreadsheet
+func (dym *DidYouMean) ExportAnalytics(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	for _, misspelling := range dym.TopMisspellings(0) {
+		if err := encoder.Encode(misspelling); err != nil {
+			return fmt.Errorf("export analytics: %w", err)
+		}
+	}
+	return nil
+}
+
+// ResetAnalytics forgets the collected misspellings
+func (dym *DidYouMean) ResetAnalytics() {
+	if dym.analytics == nil {
+		return
+	}
+	dym.analytics.mu.Lock()
+	defer dym.analytics.mu.Unlock()
+	dym.analytics.entries = make(map[harvestKey]*Misspelling)
+}
@@ -0,0 +1,52 @@
+package dymean_test
+
+import (
+	"bytes"
+	"github.com/bi0dread/dymean"
+	"strings"
+	"testing"
+)
+
+// TestAnalytics tests collecting the most frequent misspellings
+func TestAnalytics(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithAnalytics(2))
+	dym.AddWords([]string{"hello", "world", "receive"})
+
+	for i := 0; i < 3; i++ {
+		dym.GetSuggestions("recieve", 5, 2)
+	}
+	dym.GetSuggestions("Helo", 5, 2)
+	dym.GetSuggestions("hello", 5, 2) // Correct words are not collected
+	dym.RecordFeedback("recieve", "receive", true)
+	dym.RecordFeedback("recieve", "relieve", false)
+
+	top := dym.TopMisspellings(0)
+	if len(top) != 2 {
+		t.Fatalf("Expected 2 misspellings, got %v", top)
+	}
+	want := dymean.Misspelling{Input: "recieve", Language: dymean.English, Suggestion: "receive", Chosen: "receive", Count: 3, Accepted: 1, Rejected: 1}
+	if top[0] != want || top[1].Input != "helo" || top[1].Count != 1 {
+		t.Errorf("Unexpected misspellings %v", top)
+	}
+
+	// A new input replaces the least frequent one, inheriting its count
+	dym.GetSuggestions("wrld", 5, 2)
+	top = dym.TopMisspellings(5)
+	if len(top) != 2 || top[1].Input != "wrld" || top[1].Count != 2 {
+		t.Errorf("Expected wrld to replace helo, got %v", top)
+	}
+
+	var buf bytes.Buffer
+	if err := dym.ExportAnalytics(&buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"input":"recieve"`) {
+		t.Errorf("Unexpected export %q", buf.String())
+	}
+
+	dym.ResetAnalytics()
+	if top := dym.TopMisspellings(0); len(top) != 0 {
+		t.Errorf("Expected no misspellings after a reset, got %v", top)
+	}
+}
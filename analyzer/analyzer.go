@@ -0,0 +1,417 @@
+// Package analyzer provides a go/analysis Analyzer that spell checks Go
+// comments and string literals with dymean, for use with go vet, staticcheck,
+// golangci-lint or the dymean-vet command.
+//
+// Identifiers are split into words before checking, so "parseHTTPRequst" in
+// a comment is reported for "Requst" only. Words naming identifiers of the
+// package, all-caps acronyms, URLs, doc links, code blocks in doc comments,
+// format verbs and escape sequences are not checked.
+package analyzer
+
+import (
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// StringMode selects which string literals are checked
+type StringMode string
+
+const (
+	StringsNone  StringMode = "none"  // Only comments are checked
+	StringsCalls StringMode = "calls" // Literals passed to Config.StringFuncs
+	StringsAll   StringMode = "all"   // Every string literal
+)
+
+// DefaultStringFuncs are the functions whose string literal arguments are
+// checked in StringsCalls mode: messages that reach users and logs
+var DefaultStringFuncs = []string{
+	"errors.New",
+	"fmt.Errorf", "fmt.Sprintf", "fmt.Printf", "fmt.Println", "fmt.Print", "fmt.Fprintf", "fmt.Fprintln",
+	"log.Printf", "log.Println", "log.Print", "log.Fatalf", "log.Fatal", "log.Panicf",
+	"(*log.Logger).Printf", "(*log.Logger).Println", "(*log.Logger).Print",
+	"log/slog.Info", "log/slog.Warn", "log/slog.Error", "log/slog.Debug",
+}
+
+// Config configures an analyzer built with New
+type Config struct {
+	// Checker holds the dictionary to check against. It is only read, so
+	// one checker can serve every package of a run.
+	Checker *dymean.DidYouMean
+
+	// Language is the language of comments and strings, English by default
+	Language dymean.Language
+
+	// Strings selects which string literals are checked, StringsCalls by default
+	Strings StringMode
+
+	// StringFuncs lists the functions, by types.Func FullName, whose string
+	// literal arguments are checked in StringsCalls mode. DefaultStringFuncs
+	// is used when empty.
+	StringFuncs []string
+}
+
+// Analyzer spell checks comments and selected string literals against the
+// embedded English dictionary, plus the words of the files given with -dict
+var Analyzer = newFlagAnalyzer()
+
+// New returns an analyzer checking against the dictionary of cfg.Checker
+func New(cfg Config) *analysis.Analyzer {
+	if cfg.Language == "" {
+		cfg.Language = dymean.English
+	}
+	if cfg.Strings == "" {
+		cfg.Strings = StringsCalls
+	}
+	if len(cfg.StringFuncs) == 0 {
+		cfg.StringFuncs = DefaultStringFuncs
+	}
+	return &analysis.Analyzer{
+		Name:     "dymean",
+		Doc:      "report misspelled words in comments and string literals",
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			return nil, run(pass, cfg)
+		},
+	}
+}
+
+// newFlagAnalyzer returns the analyzer configured with command-line flags.
+// The checker is built on first use, after the flags are parsed.
+func newFlagAnalyzer() *analysis.Analyzer {
+	var (
+		lang    string
+		dicts   string
+		strs    string
+		once    sync.Once
+		cfg     Config
+		loadErr error
+	)
+
+	a := New(Config{})
+	a.Flags.StringVar(&lang, "lang", string(dymean.English), "language of comments and strings")
+	a.Flags.StringVar(&dicts, "dict", "", "comma-separated word lists (one word per line) to accept in addition to the embedded dictionary")
+	a.Flags.StringVar(&strs, "strings", string(StringsCalls), "string literals to check: none, calls or all")
+
+	a.Run = func(pass *analysis.Pass) (interface{}, error) {
+		once.Do(func() {
+			cfg, loadErr = flagConfig(dymean.Language(lang), dicts, StringMode(strs))
+		})
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		return nil, run(pass, cfg)
+	}
+	return a
+}
+
+// flagConfig builds the configuration of the flag-driven analyzer
+func flagConfig(lang dymean.Language, dicts string, strs StringMode) (Config, error) {
+	switch strs {
+	case StringsNone, StringsCalls, StringsAll:
+	default:
+		return Config{}, fmt.Errorf("dymean: -strings must be none, calls or all, got %q", strs)
+	}
+
+	dym := dymean.NewDidYouMean(1000000, 7)
+	dym.SetLanguage(lang)
+	if err := dym.LoadDefaultDictionary(lang); err != nil && dicts == "" {
+		return Config{}, fmt.Errorf("dymean: %w; provide word lists with -dict", err)
+	}
+	for _, path := range strings.Split(dicts, ",") {
+		if path == "" {
+			continue
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("dymean: %w", err)
+		}
+		err = dym.LoadDictionaryFromReader(file, lang)
+		file.Close()
+		if err != nil {
+			return Config{}, fmt.Errorf("dymean: %s: %w", path, err)
+		}
+	}
+
+	return Config{Checker: dym, Language: lang, Strings: strs, StringFuncs: DefaultStringFuncs}, nil
+}
+
+// run checks the comments and string literals of a package
+func run(pass *analysis.Pass, cfg Config) error {
+	if cfg.Checker == nil {
+		return fmt.Errorf("dymean: no checker configured")
+	}
+	c := &checker{pass: pass, cfg: cfg, identifiers: packageIdentifiers(pass)}
+	funcs := make(map[string]bool, len(cfg.StringFuncs))
+	for _, name := range cfg.StringFuncs {
+		funcs[name] = true
+	}
+
+	for _, file := range pass.Files {
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				c.checkComment(comment)
+			}
+		}
+	}
+
+	if cfg.Strings == StringsNone {
+		return nil
+	}
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	switch cfg.Strings {
+	case StringsAll:
+		inspect.Preorder([]ast.Node{(*ast.BasicLit)(nil)}, func(n ast.Node) {
+			if lit := n.(*ast.BasicLit); lit.Kind == token.STRING {
+				c.checkString(lit)
+			}
+		})
+	case StringsCalls:
+		inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+			call := n.(*ast.CallExpr)
+			fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+			if !ok || !funcs[fn.FullName()] {
+				return
+			}
+			for _, arg := range call.Args {
+				if lit, ok := astutil.Unparen(arg).(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					c.checkString(lit)
+				}
+			}
+		})
+	}
+	return nil
+}
+
+// packageIdentifiers collects every identifier of a package, so comments may
+// name them freely
+func packageIdentifiers(pass *analysis.Pass) map[string]bool {
+	identifiers := make(map[string]bool)
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok {
+				identifiers[ident.Name] = true
+			}
+			return true
+		})
+	}
+	return identifiers
+}
+
+// checker reports the misspelled words of one package
+type checker struct {
+	pass        *analysis.Pass
+	cfg         Config
+	identifiers map[string]bool
+}
+
+var (
+	// commentDirective matches comments that are tool directives, not prose
+	commentDirective = regexp.MustCompile(`^//(go:|line |export |extern |nolint|lint:|\+build)`)
+	// codeLike matches URLs, paths, doc links and dotted references
+	codeLike = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://\S+|\[[^\]\s]+\]|\S*[./\\]\S*[a-zA-Z]\S*`)
+	// formatVerb matches fmt verbs and their flags
+	formatVerb = regexp.MustCompile(`%[-+# 0-9.*\[\]]*[a-zA-Z%]`)
+)
+
+// checkComment checks a comment, skipping directives and the indented code
+// blocks of doc comments
+func (c *checker) checkComment(comment *ast.Comment) {
+	if commentDirective.MatchString(comment.Text) {
+		return
+	}
+	text := comment.Text
+	ignore := matchRanges(text, codeLike)
+
+	offset := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		body := strings.TrimPrefix(strings.TrimPrefix(line, "//"), "/*")
+		if strings.HasPrefix(body, "\t") || strings.HasPrefix(body, "  ") {
+			ignore = append(ignore, dymean.TextRange{Start: offset, End: offset + len(line)})
+		}
+		offset += len(line)
+	}
+
+	c.checkText(comment.Pos(), text, ignore)
+}
+
+// checkString checks a string literal. Escape sequences and format verbs are
+// blanked out, keeping offsets relative to the literal as written.
+func (c *checker) checkString(lit *ast.BasicLit) {
+	blanks := matchRanges(lit.Value, formatVerb)
+	if strings.HasPrefix(lit.Value, `"`) {
+		for i := 0; i < len(lit.Value); i++ {
+			if lit.Value[i] == '\\' {
+				n := escapeLength(lit.Value[i:])
+				blanks = append(blanks, dymean.TextRange{Start: i, End: i + n})
+				i += n - 1
+			}
+		}
+	}
+	text := blank(lit.Value, blanks)
+	c.checkText(lit.Pos(), text, matchRanges(text, codeLike))
+}
+
+// blank replaces the bytes of the ranges with spaces
+func blank(text string, ranges []dymean.TextRange) string {
+	if len(ranges) == 0 {
+		return text
+	}
+	b := []byte(text)
+	for _, r := range ranges {
+		for i := r.Start; i < r.End; i++ {
+			b[i] = ' '
+		}
+	}
+	return string(b)
+}
+
+// escapeLength returns the length of the escape sequence at the start of s
+func escapeLength(s string) int {
+	if len(s) < 2 {
+		return len(s)
+	}
+	n := 2
+	switch s[1] {
+	case 'x':
+		n = 4
+	case 'u':
+		n = 6
+	case 'U':
+		n = 10
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		n = 4
+	}
+	if n > len(s) {
+		n = len(s)
+	}
+	return n
+}
+
+// matchRanges returns the ranges of text matched by re
+func matchRanges(text string, re *regexp.Regexp) []dymean.TextRange {
+	matches := re.FindAllStringIndex(text, -1)
+	ranges := make([]dymean.TextRange, 0, len(matches))
+	for _, m := range matches {
+		ranges = append(ranges, dymean.TextRange{Start: m[0], End: m[1]})
+	}
+	return ranges
+}
+
+// checkText reports the misspelled words of a comment or literal starting at pos
+func (c *checker) checkText(pos token.Pos, text string, ignore []dymean.TextRange) {
+	dym, lang := c.cfg.Checker, c.cfg.Language
+	distance := dym.SuggestionTuning(lang).MaxEditDistance
+	for _, tok := range dymean.Tokenize(text) {
+		if overlaps(ignore, tok.Start, tok.End) || c.identifiers[tok.Text] {
+			continue
+		}
+		for _, part := range splitIdentifier(tok) {
+			if skipWord(part.Text) || dym.IsCorrectForLanguage(part.Text, lang) {
+				continue
+			}
+			c.report(pos, part, dym.GetSuggestionsForLanguage(part.Text, 1, distance, lang))
+		}
+	}
+}
+
+// report reports a misspelled word with its best suggestion as a fix
+func (c *checker) report(pos token.Pos, word dymean.Token, suggestions []dymean.Suggestion) {
+	start, end := pos+token.Pos(word.Start), pos+token.Pos(word.End)
+	if len(suggestions) == 0 {
+		c.pass.Report(analysis.Diagnostic{Pos: start, End: end, Message: fmt.Sprintf("%s is not in the dictionary", strconv.Quote(word.Text))})
+		return
+	}
+	replacement := matchCapital(word.Text, suggestions[0].Word)
+	c.pass.Report(analysis.Diagnostic{
+		Pos:     start,
+		End:     end,
+		Message: fmt.Sprintf("%s is misspelled, did you mean %s?", strconv.Quote(word.Text), strconv.Quote(replacement)),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "Replace with " + strconv.Quote(replacement),
+			TextEdits: []analysis.TextEdit{{Pos: start, End: end, NewText: []byte(replacement)}},
+		}},
+	})
+}
+
+// overlaps checks if a span overlaps any of the ranges
+func overlaps(ranges []dymean.TextRange, start, end int) bool {
+	for _, r := range ranges {
+		if start < r.End && r.Start < end {
+			return true
+		}
+	}
+	return false
+}
+
+// skipWord reports words that are not checked: acronyms, words with digits
+// and words too short to spell wrong
+func skipWord(word string) bool {
+	if utf8.RuneCountInString(word) < 3 {
+		return true
+	}
+	upper := true
+	for _, r := range word {
+		if unicode.IsDigit(r) {
+			return true
+		}
+		if unicode.IsLower(r) {
+			upper = false
+		}
+	}
+	return upper
+}
+
+// matchCapital capitalizes a replacement when the word was capitalized
+func matchCapital(word, replacement string) string {
+	first, _ := utf8.DecodeRuneInString(word)
+	if !unicode.IsUpper(first) {
+		return replacement
+	}
+	r, size := utf8.DecodeRuneInString(replacement)
+	return string(unicode.ToUpper(r)) + replacement[size:]
+}
+
+// splitIdentifier splits a camelCase, PascalCase or acronym-prefixed token
+// into its words: "parseHTTPRequest" gives "parse", "HTTP" and "Request"
+func splitIdentifier(tok dymean.Token) []dymean.Token {
+	runes := []rune(tok.Text)
+	parts := make([]dymean.Token, 0, 1)
+	start, offset, startOffset := 0, 0, 0
+	for i, r := range runes {
+		if i > 0 && wordBoundary(runes, i) {
+			parts = append(parts, dymean.Token{Text: string(runes[start:i]), Start: tok.Start + startOffset, End: tok.Start + offset})
+			start, startOffset = i, offset
+		}
+		offset += utf8.RuneLen(r)
+	}
+	return append(parts, dymean.Token{Text: string(runes[start:]), Start: tok.Start + startOffset, End: tok.End})
+}
+
+// wordBoundary checks if a new word of an identifier starts at rune i
+func wordBoundary(runes []rune, i int) bool {
+	prev, cur := runes[i-1], runes[i]
+	switch {
+	case unicode.IsLower(prev) && unicode.IsUpper(cur):
+		return true // camel|Case
+	case unicode.IsUpper(prev) && unicode.IsUpper(cur):
+		return i+1 < len(runes) && unicode.IsLower(runes[i+1]) // HTTP|Request
+	case unicode.IsDigit(prev) != unicode.IsDigit(cur):
+		return true // utf|8
+	}
+	return false
+}
@@ -0,0 +1,22 @@
+package analyzer_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"github.com/bi0dread/dymean/analyzer"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"testing"
+)
+
+// TestAnalyzer tests comments and string literals in a package
+func TestAnalyzer(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{
+		"package", "is", "checked", "by", "the", "analyzer", "tests", "reads", "body", "request",
+		"see", "and", "for", "format", "note", "below", "wrong", "hello", "world",
+		"could", "not", "read", "names", "an", "identifier", "of", "parse",
+		"get", "want", "misspelled", "did", "you", "mean", // The expectations themselves
+	})
+
+	a := analyzer.New(analyzer.Config{Checker: dym})
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "a")
+}
@@ -0,0 +1,19 @@
+// Command dymean-vet spell checks Go comments and string literals. Run it
+// directly or as a vet tool:
+//
+//	dymean-vet ./...
+//	go vet -vettool=$(which dymean-vet) ./...
+//
+// Flags select the language (-lang), extra word lists (-dict) and which
+// string literals are checked (-strings). Run it with -fix to apply the best
+// suggestion for each misspelling.
+package main
+
+import (
+	"github.com/bi0dread/dymean/analyzer"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
@@ -0,0 +1,17 @@
+module github.com/bi0dread/dymean/analyzer
+
+go 1.22.0
+
+require (
+	github.com/bi0dread/dymean v0.0.0
+	golang.org/x/tools v0.26.0
+)
+
+require (
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The analyzer is versioned with the checker it wraps
+replace github.com/bi0dread/dymean => ../
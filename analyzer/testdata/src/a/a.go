@@ -0,0 +1,28 @@
+// Package a is checked by the analyzer tests
+package a
+
+import (
+	"errors"
+	"fmt"
+)
+
+// parseRequest reads the body for getRequst // want `"Req.st" is misspelled, did you mean "Request"\?`
+func parseRequest() error {
+	// See https://exmple.com/dcs and [fmt.Prntln] for the format
+	//
+	//	code smaple in a doc comment
+	//
+	// Note: the helo below is wrong // want `"he.o" is misspelled, did you mean "hello"\?`
+	fmt.Println("hello %s\nwrld", "world") // want `"wr.d" is misspelled, did you mean "world"\?`
+	return errors.New("could not read the body")
+}
+
+//go:generate nonsense qwxz
+
+var label = "not chekced by default"
+
+// ParseHTTPRequest names an identifier of the package
+func ParseHTTPRequest() {
+	_ = parseRequest
+	_ = label
+}
@@ -0,0 +1,191 @@
+package dymean
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// loadBatchSize is the number of words added per lock acquisition by
+// asynchronous loads, so lookups keep being served while a load runs
+const loadBatchSize = 1000
+
+// LoadProgress reports the state of an asynchronous load
+type LoadProgress struct {
+	Language    Language
+	WordsLoaded int
+	TotalWords  int // 0 when the total is unknown, as for readers
+}
+
+// Percent returns the completed percentage, or 0 when the total is unknown
+func (p LoadProgress) Percent() float64 {
+	if p.TotalWords == 0 {
+		return 0
+	}
+	return 100 * float64(p.WordsLoaded) / float64(p.TotalWords)
+}
+
+// LoadHandle tracks an asynchronous dictionary load
+type LoadHandle struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu       sync.Mutex
+	progress LoadProgress
+	err      error
+}
+
+// Progress returns the current progress of the load
+func (h *LoadHandle) Progress() LoadProgress {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.progress
+}
+
+// Done returns a channel closed when the load completes, fails or is cancelled
+func (h *LoadHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Wait blocks until the load finishes and returns its error
+func (h *LoadHandle) Wait() error {
+	<-h.done
+	return h.Err()
+}
+
+// Err returns the error of a finished load: nil on success, the context error
+// when cancelled, or the read error. It returns nil while the load runs.
+func (h *LoadHandle) Err() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.err
+}
+
+// Cancel stops the load. Words loaded so far stay in the dictionary.
+func (h *LoadHandle) Cancel() {
+	h.cancel()
+}
+
+// LoadAsync adds words to a language's dictionary in the background, in
+// batches so lookups in every language are served meanwhile. onProgress, if
+// not nil, is called from the loading goroutine after every batch. Ready
+// reports a required language as not ready until its loads complete.
+func (dym *DidYouMean) LoadAsync(ctx context.Context, lang Language, words []string, onProgress func(LoadProgress)) *LoadHandle {
+	return dym.startLoad(ctx, lang, len(words), onProgress, func(ctx context.Context, add func([]string)) error {
+		for start := 0; start < len(words); start += loadBatchSize {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			end := start + loadBatchSize
+			if end > len(words) {
+				end = len(words)
+			}
+			add(words[start:end])
+		}
+		return nil
+	})
+}
+
+// LoadDefaultDictionaryAsync loads the default dictionary for a language in
+// the background. When there is none to load, the handle fails with the
+// *NoDefaultDictionaryError LoadDefaultDictionary would return.
+func (dym *DidYouMean) LoadDefaultDictionaryAsync(ctx context.Context, lang Language, onProgress func(LoadProgress)) *LoadHandle {
+	words, err := dym.defaultWords(lang)
+	if err != nil {
+		return dym.startLoad(ctx, lang, 0, onProgress, func(context.Context, func([]string)) error {
+			return err
+		})
+	}
+	dym.SetDictionaryMetadata(lang, embeddedMetadata(lang))
+	return dym.LoadAsync(ctx, lang, words, onProgress)
+}
+
+// LoadDictionaryFromReaderAsync reads a word list in the format of
+// LoadDictionaryFromReader in the background. The total is unknown, so
+// progress only reports the words loaded.
+func (dym *DidYouMean) LoadDictionaryFromReaderAsync(ctx context.Context, r io.Reader, lang Language, onProgress func(LoadProgress)) *LoadHandle {
+	return dym.startLoad(ctx, lang, 0, onProgress, func(ctx context.Context, add func([]string)) error {
+		scanner := bufio.NewScanner(r)
+		batch := make([]string, 0, loadBatchSize)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			batch = append(batch, line)
+			if len(batch) == loadBatchSize {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				add(batch)
+				batch = make([]string, 0, loadBatchSize)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("read dictionary: %w", err)
+		}
+		if len(batch) > 0 {
+			add(batch)
+		}
+		return nil
+	})
+}
+
+// startLoad runs load in a new goroutine, tracking progress on a handle and
+// the pending load for Ready
+func (dym *DidYouMean) startLoad(ctx context.Context, lang Language, total int, onProgress func(LoadProgress), load func(ctx context.Context, add func([]string)) error) *LoadHandle {
+	ctx, cancel := context.WithCancel(ctx)
+	handle := &LoadHandle{
+		cancel:   cancel,
+		done:     make(chan struct{}),
+		progress: LoadProgress{Language: lang, TotalWords: total},
+	}
+
+	dym.mu.Lock()
+	dym.pendingLoads[lang]++
+	dym.mu.Unlock()
+
+	go func() {
+		defer close(handle.done)
+		defer cancel()
+		defer dym.DeferCompaction(lang)()
+
+		err := load(ctx, func(words []string) {
+			dym.AddWordsForLanguage(words, lang)
+
+			handle.mu.Lock()
+			handle.progress.WordsLoaded += len(words)
+			progress := handle.progress
+			handle.mu.Unlock()
+
+			if onProgress != nil {
+				onProgress(progress)
+			}
+		})
+
+		dym.mu.Lock()
+		dym.pendingLoads[lang]--
+		dym.mu.Unlock()
+
+		if err != nil {
+			dym.logger.Error("background dictionary load stopped",
+				"language", lang, "words_loaded", handle.Progress().WordsLoaded, "error", err)
+		}
+
+		handle.mu.Lock()
+		handle.err = err
+		handle.mu.Unlock()
+	}()
+
+	return handle
+}
+
+// isLoading reports whether asynchronous loads for a language are running
+func (dym *DidYouMean) isLoading(lang Language) bool {
+	dym.mu.RLock()
+	defer dym.mu.RUnlock()
+	return dym.pendingLoads[lang] > 0
+}
@@ -0,0 +1,89 @@
+package dymean_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"io"
+	"testing"
+)
+
+// TestLoadAsync tests background loading with progress reporting
+func TestLoadAsync(t *testing.T) {
+	words := make([]string, 2500)
+	for i := range words {
+		words[i] = fmt.Sprintf("word%c%c%c", 'a'+i%26, 'a'+i/26%26, 'a'+i/676)
+	}
+
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWordsForLanguage([]string{"bonjour"}, dymean.French)
+
+	progress := make([]dymean.LoadProgress, 0)
+	handle := dym.LoadAsync(context.Background(), dymean.English, words, func(p dymean.LoadProgress) {
+		progress = append(progress, p)
+	})
+
+	// Other languages are served while loading
+	if !dym.IsCorrectForLanguage("bonjour", dymean.French) {
+		t.Error("Expected French to be served during the English load")
+	}
+
+	if err := handle.Wait(); err != nil {
+		t.Fatalf("Expected load to succeed, got %v", err)
+	}
+	if len(progress) != 3 || progress[2].WordsLoaded != 2500 || progress[2].Percent() != 100 {
+		t.Errorf("Unexpected progress reports %+v", progress)
+	}
+	if final := handle.Progress(); final.WordsLoaded != 2500 || final.TotalWords != 2500 {
+		t.Errorf("Unexpected final progress %+v", final)
+	}
+	if !dym.IsCorrect("wordaaa") || dym.GetWordCount(dymean.English) != 2500 {
+		t.Error("Expected all words to be loaded")
+	}
+}
+
+// TestLoadAsyncReadinessAndCancel tests readiness during a load and cancellation
+func TestLoadAsyncReadinessAndCancel(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithRequiredLanguages(dymean.English))
+	reader, writer := io.Pipe()
+
+	firstBatch := make(chan struct{}, 1)
+	handle := dym.LoadDictionaryFromReaderAsync(context.Background(), reader, dymean.English, func(p dymean.LoadProgress) {
+		select {
+		case firstBatch <- struct{}{}:
+		default:
+		}
+	})
+
+	for i := 0; i < 1500; i++ {
+		fmt.Fprintf(writer, "word%c%c\n", 'a'+i%26, 'a'+i/26%26)
+	}
+	<-firstBatch
+
+	if err := dym.Ready(); !errors.Is(err, dymean.ErrNotReady) {
+		t.Errorf("Expected not ready while loading, got %v", err)
+	}
+	if !dym.IsCorrect("wordaa") {
+		t.Error("Expected words of finished batches to be served")
+	}
+
+	handle.Cancel()
+	go func() {
+		for i := 0; i < 1000; i++ {
+			fmt.Fprintf(writer, "more%c%c\n", 'a'+i%26, 'a'+i/26%26)
+		}
+		writer.Close()
+	}()
+
+	if err := handle.Wait(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected cancelled load, got %v", err)
+	}
+	reader.Close() // Unblock the remaining writes
+	if err := dym.Ready(); err != nil {
+		t.Errorf("Expected ready after the load stopped, got %v", err)
+	}
+	if progress := handle.Progress(); progress.WordsLoaded != 1000 || progress.Percent() != 0 {
+		t.Errorf("Unexpected progress %+v", progress)
+	}
+}
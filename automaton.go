@@ -0,0 +1,202 @@
+package dymean
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// LevenshteinAutomaton accepts the strings within a maximum edit distance of
+// a word. A state is a row of the Levenshtein matrix between the word and the
+// input read so far, with distances above maxDistance capped, so there are
+// finitely many states and the automaton is deterministic. Distances count
+// runes, unlike LevenshteinDistance, which counts bytes.
+type LevenshteinAutomaton struct {
+	word        []rune
+	maxDistance int
+}
+
+// NewLevenshteinAutomaton creates an automaton for the strings within
+// maxDistance edits of word
+func NewLevenshteinAutomaton(word string, maxDistance int) *LevenshteinAutomaton {
+	return &LevenshteinAutomaton{word: []rune(word), maxDistance: maxDistance}
+}
+
+// Start returns the state before any input is read
+func (a *LevenshteinAutomaton) Start() []int {
+	state := make([]int, len(a.word)+1)
+	for i := range state {
+		state[i] = a.capped(i)
+	}
+	return state
+}
+
+// Step returns the state after reading r in state
+func (a *LevenshteinAutomaton) Step(state []int, r rune) []int {
+	next := make([]int, len(state))
+	next[0] = a.capped(state[0] + 1)
+	for i := 1; i < len(state); i++ {
+		cost := 1
+		if a.word[i-1] == r {
+			cost = 0
+		}
+		next[i] = a.capped(min(state[i]+1, next[i-1]+1, state[i-1]+cost))
+	}
+	return next
+}
+
+// IsMatch reports whether the input read so far is within the maximum distance
+func (a *LevenshteinAutomaton) IsMatch(state []int) bool {
+	return state[len(state)-1] <= a.maxDistance
+}
+
+// CanMatch reports whether some continuation of the input read so far can
+// still be within the maximum distance
+func (a *LevenshteinAutomaton) CanMatch(state []int) bool {
+	for _, distance := range state {
+		if distance <= a.maxDistance {
+			return true
+		}
+	}
+	return false
+}
+
+// Distance returns the edit distance of the input read so far, or
+// maxDistance+1 when it is farther
+func (a *LevenshteinAutomaton) Distance(state []int) int {
+	return state[len(state)-1]
+}
+
+// capped limits a distance to maxDistance+1
+func (a *LevenshteinAutomaton) capped(distance int) int {
+	if distance > a.maxDistance+1 {
+		return a.maxDistance + 1
+	}
+	return distance
+}
+
+// Trie is a rune trie of words that can enumerate the words within an edit
+// distance of a query by intersecting it with a LevenshteinAutomaton. The
+// search visits only the prefixes that can still match, instead of generating
+// every edit of the query.
+type Trie struct {
+	root trieNode
+	size int
+}
+
+// trieNode is a node of a Trie. Children are kept sorted by rune.
+type trieNode struct {
+	keys     []rune
+	children []*trieNode
+	word     bool // A word ends here
+}
+
+// TrieMatch is a word found by a Trie search with its edit distance in runes
+type TrieMatch struct {
+	Word     string
+	Distance int
+}
+
+// NewTrie creates a trie containing words
+func NewTrie(words []string) *Trie {
+	t := &Trie{}
+	for _, word := range words {
+		t.Insert(word)
+	}
+	return t
+}
+
+// Insert adds a word to the trie
+func (t *Trie) Insert(word string) {
+	node := &t.root
+	for _, r := range word {
+		i := sort.Search(len(node.keys), func(i int) bool { return node.keys[i] >= r })
+		if i == len(node.keys) || node.keys[i] != r {
+			node.keys = append(node.keys, 0)
+			copy(node.keys[i+1:], node.keys[i:])
+			node.keys[i] = r
+			node.children = append(node.children, nil)
+			copy(node.children[i+1:], node.children[i:])
+			node.children[i] = &trieNode{}
+		}
+		node = node.children[i]
+	}
+	if !node.word {
+		node.word = true
+		t.size++
+	}
+}
+
+// Len returns the number of words in the trie
+func (t *Trie) Len() int {
+	return t.size
+}
+
+// Search returns the words within maxDistance edits of word, in sorted order
+func (t *Trie) Search(word string, maxDistance int) []TrieMatch {
+	return t.search(word, maxDistance, false)
+}
+
+// search implements Search, only visiting the words starting with the first
+// rune of word when sameFirst is set
+func (t *Trie) search(word string, maxDistance int, sameFirst bool) []TrieMatch {
+	automaton := NewLevenshteinAutomaton(word, maxDistance)
+	matches := make([]TrieMatch, 0)
+	prefix := make([]rune, 0, len(word)+maxDistance)
+	first, _ := utf8.DecodeRuneInString(word)
+
+	var walk func(node *trieNode, state []int)
+	walk = func(node *trieNode, state []int) {
+		if node.word && automaton.IsMatch(state) {
+			matches = append(matches, TrieMatch{Word: string(prefix), Distance: automaton.Distance(state)})
+		}
+		for i, r := range node.keys {
+			if sameFirst && len(prefix) == 0 && r != first {
+				continue
+			}
+			next := automaton.Step(state, r)
+			if !automaton.CanMatch(next) {
+				continue
+			}
+			prefix = append(prefix, r)
+			walk(node.children[i], next)
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	walk(&t.root, automaton.Start())
+
+	return matches
+}
+
+// automatonCandidates returns the dictionary words within maxEditDistance runes
+// of a normalized word, searching the language's trie; with pruning, only the
+// words sharing its first rune are visited
+func (dym *DidYouMean) automatonCandidates(normalized string, lang Language, maxEditDistance int, pruning *CandidatePruning) []string {
+	matches := dym.trie(lang).search(normalized, maxEditDistance, pruning != nil)
+	candidates := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if match.Word != normalized && (pruning == nil || pruning.keeps(normalized, match.Word)) {
+			candidates = append(candidates, match.Word)
+		}
+	}
+	return candidates
+}
+
+// trie returns the search trie of a language, building it after the
+// dictionary changed
+func (dym *DidYouMean) trie(lang Language) *Trie {
+	dym.mu.RLock()
+	t := dym.tries[lang]
+	dym.mu.RUnlock()
+	if t != nil {
+		return t
+	}
+
+	dym.mu.Lock()
+	defer dym.mu.Unlock()
+	if t := dym.tries[lang]; t != nil {
+		return t
+	}
+	t = NewTrie(dym.dictionaryWords(lang))
+	dym.tries[lang] = t
+	return t
+}
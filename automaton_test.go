@@ -0,0 +1,86 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"testing"
+)
+
+// TestLevenshteinAutomaton tests acceptance of strings within the distance
+func TestLevenshteinAutomaton(t *testing.T) {
+	automaton := dymean.NewLevenshteinAutomaton("hello", 1)
+	tests := []struct {
+		input string
+		match bool
+	}{
+		{"hello", true},
+		{"helo", true},
+		{"hallo", true},
+		{"helloo", true},
+		{"help", false},
+		{"world", false},
+		{"", false},
+	}
+	for _, tc := range tests {
+		state := automaton.Start()
+		for _, r := range tc.input {
+			state = automaton.Step(state, r)
+		}
+		if automaton.IsMatch(state) != tc.match {
+			t.Errorf("Expected match=%v for %q", tc.match, tc.input)
+		}
+	}
+
+	// Dead states cannot match any continuation
+	state := automaton.Start()
+	for _, r := range "xyz" {
+		state = automaton.Step(state, r)
+	}
+	if automaton.CanMatch(state) {
+		t.Error("Expected no continuation of xyz to match")
+	}
+}
+
+// TestTrieSearch tests enumerating the words within an edit distance
+func TestTrieSearch(t *testing.T) {
+	trie := dymean.NewTrie([]string{"hello", "help", "hell", "world", "word", "سلام", "hello"})
+	if trie.Len() != 6 {
+		t.Errorf("Expected 6 words, got %d", trie.Len())
+	}
+
+	expected := []dymean.TrieMatch{{Word: "hell", Distance: 1}, {Word: "hello", Distance: 2}, {Word: "help", Distance: 1}}
+	if matches := trie.Search("helt", 2); !reflect.DeepEqual(matches, expected) {
+		t.Errorf("Expected %v, got %v", expected, matches)
+	}
+
+	// Distances count runes
+	if matches := trie.Search("سلم", 1); len(matches) != 1 || matches[0].Word != "سلام" {
+		t.Errorf("Expected سلام within one edit, got %v", matches)
+	}
+}
+
+// TestWithAutomatonSearch tests suggestions found with the automaton
+func TestWithAutomatonSearch(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithAutomatonSearch())
+	dym.LoadDefaultDictionary(dymean.English)
+	dym.LoadDefaultDictionary(dymean.Persian)
+
+	if suggestions := dym.GetSuggestions("helo", 5, 2); len(suggestions) == 0 || suggestions[0].Word != "hello" {
+		t.Errorf("Expected hello to be suggested, got %v", suggestions)
+	}
+
+	// Substitutions in non-Latin scripts are found too
+	if suggestions := dym.GetSuggestionsForLanguage("سلان", 3, 1, dymean.Persian); len(suggestions) == 0 || suggestions[0].Word != "سلام" {
+		t.Errorf("Expected سلام to be suggested, got %v", suggestions)
+	}
+
+	// The trie follows dictionary changes
+	dym.AddWords([]string{"helium"})
+	if suggestions := dym.GetSuggestions("heliun", 5, 1); len(suggestions) == 0 || suggestions[0].Word != "helium" {
+		t.Errorf("Expected helium to be suggested after it was added, got %v", suggestions)
+	}
+	dym.RemoveWords([]string{"helium"})
+	if suggestions := dym.GetSuggestions("heliun", 5, 1); len(suggestions) != 0 {
+		t.Errorf("Expected no suggestions after helium was removed, got %v", suggestions)
+	}
+}
@@ -0,0 +1,43 @@
+package dymean
+
+import (
+	"runtime"
+)
+
+// WordResult is the outcome of checking one word of a batch
+type WordResult struct {
+	Word        string
+	Correct     bool
+	Suggestions []Suggestion
+}
+
+// concurrency returns the number of workers a call may use: one per CPU
+// available to the process, capped by WithMaxConcurrency
+func (dym *DidYouMean) concurrency() int {
+	workers := runtime.GOMAXPROCS(0)
+	if dym.maxConcurrency > 0 && dym.maxConcurrency < workers {
+		workers = dym.maxConcurrency
+	}
+	return workers
+}
+
+// CheckWords checks a batch of words in the current language in parallel and
+// returns one result per word, in input order
+func (dym *DidYouMean) CheckWords(words []string) []WordResult {
+	return dym.CheckWordsForLanguage(words, dym.currentLang)
+}
+
+// CheckWordsForLanguage checks a batch of words in a specific language in
+// parallel and returns one result per word, in input order
+func (dym *DidYouMean) CheckWordsForLanguage(words []string, lang Language) []WordResult {
+	results := make([]WordResult, len(words))
+	parallelFor(len(words), dym.concurrency(), func(i int) {
+		correct, suggestions := dym.CheckAndSuggestForLanguage(words[i], lang)
+		results[i] = WordResult{
+			Word:        words[i],
+			Correct:     correct,
+			Suggestions: suggestions,
+		}
+	})
+	return results
+}
@@ -0,0 +1,55 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestCheckWords tests batch checking with and without a concurrency cap
+func TestCheckWords(t *testing.T) {
+	words := []string{"hello", "wrold", "world", "helo", "xyzzy"}
+
+	for _, maxConcurrency := range []int{0, 1, 3} {
+		dym := dymean.NewDidYouMean(10000, 7, dymean.WithMaxConcurrency(maxConcurrency))
+		dym.AddWords([]string{"hello", "world", "help"})
+
+		results := dym.CheckWords(words)
+		if len(results) != len(words) {
+			t.Fatalf("Expected %d results, got %d", len(words), len(results))
+		}
+		for i, result := range results {
+			if result.Word != words[i] {
+				t.Errorf("Expected result %d for %q, got %q", i, words[i], result.Word)
+			}
+			if result.Correct != dym.IsCorrect(words[i]) {
+				t.Errorf("Unexpected correctness for %q", words[i])
+			}
+		}
+		if len(results[1].Suggestions) == 0 || results[1].Suggestions[0].Word != "world" {
+			t.Errorf("Expected 'world' for 'wrold', got %v", results[1].Suggestions)
+		}
+	}
+}
+
+// TestCheckTextConcurrencyIndependent tests that parallel checking returns
+// the same corrections as sequential checking
+func TestCheckTextConcurrencyIndependent(t *testing.T) {
+	text := strings.Repeat("The quick brwon fox jumsp over the lazy dgo. ", 20)
+	vocabulary := []string{"the", "quick", "brown", "fox", "jumps", "over", "lazy", "dog"}
+
+	sequential := dymean.NewDidYouMean(10000, 7, dymean.WithMaxConcurrency(1))
+	sequential.AddWords(vocabulary)
+	parallel := dymean.NewDidYouMean(10000, 7)
+	parallel.AddWords(vocabulary)
+
+	expected := sequential.CheckTextForLanguage(text, dymean.English)
+	actual := parallel.CheckTextForLanguage(text, dymean.English)
+	if len(expected) != 60 {
+		t.Fatalf("Expected 60 corrections, got %d", len(expected))
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Error("Expected parallel CheckText to match sequential results")
+	}
+}
@@ -0,0 +1,138 @@
+package benchmarks
+
+import (
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// dictionarySizes and editDistances span the benchmark matrix
+var (
+	dictionarySizes = []int{1000, 10000, 100000}
+	editDistances   = []int{1, 2}
+)
+
+// generateWords returns n distinct pseudo-random lowercase words of 4 to 10
+// letters, the same for every run
+func generateWords(n int, seed int64) []string {
+	rng := rand.New(rand.NewSource(seed))
+	seen := make(map[string]bool, n)
+	words := make([]string, 0, n)
+	for len(words) < n {
+		word := make([]byte, 4+rng.Intn(7))
+		for i := range word {
+			word[i] = byte('a' + rng.Intn(26))
+		}
+		if !seen[string(word)] {
+			seen[string(word)] = true
+			words = append(words, string(word))
+		}
+	}
+	return words
+}
+
+// generateQueries returns misspellings of dictionary words, each with up to
+// maxDistance random edits
+func generateQueries(words []string, n, maxDistance int, seed int64) []string {
+	rng := rand.New(rand.NewSource(seed))
+	queries := make([]string, n)
+	for i := range queries {
+		query := []byte(words[rng.Intn(len(words))])
+		for e := 0; e < 1+rng.Intn(maxDistance); e++ {
+			pos := rng.Intn(len(query))
+			switch rng.Intn(3) {
+			case 0:
+				query = append(query[:pos], query[pos+1:]...)
+			case 1:
+				query = append(query[:pos], append([]byte{byte('a' + rng.Intn(26))}, query[pos:]...)...)
+			default:
+				query[pos] = byte('a' + rng.Intn(26))
+			}
+		}
+		queries[i] = string(query)
+	}
+	return queries
+}
+
+// buildStrategies creates every strategy over the same dictionary
+func buildStrategies(words []string, maxDistance int) map[string]strategy {
+	return map[string]strategy{
+		"naive":     newNaiveStrategy(words),
+		"scan":      &scanStrategy{words: words},
+		"symspell":  newSymSpellStrategy(words, maxDistance),
+		"bktree":    newBKTreeStrategy(words),
+		"trie":      newTrieStrategy(words),
+		"automaton": &automatonStrategy{trie: dymean.NewTrie(words)},
+	}
+}
+
+// TestStrategiesAgree makes sure every strategy finds the same words, so the
+// benchmarks compare equivalent work
+func TestStrategiesAgree(t *testing.T) {
+	words := generateWords(2000, 1)
+	for _, maxDistance := range editDistances {
+		strategies := buildStrategies(words, maxDistance)
+		for _, query := range generateQueries(words, 20, maxDistance, 2) {
+			expected := sorted(strategies["scan"].lookup(query, maxDistance))
+			for name, s := range strategies {
+				if actual := sorted(s.lookup(query, maxDistance)); !reflect.DeepEqual(actual, expected) {
+					t.Errorf("%s(%q, %d) = %v, expected %v", name, query, maxDistance, actual, expected)
+				}
+			}
+		}
+	}
+}
+
+// BenchmarkStrategies reports lookup time per query for every strategy,
+// dictionary size and edit distance
+func BenchmarkStrategies(b *testing.B) {
+	for _, size := range dictionarySizes {
+		words := generateWords(size, 1)
+		for _, maxDistance := range editDistances {
+			queries := generateQueries(words, 100, maxDistance, 2)
+			strategies := buildStrategies(words, maxDistance)
+			for _, name := range []string{"naive", "scan", "symspell", "bktree", "trie", "automaton"} {
+				s := strategies[name]
+				b.Run(fmt.Sprintf("%s/size=%d/distance=%d", name, size, maxDistance), func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						s.lookup(queries[i%len(queries)], maxDistance)
+					}
+				})
+			}
+		}
+	}
+}
+
+// BenchmarkBuild reports the time to build each strategy's index
+func BenchmarkBuild(b *testing.B) {
+	for _, size := range dictionarySizes {
+		words := generateWords(size, 1)
+		b.Run(fmt.Sprintf("naive/size=%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				newNaiveStrategy(words)
+			}
+		})
+		b.Run(fmt.Sprintf("symspell/size=%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				newSymSpellStrategy(words, 2)
+			}
+		})
+		b.Run(fmt.Sprintf("bktree/size=%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				newBKTreeStrategy(words)
+			}
+		})
+		b.Run(fmt.Sprintf("trie/size=%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				newTrieStrategy(words)
+			}
+		})
+		b.Run(fmt.Sprintf("automaton/size=%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dymean.NewTrie(words)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+// Package benchmarks compares suggestion lookup strategies across dictionary
+// sizes and edit distances.
+//
+// It contains no library code; run the suite to get a report for your hardware:
+//
+//	go test -run '^$' -bench . -benchmem ./benchmarks
+//
+// Every strategy finds all dictionary words within a maximum edit distance of
+// a query:
+//
+//	naive     dymean's candidate generation (all edits of the query, looked up in the dictionary)
+//	scan      Levenshtein distance against every dictionary word
+//	symspell  precomputed deletions of every word, intersected with deletions of the query
+//	bktree    Burkhard-Keller tree pruned by the triangle inequality
+//	trie      trie traversal computing one Levenshtein row per node
+//	automaton dymean's rune Trie searched with a LevenshteinAutomaton (WithAutomatonSearch)
+//
+// Candidate generation grows with the alphabet size and the edit distance but
+// not with the dictionary, while the index-based strategies trade build time
+// and memory for lookups that grow with the dictionary instead.
+package benchmarks
@@ -0,0 +1,247 @@
+package benchmarks
+
+import (
+	"github.com/bi0dread/dymean"
+	"sort"
+)
+
+// strategy finds the dictionary words within maxDistance edits of a word
+type strategy interface {
+	lookup(word string, maxDistance int) []string
+}
+
+// naiveStrategy uses dymean's edit candidate generation
+type naiveStrategy struct {
+	dym *dymean.DidYouMean
+}
+
+func newNaiveStrategy(words []string) *naiveStrategy {
+	dym := dymean.NewDidYouMean(uint(len(words)), 7)
+	dym.SetIncludeAlternativesForCorrectWords(true)
+	dym.AddWords(words)
+	return &naiveStrategy{dym: dym}
+}
+
+func (s *naiveStrategy) lookup(word string, maxDistance int) []string {
+	suggestions := s.dym.GetSuggestions(word, int(^uint(0)>>1), maxDistance)
+	result := make([]string, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		if dymean.LevenshteinDistance(word, suggestion.Word) <= maxDistance {
+			result = append(result, suggestion.Word)
+		}
+	}
+	return result
+}
+
+// scanStrategy compares the word with every dictionary word
+type scanStrategy struct {
+	words []string
+}
+
+func (s *scanStrategy) lookup(word string, maxDistance int) []string {
+	result := make([]string, 0)
+	for _, candidate := range s.words {
+		if dymean.LevenshteinDistance(word, candidate) <= maxDistance {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
+
+// symSpellStrategy indexes every deletion of every word up to maxDistance
+type symSpellStrategy struct {
+	deletes     map[string][]string
+	maxDistance int
+}
+
+func newSymSpellStrategy(words []string, maxDistance int) *symSpellStrategy {
+	s := &symSpellStrategy{deletes: make(map[string][]string), maxDistance: maxDistance}
+	for _, word := range words {
+		for variant := range deletions(word, maxDistance) {
+			s.deletes[variant] = append(s.deletes[variant], word)
+		}
+	}
+	return s
+}
+
+func (s *symSpellStrategy) lookup(word string, maxDistance int) []string {
+	seen := make(map[string]bool)
+	result := make([]string, 0)
+	for variant := range deletions(word, maxDistance) {
+		for _, candidate := range s.deletes[variant] {
+			if seen[candidate] {
+				continue
+			}
+			seen[candidate] = true
+			if dymean.LevenshteinDistance(word, candidate) <= maxDistance {
+				result = append(result, candidate)
+			}
+		}
+	}
+	return result
+}
+
+// deletions returns word and every string reachable by deleting up to n bytes
+func deletions(word string, n int) map[string]bool {
+	result := map[string]bool{word: true}
+	frontier := []string{word}
+	for d := 0; d < n; d++ {
+		next := make([]string, 0)
+		for _, w := range frontier {
+			for i := 0; i < len(w); i++ {
+				deleted := w[:i] + w[i+1:]
+				if !result[deleted] {
+					result[deleted] = true
+					next = append(next, deleted)
+				}
+			}
+		}
+		frontier = next
+	}
+	return result
+}
+
+// bkNode is a node of a Burkhard-Keller tree
+type bkNode struct {
+	word     string
+	children map[int]*bkNode
+}
+
+// bkTreeStrategy searches a BK-tree using the triangle inequality
+type bkTreeStrategy struct {
+	root *bkNode
+}
+
+func newBKTreeStrategy(words []string) *bkTreeStrategy {
+	s := &bkTreeStrategy{}
+	for _, word := range words {
+		if s.root == nil {
+			s.root = &bkNode{word: word, children: make(map[int]*bkNode)}
+			continue
+		}
+		node := s.root
+		for {
+			distance := dymean.LevenshteinDistance(word, node.word)
+			if distance == 0 {
+				break
+			}
+			child, ok := node.children[distance]
+			if !ok {
+				node.children[distance] = &bkNode{word: word, children: make(map[int]*bkNode)}
+				break
+			}
+			node = child
+		}
+	}
+	return s
+}
+
+func (s *bkTreeStrategy) lookup(word string, maxDistance int) []string {
+	result := make([]string, 0)
+	if s.root == nil {
+		return result
+	}
+	stack := []*bkNode{s.root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		distance := dymean.LevenshteinDistance(word, node.word)
+		if distance <= maxDistance {
+			result = append(result, node.word)
+		}
+		for d, child := range node.children {
+			if d >= distance-maxDistance && d <= distance+maxDistance {
+				stack = append(stack, child)
+			}
+		}
+	}
+	return result
+}
+
+// trieNode is a node of a byte trie
+type trieNode struct {
+	children map[byte]*trieNode
+	word     string // Set when a dictionary word ends here
+}
+
+// trieStrategy walks a trie keeping one Levenshtein row per node
+type trieStrategy struct {
+	root *trieNode
+}
+
+func newTrieStrategy(words []string) *trieStrategy {
+	s := &trieStrategy{root: &trieNode{children: make(map[byte]*trieNode)}}
+	for _, word := range words {
+		node := s.root
+		for i := 0; i < len(word); i++ {
+			child, ok := node.children[word[i]]
+			if !ok {
+				child = &trieNode{children: make(map[byte]*trieNode)}
+				node.children[word[i]] = child
+			}
+			node = child
+		}
+		node.word = word
+	}
+	return s
+}
+
+func (s *trieStrategy) lookup(word string, maxDistance int) []string {
+	result := make([]string, 0)
+	row := make([]int, len(word)+1)
+	for i := range row {
+		row[i] = i
+	}
+	for c, child := range s.root.children {
+		s.walk(child, c, word, row, maxDistance, &result)
+	}
+	return result
+}
+
+// walk extends the Levenshtein row by one trie byte and descends while the
+// row still has a cell within maxDistance
+func (s *trieStrategy) walk(node *trieNode, c byte, word string, prev []int, maxDistance int, result *[]string) {
+	row := make([]int, len(prev))
+	row[0] = prev[0] + 1
+	best := row[0]
+	for i := 1; i < len(row); i++ {
+		cost := 1
+		if word[i-1] == c {
+			cost = 0
+		}
+		row[i] = min(prev[i]+1, row[i-1]+1, prev[i-1]+cost)
+		if row[i] < best {
+			best = row[i]
+		}
+	}
+
+	if node.word != "" && row[len(row)-1] <= maxDistance {
+		*result = append(*result, node.word)
+	}
+	if best <= maxDistance {
+		for next, child := range node.children {
+			s.walk(child, next, word, row, maxDistance, result)
+		}
+	}
+}
+
+// automatonStrategy intersects dymean's rune trie with a Levenshtein automaton
+type automatonStrategy struct {
+	trie *dymean.Trie
+}
+
+func (s *automatonStrategy) lookup(word string, maxDistance int) []string {
+	matches := s.trie.Search(word, maxDistance)
+	result := make([]string, len(matches))
+	for i, match := range matches {
+		result[i] = match.Word
+	}
+	return result
+}
+
+// sorted returns a sorted copy of words
+func sorted(words []string) []string {
+	result := append([]string(nil), words...)
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,65 @@
+package dymean
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Bidirectional marks that editors insert around and inside RTL words
+const (
+	lrm = '\u200e' // Left-to-right mark
+	rlm = '\u200f' // Right-to-left mark
+	alm = '\u061c' // Arabic letter mark
+)
+
+// Directions of strong letters, used to split mixed RTL/LTR runs
+const (
+	dirNeutral = iota
+	dirLTR
+	dirRTL
+)
+
+// isBidiControl checks if a rune is an invisible bidirectional formatting character
+func isBidiControl(r rune) bool {
+	switch {
+	case r == lrm, r == rlm, r == alm:
+		return true
+	case r >= '\u202a' && r <= '\u202e': // Embeddings, overrides and PDF
+		return true
+	case r >= '\u2066' && r <= '\u2069': // Isolates and PDI
+		return true
+	}
+	return false
+}
+
+// isIgnorable checks if a rune can appear inside a word without changing it:
+// bidi controls and the tatweel used to stretch Arabic script
+func isIgnorable(r rune) bool {
+	return r == tatweel || isBidiControl(r)
+}
+
+// stripIgnorable removes bidi controls and tatweel from a word
+func stripIgnorable(word string) string {
+	if strings.IndexFunc(word, isIgnorable) < 0 {
+		return word
+	}
+	return strings.Map(func(r rune) rune {
+		if isIgnorable(r) {
+			return -1
+		}
+		return r
+	}, word)
+}
+
+// letterDirection returns the direction of a strong letter, or dirNeutral
+// for marks, digits and other runes
+func letterDirection(r rune) int {
+	switch {
+	case !unicode.IsLetter(r):
+		return dirNeutral
+	case unicode.In(r, unicode.Arabic, unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko):
+		return dirRTL
+	default:
+		return dirLTR
+	}
+}
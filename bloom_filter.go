@@ -1,45 +1,49 @@
 package dymean
 
-import (
-	"hash"
-	"hash/fnv"
-)
+import "math"
 
-// BloomFilter represents a probabilistic data structure for membership testing
+// BloomFilter represents a probabilistic data structure for membership testing.
+// Contains is safe for concurrent use; Add must not run concurrently with other calls.
 type BloomFilter struct {
 	bitArray     []bool
 	size         uint
-	hashFuncs    []hash.Hash64
 	numHashFuncs int
 }
 
 // NewBloomFilter creates a new Bloom filter with the specified size and number of hash functions
 func NewBloomFilter(size uint, numHashFuncs int) *BloomFilter {
-	bf := &BloomFilter{
+	return &BloomFilter{
 		bitArray:     make([]bool, size),
 		size:         size,
 		numHashFuncs: numHashFuncs,
-		hashFuncs:    make([]hash.Hash64, numHashFuncs),
 	}
+}
 
-	// Initialize hash functions
-	for i := 0; i < numHashFuncs; i++ {
-		bf.hashFuncs[i] = fnv.New64a()
-	}
+// FNV-1a parameters, as in hash/fnv
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
 
-	return bf
+// index returns the bit of an item for the i-th hash function: the 64-bit
+// FNV-1a hash of the item followed by i as a salt byte. The hash is computed
+// inline, without allocating, so lookups can run concurrently in hot loops.
+func (bf *BloomFilter) index(item string, i int) uint64 {
+	h := uint64(fnvOffset64)
+	for j := 0; j < len(item); j++ {
+		h ^= uint64(item[j])
+		h *= fnvPrime64
+	}
+	// Add salt to create different hash functions
+	h ^= uint64(byte(i))
+	h *= fnvPrime64
+	return h % uint64(bf.size)
 }
 
 // Add adds an item to the Bloom filter
 func (bf *BloomFilter) Add(item string) {
 	for i := 0; i < bf.numHashFuncs; i++ {
-		bf.hashFuncs[i].Reset()
-		bf.hashFuncs[i].Write([]byte(item))
-		// Add salt to create different hash functions
-		bf.hashFuncs[i].Write([]byte{byte(i)})
-		hash := bf.hashFuncs[i].Sum64()
-		index := hash % uint64(bf.size)
-		bf.bitArray[index] = true
+		bf.bitArray[bf.index(item, i)] = true
 	}
 }
 
@@ -47,12 +51,7 @@ func (bf *BloomFilter) Add(item string) {
 // Returns true if the item is possibly in the set, false if definitely not
 func (bf *BloomFilter) Contains(item string) bool {
 	for i := 0; i < bf.numHashFuncs; i++ {
-		bf.hashFuncs[i].Reset()
-		bf.hashFuncs[i].Write([]byte(item))
-		bf.hashFuncs[i].Write([]byte{byte(i)})
-		hash := bf.hashFuncs[i].Sum64()
-		index := hash % uint64(bf.size)
-		if !bf.bitArray[index] {
+		if !bf.bitArray[bf.index(item, i)] {
 			return false
 		}
 	}
@@ -65,3 +64,28 @@ func (bf *BloomFilter) AddWords(words []string) {
 		bf.Add(word)
 	}
 }
+
+// FillRatio returns the share of the filter's bits that are set
+func (bf *BloomFilter) FillRatio() float64 {
+	if bf.size == 0 {
+		return 0
+	}
+	set := 0
+	for _, bit := range bf.bitArray {
+		if bit {
+			set++
+		}
+	}
+	return float64(set) / float64(bf.size)
+}
+
+// FalsePositiveRate estimates the probability that Contains reports an item
+// never added, from the fill ratio: every hash function must hit a set bit
+func (bf *BloomFilter) FalsePositiveRate() float64 {
+	return math.Pow(bf.FillRatio(), float64(bf.numHashFuncs))
+}
+
+// Size returns the number of bits of the filter
+func (bf *BloomFilter) Size() uint {
+	return bf.size
+}
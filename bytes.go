@@ -0,0 +1,54 @@
+package dymean
+
+import (
+	"context"
+	"unsafe"
+)
+
+// bytesView returns a string sharing the memory of b, for lookups that do
+// not retain the string; b must not change while the string is in use
+func bytesView(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return unsafe.String(&b[0], len(b))
+}
+
+// IsCorrectBytes is IsCorrect for a word held in a byte slice, such as a
+// token of a parser working on []byte; see IsCorrectBytesForLanguage
+func (dym *DidYouMean) IsCorrectBytes(word []byte) bool {
+	return dym.IsCorrectBytesForLanguage(word, dym.currentLang)
+}
+
+// IsCorrectBytesForLanguage is IsCorrectForLanguage for a word held in a byte
+// slice. The word is looked up without being copied into a string, so words
+// already in their normalized form, such as lowercase ASCII, are checked
+// without allocating. The slice must not change during the call.
+func (dym *DidYouMean) IsCorrectBytesForLanguage(word []byte, lang Language) bool {
+	return dym.IsCorrectForLanguage(bytesView(word), lang)
+}
+
+// GetSuggestionsRunes is GetSuggestions for a word already split into runes
+// by a tokenizer
+func (dym *DidYouMean) GetSuggestionsRunes(word []rune, maxSuggestions int, maxEditDistance int) []Suggestion {
+	return dym.GetSuggestionsForLanguageRunes(word, maxSuggestions, maxEditDistance, dym.currentLang)
+}
+
+// GetSuggestionsForLanguageRunes is GetSuggestionsForLanguage for a word
+// already split into runes. The word is converted once; suggestions keep
+// their own copy, so the slice can be reused after the call.
+func (dym *DidYouMean) GetSuggestionsForLanguageRunes(word []rune, maxSuggestions int, maxEditDistance int, lang Language) []Suggestion {
+	return dym.GetSuggestionsForLanguageContext(context.Background(), string(word), maxSuggestions, maxEditDistance, lang)
+}
+
+// GetSuggestionsBytes is GetSuggestions for a word held in a byte slice
+func (dym *DidYouMean) GetSuggestionsBytes(word []byte, maxSuggestions int, maxEditDistance int) []Suggestion {
+	return dym.GetSuggestionsForLanguageBytes(word, maxSuggestions, maxEditDistance, dym.currentLang)
+}
+
+// GetSuggestionsForLanguageBytes is GetSuggestionsForLanguage for a word held
+// in a byte slice. The word is converted once; suggestions keep their own
+// copy, so the slice can be reused after the call.
+func (dym *DidYouMean) GetSuggestionsForLanguageBytes(word []byte, maxSuggestions int, maxEditDistance int, lang Language) []Suggestion {
+	return dym.GetSuggestionsForLanguageContext(context.Background(), string(word), maxSuggestions, maxEditDistance, lang)
+}
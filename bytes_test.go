@@ -0,0 +1,35 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestBytesAndRunes tests the byte and rune slice variants of the lookups
+func TestBytesAndRunes(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello", "world"})
+	dym.AddWordsForLanguage([]string{"سلام"}, dymean.Persian)
+
+	if !dym.IsCorrectBytes([]byte("hello")) || !dym.IsCorrectBytes([]byte("Hello")) || dym.IsCorrectBytes([]byte("helo")) {
+		t.Error("Expected byte lookups to match IsCorrect")
+	}
+	if !dym.IsCorrectBytesForLanguage([]byte("سلام"), dymean.Persian) || dym.IsCorrectBytes(nil) {
+		t.Error("Expected byte lookups to match IsCorrectForLanguage")
+	}
+
+	word := []byte("hello")
+	if allocs := testing.AllocsPerRun(100, func() { dym.IsCorrectBytes(word) }); allocs != 0 {
+		t.Errorf("Expected no allocations for a normalized word, got %v", allocs)
+	}
+
+	runes := []rune("wrld")
+	suggestions := dym.GetSuggestionsRunes(runes, 5, 1)
+	runes[0] = 'x'
+	if len(suggestions) == 0 || suggestions[0].Word != "world" {
+		t.Errorf("Expected world, got %v", suggestions)
+	}
+	if suggestions := dym.GetSuggestionsBytes([]byte("helo"), 5, 1); len(suggestions) == 0 || suggestions[0].Word != "hello" {
+		t.Errorf("Expected hello, got %v", suggestions)
+	}
+}
@@ -1,6 +1,8 @@
 package dymean
 
 import (
+	"context"
+	"sort"
 	"strings"
 	"unicode"
 )
@@ -17,25 +19,33 @@ func NewCandidateGenerator() *CandidateGenerator {
 	}
 }
 
-// GenerateCandidates generates possible corrections for a word
+// GenerateCandidates generates possible corrections for a word, in lexical
+// order so that runs with the same input compare equal
 func (cg *CandidateGenerator) GenerateCandidates(word string, maxDistance int) []string {
-	candidates := make(map[string]bool)
-	word = strings.ToLower(word)
-
-	// Generate candidates with different edit distances
-	for distance := 1; distance <= maxDistance; distance++ {
-		cg.generateCandidatesAtDistance(word, distance, candidates)
-	}
+	candidates := getStringSet()
+	defer putStringSet(candidates)
+	cg.generate(word, maxDistance, candidates)
 
 	// Convert map to slice
 	result := make([]string, 0, len(candidates))
 	for candidate := range candidates {
 		result = append(result, candidate)
 	}
+	sort.Strings(result)
 
 	return result
 }
 
+// generate adds the candidates of a word up to an edit distance to a set
+func (cg *CandidateGenerator) generate(word string, maxDistance int, candidates map[string]bool) {
+	word = strings.ToLower(word)
+
+	// Generate candidates with different edit distances
+	for distance := 1; distance <= maxDistance; distance++ {
+		cg.generateCandidatesAtDistance(word, distance, candidates)
+	}
+}
+
 // generateCandidatesAtDistance generates candidates at a specific edit distance
 func (cg *CandidateGenerator) generateCandidatesAtDistance(word string, distance int, candidates map[string]bool) {
 	if distance == 0 {
@@ -90,42 +100,46 @@ func (cg *CandidateGenerator) generateCandidatesAtDistance(word string, distance
 	}
 }
 
-// GenerateCommonTypos generates candidates based on common typing errors
-func (cg *CandidateGenerator) GenerateCommonTypos(word string) []string {
-	candidates := make(map[string]bool)
-	word = strings.ToLower(word)
-
-	// Common keyboard layout for QWERTY
-	keyboard := map[rune][]rune{
-		'q': {'w', 'a'}, 'w': {'q', 'e', 'a', 's'}, 'e': {'w', 'r', 's', 'd'},
-		'r': {'e', 't', 'd', 'f'}, 't': {'r', 'y', 'f', 'g'}, 'y': {'t', 'u', 'g', 'h'},
-		'u': {'y', 'i', 'h', 'j'}, 'i': {'u', 'o', 'j', 'k'}, 'o': {'i', 'p', 'k', 'l'},
-		'p': {'o', 'l'}, 'a': {'q', 'w', 's', 'z'}, 's': {'a', 'w', 'e', 'd', 'x', 'z'},
-		'd': {'s', 'e', 'r', 'f', 'c', 'x'}, 'f': {'d', 'r', 't', 'g', 'v', 'c'},
-		'g': {'f', 't', 'y', 'h', 'b', 'v'}, 'h': {'g', 'y', 'u', 'j', 'n', 'b'},
-		'j': {'h', 'u', 'i', 'k', 'm', 'n'}, 'k': {'j', 'i', 'o', 'l', 'm'},
-		'l': {'k', 'o', 'p'}, 'z': {'a', 's', 'x'}, 'x': {'z', 's', 'd', 'c'},
-		'c': {'x', 'd', 'f', 'v'}, 'v': {'c', 'f', 'g', 'b'}, 'b': {'v', 'g', 'h', 'n'},
-		'n': {'b', 'h', 'j', 'm'}, 'm': {'n', 'j', 'k'},
+// generatedCandidates returns the dictionary words among the edits and
+// keyboard typos of a normalized word, without those pruned when pruning is
+// not nil. The distance is capped at maxGeneratedEditDistance.
+func (dym *DidYouMean) generatedCandidates(ctx context.Context, normalized string, lang Language, maxEditDistance int, pruning *CandidatePruning) []string {
+	if maxEditDistance > maxGeneratedEditDistance {
+		maxEditDistance = maxGeneratedEditDistance
 	}
-
-	// Generate candidates by replacing each character with adjacent keyboard characters
-	for i, char := range word {
-		if neighbors, exists := keyboard[char]; exists {
-			for _, neighbor := range neighbors {
-				candidate := word[:i] + string(neighbor) + word[i+1:]
-				candidates[candidate] = true
-			}
-		}
+	end := dym.startStage(ctx, StageGenerate, lang, normalized)
+	candidates := getStringSet()
+	defer putStringSet(candidates)
+	dym.candidates.generate(normalized, maxEditDistance, candidates)
+
+	// Also include typos on the language's keyboard; the set drops the
+	// duplicates produced by both edit and keyboard typo generation
+	for _, typo := range keyboardTypos(normalized, GetLanguageInfo(lang).KeyboardLayout) {
+		candidates[typo] = true
 	}
+	delete(candidates, normalized)
+	end()
 
-	// Convert map to slice
-	result := make([]string, 0, len(candidates))
+	// Keep the candidates that exist in the dictionary, in lexical order
+	end = dym.startStage(ctx, StageFilter, lang, normalized)
+	validCandidates := make([]string, 0)
 	for candidate := range candidates {
-		result = append(result, candidate)
+		if pruning != nil && !pruning.keeps(normalized, candidate) {
+			continue
+		}
+		if dym.contains(lang, candidate) {
+			validCandidates = append(validCandidates, candidate)
+		}
 	}
+	sort.Strings(validCandidates)
+	end()
+	return validCandidates
+}
 
-	return result
+// GenerateCommonTypos generates candidates based on common typing errors on
+// a QWERTY keyboard
+func (cg *CandidateGenerator) GenerateCommonTypos(word string) []string {
+	return keyboardTypos(strings.ToLower(word), qwertyNeighbors)
 }
 
 // IsValidWord checks if a word contains only valid characters
@@ -0,0 +1,17 @@
+package dymean
+
+// Checker is the spell checking API shared by DidYouMean, the remote client
+// of package client and the fakes of package dymeantest. Code that only
+// checks words and texts can depend on it and be handed any of them.
+type Checker interface {
+	// IsCorrect checks if a word is correct in the current language
+	IsCorrect(word string) bool
+	// Suggest returns the best suggestion for a word, or the word itself
+	Suggest(word string) string
+	// GetSuggestions returns ranked suggestions for a word
+	GetSuggestions(word string, maxSuggestions int, maxEditDistance int) []Suggestion
+	// CheckText reports the issues of a text in the order they appear
+	CheckText(text string) []Correction
+}
+
+var _ Checker = (*DidYouMean)(nil)
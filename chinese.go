@@ -0,0 +1,118 @@
+package dymean
+
+import "strings"
+
+// ChineseVariant is a written form of Chinese
+type ChineseVariant int
+
+const (
+	// ChineseAuto returns suggestions in the variant of the input word:
+	// Traditional when it holds a Traditional-only character, else Simplified
+	ChineseAuto ChineseVariant = iota
+	// ChineseSimplified returns suggestions in Simplified characters
+	ChineseSimplified
+	// ChineseTraditional returns suggestions in Traditional characters
+	ChineseTraditional
+)
+
+// traditionalSimplifiedPairs lists common Traditional characters, each
+// followed by its Simplified form. When several Traditional characters share
+// a Simplified one, the first listed is used to convert back.
+const traditionalSimplifiedPairs = "" +
+	"國国學学說说語语書书車车馬马門门開开關关時时間间問问東东們们個个來来" +
+	"會会對对這这過过還还後后從从為为爲为發发髮发現现見见長长電电話话當当" +
+	"與与動动實实點点體体應应機机樣样經经進进種种員员無无義义頭头氣气麼么" +
+	"業业場场處处產产邊边條条變变聽听讀读寫写買买賣卖錢钱銀银鐵铁飛飞魚鱼" +
+	"鳥鸟龍龙雞鸡雲云風风愛爱歡欢樂乐親亲號号區区華华漢汉韓韩題题網网視视" +
+	"聞闻聯联絡络線线紅红綠绿藍蓝黃黄顏颜務务險险歲岁歷历裡里裏里準准確确" +
+	"認认識识計计劃划畫画圖图館馆飯饭鐘钟錶表腦脑壓压齊齐歸归廣广廠厂醫医" +
+	"藥药療疗護护報报紙纸隊队陽阳陰阴際际雖虽難难離离頁页須须顯显願愿類类" +
+	"總总統统結结給给級级約约終终組组織织紀纪續续練练繼继習习傳传億亿價价" +
+	"僅仅優优儲储兒儿內内兩两農农劉刘則则剛刚創创劇剧勞劳勢势勵励單单嚴严" +
+	"團团圍围園园塊块壞坏聲声夢梦奮奋婦妇孫孙寶宝將将專专尋寻導导層层島岛" +
+	"師师帶带幫帮幹干幾几庫库張张強强彈弹徑径復复複复態态慣惯憶忆戰战戲戏" +
+	"擁拥據据擇择擊击擔担敵敌數数斷断舊旧暫暂極极構构標标橋桥檢检權权歐欧" +
+	"殺杀決决沒没況况測测濟济滿满災灾煙烟熱热燈灯爺爷獨独獲获環环畢毕異异" +
+	"盡尽監监盤盘眾众碼码礎础禮礼禍祸稅税穩稳競竞筆笔節节範范簡简糧粮純纯" +
+	"細细維维緊紧編编縣县罷罢羅罗聖圣職职肅肃膽胆臉脸興兴舉举艦舰藝艺蘇苏" +
+	"蟲虫術术衛卫補补製制覺觉觀观規规記记訪访設设許许診诊證证評评試试詩诗" +
+	"誌志誤误課课調调請请論论諸诸謝谢讓让豐丰貝贝負负財财貨货質质購购費费" +
+	"資资賽赛趕赶跡迹踐践軍军輕轻載载較较輸输轉转辦办運运遠远適适選选遺遗" +
+	"郵邮鄉乡針针錄录錯错鏡镜閱阅闆板陸陆隨随雙双雜杂靈灵響响順顺預预領领" +
+	"飲饮養养餘余驗验鬥斗魯鲁麥麦齒齿龜龟"
+
+// sharedSimplified are Simplified characters that are also Traditional
+// characters of their own ("里" in "公里"), never converted to Traditional
+const sharedSimplified = "后里表干志制范板余斗"
+
+// Conversion tables built from traditionalSimplifiedPairs
+var (
+	traditionalToSimplified = make(map[rune]rune)
+	simplifiedToTraditional = make(map[rune]rune)
+)
+
+func init() {
+	runes := []rune(traditionalSimplifiedPairs)
+	for i := 0; i+1 < len(runes); i += 2 {
+		traditional, simplified := runes[i], runes[i+1]
+		traditionalToSimplified[traditional] = simplified
+		if _, ok := simplifiedToTraditional[simplified]; !ok && !strings.ContainsRune(sharedSimplified, simplified) {
+			simplifiedToTraditional[simplified] = traditional
+		}
+	}
+}
+
+// ToSimplified converts the Traditional Chinese characters of a string to
+// their Simplified forms, leaving other characters unchanged
+func ToSimplified(s string) string {
+	return convertChinese(s, traditionalToSimplified)
+}
+
+// ToTraditional converts the Simplified Chinese characters of a string to
+// their Traditional forms, leaving other characters unchanged
+func ToTraditional(s string) string {
+	return convertChinese(s, simplifiedToTraditional)
+}
+
+// convertChinese maps the characters of a string through a conversion table
+func convertChinese(s string, table map[rune]rune) string {
+	return strings.Map(func(r rune) rune {
+		if converted, ok := table[r]; ok {
+			return converted
+		}
+		return r
+	}, s)
+}
+
+// IsTraditionalChinese reports whether a string holds a Traditional-only
+// Chinese character
+func IsTraditionalChinese(s string) bool {
+	for _, r := range s {
+		if _, ok := traditionalToSimplified[r]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// WithChineseVariants makes Chinese dictionaries and lookups fold Traditional
+// characters to Simplified, so a dictionary in either variant matches input
+// in both, and returns Chinese suggestions in the given variant
+func WithChineseVariants(output ChineseVariant) Option {
+	return func(dym *DidYouMean) {
+		dym.chineseFolding = true
+		dym.chineseOutput = output
+	}
+}
+
+// convertChineseSuggestions writes suggestions for a word in the output
+// variant set with WithChineseVariants
+func (dym *DidYouMean) convertChineseSuggestions(word string, suggestions []Suggestion) {
+	convert := ToSimplified
+	if dym.chineseOutput == ChineseTraditional || (dym.chineseOutput == ChineseAuto && IsTraditionalChinese(word)) {
+		convert = ToTraditional
+	}
+	for i := range suggestions {
+		suggestions[i].Word = convert(suggestions[i].Word)
+	}
+}
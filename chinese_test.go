@@ -0,0 +1,54 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestChineseConversion tests Simplified and Traditional conversion
+func TestChineseConversion(t *testing.T) {
+	if simplified := dymean.ToSimplified("學習中文"); simplified != "学习中文" {
+		t.Errorf("ToSimplified = %q, expected 学习中文", simplified)
+	}
+	if traditional := dymean.ToTraditional("学习中文"); traditional != "學習中文" {
+		t.Errorf("ToTraditional = %q, expected 學習中文", traditional)
+	}
+	// Shared characters are kept rather than guessed
+	if traditional := dymean.ToTraditional("公里"); traditional != "公里" {
+		t.Errorf("ToTraditional = %q, expected 公里", traditional)
+	}
+	if !dymean.IsTraditionalChinese("國家") || dymean.IsTraditionalChinese("国家") {
+		t.Error("Expected only 國家 to be Traditional")
+	}
+}
+
+// TestWithChineseVariants tests matching both variants and the output variant
+func TestWithChineseVariants(t *testing.T) {
+	plain := dymean.NewDidYouMean(10000, 7)
+	plain.AddWordsForLanguage([]string{"学习", "电话"}, dymean.Chinese)
+	if plain.IsCorrectForLanguage("學習", dymean.Chinese) {
+		t.Error("Expected Traditional input not to match without WithChineseVariants")
+	}
+
+	tests := []struct {
+		output   dymean.ChineseVariant
+		input    string
+		expected string
+	}{
+		{dymean.ChineseAuto, "學習", "學習"},
+		{dymean.ChineseAuto, "学习", "学习"},
+		{dymean.ChineseSimplified, "學習", "学习"},
+		{dymean.ChineseTraditional, "学习", "學習"},
+	}
+	for _, tc := range tests {
+		dym := dymean.NewDidYouMean(10000, 7, dymean.WithChineseVariants(tc.output))
+		dym.AddWordsForLanguage([]string{"學習", "电话"}, dymean.Chinese)
+		if !dym.IsCorrectForLanguage(tc.input, dymean.Chinese) || !dym.IsCorrectForLanguage("電話", dymean.Chinese) {
+			t.Errorf("Expected %q and 電話 to be correct", tc.input)
+		}
+		suggestions := dym.GetSuggestionsForLanguage(tc.input, 5, 1, dymean.Chinese)
+		if len(suggestions) == 0 || suggestions[0].Word != tc.expected {
+			t.Errorf("Suggestions for %q with variant %d: expected %q first, got %v", tc.input, tc.output, tc.expected, suggestions)
+		}
+	}
+}
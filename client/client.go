@@ -0,0 +1,389 @@
+// Package client is a Go client for the dymean HTTP server. A Client has the
+// checking and dictionary methods of dymean.DidYouMean with the same
+// signatures, so an application can switch between embedded and remote
+// checking by swapping one value:
+//
+//	checker := client.New("http://spell:8080", client.Config{})
+//	if checker.IsCorrect(word) { ... }
+//
+// Those methods cannot return errors; a failed request makes them return
+// zero results, records the error for Err and passes it to Config.OnError.
+// The methods taking a context return errors instead.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"github.com/bi0dread/dymean/server"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultTimeout bounds the requests of the methods without a context
+const defaultTimeout = 10 * time.Second
+
+// ErrServer is matched by errors.Is for every *ServerError
+var ErrServer = errors.New("server error")
+
+// ServerError reports a request answered with an error status
+type ServerError struct {
+	StatusCode int
+	Message    string        // Error message of the response, possibly translated
+	RetryAfter time.Duration // From the Retry-After header of 429 and 503 responses
+}
+
+func (e *ServerError) Error() string {
+	return fmt.Sprintf("%s: %s", http.StatusText(e.StatusCode), e.Message)
+}
+
+// Is makes errors.Is(err, ErrServer) match
+func (e *ServerError) Is(target error) bool {
+	return target == ErrServer
+}
+
+// Config configures a Client
+type Config struct {
+	// HTTPClient sends the requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Token is sent as a bearer token, as the dictionary endpoints require
+	Token string
+
+	// Language is the initial current language. Defaults to the server's.
+	Language dymean.Language
+
+	// AcceptLanguage asks the server for error messages in a language
+	AcceptLanguage string
+
+	// Timeout bounds the requests of the methods without a context.
+	// Defaults to 10 seconds.
+	Timeout time.Duration
+
+	// OnError is called with the error of every failed request made by the
+	// methods without a context
+	OnError func(err error)
+}
+
+var _ dymean.Checker = (*Client)(nil)
+
+// Client checks words and texts with a remote dymean server
+type Client struct {
+	base string
+	cfg  Config
+
+	mu   sync.Mutex // Guards lang and err
+	lang dymean.Language
+	err  error
+}
+
+// New creates a client for the server at baseURL, such as "http://localhost:8080"
+func New(baseURL string, cfg Config) *Client {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	return &Client{base: strings.TrimSuffix(baseURL, "/"), cfg: cfg, lang: cfg.Language}
+}
+
+// SetLanguage sets the current language, "auto" detecting it per word
+func (c *Client) SetLanguage(lang dymean.Language) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lang = lang
+}
+
+// GetCurrentLanguage returns the current language, empty for the server's
+func (c *Client) GetCurrentLanguage() dymean.Language {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lang
+}
+
+// Err returns the error of the last failed request made by the methods
+// without a context, or nil
+func (c *Client) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// fail records the error of a method without a context
+func (c *Client) fail(err error) {
+	c.mu.Lock()
+	c.err = err
+	c.mu.Unlock()
+	if c.cfg.OnError != nil {
+		c.cfg.OnError(err)
+	}
+}
+
+// background returns the context of the methods without a context
+func (c *Client) background() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.cfg.Timeout)
+}
+
+// Check reports whether a word is correct in a language, with suggestions
+// when it is not
+func (c *Client) Check(ctx context.Context, word string, lang dymean.Language) (bool, []dymean.Suggestion, error) {
+	var response server.CheckResponse
+	query := url.Values{"word": {word}}
+	if err := c.do(ctx, http.MethodGet, "/check", query, lang, nil, "", &response); err != nil {
+		return false, nil, err
+	}
+	return response.Correct, fromSuggestions(response.Suggestions), nil
+}
+
+// SuggestionsPage returns a page of the ranked suggestions for a word. The
+// server picks the edit distance from the language's suggestion tuning.
+func (c *Client) SuggestionsPage(ctx context.Context, word string, offset, limit int, lang dymean.Language) (dymean.SuggestionPage, error) {
+	var response server.CheckResponse
+	query := url.Values{"word": {word}, "offset": {strconv.Itoa(offset)}}
+	if limit > 0 {
+		query.Set("max", strconv.Itoa(limit))
+	}
+	if err := c.do(ctx, http.MethodGet, "/suggest", query, lang, nil, "", &response); err != nil {
+		return dymean.SuggestionPage{}, err
+	}
+	return dymean.SuggestionPage{
+		Suggestions: fromSuggestions(response.Suggestions),
+		Offset:      offset,
+		Total:       response.Total,
+		HasMore:     response.HasMore,
+	}, nil
+}
+
+// CheckTextContext checks a text in a language, "auto" detecting it per word
+func (c *Client) CheckTextContext(ctx context.Context, text string, lang dymean.Language) ([]dymean.Correction, error) {
+	var response server.CheckTextResponse
+	if err := c.do(ctx, http.MethodPost, "/check-text", nil, lang, strings.NewReader(text), "text/plain; charset=utf-8", &response); err != nil {
+		return nil, err
+	}
+	corrections := make([]dymean.Correction, 0, len(response.Corrections))
+	for _, correction := range response.Corrections {
+		corrections = append(corrections, dymean.Correction{
+			Start:       correction.Start,
+			End:         correction.End,
+			Original:    correction.Original,
+			Suggestions: fromSuggestions(correction.Suggestions),
+			Category:    dymean.CorrectionCategory(correction.Category),
+			Language:    dymean.Language(correction.Language),
+		})
+	}
+	return corrections, nil
+}
+
+// AddWordsContext adds words to a dictionary of the server and reports the
+// words it did not add. It needs a token allowed to modify dictionaries.
+func (c *Client) AddWordsContext(ctx context.Context, words []string, lang dymean.Language) (dymean.AddReport, error) {
+	var response server.DictionaryResponse
+	if err := c.dictionary(ctx, "/dictionary/add", words, lang, &response); err != nil {
+		return dymean.AddReport{}, err
+	}
+	report := dymean.AddReport{Language: dymean.Language(response.Language), Added: response.Added, Rejected: make(map[dymean.RejectReason]int)}
+	for reason, count := range response.Rejected {
+		report.Rejected[dymean.RejectReason(reason)] = count
+	}
+	return report, nil
+}
+
+// RemoveWordsContext removes words from a dictionary of the server and
+// returns the number removed. It needs a token allowed to modify dictionaries.
+func (c *Client) RemoveWordsContext(ctx context.Context, words []string, lang dymean.Language) (int, error) {
+	var response server.DictionaryResponse
+	if err := c.dictionary(ctx, "/dictionary/remove", words, lang, &response); err != nil {
+		return 0, err
+	}
+	return response.Removed, nil
+}
+
+// Ready returns nil once the server's dictionaries are loaded
+func (c *Client) Ready(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, "/readyz", nil, "", nil, "", nil)
+}
+
+// IsCorrect checks if a word is correct in the current language
+func (c *Client) IsCorrect(word string) bool {
+	return c.IsCorrectForLanguage(word, c.GetCurrentLanguage())
+}
+
+// IsCorrectForLanguage checks if a word is correct in a language
+func (c *Client) IsCorrectForLanguage(word string, lang dymean.Language) bool {
+	correct, _ := c.CheckAndSuggestForLanguage(word, lang)
+	return correct
+}
+
+// CheckAndSuggest checks a word in the current language and returns
+// suggestions when it is not correct
+func (c *Client) CheckAndSuggest(word string) (bool, []dymean.Suggestion) {
+	return c.CheckAndSuggestForLanguage(word, c.GetCurrentLanguage())
+}
+
+// CheckAndSuggestForLanguage checks a word in a language and returns
+// suggestions when it is not correct
+func (c *Client) CheckAndSuggestForLanguage(word string, lang dymean.Language) (bool, []dymean.Suggestion) {
+	ctx, cancel := c.background()
+	defer cancel()
+	correct, suggestions, err := c.Check(ctx, word, lang)
+	if err != nil {
+		c.fail(err)
+	}
+	return correct, suggestions
+}
+
+// GetSuggestions returns suggestions for a word in the current language. The
+// server picks the edit distance, so maxEditDistance is ignored.
+func (c *Client) GetSuggestions(word string, maxSuggestions int, maxEditDistance int) []dymean.Suggestion {
+	return c.GetSuggestionsForLanguage(word, maxSuggestions, maxEditDistance, c.GetCurrentLanguage())
+}
+
+// GetSuggestionsForLanguage returns suggestions for a word in a language. The
+// server picks the edit distance, so maxEditDistance is ignored.
+func (c *Client) GetSuggestionsForLanguage(word string, maxSuggestions int, maxEditDistance int, lang dymean.Language) []dymean.Suggestion {
+	ctx, cancel := c.background()
+	defer cancel()
+	page, err := c.SuggestionsPage(ctx, word, 0, maxSuggestions, lang)
+	if err != nil {
+		c.fail(err)
+	}
+	return page.Suggestions
+}
+
+// Suggest returns the best suggestion for a word in the current language, or
+// the word itself when there is none
+func (c *Client) Suggest(word string) string {
+	return c.SuggestForLanguage(word, c.GetCurrentLanguage())
+}
+
+// SuggestForLanguage returns the best suggestion for a word in a language, or
+// the word itself when there is none
+func (c *Client) SuggestForLanguage(word string, lang dymean.Language) string {
+	if suggestions := c.GetSuggestionsForLanguage(word, 1, 0, lang); len(suggestions) > 0 {
+		return suggestions[0].Word
+	}
+	return word
+}
+
+// CheckText checks a text, detecting the language of each word
+func (c *Client) CheckText(text string) []dymean.Correction {
+	return c.CheckTextForLanguage(text, "auto")
+}
+
+// CheckTextForLanguage checks a text against a language
+func (c *Client) CheckTextForLanguage(text string, lang dymean.Language) []dymean.Correction {
+	ctx, cancel := c.background()
+	defer cancel()
+	corrections, err := c.CheckTextContext(ctx, text, lang)
+	if err != nil {
+		c.fail(err)
+	}
+	return corrections
+}
+
+// AddWords adds words to the dictionary of the current language
+func (c *Client) AddWords(words []string) {
+	c.AddWordsForLanguage(words, c.GetCurrentLanguage())
+}
+
+// AddWordsForLanguage adds words to the dictionary of a language
+func (c *Client) AddWordsForLanguage(words []string, lang dymean.Language) {
+	ctx, cancel := c.background()
+	defer cancel()
+	if _, err := c.AddWordsContext(ctx, words, lang); err != nil {
+		c.fail(err)
+	}
+}
+
+// RemoveWords removes words from the dictionary of the current language
+func (c *Client) RemoveWords(words []string) {
+	c.RemoveWordsForLanguage(words, c.GetCurrentLanguage())
+}
+
+// RemoveWordsForLanguage removes words from the dictionary of a language
+func (c *Client) RemoveWordsForLanguage(words []string, lang dymean.Language) {
+	ctx, cancel := c.background()
+	defer cancel()
+	if _, err := c.RemoveWordsContext(ctx, words, lang); err != nil {
+		c.fail(err)
+	}
+}
+
+// dictionary sends the words of a dictionary mutation
+func (c *Client) dictionary(ctx context.Context, path string, words []string, lang dymean.Language, response *server.DictionaryResponse) error {
+	body, err := json.Marshal(server.DictionaryRequest{Words: words})
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodPost, path, nil, lang, bytes.NewReader(body), "application/json", response)
+}
+
+// do sends a request and decodes its JSON response into response, unless nil
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, lang dymean.Language, body io.Reader, contentType string, response any) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	if lang != "" {
+		query.Set("lang", string(lang))
+	}
+	target := c.base + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	if c.cfg.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+	}
+	if c.cfg.AcceptLanguage != "" {
+		request.Header.Set("Accept-Language", c.cfg.AcceptLanguage)
+	}
+
+	resp, err := c.cfg.HTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		serverErr := &ServerError{StatusCode: resp.StatusCode}
+		var payload struct {
+			Error string `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&payload) == nil {
+			serverErr.Message = payload.Error
+		}
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			serverErr.RetryAfter = time.Duration(seconds) * time.Second
+		}
+		return serverErr
+	}
+	if response == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
+
+// fromSuggestions converts suggestions from their API form
+func fromSuggestions(suggestions []server.Suggestion) []dymean.Suggestion {
+	result := make([]dymean.Suggestion, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		result = append(result, dymean.Suggestion{Word: suggestion.Word, Similarity: suggestion.Similarity, Reason: suggestion.Reason})
+	}
+	return result
+}
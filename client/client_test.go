@@ -0,0 +1,99 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"github.com/bi0dread/dymean"
+	"github.com/bi0dread/dymean/client"
+	"github.com/bi0dread/dymean/server"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// newServer starts a server with a small English dictionary, accepting the
+// token "secret" for dictionary changes
+func newServer(t *testing.T) (*dymean.DidYouMean, *httptest.Server) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello", "world", "help"})
+	s := server.New(dym, server.Config{
+		ValidateToken: func(ctx context.Context, token string) error {
+			if token != "secret" {
+				return errors.New("unknown token")
+			}
+			return nil
+		},
+	})
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+	return dym, ts
+}
+
+// TestClientMatchesEmbedded tests that the client answers like the embedded checker
+func TestClientMatchesEmbedded(t *testing.T) {
+	dym, ts := newServer(t)
+	c := client.New(ts.URL, client.Config{})
+
+	if !c.IsCorrect("hello") || c.IsCorrect("helo") {
+		t.Error("Expected hello correct and helo not")
+	}
+	if remote, local := c.GetSuggestions("helo", 2, 2), dym.GetSuggestions("helo", 2, 2); !reflect.DeepEqual(remote, local) {
+		t.Errorf("Expected suggestions %+v, got %+v", local, remote)
+	}
+	if suggestion := c.Suggest("wrold"); suggestion != "world" {
+		t.Errorf("Expected world, got %q", suggestion)
+	}
+
+	text := "helo wrold"
+	if remote, local := c.CheckTextForLanguage(text, dymean.English), dym.CheckTextForLanguage(text, dymean.English); !reflect.DeepEqual(remote, local) {
+		t.Errorf("Expected corrections %+v, got %+v", local, remote)
+	}
+	if err := c.Err(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+// TestClientDictionary tests changing dictionaries with a token
+func TestClientDictionary(t *testing.T) {
+	dym, ts := newServer(t)
+
+	var failures []error
+	anonymous := client.New(ts.URL, client.Config{OnError: func(err error) { failures = append(failures, err) }})
+	anonymous.AddWords([]string{"gopher"})
+	var serverErr *client.ServerError
+	if !errors.As(anonymous.Err(), &serverErr) || serverErr.StatusCode != 401 || !errors.Is(serverErr, client.ErrServer) {
+		t.Errorf("Expected a 401 server error, got %v", anonymous.Err())
+	}
+	if len(failures) != 1 || dym.IsCorrect("gopher") {
+		t.Errorf("Expected the failure reported and nothing added, got %v", failures)
+	}
+
+	c := client.New(ts.URL, client.Config{Token: "secret", Language: dymean.English})
+	report, err := c.AddWordsContext(context.Background(), []string{"gopher", "hello"}, dymean.English)
+	if err != nil || report.Added != 1 || report.Rejected[dymean.RejectDuplicate] != 1 {
+		t.Errorf("Unexpected report %+v, %v", report, err)
+	}
+	if !c.IsCorrect("gopher") {
+		t.Error("Expected the added word to be correct")
+	}
+	if removed, err := c.RemoveWordsContext(context.Background(), []string{"gopher"}, dymean.English); err != nil || removed != 1 {
+		t.Errorf("Expected 1 word removed, got %d, %v", removed, err)
+	}
+}
+
+// TestClientErrors tests errors of the context methods
+func TestClientErrors(t *testing.T) {
+	_, ts := newServer(t)
+	c := client.New(ts.URL, client.Config{AcceptLanguage: "fa"})
+
+	_, _, err := c.Check(context.Background(), "", dymean.English)
+	var serverErr *client.ServerError
+	if !errors.As(err, &serverErr) || serverErr.StatusCode != 400 || serverErr.Message != "پارامتر word فرستاده نشده" {
+		t.Errorf("Expected a translated 400 error, got %v", err)
+	}
+
+	ts.Close()
+	if err := c.Ready(context.Background()); err == nil {
+		t.Error("Expected an error from a stopped server")
+	}
+}
@@ -0,0 +1,199 @@
+// Command dymean-server serves the dymean JSON API of package server, for
+// deploying the checker as a container without writing Go. Besides the
+// request/response endpoints, editors stream their edits to /check-stream
+// and get incremental corrections and completions back over the same
+// connection, over HTTP/2 or full-duplex HTTP/1.1; there is no gRPC service.
+//
+// Usage:
+//
+//	dymean-server [flags]
+//
+// Every flag defaults to an environment variable, so containers are
+// configured with the environment alone. The settings of the checker are
+// those of package config:
+//
+//	-langs              DYMEAN_LANGS              comma-separated languages served ("en")
+//	-dict-dir           DYMEAN_DICT_DIR           directory of compiled dictionaries (*.dymc) loaded at boot
+//	-embedded           DYMEAN_EMBEDDED           load the embedded dictionary of languages without a compiled one (true)
+//	-max-edit-distance  DYMEAN_MAX_EDIT_DISTANCE  edit distance of suggestions, 0 for each language's default
+//	-max-suggestions    DYMEAN_MAX_SUGGESTIONS    suggestions returned by default (5)
+//	-cache-size         DYMEAN_CACHE_SIZE         entries of the similarity cache, 0 for none
+//	-max-word-length    DYMEAN_MAX_WORD_LENGTH    longest word checked, 0 for the default
+//	-max-text-length    DYMEAN_MAX_TEXT_LENGTH    longest text checked, 0 for the default
+//
+// and the server adds:
+//
+//	-addr               DYMEAN_ADDR               address to listen on (":8080")
+//	-rate-limit         DYMEAN_RATE_LIMIT         checking requests per second per client, 0 for none
+//	-rate-burst         DYMEAN_RATE_BURST         requests a client may make at once
+//	-max-concurrent     DYMEAN_MAX_CONCURRENT     checking requests served at once, 0 for no cap
+//	-queue-timeout      DYMEAN_QUEUE_TIMEOUT      wait for a slot before rejecting a request ("100ms")
+//
+// DYMEAN_ADMIN_TOKEN, only read from the environment to keep it out of
+// process listings, enables the dictionary endpoints for requests bearing it.
+//
+// The server listens before the dictionaries are loaded: /healthz answers at
+// once and /readyz once every language is loaded, for container probes. It
+// stops gracefully on SIGINT and SIGTERM, closing the streams still open
+// after the shutdown timeout.
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"github.com/bi0dread/dymean/config"
+	"github.com/bi0dread/dymean/server"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// shutdownTimeout bounds the wait for requests in flight on shutdown
+const shutdownTimeout = 10 * time.Second
+
+// options are the settings of the server
+type options struct {
+	checker       config.Settings
+	addr          string
+	rateLimit     float64
+	rateBurst     int
+	maxConcurrent int
+	queueTimeout  time.Duration
+	adminToken    string
+}
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, nil))
+	opts, err := parseOptions(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "dymean-server: %v\n", err)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := run(ctx, opts, logger); err != nil {
+		logger.Error("server stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+// parseOptions reads the flags, defaulting to the environment
+func parseOptions(args []string) (options, error) {
+	env := &config.Env{}
+	opts := options{checker: config.Default()}
+	opts.checker.Languages = []dymean.Language{dymean.English}
+	opts.checker.MaxSuggestions = 5
+	opts.checker.ReadEnv(env)
+	opts.addr = env.String("DYMEAN_ADDR", ":8080")
+	opts.rateLimit = env.Float("DYMEAN_RATE_LIMIT", 0)
+	opts.rateBurst = env.Int("DYMEAN_RATE_BURST", 0)
+	opts.maxConcurrent = env.Int("DYMEAN_MAX_CONCURRENT", 0)
+	opts.queueTimeout = env.Duration("DYMEAN_QUEUE_TIMEOUT", 100*time.Millisecond)
+	opts.adminToken = os.Getenv("DYMEAN_ADMIN_TOKEN")
+	if err := env.Err(); err != nil {
+		return options{}, err
+	}
+
+	flags := flag.NewFlagSet("dymean-server", flag.ContinueOnError)
+	opts.checker.RegisterFlags(flags)
+	flags.StringVar(&opts.addr, "addr", opts.addr, "address to listen on")
+	flags.Float64Var(&opts.rateLimit, "rate-limit", opts.rateLimit, "checking requests per second per client, 0 for no limit")
+	flags.IntVar(&opts.rateBurst, "rate-burst", opts.rateBurst, "checking requests a client may make at once")
+	flags.IntVar(&opts.maxConcurrent, "max-concurrent", opts.maxConcurrent, "checking requests served at once, 0 for no cap")
+	flags.DurationVar(&opts.queueTimeout, "queue-timeout", opts.queueTimeout, "wait for a slot before rejecting a request")
+	if err := flags.Parse(args); err != nil {
+		return options{}, err
+	}
+
+	if len(opts.checker.Languages) == 0 {
+		return options{}, fmt.Errorf("no language to serve")
+	}
+	return opts, opts.checker.Validate()
+}
+
+// run serves the API until ctx is done
+func run(ctx context.Context, opts options, logger *slog.Logger) error {
+	checkerOptions := append([]dymean.Option{dymean.WithLogger(logger), dymean.WithRecovery(nil)}, opts.checker.Options()...)
+	dym := dymean.NewDidYouMean(1000000, 7, checkerOptions...)
+	dym.SetLanguage(opts.checker.Languages[0])
+
+	serverConfig := server.Config{
+		MaxSuggestions: opts.checker.MaxSuggestions,
+		RateLimit:      opts.rateLimit,
+		RateBurst:      opts.rateBurst,
+		MaxConcurrent:  opts.maxConcurrent,
+		QueueTimeout:   opts.queueTimeout,
+	}
+	if opts.adminToken != "" {
+		serverConfig.ValidateToken = func(_ context.Context, token string) error {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(opts.adminToken)) != 1 {
+				return errors.New("unknown token")
+			}
+			return nil
+		}
+	}
+	srv := &http.Server{
+		Addr:              opts.addr,
+		Handler:           server.New(dym, serverConfig),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		logger.Info("listening", "addr", opts.addr, "languages", opts.checker.Languages)
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	if err := loadDictionaries(dym, opts.checker, logger); err != nil {
+		srv.Close()
+		return err
+	}
+	logger.Info("ready", "languages", dym.GetLoadedLanguages())
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+	logger.Info("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	err := srv.Shutdown(shutdownCtx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		// Streams stay open as long as their clients do
+		logger.Info("closing open streams")
+		return srv.Close()
+	}
+	return err
+}
+
+// loadDictionaries loads the dictionaries of the settings, logging them
+func loadDictionaries(dym *dymean.DidYouMean, settings config.Settings, logger *slog.Logger) error {
+	headers, err := settings.LoadDictionaries(dym)
+	compiled := make(map[dymean.Language]bool, len(headers))
+	for _, header := range headers {
+		compiled[header.Language] = true
+		logger.Info("loaded dictionary", "language", header.Language,
+			"words", header.WordCount, "version", header.Metadata.Version)
+	}
+	if err != nil {
+		return err
+	}
+	for _, lang := range settings.Languages {
+		if !compiled[lang] {
+			logger.Info("loaded embedded dictionary", "language", lang, "words", dym.GetWordCount(lang))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"os"
+	"time"
+)
+
+// runBuildIndex compiles a word list into the versioned compiled dictionary format
+func runBuildIndex(args []string) error {
+	flags := flag.NewFlagSet("build-index", flag.ContinueOnError)
+	lang := flags.String("lang", "en", "language code of the dictionary")
+	dictPath := flags.String("dict", "", "dictionary file with one word per line (required)")
+	output := flags.String("o", "", "output file for the compiled dictionary (required)")
+	compress := flags.Bool("compress", true, "gzip-compress the word list")
+	source := flags.String("source", "", "where the word list came from, recorded in the artifact")
+	license := flags.String("license", "", "license of the word list, such as an SPDX identifier")
+	version := flags.String("version", "", "version of the word list")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *dictPath == "" || *output == "" {
+		return fmt.Errorf("-dict and -o are required")
+	}
+
+	language := dymean.Language(*lang)
+	dym, err := loadDictionary(*dictPath, language)
+	if err != nil {
+		return err
+	}
+	dym.SetDictionaryMetadata(language, dymean.DictionaryMetadata{Source: *source, License: *license, Version: *version})
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := dym.WriteCompiled(file, language, *compress); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	// Read the artifact back so a broken build never ships
+	file, err = os.Open(*output)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	header, err := dymean.ValidateCompiled(file)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "wrote %d %s words to %s (format v%d, checksum %08x, built %s)\n",
+		header.WordCount, header.Language, *output, header.Version, header.Checksum, header.Metadata.BuildDate.Format(time.RFC3339))
+	return nil
+}
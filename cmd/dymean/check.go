@@ -0,0 +1,298 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/bi0dread/dymean"
+	envconfig "github.com/bi0dread/dymean/config"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// checkExtensions are the file types checked when walking a directory
+var checkExtensions = map[string]bool{".md": true, ".markdown": true, ".txt": true}
+
+// issue is a correction located in a file
+type issue struct {
+	File        string   `json:"file"`
+	Line        int      `json:"line"`
+	Column      int      `json:"column"`
+	EndLine     int      `json:"end_line"`
+	EndColumn   int      `json:"end_column"`
+	Token       string   `json:"token"`
+	Suggestions []string `json:"suggestions"`
+	Category    string   `json:"category"`
+	Language    string   `json:"language,omitempty"`
+}
+
+// String formats an issue as file:line:column: message
+func (i issue) String() string {
+	message := fmt.Sprintf("%s %q", i.Category, i.Token)
+	if len(i.Suggestions) > 0 {
+		message += fmt.Sprintf(", did you mean %q?", i.Suggestions[0])
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", i.File, i.Line, i.Column, message)
+}
+
+// resultWriters encode the results of check, one per -format value
+var resultWriters = map[string]func(w io.Writer, results []dymean.DocumentResult) error{
+	"text":  writeIssuesText,
+	"json":  writeIssuesJSON,
+	"csv":   writeIssuesCSV,
+	"sarif": dymean.WriteSARIF,
+}
+
+// runCheck spell checks files and directories and reports the issues found
+func runCheck(args []string) error {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	configPath := flags.String("config", "", "configuration file, by default the "+dymean.ConfigFileName+" found in the working directory or its parents")
+	lang := flags.String("lang", "", "language of the files, or auto to detect it per word (default from the configuration, else auto)")
+	dicts := flags.String("dict", "", "comma-separated word lists (one word per line) to accept in addition to the embedded dictionaries")
+	format := flags.String("format", "text", "output format: text, json, csv or sarif")
+	watchFiles := flags.Bool("watch", false, "keep checking files as they change, printing new issues with + and resolved ones with -")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	write, ok := resultWriters[*format]
+	if !ok {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	config, fromFile, err := loadConfig(*configPath, *lang, *dicts)
+	if err != nil {
+		return err
+	}
+	paths := flags.Args()
+	if len(paths) == 0 {
+		if !fromFile {
+			return fmt.Errorf("no files or directories given")
+		}
+		paths = []string{"."} // The configuration selects the files
+	}
+	dym, err := newChecker(config)
+	if err != nil {
+		return err
+	}
+
+	if *watchFiles {
+		if *format != "text" {
+			return fmt.Errorf("-watch only supports the text format")
+		}
+		return watch(dym, config, paths, os.Stdout)
+	}
+
+	files, err := collectFiles(config, paths)
+	if err != nil {
+		return err
+	}
+
+	results := make([]dymean.DocumentResult, 0, len(files))
+	found := 0
+	for _, path := range files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		result := checkFile(dym, config, path, string(content))
+		found += len(result.Corrections)
+		results = append(results, result)
+	}
+
+	if err := write(os.Stdout, results); err != nil {
+		return err
+	}
+	if found > 0 {
+		return fmt.Errorf("%d issues found in %d files", found, len(files))
+	}
+	return nil
+}
+
+// loadConfig reads the configuration file at path, or the one found from the
+// working directory when path is empty, and applies DYMEAN_LANGS, then the
+// -lang and -dict flags on top of it. It reports whether a configuration file
+// was read.
+func loadConfig(path, lang, dicts string) (*dymean.Config, bool, error) {
+	settings, err := envconfig.FromEnv()
+	if err != nil {
+		return nil, false, err
+	}
+	if path == "" {
+		found, err := dymean.FindConfig(".")
+		if err != nil && !errors.Is(err, dymean.ErrNoConfig) {
+			return nil, false, err
+		}
+		path = found
+	}
+
+	config := &dymean.Config{}
+	if path != "" {
+		var err error
+		if config, err = dymean.LoadConfig(path); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if len(settings.Languages) > 0 {
+		config.Languages = settings.Languages
+	}
+	switch lang {
+	case "":
+	case "auto":
+		config.Languages = nil
+	default:
+		config.Languages = []dymean.Language{dymean.Language(lang)}
+	}
+	for _, dict := range strings.Split(dicts, ",") {
+		if dict != "" {
+			config.Dictionaries = append(config.Dictionaries, dict)
+		}
+	}
+	return config, path != "", config.Validate()
+}
+
+// newChecker creates a checker with the embedded dictionaries and the word
+// lists of a configuration, then the compiled dictionaries of DYMEAN_DICT_DIR.
+// The settings of the environment override those of the configuration.
+func newChecker(config *dymean.Config) (*dymean.DidYouMean, error) {
+	settings, err := envconfig.FromEnv()
+	if err != nil {
+		return nil, err
+	}
+	dym := dymean.NewDidYouMean(1000000, 7, append(config.Options(), settings.Options()...)...)
+	if err := config.Load(dym); err != nil {
+		if errors.Is(err, dymean.ErrNoDefaultDictionary) {
+			return nil, fmt.Errorf("%w; provide word lists with -dict", err)
+		}
+		return nil, err
+	}
+	if _, err := settings.LoadCompiled(dym); err != nil {
+		return nil, err
+	}
+	return dym, nil
+}
+
+// checkable checks if a file found while walking a directory should be
+// checked: by default Markdown and text files, unless the configuration
+// selects files with globs
+func checkable(config *dymean.Config, path string) bool {
+	if len(config.Files) == 0 && !checkExtensions[strings.ToLower(filepath.Ext(path))] {
+		return false
+	}
+	return config.Matches(path)
+}
+
+// collectFiles expands directories to the checkable files they contain.
+// Files given explicitly are always checked.
+func collectFiles(config *dymean.Config, paths []string) ([]string, error) {
+	files := make([]string, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() && p != path && (strings.HasPrefix(d.Name(), ".") || config.Ignored(p)) {
+				return filepath.SkipDir // .git and other tool directories
+			}
+			if !d.IsDir() && checkable(config, p) {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// checkFile checks the content of a file as configured, as Markdown for
+// Markdown files
+func checkFile(dym *dymean.DidYouMean, config *dymean.Config, path, content string) dymean.DocumentResult {
+	var ignore []dymean.TextRange
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".md" || ext == ".markdown" {
+		ignore = dymean.MarkdownIgnoreRanges(content)
+	}
+	return dymean.DocumentResult{Path: path, Text: content, Corrections: config.CheckText(dym, content, ignore)}
+}
+
+// issues locates the corrections of check results in their files
+func issues(results []dymean.DocumentResult) []issue {
+	located := make([]issue, 0)
+	for _, result := range results {
+		for _, correction := range result.Corrections {
+			line, column := dymean.LineColumn(result.Text, correction.Start)
+			endLine, endColumn := dymean.LineColumn(result.Text, correction.End)
+			suggestions := make([]string, 0, len(correction.Suggestions))
+			for _, suggestion := range correction.Suggestions {
+				suggestions = append(suggestions, suggestion.Word)
+			}
+			located = append(located, issue{
+				File:        result.Path,
+				Line:        line,
+				Column:      column,
+				EndLine:     endLine,
+				EndColumn:   endColumn,
+				Token:       correction.Original,
+				Suggestions: suggestions,
+				Category:    string(correction.Category),
+				Language:    string(correction.Language),
+			})
+		}
+	}
+	return located
+}
+
+// writeIssuesText writes one issue per line in the file:line:column format
+// understood by editors
+func writeIssuesText(w io.Writer, results []dymean.DocumentResult) error {
+	for _, issue := range issues(results) {
+		if _, err := fmt.Fprintln(w, issue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeIssuesJSON writes the issues as a JSON array
+func writeIssuesJSON(w io.Writer, results []dymean.DocumentResult) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(issues(results))
+}
+
+// writeIssuesCSV writes the issues as CSV with a header row. Suggestions are
+// separated by '|'.
+func writeIssuesCSV(w io.Writer, results []dymean.DocumentResult) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"file", "line", "column", "end_line", "end_column", "token", "suggestions", "category", "language"})
+	for _, issue := range issues(results) {
+		writer.Write([]string{
+			issue.File,
+			strconv.Itoa(issue.Line),
+			strconv.Itoa(issue.Column),
+			strconv.Itoa(issue.EndLine),
+			strconv.Itoa(issue.EndColumn),
+			issue.Token,
+			strings.Join(issue.Suggestions, "|"),
+			issue.Category,
+			issue.Language,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
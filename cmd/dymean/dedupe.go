@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"os"
+	"strings"
+)
+
+// runDedupe reports near-duplicate entries of a dictionary file and optionally
+// writes the merged dictionary
+func runDedupe(args []string) error {
+	flags := flag.NewFlagSet("dedupe", flag.ContinueOnError)
+	lang := flags.String("lang", "en", "language code of the dictionary")
+	dictPath := flags.String("dict", "", "dictionary file with one word per line (required)")
+	threshold := flags.Float64("threshold", 1.0, "minimum similarity of folded forms to group words")
+	merge := flags.Bool("merge", false, "write the dictionary with duplicates merged")
+	output := flags.String("o", "", "output file for -merge (default stdout)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *dictPath == "" {
+		return fmt.Errorf("-dict is required")
+	}
+
+	language := dymean.Language(*lang)
+	dym, err := loadDictionary(*dictPath, language)
+	if err != nil {
+		return err
+	}
+
+	groups := dym.DeduplicateDictionary(language, *threshold)
+	for _, group := range groups {
+		fmt.Fprintf(os.Stderr, "%s <- %s\n", group.Canonical, strings.Join(group.Variants, ", "))
+	}
+	fmt.Fprintf(os.Stderr, "%d duplicate groups found\n", len(groups))
+
+	if !*merge {
+		return nil
+	}
+
+	removed := dym.MergeDuplicates(language, groups)
+	fmt.Fprintf(os.Stderr, "%d variants removed\n", removed)
+
+	out := os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	return dym.WriteDictionary(out, language)
+}
+
+// loadDictionary creates a checker with the words of a dictionary file
+func loadDictionary(path string, lang dymean.Language) (*dymean.DidYouMean, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	dym := dymean.NewDidYouMean(10000, 7)
+	if err := dym.LoadDictionaryFromReader(file, lang); err != nil {
+		return nil, err
+	}
+	return dym, nil
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// runFix walks the misspellings of a file interactively, writes the corrected
+// file and adds the accepted words to the user dictionary
+func runFix(args []string) error {
+	flags := flag.NewFlagSet("fix", flag.ContinueOnError)
+	configPath := flags.String("config", "", "configuration file, by default the "+dymean.ConfigFileName+" found in the working directory or its parents")
+	lang := flags.String("lang", "", "language of the file, or auto to detect it per word (default from the configuration, else auto)")
+	dicts := flags.String("dict", "", "comma-separated word lists (one word per line) to accept in addition to the embedded dictionaries")
+	userDict := flags.String("user-dict", defaultUserDictionary(), "word list that words added during the session are appended to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("exactly one file is required")
+	}
+	path := flags.Arg(0)
+
+	lists := *dicts
+	if _, err := os.Stat(*userDict); err == nil {
+		lists = strings.TrimPrefix(lists+","+*userDict, ",")
+	}
+	config, _, err := loadConfig(*configPath, *lang, lists)
+	if err != nil {
+		return err
+	}
+	dym, err := newChecker(config)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	result := checkFile(dym, config, path, string(content))
+
+	session := &fixSession{in: bufio.NewReader(os.Stdin), out: os.Stdout, lang: messageLanguage()}
+	corrected, err := session.run(result)
+	if err != nil {
+		return err
+	}
+
+	if corrected != result.Text {
+		if err := writeFileAtomic(path, []byte(corrected)); err != nil {
+			return err
+		}
+	}
+	if len(session.added) > 0 {
+		if err := appendWords(*userDict, session.added); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(os.Stderr, dymean.Translate(session.lang, "%d corrected, %d added to %s", session.replaced, len(session.added), *userDict))
+	return nil
+}
+
+// defaultUserDictionary returns the user dictionary in the user's
+// configuration directory
+func defaultUserDictionary() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "dymean-words.txt"
+	}
+	return filepath.Join(dir, "dymean", "words.txt")
+}
+
+// fixSession holds the decisions of an interactive fix
+type fixSession struct {
+	in   *bufio.Reader
+	out  io.Writer
+	lang dymean.Language // Language of the prompts
+
+	skip     map[string]bool // Words added or ignored for the rest of the session
+	added    []string
+	replaced int
+}
+
+// run asks for a decision on every spelling correction and returns the text
+// with the chosen replacements applied
+func (s *fixSession) run(result dymean.DocumentResult) (string, error) {
+	s.skip = make(map[string]bool)
+	text := result.Text
+	var builder strings.Builder
+	last := 0
+
+	for _, correction := range result.Corrections {
+		if correction.Category != dymean.CategorySpelling || s.skip[strings.ToLower(correction.Original)] {
+			continue
+		}
+		replacement, quit, err := s.ask(result.Path, text, correction)
+		if err != nil {
+			return "", err
+		}
+		if quit {
+			break
+		}
+		if replacement == "" {
+			continue
+		}
+		builder.WriteString(text[last:correction.Start])
+		builder.WriteString(replacement)
+		last = correction.End
+		s.replaced++
+	}
+	builder.WriteString(text[last:])
+	return builder.String(), nil
+}
+
+// ask prompts for one correction and returns the replacement, or "" to keep
+// the word, and whether the user quit
+func (s *fixSession) ask(path, text string, correction dymean.Correction) (string, bool, error) {
+	line, column := dymean.LineColumn(text, correction.Start)
+	lineStart := strings.LastIndexByte(text[:correction.Start], '\n') + 1
+	lineEnd := strings.IndexByte(text[correction.End:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(text)
+	} else {
+		lineEnd += correction.End
+	}
+
+	fmt.Fprintf(s.out, "\n%s:%d:%d: %s[%s]%s\n", path, line, column,
+		text[lineStart:correction.Start], correction.Original, text[correction.End:lineEnd])
+	for i, suggestion := range correction.Suggestions {
+		fmt.Fprintf(s.out, "  %d) %s\n", i+1, suggestion.Word)
+	}
+
+	for {
+		fmt.Fprint(s.out, dymean.Translate(s.lang, "[number] replace, r) type replacement, a) add to dictionary, i) ignore, I) ignore all, q) quit: "))
+		answer, err := s.in.ReadString('\n')
+		if err != nil && answer == "" {
+			if err == io.EOF {
+				return "", true, nil
+			}
+			return "", false, err
+		}
+		answer = strings.TrimSpace(answer)
+
+		switch answer {
+		case "":
+			if len(correction.Suggestions) > 0 {
+				return matchCapital(correction.Original, correction.Suggestions[0].Word), false, nil
+			}
+		case "r":
+			fmt.Fprint(s.out, dymean.Translate(s.lang, "replacement: "))
+			typed, err := s.in.ReadString('\n')
+			if err != nil && typed == "" {
+				return "", false, err
+			}
+			if typed = strings.TrimSpace(typed); typed != "" {
+				return typed, false, nil
+			}
+		case "a":
+			s.added = append(s.added, correction.Original)
+			s.skip[strings.ToLower(correction.Original)] = true
+			return "", false, nil
+		case "i":
+			return "", false, nil
+		case "I":
+			s.skip[strings.ToLower(correction.Original)] = true
+			return "", false, nil
+		case "q":
+			return "", true, nil
+		default:
+			if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(correction.Suggestions) {
+				return matchCapital(correction.Original, correction.Suggestions[n-1].Word), false, nil
+			}
+		}
+		fmt.Fprintln(s.out, "unknown choice")
+	}
+}
+
+// matchCapital capitalizes a suggestion when the word it replaces is capitalized
+func matchCapital(word, suggestion string) string {
+	first, _ := utf8.DecodeRuneInString(word)
+	if !unicode.IsUpper(first) {
+		return suggestion
+	}
+	r, size := utf8.DecodeRuneInString(suggestion)
+	return string(unicode.ToUpper(r)) + suggestion[size:]
+}
+
+// writeFileAtomic replaces a file's content through a temporary file, so an
+// interrupted write never truncates it
+func writeFileAtomic(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// appendWords appends words to a word list, creating it if needed
+func appendWords(path string, words []string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(strings.Join(words, "\n") + "\n"); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// messageLanguage returns the language of the user's locale, from LC_ALL,
+// LC_MESSAGES or LANG, for prompts; English when it is not supported
+func messageLanguage() dymean.Language {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if locale := os.Getenv(name); locale != "" {
+			if lang, ok := dymean.ParseLocale(locale); ok {
+				return lang
+			}
+			break
+		}
+	}
+	return dymean.English
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bufio"
+	"github.com/bi0dread/dymean"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fixResult returns a check result for text with a spelling correction for
+// each occurrence of the misspellings, and a grammar correction of "a apple"
+func fixResult(text string, misspellings map[string][]string) dymean.DocumentResult {
+	result := dymean.DocumentResult{Path: "notes.txt", Text: text}
+	for offset := 0; offset < len(text); {
+		start, end := len(text), 0
+		var word string
+		for misspelled := range misspellings {
+			if i := strings.Index(strings.ToLower(text[offset:]), misspelled); i >= 0 && offset+i < start {
+				start, end, word = offset+i, offset+i+len(misspelled), misspelled
+			}
+		}
+		if word == "" {
+			break
+		}
+		correction := dymean.Correction{Start: start, End: end, Original: text[start:end], Category: dymean.CategorySpelling}
+		for _, suggestion := range misspellings[word] {
+			correction.Suggestions = append(correction.Suggestions, dymean.Suggestion{Word: suggestion})
+		}
+		result.Corrections = append(result.Corrections, correction)
+		offset = end
+	}
+	if i := strings.Index(text, "a apple"); i >= 0 {
+		result.Corrections = append(result.Corrections, dymean.Correction{Start: i, End: i + 1, Original: "a",
+			Suggestions: []dymean.Suggestion{{Word: "an"}}, Category: dymean.CategoryGrammar})
+	}
+	return result
+}
+
+// TestFixSession tests the choices of an interactive fix
+func TestFixSession(t *testing.T) {
+	result := fixResult("Teh cat ate a apple, teh end wiht me", map[string][]string{
+		"teh":  {"the", "ten"},
+		"wiht": {"with", "wit"},
+	})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		added    []string
+		replaced int
+	}{
+		{"numbers", "1\n2\n1\n", "The cat ate a apple, ten end with me", nil, 3},
+		{"default suggestion", "\n\n\n", "The cat ate a apple, the end with me", nil, 3},
+		{"typed replacement", "r\nThy\ni\n2\n", "Thy cat ate a apple, teh end wit me", nil, 2},
+		{"empty replacement asks again", "r\n\n1\ni\ni\n", "The cat ate a apple, teh end wiht me", nil, 1},
+		{"add skips the word", "a\n1\n", "Teh cat ate a apple, teh end with me", []string{"Teh"}, 1},
+		{"ignore all skips the word", "I\n1\n", "Teh cat ate a apple, teh end with me", nil, 1},
+		{"ignore once", "i\n1\n1\n", "Teh cat ate a apple, the end with me", nil, 2},
+		{"unknown choices ask again", "9\nx\n1\nq\n", "The cat ate a apple, teh end wiht me", nil, 1},
+		{"quit keeps earlier choices", "1\nq\n", "The cat ate a apple, teh end wiht me", nil, 1},
+		{"end of input quits", "1\n", "The cat ate a apple, teh end wiht me", nil, 1},
+		{"last answer without newline", "i\ni\n2", "Teh cat ate a apple, teh end wit me", nil, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out strings.Builder
+			session := &fixSession{in: bufio.NewReader(strings.NewReader(test.input)), out: &out, lang: dymean.English}
+			corrected, err := session.run(result)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if corrected != test.expected {
+				t.Errorf("Expected %q, got %q", test.expected, corrected)
+			}
+			if !reflect.DeepEqual(session.added, test.added) {
+				t.Errorf("Expected %v added, got %v", test.added, session.added)
+			}
+			if session.replaced != test.replaced {
+				t.Errorf("Expected %d replaced, got %d", test.replaced, session.replaced)
+			}
+			if strings.Contains(out.String(), "[a] apple") {
+				t.Error("Expected grammar corrections to be left out")
+			}
+		})
+	}
+}
+
+// TestFixSessionPrompt tests that a correction is shown in its line with its
+// suggestions
+func TestFixSessionPrompt(t *testing.T) {
+	result := fixResult("first line\nsecond wiht third\nlast", map[string][]string{"wiht": {"with", "wit"}})
+	var out strings.Builder
+	session := &fixSession{in: bufio.NewReader(strings.NewReader("q\n")), out: &out, lang: dymean.English}
+	if _, err := session.run(result); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, expected := range []string{"notes.txt:2:8: second [wiht] third\n", "  1) with\n", "  2) wit\n"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("Expected %q in %q", expected, out.String())
+		}
+	}
+}
+
+// TestMatchCapital tests that suggestions follow the capitalization of the
+// word they replace
+func TestMatchCapital(t *testing.T) {
+	tests := []struct {
+		word, suggestion, expected string
+	}{
+		{"teh", "the", "the"},
+		{"Teh", "the", "The"},
+		{"TEH", "the", "The"},
+		{"Éte", "été", "Été"},
+		{"۱تست", "تست", "تست"},
+	}
+	for _, test := range tests {
+		if got := matchCapital(test.word, test.suggestion); got != test.expected {
+			t.Errorf("matchCapital(%q, %q) = %q, expected %q", test.word, test.suggestion, got, test.expected)
+		}
+	}
+}
+
+// TestWriteFileAtomic tests that a file is replaced with its permissions and
+// no temporary file is left behind
+func TestWriteFileAtomic(t *testing.T) {
+	for _, perm := range []os.FileMode{0o600, 0o644, 0o755} {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "notes.txt")
+		if err := os.WriteFile(path, []byte("teh"), perm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chmod(path, perm); err != nil { // Regardless of the umask
+			t.Fatal(err)
+		}
+
+		if err := writeFileAtomic(path, []byte("the")); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if content, _ := os.ReadFile(path); string(content) != "the" {
+			t.Errorf("Expected the new content, got %q", content)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != perm {
+			t.Errorf("Expected permissions %v, got %v", perm, info.Mode().Perm())
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+			t.Errorf("Expected only the file in the directory, got %d entries", len(entries))
+		}
+	}
+
+	if err := writeFileAtomic(filepath.Join(t.TempDir(), "missing.txt"), []byte("the")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
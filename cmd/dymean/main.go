@@ -0,0 +1,66 @@
+// Command dymean is a command-line front end for the dymean spell checker.
+//
+// Usage:
+//
+//	dymean <command> [flags]
+//
+// Commands:
+//
+//	check        spell check files and directories, as text, JSON, CSV or SARIF
+//	report       aggregate frequent misspellings and dictionary candidates of many files
+//	fix          walk the misspellings of a file interactively and correct it
+//	dedupe       report or merge near-duplicate dictionary entries
+//	build-index  compile a word list into a versioned dictionary artifact
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// command is a CLI subcommand
+type command struct {
+	name        string
+	description string
+	run         func(args []string) error
+}
+
+// commands lists the available subcommands
+var commands = []command{
+	{name: "check", description: "spell check files and directories, as text, JSON, CSV or SARIF", run: runCheck},
+	{name: "report", description: "aggregate frequent misspellings and dictionary candidates of many files", run: runReport},
+	{name: "fix", description: "walk the misspellings of a file interactively and correct it", run: runFix},
+	{name: "dedupe", description: "report or merge near-duplicate dictionary entries", run: runDedupe},
+	{name: "build-index", description: "compile a word list into a versioned dictionary artifact", run: runBuildIndex},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == os.Args[1] {
+			if err := cmd.run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "dymean %s: %v\n", cmd.name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "dymean: unknown command %q\n", os.Args[1])
+	usage()
+	os.Exit(2)
+}
+
+// usage prints the list of commands
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: dymean <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", cmd.name, cmd.description)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"io"
+	"os"
+	"strings"
+)
+
+// runReport checks many files and reports the most frequent misspellings,
+// the unknown words and the words worth adding to the project dictionary
+func runReport(args []string) error {
+	flags := flag.NewFlagSet("report", flag.ContinueOnError)
+	configPath := flags.String("config", "", "configuration file, by default the "+dymean.ConfigFileName+" found in the working directory or its parents")
+	lang := flags.String("lang", "", "language of the files, or auto to detect it per word (default from the configuration, else auto)")
+	dicts := flags.String("dict", "", "comma-separated word lists (one word per line) to accept in addition to the embedded dictionaries")
+	format := flags.String("format", "text", "output format: text or json")
+	top := flags.Int("top", 20, "entries listed per section in the text format, 0 for all")
+	additions := flags.String("additions", "", "file to write the suggested dictionary additions to, one word per line")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	config, fromFile, err := loadConfig(*configPath, *lang, *dicts)
+	if err != nil {
+		return err
+	}
+	paths := flags.Args()
+	if len(paths) == 0 {
+		if !fromFile {
+			return fmt.Errorf("no files or directories given")
+		}
+		paths = []string{"."}
+	}
+	dym, err := newChecker(config)
+	if err != nil {
+		return err
+	}
+	files, err := collectFiles(config, paths)
+	if err != nil {
+		return err
+	}
+
+	results := make([]dymean.DocumentResult, 0, len(files))
+	for _, path := range files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		results = append(results, checkFile(dym, config, path, string(content)))
+	}
+	report := dymean.NewCorpusReport(results)
+
+	if *additions != "" {
+		if err := writeAdditions(*additions, report.SuggestedAdditions); err != nil {
+			return err
+		}
+	}
+	if *format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	return writeReportText(os.Stdout, report, *top)
+}
+
+// writeReportText writes the sections of a corpus report as aligned tables
+func writeReportText(w io.Writer, report dymean.CorpusReport, top int) error {
+	fmt.Fprintf(w, "%d spelling issues in %d files\n", report.Corrections, report.Documents)
+	sections := []struct {
+		title   string
+		entries []dymean.CorpusEntry
+	}{
+		{"Most frequent misspellings", report.Misspellings},
+		{"Unknown words", report.UnknownWords},
+		{"Suggested dictionary additions", report.SuggestedAdditions},
+	}
+	for _, section := range sections {
+		fmt.Fprintf(w, "\n%s (%d):\n", section.title, len(section.entries))
+		entries := section.entries
+		if top > 0 && len(entries) > top {
+			entries = entries[:top]
+		}
+		for _, entry := range entries {
+			line := fmt.Sprintf("  %6d  %-24s %d files", entry.Count, entry.Word, entry.Documents)
+			if len(entry.Suggestions) > 0 {
+				line += " -> " + strings.Join(entry.Suggestions, ", ")
+			}
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeAdditions writes suggested dictionary additions as a word list
+func writeAdditions(path string, entries []dymean.CorpusEntry) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		fmt.Fprintln(file, entry.Word)
+	}
+	return file.Close()
+}
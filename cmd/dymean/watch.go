@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"github.com/fsnotify/fsnotify"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// watchDebounce is how long a file must stay unchanged before it is checked
+// again, so editors saving in several steps trigger one check
+const watchDebounce = 200 * time.Millisecond
+
+// watcher re-checks files as they change and prints only the issues that
+// appeared or were resolved since the previous check of each file
+type watcher struct {
+	dym    *dymean.DidYouMean
+	config *dymean.Config
+	out    io.Writer
+	issues map[string]map[string]issue // Current issues of each file by issueKey
+}
+
+// watch checks the files under paths and then keeps checking them as they
+// change, until interrupted
+func watch(dym *dymean.DidYouMean, config *dymean.Config, paths []string, out io.Writer) error {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer fsw.Close()
+
+	w := &watcher{dym: dym, config: config, out: out, issues: make(map[string]map[string]issue)}
+	files, err := collectFiles(config, paths)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if err := addWatches(fsw, config, path); err != nil {
+			return err
+		}
+	}
+	for _, file := range files {
+		w.recheck(file)
+	}
+	fmt.Fprintf(out, "watching %d files for changes\n", len(files))
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	pending := make(map[string]bool)
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+
+	for {
+		select {
+		case event, ok := <-fsw.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if !config.Ignored(event.Name) {
+						addWatches(fsw, config, event.Name) // New directories are watched too
+					}
+					continue
+				}
+			}
+			if watched(config, paths, event.Name) {
+				pending[event.Name] = true
+				timer.Reset(watchDebounce)
+			}
+		case err, ok := <-fsw.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "watch: %v\n", err)
+		case <-timer.C:
+			changed := make([]string, 0, len(pending))
+			for file := range pending {
+				changed = append(changed, file)
+			}
+			sort.Strings(changed)
+			for _, file := range changed {
+				w.recheck(file)
+			}
+			pending = make(map[string]bool)
+		case <-interrupt:
+			return nil
+		}
+	}
+}
+
+// watched checks if a file is one of paths, or a checkable file under one of them
+func watched(config *dymean.Config, paths []string, name string) bool {
+	for _, path := range paths {
+		rel, err := filepath.Rel(path, name)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		if rel == "." || checkable(config, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// addWatches watches a directory and its subdirectories, or a single file
+func addWatches(fsw *fsnotify.Watcher, config *dymean.Config, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fsw.Add(filepath.Dir(path)) // Editors replace files, so watch the directory
+	}
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if p != path && (strings.HasPrefix(d.Name(), ".") || config.Ignored(p)) {
+			return filepath.SkipDir
+		}
+		return fsw.Add(p)
+	})
+}
+
+// recheck checks a file again and prints the issues that appeared with "+"
+// and the ones resolved with "-". A removed file resolves all its issues.
+func (w *watcher) recheck(path string) {
+	current := make(map[string]issue)
+	if content, err := os.ReadFile(path); err == nil {
+		occurrences := make(map[string]int)
+		for _, issue := range issues([]dymean.DocumentResult{checkFile(w.dym, w.config, path, string(content))}) {
+			key := issueKey(issue, occurrences)
+			current[key] = issue
+		}
+	}
+
+	previous := w.issues[path]
+	for _, key := range sortedKeys(previous) {
+		if _, ok := current[key]; !ok {
+			w.print("-", previous[key])
+		}
+	}
+	for _, key := range sortedKeys(current) {
+		if _, ok := previous[key]; !ok {
+			w.print("+", current[key])
+		}
+	}
+	w.issues[path] = current
+}
+
+// issueKey identifies an issue across edits by its category, token and
+// occurrence, since edits above it move its line and column
+func issueKey(issue issue, occurrences map[string]int) string {
+	base := issue.Category + "\x00" + issue.Token
+	occurrences[base]++
+	return fmt.Sprintf("%s\x00%d", base, occurrences[base])
+}
+
+// sortedKeys returns the keys of an issue set ordered by position
+func sortedKeys(set map[string]issue) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := set[keys[i]], set[keys[j]]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return keys
+}
+
+// print writes an issue prefixed with "+" or "-"
+func (w *watcher) print(sign string, issue issue) {
+	fmt.Fprintf(w.out, "%s %s\n", sign, issue)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"github.com/bi0dread/dymean"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestWatcherRecheck tests that rechecks print only the issues that appeared
+// or were resolved since the previous check
+func TestWatcherRecheck(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello", "world", "some", "text"})
+	config := &dymean.Config{Languages: []dymean.Language{dymean.English}}
+	var out strings.Builder
+	w := &watcher{dym: dym, config: config, out: &out, issues: make(map[string]map[string]issue)}
+
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	steps := []struct {
+		name    string
+		content string // Removes the file when empty
+		lines   []string
+	}{
+		{"first check", "helo world", []string{
+			`+ ` + path + `:1:1: spelling "helo", did you mean "hello"?`,
+		}},
+		{"unchanged", "helo world", nil},
+		{"moved issue and a new one", "some text\nhelo wrld", []string{
+			`+ ` + path + `:2:6: spelling "wrld", did you mean "world"?`,
+		}},
+		{"second occurrence", "some text\nhelo wrld helo", []string{
+			`+ ` + path + `:2:11: spelling "helo", did you mean "hello"?`,
+		}},
+		{"resolved issues", "some text\nhello world helo", []string{
+			`- ` + path + `:2:6: spelling "wrld", did you mean "world"?`,
+			`- ` + path + `:2:11: spelling "helo", did you mean "hello"?`,
+		}},
+		{"removed file", "", []string{
+			`- ` + path + `:2:13: spelling "helo", did you mean "hello"?`,
+		}},
+	}
+	for _, step := range steps {
+		if step.content == "" {
+			if err := os.Remove(path); err != nil {
+				t.Fatal(err)
+			}
+		} else if err := os.WriteFile(path, []byte(step.content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		out.Reset()
+		w.recheck(path)
+		var lines []string
+		if out.Len() > 0 {
+			lines = strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+		}
+		if !reflect.DeepEqual(lines, step.lines) {
+			t.Errorf("%s: expected %q, got %q", step.name, step.lines, lines)
+		}
+	}
+}
+
+// TestIssueKey tests that issues are identified by category, token and
+// occurrence rather than position
+func TestIssueKey(t *testing.T) {
+	occurrences := make(map[string]int)
+	first := issueKey(issue{Category: "spelling", Token: "helo", Line: 1, Column: 1}, occurrences)
+	second := issueKey(issue{Category: "spelling", Token: "helo", Line: 3, Column: 5}, occurrences)
+	other := issueKey(issue{Category: "grammar", Token: "helo", Line: 1, Column: 1}, occurrences)
+	if first == second || first == other || second == other {
+		t.Errorf("Expected distinct keys, got %q, %q and %q", first, second, other)
+	}
+
+	moved := issueKey(issue{Category: "spelling", Token: "helo", Line: 7, Column: 2}, make(map[string]int))
+	if moved != first {
+		t.Errorf("Expected a moved issue to keep its key, got %q and %q", moved, first)
+	}
+}
+
+// TestWatched tests which changed files are rechecked for single files and
+// directories
+func TestWatched(t *testing.T) {
+	dir := t.TempDir()
+	config := &dymean.Config{}
+	tests := []struct {
+		name     string
+		paths    []string
+		file     string
+		expected bool
+	}{
+		{"file target", []string{filepath.Join(dir, "notes.go")}, filepath.Join(dir, "notes.go"), true},
+		{"file target sibling", []string{filepath.Join(dir, "notes.go")}, filepath.Join(dir, "other.md"), false},
+		{"directory target", []string{dir}, filepath.Join(dir, "docs", "guide.md"), true},
+		{"directory target unchecked type", []string{dir}, filepath.Join(dir, "main.go"), false},
+		{"outside directory target", []string{filepath.Join(dir, "docs")}, filepath.Join(dir, "readme.md"), false},
+		{"second target", []string{filepath.Join(dir, "docs"), filepath.Join(dir, "notes.txt")}, filepath.Join(dir, "notes.txt"), true},
+	}
+	for _, test := range tests {
+		if got := watched(config, test.paths, test.file); got != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, got)
+		}
+	}
+}
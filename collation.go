@@ -0,0 +1,135 @@
+package dymean
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// collationWeight is the sort weight of a letter: letters with different
+// primary weights are different letters, while the secondary weight orders
+// the variants of a letter, such as "a" before "ä"
+type collationWeight struct {
+	primary, secondary int
+}
+
+// collator compares words in a language's alphabetical order
+type collator struct {
+	weights  map[rune][]collationWeight // Letters sorting as several, such as "ß" as "ss", have several weights
+	unlisted int                        // Primary weight of the first letter outside the language's collation
+}
+
+// collators caches the collator of each language, reset by RegisterLanguage
+var collators sync.Map // Language -> *collator
+
+// collatorFor returns the collator of a language
+func collatorFor(lang Language) *collator {
+	if c, ok := collators.Load(lang); ok {
+		return c.(*collator)
+	}
+	c, _ := collators.LoadOrStore(lang, newCollator(GetLanguageInfo(lang)))
+	return c.(*collator)
+}
+
+// newCollator builds the collator of a language from its Collation groups,
+// or from its Alphabet order with letters that fold to other letters of the
+// alphabet ("ä" to "a", "ß" to "ss") sorted as variants of them
+func newCollator(info LanguageInfo) *collator {
+	c := &collator{weights: make(map[rune][]collationWeight)}
+	groups := info.Collation
+	if len(groups) == 0 {
+		groups = make([]string, 0, len(info.Alphabet))
+		for _, r := range info.Alphabet {
+			if !foldsInto(r, info.Alphabet) {
+				groups = append(groups, string(r))
+			}
+		}
+	}
+	for primary, group := range groups {
+		secondary := 0
+		for _, r := range group {
+			if _, ok := c.weights[r]; !ok {
+				c.weights[r] = []collationWeight{{primary: primary + 1, secondary: secondary}}
+				secondary++
+			}
+		}
+	}
+	c.unlisted = len(groups) + 1
+	return c
+}
+
+// foldsInto reports whether a letter folds to letters all in a set
+func foldsInto(r rune, letters string) bool {
+	folded, ok := latinFolds[r]
+	if !ok {
+		return false
+	}
+	for _, f := range folded {
+		if !strings.ContainsRune(letters, f) {
+			return false
+		}
+	}
+	return true
+}
+
+// appendWeights appends the weights of a letter: its own, those of the
+// letters it folds to as a variant of them, or a primary weight after every
+// letter of the language for a letter outside it
+func (c *collator) appendWeights(weights []collationWeight, r rune) []collationWeight {
+	r = unicode.ToLower(r)
+	if own, ok := c.weights[r]; ok {
+		return append(weights, own...)
+	}
+	if folded, ok := latinFolds[r]; ok {
+		base := make([]collationWeight, 0, len(folded))
+		for _, f := range folded {
+			own, ok := c.weights[f]
+			if !ok {
+				break
+			}
+			base = append(base, collationWeight{primary: own[0].primary, secondary: own[0].secondary + int(r)})
+		}
+		if len(base) == len([]rune(folded)) {
+			return append(weights, base...)
+		}
+	}
+	return append(weights, collationWeight{primary: c.unlisted + int(r)})
+}
+
+// key returns the weights of a word's letters
+func (c *collator) key(word string) []collationWeight {
+	weights := make([]collationWeight, 0, len(word))
+	for _, r := range word {
+		weights = c.appendWeights(weights, r)
+	}
+	return weights
+}
+
+// compare orders two words by their letters, then by the variants of their
+// letters, then by bytes, returning a negative number, zero or a positive
+// number as a sorts before, like or after b
+func (c *collator) compare(a, b string) int {
+	ka, kb := c.key(a), c.key(b)
+	for i := 0; i < len(ka) && i < len(kb); i++ {
+		if ka[i].primary != kb[i].primary {
+			return ka[i].primary - kb[i].primary
+		}
+	}
+	if len(ka) != len(kb) {
+		return len(ka) - len(kb)
+	}
+	for i := range ka {
+		if ka[i].secondary != kb[i].secondary {
+			return ka[i].secondary - kb[i].secondary
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// CompareWords orders two words alphabetically in a language, as suggestions
+// tied in similarity are sorted: by letters first, treating variants such as
+// the German "ä" as their base letter, then by variants. It returns a negative
+// number, zero or a positive number as a sorts before, like or after b.
+func CompareWords(a, b string, lang Language) int {
+	return collatorFor(lang).compare(a, b)
+}
@@ -0,0 +1,65 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"sort"
+	"testing"
+)
+
+// TestCompareWords tests alphabetical order in several languages
+func TestCompareWords(t *testing.T) {
+	tests := []struct {
+		lang  dymean.Language
+		words []string // In the language's order
+	}{
+		{dymean.German, []string{"apfel", "äpfel", "bär", "masse", "maße", "zug"}},
+		{dymean.French, []string{"cote", "coté", "côte", "côté", "da"}},
+		{dymean.Persian, []string{"آب", "اسب", "پدر", "تو", "چای", "گل", "لب", "یک"}},
+		{dymean.Spanish, []string{"nube", "nzz", "ñu", "oso"}},
+		{dymean.Turkish, []string{"cam", "çay", "ısı", "iğne", "ocak", "ön"}},
+		{dymean.Polish, []string{"len", "łan", "zupa", "źle", "żaba"}},
+		{dymean.Greek, []string{"άλφα", "βήτα", "ωμέγα"}},
+		{dymean.English, []string{"apple", "banana", "cherry"}},
+	}
+
+	for _, tt := range tests {
+		shuffled := append([]string{}, tt.words...)
+		sort.Sort(sort.Reverse(sort.StringSlice(shuffled)))
+		sort.Slice(shuffled, func(i, j int) bool {
+			return dymean.CompareWords(shuffled[i], shuffled[j], tt.lang) < 0
+		})
+		for i := range tt.words {
+			if shuffled[i] != tt.words[i] {
+				t.Errorf("%s: expected %v, got %v", tt.lang, tt.words, shuffled)
+				break
+			}
+		}
+	}
+
+	if dymean.CompareWords("bär", "bär", dymean.German) != 0 {
+		t.Error("Expected equal words to compare equal")
+	}
+}
+
+// TestSuggestionsTieOrder tests that suggestions tied in similarity are in
+// alphabetical rather than byte order
+func TestSuggestionsTieOrder(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithAutomatonSearch())
+	dym.AddWordsForLanguage([]string{"لدرام", "گدرام", "تدرام", "پدرام"}, dymean.Persian)
+
+	// Byte order would be ت ل پ گ
+	expected := []string{"پدرام", "تدرام", "گدرام", "لدرام"}
+	suggestions := dym.GetSuggestionsForLanguage("درام", 5, 1, dymean.Persian)
+	if len(suggestions) != len(expected) {
+		t.Fatalf("Expected %d suggestions, got %v", len(expected), suggestions)
+	}
+	for i, suggestion := range suggestions {
+		if suggestion.Similarity != suggestions[0].Similarity {
+			t.Fatalf("Expected tied suggestions, got %v", suggestions)
+		}
+		if suggestion.Word != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, suggestions)
+			break
+		}
+	}
+}
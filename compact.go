@@ -0,0 +1,119 @@
+package dymean
+
+import (
+	"encoding/binary"
+	"sort"
+	"strings"
+)
+
+// frontCodingBucketSize is the number of words per front-coded bucket. Larger
+// buckets save more memory but make lookups scan further.
+const frontCodingBucketSize = 16
+
+// frontCodedSet is a read-only set of words stored as a front-coded sorted
+// array. Words are grouped into buckets; the first word of a bucket is stored
+// in full and every following word as the length of the prefix it shares with
+// its predecessor plus the remaining suffix. Lookups binary search the bucket
+// heads and scan a single bucket.
+type frontCodedSet struct {
+	data    []byte   // Encoded entries: uvarint shared, uvarint suffix length, suffix
+	offsets []uint32 // Offset of each bucket head in data
+	count   int
+}
+
+// newFrontCodedSet builds a set from sorted, unique words
+func newFrontCodedSet(words []string) *frontCodedSet {
+	set := &frontCodedSet{
+		offsets: make([]uint32, 0, (len(words)+frontCodingBucketSize-1)/frontCodingBucketSize),
+		count:   len(words),
+	}
+
+	var buf [binary.MaxVarintLen64]byte
+	prev := ""
+	for i, word := range words {
+		shared := 0
+		if i%frontCodingBucketSize == 0 {
+			set.offsets = append(set.offsets, uint32(len(set.data)))
+		} else {
+			shared = sharedPrefixLength(prev, word)
+		}
+
+		n := binary.PutUvarint(buf[:], uint64(shared))
+		set.data = append(set.data, buf[:n]...)
+		n = binary.PutUvarint(buf[:], uint64(len(word)-shared))
+		set.data = append(set.data, buf[:n]...)
+		set.data = append(set.data, word[shared:]...)
+		prev = word
+	}
+
+	return set
+}
+
+// sharedPrefixLength returns the number of leading bytes a and b have in common
+func sharedPrefixLength(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// decode reads the entry at offset given the previous word of its bucket and
+// returns the word and the offset of the next entry
+func (s *frontCodedSet) decode(offset int, prev string) (string, int) {
+	shared, n := binary.Uvarint(s.data[offset:])
+	offset += n
+	suffixLen, n := binary.Uvarint(s.data[offset:])
+	offset += n
+	end := offset + int(suffixLen)
+	return prev[:shared] + string(s.data[offset:end]), end
+}
+
+// bucketEnd returns the offset just past the last entry of bucket i
+func (s *frontCodedSet) bucketEnd(i int) int {
+	if i+1 < len(s.offsets) {
+		return int(s.offsets[i+1])
+	}
+	return len(s.data)
+}
+
+// contains reports whether word is in the set
+func (s *frontCodedSet) contains(word string) bool {
+	// Find the last bucket whose head is not greater than word
+	bucket := sort.Search(len(s.offsets), func(i int) bool {
+		head, _ := s.decode(int(s.offsets[i]), "")
+		return head > word
+	}) - 1
+	if bucket < 0 {
+		return false
+	}
+
+	prev := ""
+	for offset, end := int(s.offsets[bucket]), s.bucketEnd(bucket); offset < end; {
+		var current string
+		current, offset = s.decode(offset, prev)
+		if cmp := strings.Compare(current, word); cmp >= 0 {
+			return cmp == 0
+		}
+		prev = current
+	}
+	return false
+}
+
+// words returns all words of the set in sorted order
+func (s *frontCodedSet) words() []string {
+	words := make([]string, 0, s.count)
+	for bucket := range s.offsets {
+		prev := ""
+		for offset, end := int(s.offsets[bucket]), s.bucketEnd(bucket); offset < end; {
+			prev, offset = s.decode(offset, prev)
+			words = append(words, prev)
+		}
+	}
+	return words
+}
+
+// len returns the number of words in the set
+func (s *frontCodedSet) len() int {
+	return s.count
+}
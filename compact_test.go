@@ -0,0 +1,81 @@
+package dymean_test
+
+import (
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"testing"
+)
+
+// TestCompactStorage tests that compact dictionaries behave like map-backed ones
+func TestCompactStorage(t *testing.T) {
+	words := []string{"hello", "help", "helper", "world", "word", "work", "a", "zebra"}
+	for i := 0; i < 100; i++ {
+		words = append(words, fmt.Sprintf("word%c%c", 'a'+i%26, 'a'+i/26))
+	}
+
+	plain := dymean.NewDidYouMean(10000, 7)
+	plain.AddWords(words)
+	compact := dymean.NewDidYouMean(10000, 7, dymean.WithCompactStorage(dymean.English))
+	compact.AddWords(words[:50])
+	compact.AddWords(words[50:])
+
+	if !dymean.DiffDictionaries(plain, compact, dymean.English).Equal() {
+		t.Fatal("Expected compact dictionary to contain the same words")
+	}
+	for _, word := range []string{"hello", "a", "zebra", "wordzc", "helpe", "zz", ""} {
+		if plain.IsCorrect(word) != compact.IsCorrect(word) {
+			t.Errorf("IsCorrect(%q) differs between storages", word)
+		}
+	}
+
+	expected := make(map[dymean.Suggestion]bool)
+	for _, suggestion := range plain.GetSuggestions("wrold", 10, 2) {
+		expected[suggestion] = true
+	}
+	actual := compact.GetSuggestions("wrold", 10, 2)
+	if len(actual) != len(expected) {
+		t.Errorf("Expected %d suggestions, got %v", len(expected), actual)
+	}
+	for _, suggestion := range actual {
+		if !expected[suggestion] {
+			t.Errorf("Unexpected suggestion %v", suggestion)
+		}
+	}
+
+	compact.RemoveWords([]string{"helper"})
+	if compact.IsCorrect("helper") || !compact.IsCorrect("help") {
+		t.Error("Expected only 'helper' to be removed")
+	}
+}
+
+// TestDeferCompaction tests that words added while compaction is deferred
+// are found, and compacted once it ends
+func TestDeferCompaction(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithCompactStorage(dymean.English))
+	dym.AddWords([]string{"hello", "world"})
+
+	done := dym.DeferCompaction(dymean.English)
+	dym.AddWords([]string{"help", "word"})
+	dym.AddWords([]string{"work"})
+	dym.RemoveWords([]string{"world", "word"})
+	if !dym.IsCorrect("hello") || !dym.IsCorrect("help") || dym.IsCorrect("world") || dym.IsCorrect("word") {
+		t.Error("Expected lookups to see both storages while compaction is deferred")
+	}
+	if count := dym.GetWordCount(dymean.English); count != 3 {
+		t.Errorf("Expected 3 words, got %d", count)
+	}
+	if bytes := dym.Stats().Languages[dymean.English].DictionaryBytes; bytes == 0 {
+		t.Error("Expected the pending words to be counted")
+	}
+
+	done()
+	done() // Only the first call counts
+	expected := []string{"hello", "help", "work"}
+	if got := dym.GetDictionaryWords(dymean.English); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if stats := dym.Stats().Languages[dymean.English]; !stats.Compact || stats.Words != 3 {
+		t.Errorf("Expected 3 compact words, got %+v", stats)
+	}
+}
@@ -0,0 +1,248 @@
+package dymean
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"strings"
+	"time"
+)
+
+// Compiled dictionary container layout (all integers big-endian):
+//
+//	magic      [4]byte  "DYMC"
+//	version    uint16   compiledFormatVersion
+//	flags      uint8    compiledFlagGzip
+//	langLen    uint8    length of the language code
+//	lang       []byte   language code
+//	source     string   provenance metadata (version 2 and later); each
+//	license    string   string is a uint16 length followed by its bytes
+//	dictVer    string
+//	buildDate  int64    Unix time the artifact was built, 0 when unknown
+//	wordCount  uint32   number of words
+//	checksum   uint32   CRC-32 (IEEE) of the payload as stored
+//	payloadLen uint64   length of the payload as stored
+//	payload    []byte   sorted words separated by '\n', gzip-compressed if flagged
+const (
+	compiledMagic         = "DYMC"
+	compiledFormatVersion = 2
+	compiledFlagGzip      = 1 << 0
+
+	// maxCompiledWordBytes is the longest word, in bytes, a compiled
+	// dictionary may hold, which bounds its decompressed payload by its word
+	// count
+	maxCompiledWordBytes = 1024
+	// maxCompiledContent bounds the decompressed payload of any compiled
+	// dictionary, whatever its header claims
+	maxCompiledContent = 256 << 20
+)
+
+// Errors returned when reading compiled dictionaries
+var (
+	ErrInvalidCompiledDictionary = errors.New("invalid compiled dictionary")
+	ErrUnsupportedCompiledFormat = errors.New("unsupported compiled dictionary version")
+	ErrCompiledChecksum          = errors.New("compiled dictionary checksum mismatch")
+)
+
+// CompiledHeader describes a compiled dictionary artifact
+type CompiledHeader struct {
+	Version    uint16
+	Language   Language
+	WordCount  int
+	Compressed bool
+	Checksum   uint32
+	Metadata   DictionaryMetadata // Provenance; only Language and WordCount for version 1 artifacts
+}
+
+// WriteCompiled writes a language's dictionary to w in the versioned compiled
+// format, optionally gzip-compressed. The dictionary's metadata is written
+// along, with the build date set to now unless one was recorded.
+func (dym *DidYouMean) WriteCompiled(w io.Writer, lang Language, compress bool) error {
+	words := dym.GetDictionaryWords(lang)
+	metadata := dym.DictionaryInfo(lang)
+	if metadata.BuildDate.IsZero() {
+		metadata.BuildDate = time.Now()
+	}
+
+	var payload bytes.Buffer
+	var flags uint8
+	if compress {
+		flags |= compiledFlagGzip
+		gz := gzip.NewWriter(&payload)
+		if _, err := io.WriteString(gz, strings.Join(words, "\n")); err != nil {
+			return fmt.Errorf("write compiled dictionary: %w", err)
+		}
+		if err := gz.Close(); err != nil {
+			return fmt.Errorf("write compiled dictionary: %w", err)
+		}
+	} else {
+		payload.WriteString(strings.Join(words, "\n"))
+	}
+
+	if len(lang) > 255 {
+		return fmt.Errorf("write compiled dictionary: language code %q too long", lang)
+	}
+
+	var header bytes.Buffer
+	header.WriteString(compiledMagic)
+	binary.Write(&header, binary.BigEndian, uint16(compiledFormatVersion))
+	header.WriteByte(flags)
+	header.WriteByte(uint8(len(lang)))
+	header.WriteString(string(lang))
+	for _, field := range []string{metadata.Source, metadata.License, metadata.Version} {
+		if len(field) > 0xffff {
+			return fmt.Errorf("write compiled dictionary: metadata field too long")
+		}
+		binary.Write(&header, binary.BigEndian, uint16(len(field)))
+		header.WriteString(field)
+	}
+	binary.Write(&header, binary.BigEndian, metadata.BuildDate.Unix())
+	binary.Write(&header, binary.BigEndian, uint32(len(words)))
+	binary.Write(&header, binary.BigEndian, crc32.ChecksumIEEE(payload.Bytes()))
+	binary.Write(&header, binary.BigEndian, uint64(payload.Len()))
+
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return fmt.Errorf("write compiled dictionary: %w", err)
+	}
+	if _, err := w.Write(payload.Bytes()); err != nil {
+		return fmt.Errorf("write compiled dictionary: %w", err)
+	}
+	return nil
+}
+
+// LoadCompiled validates a compiled dictionary read from r and adds its words
+// to the dictionary of the language recorded in its header, recording its
+// metadata for DictionaryInfo. Nothing is loaded unless the whole artifact is
+// valid.
+func (dym *DidYouMean) LoadCompiled(r io.Reader) (CompiledHeader, error) {
+	header, words, err := readCompiled(r)
+	if err != nil {
+		return header, err
+	}
+	dym.AddWordsForLanguage(words, header.Language)
+	dym.SetDictionaryMetadata(header.Language, header.Metadata)
+	return header, nil
+}
+
+// ValidateCompiled checks the magic bytes, version, checksum and word count of
+// a compiled dictionary without loading it
+func ValidateCompiled(r io.Reader) (CompiledHeader, error) {
+	header, _, err := readCompiled(r)
+	return header, err
+}
+
+// readCompiled parses and verifies a compiled dictionary
+func readCompiled(r io.Reader) (CompiledHeader, []string, error) {
+	var header CompiledHeader
+	br := bufio.NewReader(r)
+
+	magic := make([]byte, len(compiledMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic) != compiledMagic {
+		return header, nil, ErrInvalidCompiledDictionary
+	}
+
+	if err := binary.Read(br, binary.BigEndian, &header.Version); err != nil {
+		return header, nil, ErrInvalidCompiledDictionary
+	}
+	if header.Version < 1 || header.Version > compiledFormatVersion {
+		return header, nil, fmt.Errorf("%w %d", ErrUnsupportedCompiledFormat, header.Version)
+	}
+
+	flags, err := br.ReadByte()
+	if err != nil {
+		return header, nil, ErrInvalidCompiledDictionary
+	}
+	header.Compressed = flags&compiledFlagGzip != 0
+
+	langLen, err := br.ReadByte()
+	if err != nil {
+		return header, nil, ErrInvalidCompiledDictionary
+	}
+	lang := make([]byte, langLen)
+	if _, err := io.ReadFull(br, lang); err != nil {
+		return header, nil, ErrInvalidCompiledDictionary
+	}
+	header.Language = Language(lang)
+	header.Metadata.Language = header.Language
+
+	if header.Version >= 2 {
+		fields := []*string{&header.Metadata.Source, &header.Metadata.License, &header.Metadata.Version}
+		for _, field := range fields {
+			var fieldLen uint16
+			if err := binary.Read(br, binary.BigEndian, &fieldLen); err != nil {
+				return header, nil, ErrInvalidCompiledDictionary
+			}
+			value := make([]byte, fieldLen)
+			if _, err := io.ReadFull(br, value); err != nil {
+				return header, nil, ErrInvalidCompiledDictionary
+			}
+			*field = string(value)
+		}
+		var buildDate int64
+		if err := binary.Read(br, binary.BigEndian, &buildDate); err != nil {
+			return header, nil, ErrInvalidCompiledDictionary
+		}
+		if buildDate != 0 {
+			header.Metadata.BuildDate = time.Unix(buildDate, 0).UTC()
+		}
+	}
+
+	var wordCount uint32
+	var payloadLen uint64
+	if err := binary.Read(br, binary.BigEndian, &wordCount); err != nil {
+		return header, nil, ErrInvalidCompiledDictionary
+	}
+	if err := binary.Read(br, binary.BigEndian, &header.Checksum); err != nil {
+		return header, nil, ErrInvalidCompiledDictionary
+	}
+	if err := binary.Read(br, binary.BigEndian, &payloadLen); err != nil {
+		return header, nil, ErrInvalidCompiledDictionary
+	}
+	header.WordCount = int(wordCount)
+	header.Metadata.WordCount = header.WordCount
+
+	var payload bytes.Buffer
+	if n, err := io.CopyN(&payload, br, int64(payloadLen)); err != nil || uint64(n) != payloadLen {
+		return header, nil, ErrInvalidCompiledDictionary
+	}
+	if crc32.ChecksumIEEE(payload.Bytes()) != header.Checksum {
+		return header, nil, ErrCompiledChecksum
+	}
+
+	var content []byte
+	if header.Compressed {
+		gz, err := gzip.NewReader(&payload)
+		if err != nil {
+			return header, nil, fmt.Errorf("%w: %v", ErrInvalidCompiledDictionary, err)
+		}
+		// The word count is untrusted, so it only bounds the payload
+		limit := int64(header.WordCount) * (maxCompiledWordBytes + 1)
+		if limit > maxCompiledContent {
+			limit = maxCompiledContent
+		}
+		content, err = io.ReadAll(io.LimitReader(gz, limit+1))
+		if err != nil {
+			return header, nil, fmt.Errorf("%w: %v", ErrInvalidCompiledDictionary, err)
+		}
+		if int64(len(content)) > limit {
+			return header, nil, fmt.Errorf("%w: payload larger than %d bytes for %d words", ErrInvalidCompiledDictionary, limit, header.WordCount)
+		}
+	} else {
+		content = payload.Bytes()
+	}
+
+	var words []string
+	if len(content) > 0 {
+		words = strings.Split(string(content), "\n")
+	}
+	if len(words) != header.WordCount {
+		return header, nil, fmt.Errorf("%w: expected %d words, found %d", ErrInvalidCompiledDictionary, header.WordCount, len(words))
+	}
+
+	return header, words, nil
+}
@@ -0,0 +1,120 @@
+package dymean_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"github.com/bi0dread/dymean"
+	"hash/crc32"
+	"strings"
+	"testing"
+)
+
+// TestCompiledRoundTrip tests writing and loading compiled dictionaries
+func TestCompiledRoundTrip(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		src := dymean.NewDidYouMean(10000, 7)
+		src.AddWordsForLanguage([]string{"bonjour", "maison", "café"}, dymean.French)
+
+		var buf bytes.Buffer
+		if err := src.WriteCompiled(&buf, dymean.French, compress); err != nil {
+			t.Fatalf("Failed to write compiled dictionary: %v", err)
+		}
+
+		dst := dymean.NewDidYouMean(10000, 7)
+		header, err := dst.LoadCompiled(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("Failed to load compiled dictionary (compress=%v): %v", compress, err)
+		}
+		if header.Language != dymean.French || header.WordCount != 3 || header.Compressed != compress || header.Version != 2 {
+			t.Errorf("Unexpected header %+v", header)
+		}
+		if !dymean.DiffDictionaries(src, dst, dymean.French).Equal() {
+			t.Error("Expected loaded dictionary to match the source")
+		}
+	}
+}
+
+// TestCompiledValidation tests that corrupt artifacts are rejected
+func TestCompiledValidation(t *testing.T) {
+	src := dymean.NewDidYouMean(10000, 7)
+	src.AddWords([]string{"hello", "world"})
+
+	var buf bytes.Buffer
+	if err := src.WriteCompiled(&buf, dymean.English, true); err != nil {
+		t.Fatalf("Failed to write compiled dictionary: %v", err)
+	}
+	data := buf.Bytes()
+
+	if _, err := dymean.ValidateCompiled(bytes.NewReader([]byte("hello\nworld\n"))); !errors.Is(err, dymean.ErrInvalidCompiledDictionary) {
+		t.Errorf("Expected invalid dictionary error for plain text, got %v", err)
+	}
+
+	corrupt := append([]byte(nil), data...)
+	corrupt[len(corrupt)-1] ^= 0xff
+	if _, err := dymean.ValidateCompiled(bytes.NewReader(corrupt)); !errors.Is(err, dymean.ErrCompiledChecksum) {
+		t.Errorf("Expected checksum error, got %v", err)
+	}
+
+	future := append([]byte(nil), data...)
+	future[5] = 99
+	if _, err := dymean.ValidateCompiled(bytes.NewReader(future)); !errors.Is(err, dymean.ErrUnsupportedCompiledFormat) {
+		t.Errorf("Expected unsupported version error, got %v", err)
+	}
+
+	dst := dymean.NewDidYouMean(10000, 7)
+	if _, err := dst.LoadCompiled(bytes.NewReader(data[:len(data)-3])); err == nil {
+		t.Error("Expected truncated dictionary to be rejected")
+	}
+	if dst.IsCorrect("hello") {
+		t.Error("Expected nothing to be loaded from a truncated dictionary")
+	}
+}
+
+// forgeCompiled builds a version 1 English artifact with a valid checksum
+// around an arbitrary word count and payload
+func forgeCompiled(wordCount uint32, payload []byte, compressed bool) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("DYMC")
+	binary.Write(&buf, binary.BigEndian, uint16(1))
+	if compressed {
+		buf.WriteByte(1)
+	} else {
+		buf.WriteByte(0)
+	}
+	buf.WriteByte(2)
+	buf.WriteString("en")
+	binary.Write(&buf, binary.BigEndian, wordCount)
+	binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(payload))
+	binary.Write(&buf, binary.BigEndian, uint64(len(payload)))
+	buf.Write(payload)
+	return buf.Bytes()
+}
+
+// TestCompiledForgedHeader tests that word counts and payloads out of
+// proportion are rejected without allocating for them
+func TestCompiledForgedHeader(t *testing.T) {
+	if _, err := dymean.ValidateCompiled(bytes.NewReader(forgeCompiled(1<<32-1, []byte("hello"), false))); !errors.Is(err, dymean.ErrInvalidCompiledDictionary) {
+		t.Errorf("Expected invalid dictionary error for a forged word count, got %v", err)
+	}
+
+	var bomb bytes.Buffer
+	gz := gzip.NewWriter(&bomb)
+	gz.Write([]byte(strings.Repeat("a", 8<<20)))
+	gz.Close()
+	for _, wordCount := range []uint32{1, 1<<32 - 1} {
+		_, err := dymean.ValidateCompiled(bytes.NewReader(forgeCompiled(wordCount, bomb.Bytes(), true)))
+		if !errors.Is(err, dymean.ErrInvalidCompiledDictionary) {
+			t.Errorf("Expected invalid dictionary error for a gzip bomb of %d words, got %v", wordCount, err)
+		}
+	}
+
+	var valid bytes.Buffer
+	gz = gzip.NewWriter(&valid)
+	gz.Write([]byte("hello\nworld"))
+	gz.Close()
+	if header, err := dymean.ValidateCompiled(bytes.NewReader(forgeCompiled(2, valid.Bytes(), true))); err != nil || header.WordCount != 2 {
+		t.Errorf("Expected a valid forged artifact, got %+v, %v", header, err)
+	}
+}
@@ -0,0 +1,87 @@
+package dymean
+
+import (
+	"sort"
+)
+
+// maxCompletionCandidates bounds the words ranked by Complete, so short
+// prefixes of large dictionaries stay cheap to complete as the user types
+const maxCompletionCandidates = 1000
+
+// Complete returns up to limit words of the trie starting with prefix, other
+// than prefix itself, shortest first and then in rune order
+func (t *Trie) Complete(prefix string, limit int) []string {
+	completions := make([]string, 0)
+	if limit <= 0 {
+		return completions
+	}
+
+	node := &t.root
+	for _, r := range prefix {
+		i := sort.Search(len(node.keys), func(i int) bool { return node.keys[i] >= r })
+		if i == len(node.keys) || node.keys[i] != r {
+			return completions
+		}
+		node = node.children[i]
+	}
+
+	// Breadth-first, so shorter words come first
+	type entry struct {
+		node *trieNode
+		word []rune
+	}
+	level := []entry{{node: node, word: []rune(prefix)}}
+	for depth := 0; len(level) > 0; depth++ {
+		next := make([]entry, 0)
+		for _, e := range level {
+			if depth > 0 && e.node.word {
+				completions = append(completions, string(e.word))
+				if len(completions) == limit {
+					return completions
+				}
+			}
+			for i, r := range e.node.keys {
+				word := make([]rune, len(e.word)+1)
+				copy(word, e.word)
+				word[len(e.word)] = r
+				next = append(next, entry{node: e.node.children[i], word: word})
+			}
+		}
+		level = next
+	}
+	return completions
+}
+
+// Complete returns up to limit dictionary words of a language completing a
+// prefix typed by the user, for autocompletion. The shortest words starting
+// with the normalized prefix are ranked by their frequency set with
+// SetWordFrequencies, then by length and in the language's alphabetical
+// order.
+func (dym *DidYouMean) Complete(prefix string, lang Language, limit int) []string {
+	normalized := dym.normalize(prefix, lang)
+	if normalized == "" || limit <= 0 {
+		return []string{}
+	}
+
+	completions := dym.trie(lang).Complete(normalized, maxCompletionCandidates)
+	frequencies := make(map[string]int, len(completions))
+	for _, word := range completions {
+		frequencies[word] = dym.WordFrequency(word, lang)
+	}
+	collator := collatorFor(lang)
+	sort.SliceStable(completions, func(i, j int) bool {
+		a, b := completions[i], completions[j]
+		if frequencies[a] != frequencies[b] {
+			return frequencies[a] > frequencies[b]
+		}
+		if la, lb := len([]rune(a)), len([]rune(b)); la != lb {
+			return la < lb
+		}
+		return collator.compare(a, b) < 0
+	})
+
+	if len(completions) > limit {
+		completions = completions[:limit]
+	}
+	return completions
+}
@@ -0,0 +1,43 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"testing"
+)
+
+// TestTrieComplete tests that completions come shortest first, then in rune
+// order
+func TestTrieComplete(t *testing.T) {
+	trie := dymean.NewTrie([]string{"help", "helper", "hello", "helm", "hel", "held", "world"})
+	if got, expected := trie.Complete("hel", 10), []string{"held", "helm", "help", "hello", "helper"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := trie.Complete("hel", 2); len(got) != 2 {
+		t.Errorf("Expected the limit to apply, got %v", got)
+	}
+	if got := trie.Complete("xyz", 5); len(got) != 0 {
+		t.Errorf("Expected no completion, got %v", got)
+	}
+}
+
+// TestComplete tests that completions are ranked by frequency, then length
+func TestComplete(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"help", "helper", "hello", "helm", "held", "world"})
+
+	if got, expected := dym.Complete("Hel", dymean.English, 3), []string{"held", "helm", "help"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	dym.SetWordFrequencies(map[string]int{"hello": 100, "helper": 10}, dymean.English)
+	if got, expected := dym.Complete("hel", dymean.English, 3), []string{"hello", "helper", "held"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected frequent words first, %v, got %v", expected, got)
+	}
+	if got := dym.Complete("help", dymean.English, 5); !reflect.DeepEqual(got, []string{"helper"}) {
+		t.Errorf("Expected the prefix itself to be left out, got %v", got)
+	}
+	if got := dym.Complete("", dymean.English, 5); len(got) != 0 {
+		t.Errorf("Expected no completion of an empty prefix, got %v", got)
+	}
+}
@@ -0,0 +1,313 @@
+package dymean
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ConfigFileName is the name of the project configuration file found by FindConfig
+const ConfigFileName = ".dymean.yaml"
+
+// ErrNoConfig is returned by FindConfig when no configuration file exists
+var ErrNoConfig = errors.New("no " + ConfigFileName + " found")
+
+// Config is a project configuration shared by the library and the CLI. It is
+// usually read from a .dymean.yaml file:
+//
+//	languages: [en]
+//	dictionaries: [docs/words.txt]
+//	files: ["**/*.md"]
+//	ignore: ["vendor/**", "CHANGELOG.md"]
+//	ignore_patterns: ['\b[0-9a-f]{7,40}\b']
+//	thresholds:
+//	  min_similarity: 0.6
+//	  max_suggestions: 3
+//	rules:
+//	  grammar: true
+//	  whitespace: false
+type Config struct {
+	Languages      []Language      `yaml:"languages"`       // Languages to check; English and Persian when empty
+	Dictionaries   []string        `yaml:"dictionaries"`    // Word lists of the first language, relative to the file
+	Files          []string        `yaml:"files"`           // Globs of files checked when walking directories
+	Ignore         []string        `yaml:"ignore"`          // Globs of files and directories never checked
+	IgnorePatterns []string        `yaml:"ignore_patterns"` // Regular expressions of text left unchecked
+	Thresholds     Thresholds      `yaml:"thresholds"`
+	Rules          map[string]bool `yaml:"rules"` // Toggles by rule: spelling, whitespace, grammar, layout and acronyms
+
+	dir      string           // Directory globs are relative to
+	patterns []*regexp.Regexp // Compiled IgnorePatterns
+}
+
+// Thresholds tune which corrections are reported. Zero values keep the defaults.
+type Thresholds struct {
+	MinSimilarity  float64 `yaml:"min_similarity"`  // Suggestions less similar are dropped
+	MaxSuggestions int     `yaml:"max_suggestions"` // Suggestions kept per correction
+	MaxWordLength  int     `yaml:"max_word_length"` // See WithMaxWordLength
+	MaxTextLength  int     `yaml:"max_text_length"` // See WithMaxTextLength
+}
+
+// configRules are the rules that can be toggled, with their default state
+var configRules = map[string]bool{
+	string(CategorySpelling):     true,
+	string(CategoryWhitespace):   true,
+	string(CategoryGrammar):      false,
+	string(CategoryLayoutSwitch): false,
+	"acronyms":                   true,
+}
+
+// LoadConfig reads a configuration file. Unknown keys are rejected, and
+// dictionary paths and globs are resolved relative to the file's directory.
+func LoadConfig(filename string) (*Config, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	config := &Config{}
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	config.dir = filepath.Dir(filename)
+	for i, dict := range config.Dictionaries {
+		if !filepath.IsAbs(dict) {
+			config.Dictionaries[i] = filepath.Join(config.dir, dict)
+		}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return config, nil
+}
+
+// FindConfig looks for a configuration file in dir and its parents and
+// returns its path, or ErrNoConfig
+func FindConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		candidate := filepath.Join(dir, ConfigFileName)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrNoConfig
+		}
+		dir = parent
+	}
+}
+
+// Validate checks the rules, globs and patterns of a configuration and
+// compiles its ignore patterns. LoadConfig calls it; configurations built in
+// code should call it before use.
+func (c *Config) Validate() error {
+	for rule := range c.Rules {
+		if _, ok := configRules[rule]; !ok {
+			return fmt.Errorf("unknown rule %q", rule)
+		}
+	}
+	for _, glob := range append(append([]string{}, c.Files...), c.Ignore...) {
+		if _, err := path.Match(strings.ReplaceAll(glob, "**", "*"), ""); err != nil {
+			return fmt.Errorf("glob %q: %w", glob, err)
+		}
+	}
+	if c.Thresholds.MinSimilarity < 0 || c.Thresholds.MinSimilarity > 1 {
+		return fmt.Errorf("min_similarity %v is outside [0, 1]", c.Thresholds.MinSimilarity)
+	}
+
+	c.patterns = c.patterns[:0]
+	for _, pattern := range c.IgnorePatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("ignore pattern: %w", err)
+		}
+		c.patterns = append(c.patterns, re)
+	}
+	return nil
+}
+
+// Enabled checks if a rule is on, falling back to its default state
+func (c *Config) Enabled(rule string) bool {
+	if enabled, ok := c.Rules[rule]; ok {
+		return enabled
+	}
+	return configRules[rule]
+}
+
+// Options returns the options implied by the thresholds, for NewDidYouMean
+func (c *Config) Options() []Option {
+	var opts []Option
+	if c.Thresholds.MaxWordLength > 0 {
+		opts = append(opts, WithMaxWordLength(c.Thresholds.MaxWordLength))
+	}
+	if c.Thresholds.MaxTextLength > 0 {
+		opts = append(opts, WithMaxTextLength(c.Thresholds.MaxTextLength))
+	}
+	return opts
+}
+
+// languages returns the configured languages, or the embedded ones
+func (c *Config) languages() []Language {
+	if len(c.Languages) > 0 {
+		return c.Languages
+	}
+	return []Language{English, Persian}
+}
+
+// Load loads the embedded dictionaries of the configured languages and the
+// configured word lists into dym, and applies the rule toggles. A missing
+// embedded dictionary is only an error when no word lists are configured.
+func (c *Config) Load(dym *DidYouMean) error {
+	langs := c.languages()
+	for _, lang := range langs {
+		if err := dym.LoadDefaultDictionary(lang); err != nil && len(c.Dictionaries) == 0 {
+			return err
+		}
+	}
+	for _, dict := range c.Dictionaries {
+		file, err := os.Open(dict)
+		if err != nil {
+			return err
+		}
+		err = dym.LoadDictionaryFromReader(file, langs[0])
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", dict, err)
+		}
+	}
+
+	dym.SetGrammarChecks(c.Enabled(string(CategoryGrammar)))
+	dym.SetLayoutDetection(c.Enabled(string(CategoryLayoutSwitch)))
+	dym.SetAcronymAwareness(c.Enabled("acronyms"))
+	return nil
+}
+
+// CheckText checks a text as configured: in the only configured language or
+// with detection per word, skipping ignore and the ignore patterns, and
+// reporting only the enabled rules within the thresholds
+func (c *Config) CheckText(dym *DidYouMean, text string, ignore []TextRange) []Correction {
+	for _, re := range c.patterns {
+		for _, match := range re.FindAllStringIndex(text, -1) {
+			ignore = append(ignore, TextRange{Start: match[0], End: match[1]})
+		}
+	}
+
+	var corrections []Correction
+	if len(c.Languages) == 1 {
+		corrections = dym.CheckTextIgnoringForLanguage(text, c.Languages[0], ignore)
+	} else {
+		corrections = dym.CheckTextIgnoring(text, ignore)
+	}
+	return c.Filter(corrections)
+}
+
+// Filter drops the corrections of disabled rules and the suggestions outside
+// the thresholds, reusing the storage of corrections. Corrections left
+// without suggestions are kept.
+func (c *Config) Filter(corrections []Correction) []Correction {
+	filtered := corrections[:0]
+	for _, correction := range corrections {
+		if !c.Enabled(string(correction.Category)) {
+			continue
+		}
+		suggestions := correction.Suggestions[:0]
+		for _, suggestion := range correction.Suggestions {
+			if suggestion.Similarity >= c.Thresholds.MinSimilarity {
+				suggestions = append(suggestions, suggestion)
+			}
+		}
+		if max := c.Thresholds.MaxSuggestions; max > 0 && len(suggestions) > max {
+			suggestions = suggestions[:max]
+		}
+		correction.Suggestions = suggestions
+		filtered = append(filtered, correction)
+	}
+	return filtered
+}
+
+// Matches checks if a file found while walking a directory should be checked:
+// it must match one of the file globs, when there are any, and none of the
+// ignore globs. Globs use forward slashes, are relative to the configuration
+// file, and "**" matches any number of directories.
+func (c *Config) Matches(name string) bool {
+	rel := c.relative(name)
+	if c.matchesAny(c.Ignore, rel) {
+		return false
+	}
+	return len(c.Files) == 0 || c.matchesAny(c.Files, rel)
+}
+
+// Ignored checks if a file or directory matches one of the ignore globs
+func (c *Config) Ignored(name string) bool {
+	return c.matchesAny(c.Ignore, c.relative(name))
+}
+
+// relative returns name relative to the configuration directory with forward slashes
+func (c *Config) relative(name string) string {
+	dir := c.dir
+	if dir == "" {
+		dir = "."
+	}
+	absDir, err1 := filepath.Abs(dir)
+	absName, err2 := filepath.Abs(name)
+	if err1 == nil && err2 == nil {
+		if rel, err := filepath.Rel(absDir, absName); err == nil {
+			name = rel
+		}
+	}
+	return filepath.ToSlash(name)
+}
+
+// matchesAny checks if a slash-separated path matches one of the globs. A
+// glob without a slash matches the base name at any depth, and a glob also
+// matches everything below a directory it matches.
+func (c *Config) matchesAny(globs []string, name string) bool {
+	parts := strings.Split(name, "/")
+	for _, glob := range globs {
+		glob = strings.TrimPrefix(glob, "./")
+		if !strings.Contains(glob, "/") {
+			glob = "**/" + glob
+		}
+		if matchGlob(strings.Split(glob, "/"), parts) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches path segments against glob segments, where "**" matches
+// zero or more segments. A match of a leading part of the path counts, so a
+// directory glob covers its contents.
+func matchGlob(glob, parts []string) bool {
+	if len(glob) == 0 {
+		return true
+	}
+	if glob[0] == "**" {
+		for i := 0; i <= len(parts); i++ {
+			if matchGlob(glob[1:], parts[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(parts) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(glob[0], parts[0]); !ok {
+		return false
+	}
+	return matchGlob(glob[1:], parts[1:])
+}
@@ -0,0 +1,232 @@
+// Package config binds the settings of a checker to environment variables and
+// command-line flags, so the server and the CLI are configured the same way
+// and deployments can keep their configuration in the environment:
+//
+//	settings, err := config.FromEnv()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	settings.RegisterFlags(flag.CommandLine) // Flags override the environment
+//	flag.Parse()
+//
+//	dym := dymean.NewDidYouMean(1000000, 7, settings.Options()...)
+//	if _, err := settings.LoadDictionaries(dym); err != nil {
+//		log.Fatal(err)
+//	}
+package config
+
+import (
+	"flag"
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Environment variables of the settings
+const (
+	EnvLanguages       = "DYMEAN_LANGS"             // Comma-separated languages, the first being the default
+	EnvDictDir         = "DYMEAN_DICT_DIR"          // Directory of compiled dictionaries (*.dymc)
+	EnvEmbedded        = "DYMEAN_EMBEDDED"          // Whether to load embedded dictionaries
+	EnvMaxEditDistance = "DYMEAN_MAX_EDIT_DISTANCE" // Edit distance of suggestions
+	EnvMaxSuggestions  = "DYMEAN_MAX_SUGGESTIONS"   // Suggestions returned by default
+	EnvCacheSize       = "DYMEAN_CACHE_SIZE"        // Entries of the similarity cache
+	EnvMaxWordLength   = "DYMEAN_MAX_WORD_LENGTH"   // Longest word checked, in runes
+	EnvMaxTextLength   = "DYMEAN_MAX_TEXT_LENGTH"   // Longest text checked, in bytes
+)
+
+// CompiledExtension is the file extension of the compiled dictionaries loaded
+// from Settings.DictDir
+const CompiledExtension = ".dymc"
+
+// Settings are the settings of a checker. Zero values keep the library's
+// defaults.
+type Settings struct {
+	Languages       []dymean.Language // Languages served; empty for every language with a dictionary
+	DictDir         string            // Directory of compiled dictionaries loaded by LoadDictionaries
+	Embedded        bool              // Load the embedded dictionary of languages without a compiled one
+	MaxEditDistance int               // See dymean.WithSuggestionTuning
+	MaxSuggestions  int               // See dymean.WithSuggestionTuning
+	CacheSize       int               // Entries of a similarity cache, none when 0
+	MaxWordLength   int               // See dymean.WithMaxWordLength
+	MaxTextLength   int               // See dymean.WithMaxTextLength
+}
+
+// Default returns the default settings: embedded dictionaries and the
+// library's limits
+func Default() Settings {
+	return Settings{Embedded: true}
+}
+
+// FromEnv returns the default settings overridden by the environment
+func FromEnv() (Settings, error) {
+	settings := Default()
+	env := &Env{}
+	settings.ReadEnv(env)
+	return settings, env.Err()
+}
+
+// ReadEnv overrides settings with the variables set in env. Errors are kept
+// by env, so callers reading more variables check them once.
+func (s *Settings) ReadEnv(env *Env) {
+	if langs := env.List(EnvLanguages, nil); langs != nil {
+		s.Languages = languages(langs)
+	}
+	s.DictDir = env.String(EnvDictDir, s.DictDir)
+	s.Embedded = env.Bool(EnvEmbedded, s.Embedded)
+	s.MaxEditDistance = env.Int(EnvMaxEditDistance, s.MaxEditDistance)
+	s.MaxSuggestions = env.Int(EnvMaxSuggestions, s.MaxSuggestions)
+	s.CacheSize = env.Int(EnvCacheSize, s.CacheSize)
+	s.MaxWordLength = env.Int(EnvMaxWordLength, s.MaxWordLength)
+	s.MaxTextLength = env.Int(EnvMaxTextLength, s.MaxTextLength)
+}
+
+// RegisterFlags defines flags for the settings on a flag set, defaulting to
+// their current values, so flags parsed afterwards override the environment
+func (s *Settings) RegisterFlags(flags *flag.FlagSet) {
+	flags.Var((*languagesValue)(&s.Languages), "langs", "comma-separated languages, the first being the default (env "+EnvLanguages+")")
+	flags.StringVar(&s.DictDir, "dict-dir", s.DictDir, "directory of compiled dictionaries (*"+CompiledExtension+") (env "+EnvDictDir+")")
+	flags.BoolVar(&s.Embedded, "embedded", s.Embedded, "load the embedded dictionary of languages without a compiled one (env "+EnvEmbedded+")")
+	flags.IntVar(&s.MaxEditDistance, "max-edit-distance", s.MaxEditDistance, "edit distance of suggestions, 0 for each language's default (env "+EnvMaxEditDistance+")")
+	flags.IntVar(&s.MaxSuggestions, "max-suggestions", s.MaxSuggestions, "suggestions returned by default, 0 for each language's default (env "+EnvMaxSuggestions+")")
+	flags.IntVar(&s.CacheSize, "cache-size", s.CacheSize, "entries of the similarity cache, 0 for none (env "+EnvCacheSize+")")
+	flags.IntVar(&s.MaxWordLength, "max-word-length", s.MaxWordLength, "longest word checked, 0 for the default (env "+EnvMaxWordLength+")")
+	flags.IntVar(&s.MaxTextLength, "max-text-length", s.MaxTextLength, "longest text checked, 0 for the default (env "+EnvMaxTextLength+")")
+}
+
+// Validate checks that the settings are usable
+func (s Settings) Validate() error {
+	switch {
+	case s.MaxEditDistance < 0:
+		return fmt.Errorf("max edit distance %d is negative", s.MaxEditDistance)
+	case s.MaxSuggestions < 0:
+		return fmt.Errorf("max suggestions %d is negative", s.MaxSuggestions)
+	case s.CacheSize < 0:
+		return fmt.Errorf("cache size %d is negative", s.CacheSize)
+	case !s.Embedded && s.DictDir == "" && len(s.Languages) > 0:
+		return fmt.Errorf("no dictionary source: set a dictionary directory or enable embedded dictionaries")
+	}
+	return nil
+}
+
+// Options returns the checker options of the settings. The languages are
+// required for readiness, see dymean.WithRequiredLanguages.
+func (s Settings) Options() []dymean.Option {
+	options := make([]dymean.Option, 0)
+	if len(s.Languages) > 0 {
+		options = append(options, dymean.WithRequiredLanguages(s.Languages...))
+	}
+	if s.MaxEditDistance > 0 || s.MaxSuggestions > 0 {
+		options = append(options, dymean.WithSuggestionTuning(dymean.SuggestionTuning{
+			MaxSuggestions:  s.MaxSuggestions,
+			MaxEditDistance: s.MaxEditDistance,
+		}))
+	}
+	if s.CacheSize > 0 {
+		options = append(options, dymean.WithSimilarityCache(dymean.NewSimilarityCache(s.CacheSize)))
+	}
+	if s.MaxWordLength > 0 {
+		options = append(options, dymean.WithMaxWordLength(s.MaxWordLength))
+	}
+	if s.MaxTextLength > 0 {
+		options = append(options, dymean.WithMaxTextLength(s.MaxTextLength))
+	}
+	return options
+}
+
+// LoadCompiled loads the compiled dictionaries of DictDir, if set, and
+// returns their headers. A directory without one is an error.
+func (s Settings) LoadCompiled(dym *dymean.DidYouMean) ([]dymean.CompiledHeader, error) {
+	if s.DictDir == "" {
+		return nil, nil
+	}
+	paths, err := filepath.Glob(filepath.Join(s.DictDir, "*"+CompiledExtension))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no compiled dictionary (*%s) in %s", CompiledExtension, s.DictDir)
+	}
+
+	headers := make([]dymean.CompiledHeader, 0, len(paths))
+	for _, path := range paths {
+		header, err := loadCompiled(dym, path)
+		if err != nil {
+			return headers, fmt.Errorf("%s: %w", path, err)
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
+
+// loadCompiled loads a compiled dictionary file
+func loadCompiled(dym *dymean.DidYouMean, path string) (dymean.CompiledHeader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return dymean.CompiledHeader{}, err
+	}
+	defer file.Close()
+	return dym.LoadCompiled(file)
+}
+
+// LoadDictionaries loads the compiled dictionaries of DictDir, then the
+// embedded dictionaries of the languages still without one, and sets the
+// current language to the first. It returns the headers of the compiled
+// dictionaries.
+func (s Settings) LoadDictionaries(dym *dymean.DidYouMean) ([]dymean.CompiledHeader, error) {
+	headers, err := s.LoadCompiled(dym)
+	if err != nil {
+		return headers, err
+	}
+	loaded := make(map[dymean.Language]bool, len(headers))
+	for _, header := range headers {
+		loaded[header.Language] = true
+	}
+
+	for _, lang := range s.Languages {
+		if loaded[lang] {
+			continue
+		}
+		if !s.Embedded {
+			return headers, fmt.Errorf("no compiled dictionary for %s in %s", lang, s.DictDir)
+		}
+		if err := dym.LoadDefaultDictionary(lang); err != nil {
+			return headers, err
+		}
+	}
+	if len(s.Languages) > 0 {
+		dym.SetLanguage(s.Languages[0])
+	}
+	return headers, nil
+}
+
+// languages converts language codes
+func languages(codes []string) []dymean.Language {
+	langs := make([]dymean.Language, 0, len(codes))
+	for _, code := range codes {
+		langs = append(langs, dymean.Language(code))
+	}
+	return langs
+}
+
+// languagesValue is a flag.Value of comma-separated languages
+type languagesValue []dymean.Language
+
+// String implements flag.Value
+func (v *languagesValue) String() string {
+	if v == nil {
+		return ""
+	}
+	codes := make([]string, 0, len(*v))
+	for _, lang := range *v {
+		codes = append(codes, string(lang))
+	}
+	return strings.Join(codes, ",")
+}
+
+// Set implements flag.Value
+func (v *languagesValue) Set(value string) error {
+	*v = languages(splitList(value))
+	return nil
+}
@@ -0,0 +1,180 @@
+package config_test
+
+import (
+	"flag"
+	"github.com/bi0dread/dymean"
+	"github.com/bi0dread/dymean/config"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// lookup returns a lookup of the variables in vars
+func lookup(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := vars[name]
+		return value, ok
+	}
+}
+
+// TestReadEnv tests that variables override the settings and invalid ones are
+// reported
+func TestReadEnv(t *testing.T) {
+	env := &config.Env{Lookup: lookup(map[string]string{
+		config.EnvLanguages:       " en, fa ,",
+		config.EnvDictDir:         "/dictionaries",
+		config.EnvEmbedded:        "false",
+		config.EnvMaxEditDistance: "1",
+		config.EnvCacheSize:       "1024",
+		config.EnvMaxWordLength:   "",
+	})}
+	settings := config.Default()
+	settings.MaxWordLength = 32
+	settings.ReadEnv(env)
+	if err := env.Err(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := config.Settings{
+		Languages:       []dymean.Language{dymean.English, dymean.Persian},
+		DictDir:         "/dictionaries",
+		MaxEditDistance: 1,
+		CacheSize:       1024,
+		MaxWordLength:   32,
+	}
+	if !reflect.DeepEqual(settings, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, settings)
+	}
+
+	env = &config.Env{Lookup: lookup(map[string]string{
+		config.EnvMaxEditDistance: "two",
+		config.EnvCacheSize:       "big",
+	})}
+	settings = config.Default()
+	settings.ReadEnv(env)
+	if env.Err() == nil {
+		t.Fatal("Expected an error for an invalid edit distance")
+	}
+	if settings.MaxEditDistance != 0 || settings.CacheSize != 0 {
+		t.Errorf("Expected invalid variables to keep the defaults, got %+v", settings)
+	}
+}
+
+// TestFromEnv tests reading the settings from the process environment
+func TestFromEnv(t *testing.T) {
+	t.Setenv(config.EnvLanguages, "fa")
+	t.Setenv(config.EnvMaxEditDistance, "1")
+	settings, err := config.FromEnv()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(settings.Languages, []dymean.Language{dymean.Persian}) || settings.MaxEditDistance != 1 || !settings.Embedded {
+		t.Errorf("Unexpected settings %+v", settings)
+	}
+
+	t.Setenv(config.EnvEmbedded, "maybe")
+	if _, err := config.FromEnv(); err == nil {
+		t.Error("Expected an error for an invalid boolean")
+	}
+}
+
+// TestRegisterFlags tests that flags override the environment
+func TestRegisterFlags(t *testing.T) {
+	settings := config.Default()
+	settings.ReadEnv(&config.Env{Lookup: lookup(map[string]string{
+		config.EnvLanguages:       "en",
+		config.EnvMaxEditDistance: "1",
+		config.EnvCacheSize:       "64",
+	})})
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	settings.RegisterFlags(flags)
+	if err := flags.Parse([]string{"-langs", "fa,en", "-max-edit-distance", "2"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(settings.Languages, []dymean.Language{dymean.Persian, dymean.English}) {
+		t.Errorf("Expected the flag's languages, got %v", settings.Languages)
+	}
+	if settings.MaxEditDistance != 2 {
+		t.Errorf("Expected the flag's edit distance, got %d", settings.MaxEditDistance)
+	}
+	if settings.CacheSize != 64 {
+		t.Errorf("Expected the environment's cache size, got %d", settings.CacheSize)
+	}
+}
+
+// TestValidate tests the rejection of unusable settings
+func TestValidate(t *testing.T) {
+	for _, settings := range []config.Settings{
+		{MaxEditDistance: -1},
+		{CacheSize: -1},
+		{Languages: []dymean.Language{dymean.English}},
+	} {
+		if err := settings.Validate(); err == nil {
+			t.Errorf("Expected an error for %+v", settings)
+		}
+	}
+	if err := config.Default().Validate(); err != nil {
+		t.Errorf("Unexpected error for the defaults: %v", err)
+	}
+}
+
+// TestOptions tests that the options of the settings apply to the checker
+func TestOptions(t *testing.T) {
+	settings := config.Settings{MaxEditDistance: 1, MaxSuggestions: 2, CacheSize: 16}
+	dym := dymean.NewDidYouMean(10000, 7, settings.Options()...)
+	dym.AddWords([]string{"hello", "help", "held", "hell"})
+
+	tuning := dym.SuggestionTuning(dymean.English)
+	if tuning.MaxEditDistance != 1 || tuning.MaxSuggestions != 2 {
+		t.Fatalf("Expected the tuning of the settings, got %+v", tuning)
+	}
+	corrections := dym.CheckText("helo")
+	if len(corrections) != 1 || len(corrections[0].Suggestions) != 2 {
+		t.Errorf("Expected 2 suggestions, got %+v", corrections)
+	}
+	if suggestion := dym.Suggest("hexxo"); suggestion != "hexxo" {
+		t.Errorf("Expected no suggestion beyond an edit, got %q", suggestion)
+	}
+}
+
+// TestLoadDictionaries tests loading compiled dictionaries, then embedded ones
+func TestLoadDictionaries(t *testing.T) {
+	source := dymean.NewDidYouMean(10000, 7)
+	source.AddWordsForLanguage([]string{"سلام", "دنیا"}, dymean.Persian)
+	dir := t.TempDir()
+	file, err := os.Create(filepath.Join(dir, "fa"+config.CompiledExtension))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := source.WriteCompiled(file, dymean.Persian, false); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	settings := config.Settings{Languages: []dymean.Language{dymean.Persian, dymean.English}, DictDir: dir, Embedded: true}
+	dym := dymean.NewDidYouMean(1000000, 7)
+	headers, err := settings.LoadDictionaries(dym)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(headers) != 1 || headers[0].Language != dymean.Persian {
+		t.Errorf("Expected the Persian compiled dictionary, got %+v", headers)
+	}
+	if !dym.IsCorrectForLanguage("دنیا", dymean.Persian) || !dym.IsCorrectForLanguage("hello", dymean.English) {
+		t.Error("Expected the compiled and embedded dictionaries to be loaded")
+	}
+	if dym.GetCurrentLanguage() != dymean.Persian {
+		t.Errorf("Expected the first language to be current, got %s", dym.GetCurrentLanguage())
+	}
+
+	settings.Embedded = false
+	if _, err := settings.LoadDictionaries(dymean.NewDidYouMean(10000, 7)); err == nil {
+		t.Error("Expected an error for a language without a compiled dictionary")
+	}
+	settings.DictDir = t.TempDir()
+	if _, err := settings.LoadDictionaries(dymean.NewDidYouMean(10000, 7)); err == nil {
+		t.Error("Expected an error for a directory without compiled dictionaries")
+	}
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Env reads typed settings from environment variables. Unset and empty
+// variables give the fallback, and so do invalid ones, the first of which is
+// kept for Err, so a configuration is read in one go and checked once.
+type Env struct {
+	// Lookup reads a variable, os.LookupEnv when nil
+	Lookup func(name string) (string, bool)
+
+	err error
+}
+
+// Err returns the error of the first invalid variable read, or nil
+func (e *Env) Err() error {
+	return e.err
+}
+
+// lookup returns the trimmed value of a variable, and whether it is set and
+// not empty
+func (e *Env) lookup(name string) (string, bool) {
+	lookup := e.Lookup
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	value, _ := lookup(name)
+	value = strings.TrimSpace(value)
+	return value, value != ""
+}
+
+// fail records an invalid variable
+func (e *Env) fail(name, value string, err error) {
+	if e.err == nil {
+		e.err = fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+}
+
+// String reads a string variable
+func (e *Env) String(name, fallback string) string {
+	if value, ok := e.lookup(name); ok {
+		return value
+	}
+	return fallback
+}
+
+// List reads a comma-separated variable, without empty items
+func (e *Env) List(name string, fallback []string) []string {
+	value, ok := e.lookup(name)
+	if !ok {
+		return fallback
+	}
+	return splitList(value)
+}
+
+// Bool reads a variable parsed by strconv.ParseBool
+func (e *Env) Bool(name string, fallback bool) bool {
+	value, ok := e.lookup(name)
+	if !ok {
+		return fallback
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		e.fail(name, value, err)
+		return fallback
+	}
+	return parsed
+}
+
+// Int reads an integer variable
+func (e *Env) Int(name string, fallback int) int {
+	value, ok := e.lookup(name)
+	if !ok {
+		return fallback
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		e.fail(name, value, err)
+		return fallback
+	}
+	return parsed
+}
+
+// Float reads a floating-point variable
+func (e *Env) Float(name string, fallback float64) float64 {
+	value, ok := e.lookup(name)
+	if !ok {
+		return fallback
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		e.fail(name, value, err)
+		return fallback
+	}
+	return parsed
+}
+
+// Duration reads a variable parsed by time.ParseDuration, such as "100ms"
+func (e *Env) Duration(name string, fallback time.Duration) time.Duration {
+	value, ok := e.lookup(name)
+	if !ok {
+		return fallback
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		e.fail(name, value, err)
+		return fallback
+	}
+	return parsed
+}
+
+// splitList splits a comma-separated list, trimming items and dropping empty
+// ones
+func splitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
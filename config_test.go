@@ -0,0 +1,143 @@
+package dymean_test
+
+import (
+	"errors"
+	"github.com/bi0dread/dymean"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfig writes a configuration file and a word list into a temporary directory
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "words.txt"), []byte("bonjour\nmaison\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, dymean.ConfigFileName)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestLoadConfig tests parsing, path resolution and validation of configuration files
+func TestLoadConfig(t *testing.T) {
+	path := writeConfig(t, `
+languages: [fr]
+dictionaries: [words.txt]
+files: ["docs/**/*.md"]
+ignore: ["docs/drafts"]
+ignore_patterns: ['[0-9a-f]{7}']
+thresholds:
+  min_similarity: 0.5
+  max_suggestions: 2
+rules:
+  whitespace: false
+`)
+	config, err := dymean.LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Languages) != 1 || config.Languages[0] != dymean.French {
+		t.Errorf("Expected languages [fr], got %v", config.Languages)
+	}
+	if want := filepath.Join(filepath.Dir(path), "words.txt"); config.Dictionaries[0] != want {
+		t.Errorf("Expected dictionary %s, got %s", want, config.Dictionaries[0])
+	}
+	if config.Enabled("whitespace") || !config.Enabled("spelling") || config.Enabled("grammar") {
+		t.Errorf("Expected whitespace off, spelling on and grammar off by default")
+	}
+
+	dir := filepath.Dir(path)
+	for name, want := range map[string]bool{
+		"docs/guide.md":        true,
+		"docs/api/index.md":    true,
+		"docs/drafts/notes.md": false,
+		"docs/guide.txt":       false,
+		"README.md":            false,
+	} {
+		if got := config.Matches(filepath.Join(dir, name)); got != want {
+			t.Errorf("Matches(%s) = %v, want %v", name, got, want)
+		}
+	}
+
+	for _, content := range []string{
+		"languages: [en]\nspeling: true\n",
+		"rules:\n  style: true\n",
+		"ignore_patterns: ['[']\n",
+		"thresholds:\n  min_similarity: 2\n",
+	} {
+		if _, err := dymean.LoadConfig(writeConfig(t, content)); err == nil {
+			t.Errorf("Expected an error for %q", content)
+		}
+	}
+}
+
+// TestFindConfig tests that configuration files are found in parent directories
+func TestFindConfig(t *testing.T) {
+	path := writeConfig(t, "languages: [en]\n")
+	nested := filepath.Join(filepath.Dir(path), "a", "b")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	found, err := dymean.FindConfig(nested)
+	if err != nil || found != path {
+		t.Errorf("Expected %s, got %s (%v)", path, found, err)
+	}
+
+	if _, err := dymean.FindConfig(t.TempDir()); !errors.Is(err, dymean.ErrNoConfig) {
+		t.Errorf("Expected ErrNoConfig, got %v", err)
+	}
+}
+
+// TestConfigCheckText tests that checks follow the configured language, patterns, rules and thresholds
+func TestConfigCheckText(t *testing.T) {
+	config, err := dymean.LoadConfig(writeConfig(t, `
+languages: [fr]
+dictionaries: [words.txt]
+ignore_patterns: ['maisn']
+thresholds:
+  max_suggestions: 1
+rules:
+  whitespace: false
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dym := dymean.NewDidYouMean(10000, 7, config.Options()...)
+	if err := config.Load(dym); err != nil {
+		t.Fatal(err)
+	}
+
+	corrections := config.CheckText(dym, "bonjuor  maisn", nil)
+	if len(corrections) != 1 || corrections[0].Original != "bonjuor" {
+		t.Fatalf("Expected only the misspelled bonjuor, got %+v", corrections)
+	}
+	if len(corrections[0].Suggestions) != 1 || corrections[0].Suggestions[0].Word != "bonjour" {
+		t.Errorf("Expected the single suggestion bonjour, got %+v", corrections[0].Suggestions)
+	}
+	if corrections[0].Language != dymean.French {
+		t.Errorf("Expected French, got %s", corrections[0].Language)
+	}
+
+	strict := &dymean.Config{Thresholds: dymean.Thresholds{MinSimilarity: 0.99}}
+	if err := strict.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	filtered := strict.Filter(dym.CheckTextForLanguage("bonjuor", dymean.French))
+	if len(filtered) != 1 || len(filtered[0].Suggestions) != 0 {
+		t.Errorf("Expected the correction without suggestions, got %+v", filtered)
+	}
+}
+
+// TestConfigLoadWithoutDictionaries tests that a missing embedded dictionary is reported
+func TestConfigLoadWithoutDictionaries(t *testing.T) {
+	config := &dymean.Config{Languages: []dymean.Language{dymean.French}}
+	err := config.Load(dymean.NewDidYouMean(1000, 7))
+	if !errors.Is(err, dymean.ErrNoDefaultDictionary) || !strings.Contains(err.Error(), "fr") {
+		t.Errorf("Expected ErrNoDefaultDictionary for French, got %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package dymean
+
+// confusableCeiling keeps boosted confusables just below an exact match
+const confusableCeiling = 0.999
+
+// ConfusablePair is a pair of words often used or typed in place of each
+// other, such as "affect" and "effect"
+type ConfusablePair struct {
+	A, B   string
+	Weight float64 // Ranking boost between 0 and 1
+}
+
+// AddConfusables registers confusion pairs for the current language
+func (dym *DidYouMean) AddConfusables(pairs []ConfusablePair) {
+	dym.AddConfusablesForLanguage(pairs, dym.currentLang)
+}
+
+// AddConfusablesForLanguage registers confusion pairs for a language. When a
+// word matches one side of a pair, suggestions for it include the other side
+// if it is a dictionary word, boosted by the pair's weight: a weight of 1
+// ranks it first whatever its edit distance, and a correct word is offered
+// its confusable as an alternative ranked after itself. Registering a pair
+// again replaces its weight.
+func (dym *DidYouMean) AddConfusablesForLanguage(pairs []ConfusablePair, lang Language) {
+	dym.mu.Lock()
+	defer dym.mu.Unlock()
+
+	if dym.confusables[lang] == nil {
+		dym.confusables[lang] = make(map[string]map[string]float64)
+	}
+	for _, pair := range pairs {
+		a, b := dym.normalize(pair.A, lang), dym.normalize(pair.B, lang)
+		if a == "" || b == "" || a == b {
+			continue
+		}
+		weight := pair.Weight
+		if weight < 0 {
+			weight = 0
+		} else if weight > 1 {
+			weight = 1
+		}
+		dym.addConfusable(lang, a, b, weight)
+		dym.addConfusable(lang, b, a, weight)
+	}
+}
+
+// addConfusable records one direction of a pair. The caller must hold the
+// write lock.
+func (dym *DidYouMean) addConfusable(lang Language, word, other string, weight float64) {
+	if dym.confusables[lang][word] == nil {
+		dym.confusables[lang][word] = make(map[string]float64)
+	}
+	dym.confusables[lang][word][other] = weight
+}
+
+// RemoveConfusablesForLanguage unregisters confusion pairs of a language
+func (dym *DidYouMean) RemoveConfusablesForLanguage(pairs []ConfusablePair, lang Language) {
+	dym.mu.Lock()
+	defer dym.mu.Unlock()
+
+	for _, pair := range pairs {
+		a, b := dym.normalize(pair.A, lang), dym.normalize(pair.B, lang)
+		delete(dym.confusables[lang][a], b)
+		delete(dym.confusables[lang][b], a)
+		if len(dym.confusables[lang][a]) == 0 {
+			delete(dym.confusables[lang], a)
+		}
+		if len(dym.confusables[lang][b]) == 0 {
+			delete(dym.confusables[lang], b)
+		}
+	}
+}
+
+// Confusables returns the words registered as confusable with a word in a
+// language, with their weights
+func (dym *DidYouMean) Confusables(word string, lang Language) map[string]float64 {
+	normalized := dym.normalize(word, lang)
+	dym.mu.RLock()
+	defer dym.mu.RUnlock()
+
+	result := make(map[string]float64, len(dym.confusables[lang][normalized]))
+	for other, weight := range dym.confusables[lang][normalized] {
+		result[other] = weight
+	}
+	return result
+}
+
+// applyConfusables adds the dictionary words confusable with a word to its
+// candidates and boosts them by their weights
+func (dym *DidYouMean) applyConfusables(normalized string, lang Language, suggestions []Suggestion, memo *similarityMemo) []Suggestion {
+	confusables := dym.Confusables(normalized, lang)
+	if len(confusables) == 0 {
+		return suggestions
+	}
+
+	index := make(map[string]int, len(suggestions))
+	for i, suggestion := range suggestions {
+		index[suggestion.Word] = i
+	}
+	for other, weight := range confusables {
+		i, ok := index[other]
+		if !ok {
+			if !dym.hasWord(lang, other) {
+				continue
+			}
+			i = len(suggestions)
+			suggestions = append(suggestions, Suggestion{Word: other, Similarity: memo.similarity(normalized, other)})
+		}
+		// A full weight ranks the confusable above every other candidate,
+		// while staying below a correct word
+		suggestions[i].Similarity += (1.0 - suggestions[i].Similarity) * weight * confusableCeiling
+	}
+	return suggestions
+}
+
+// confusableAlternatives returns the dictionary words confusable with a
+// correct word, best first
+func (dym *DidYouMean) confusableAlternatives(normalized string, lang Language) []Suggestion {
+	alternatives := dym.applyConfusables(normalized, lang, nil, dym.newSimilarityMemo())
+	sortSuggestions(alternatives, lang)
+	return alternatives
+}
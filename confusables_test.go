@@ -0,0 +1,33 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestConfusables tests that registered confusion pairs are offered and boosted
+func TestConfusables(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"affect", "effect", "affects", "defect"})
+	dym.AddWordsForLanguage([]string{"قضاوت", "قضا"}, dymean.Persian)
+	dym.AddConfusables([]dymean.ConfusablePair{{A: "Affect", B: "effect", Weight: 0.5}})
+	dym.AddConfusablesForLanguage([]dymean.ConfusablePair{{A: "قضاوت", B: "قزاوت", Weight: 1}}, dymean.Persian)
+
+	suggestions := dym.GetSuggestions("affect", 3, 2)
+	if len(suggestions) != 2 || suggestions[0].Word != "affect" || suggestions[1].Word != "effect" {
+		t.Errorf("Expected the confusable as an alternative to a correct word, got %v", suggestions)
+	}
+
+	suggestions = dym.GetSuggestionsForLanguage("قزاوت", 3, 1, dymean.Persian)
+	if len(suggestions) == 0 || suggestions[0].Word != "قضاوت" || suggestions[0].Similarity >= 1 {
+		t.Errorf("Expected the boosted confusable first below an exact match, got %v", suggestions)
+	}
+
+	if confusables := dym.Confusables("effect", dymean.English); confusables["affect"] != 0.5 {
+		t.Errorf("Expected a symmetric pair, got %v", confusables)
+	}
+	dym.RemoveConfusablesForLanguage([]dymean.ConfusablePair{{A: "effect", B: "affect"}}, dymean.English)
+	if suggestions := dym.GetSuggestions("affect", 3, 2); len(suggestions) != 1 {
+		t.Errorf("Expected no alternatives after removal, got %v", suggestions)
+	}
+}
@@ -0,0 +1,130 @@
+package dymean
+
+import (
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// CorpusEntry is a word reported as misspelled across a corpus
+type CorpusEntry struct {
+	Word        string   // The word as first found
+	Count       int      // Times it was reported
+	Documents   int      // Documents it was reported in
+	Suggestions []string // Best suggestions, empty for unknown words
+	Language    Language
+}
+
+// CorpusReport aggregates the spelling corrections of many documents, to
+// curate a project dictionary
+type CorpusReport struct {
+	Documents          int           // Documents checked
+	Corrections        int           // Spelling corrections reported
+	Misspellings       []CorpusEntry // Words with suggestions, most frequent first
+	UnknownWords       []CorpusEntry // Words without suggestions, most frequent first
+	SuggestedAdditions []CorpusEntry // Likely correct words to add to the dictionary
+}
+
+// NewCorpusReport aggregates the spelling corrections of checked documents,
+// counting words case-insensitively. A word is suggested for the dictionary
+// when it is reported in at least two documents and occurs more often in the
+// corpus than any of its suggestions, as project terms ("kubernetes") do and
+// typos ("teh") do not.
+func NewCorpusReport(results []DocumentResult) CorpusReport {
+	report := CorpusReport{Documents: len(results)}
+	entries := make(map[string]*CorpusEntry)
+	order := make([]string, 0)
+	for _, result := range results {
+		seen := make(map[string]bool)
+		for _, correction := range result.Corrections {
+			if correction.Category != CategorySpelling {
+				continue
+			}
+			report.Corrections++
+
+			key := strings.ToLower(correction.Original)
+			entry, ok := entries[key]
+			if !ok {
+				entry = &CorpusEntry{Word: correction.Original, Language: correction.Language}
+				for _, suggestion := range correction.Suggestions {
+					entry.Suggestions = append(entry.Suggestions, suggestion.Word)
+				}
+				entries[key] = entry
+				order = append(order, key)
+			}
+			entry.Count++
+			if !seen[key] {
+				seen[key] = true
+				entry.Documents++
+			}
+		}
+	}
+
+	// Count the suggestions wherever they occur in the corpus
+	occurrences := make(map[string]int)
+	for _, entry := range entries {
+		for _, suggestion := range entry.Suggestions {
+			occurrences[strings.ToLower(suggestion)] = 0
+		}
+	}
+	for _, result := range results {
+		for _, token := range Tokenize(result.Text) {
+			word := strings.ToLower(token.Text)
+			if _, ok := occurrences[word]; ok {
+				occurrences[word]++
+			}
+		}
+	}
+
+	for _, key := range order {
+		entry := *entries[key]
+		if len(entry.Suggestions) > 0 {
+			report.Misspellings = append(report.Misspellings, entry)
+		} else {
+			report.UnknownWords = append(report.UnknownWords, entry)
+		}
+		common := 0
+		for _, suggestion := range entry.Suggestions {
+			common = max(common, occurrences[strings.ToLower(suggestion)])
+		}
+		if entry.Documents >= 2 && entry.Count > common {
+			report.SuggestedAdditions = append(report.SuggestedAdditions, entry)
+		}
+	}
+	sortCorpusEntries(report.Misspellings)
+	sortCorpusEntries(report.UnknownWords)
+	sortCorpusEntries(report.SuggestedAdditions)
+	return report
+}
+
+// CheckCorpus checks files of a file system with CheckText, or CheckMarkdown
+// for Markdown files, and aggregates their corrections with NewCorpusReport
+func (dym *DidYouMean) CheckCorpus(fsys fs.FS, paths []string) (CorpusReport, error) {
+	results := make([]DocumentResult, 0, len(paths))
+	for _, name := range paths {
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return CorpusReport{}, err
+		}
+		text := string(content)
+		result := DocumentResult{Path: name, Text: text}
+		if ext := strings.ToLower(path.Ext(name)); ext == ".md" || ext == ".markdown" {
+			result.Corrections = dym.CheckMarkdown(text)
+		} else {
+			result.Corrections = dym.CheckText(text)
+		}
+		results = append(results, result)
+	}
+	return NewCorpusReport(results), nil
+}
+
+// sortCorpusEntries sorts entries by descending count, then by word
+func sortCorpusEntries(entries []CorpusEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return entries[i].Word < entries[j].Word
+	})
+}
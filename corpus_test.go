@@ -0,0 +1,51 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+	"testing/fstest"
+)
+
+// TestCheckCorpus tests aggregating the corrections of many documents
+func TestCheckCorpus(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"the", "cluster", "runs", "on", "nodes", "deploy", "to", "with"})
+
+	fsys := fstest.MapFS{
+		"a.md":  {Data: []byte("Teh cluster runs on kubernetes.\n\n`xyzzy`")},
+		"b.txt": {Data: []byte("Deploy to kubernetes with teh nodes. The nodes.")},
+		"c.txt": {Data: []byte("The cluster runs on qqqq and kubernetes")},
+	}
+	report, err := dym.CheckCorpus(fsys, []string{"a.md", "b.txt", "c.txt"})
+	if err != nil {
+		t.Fatalf("Failed to check corpus: %v", err)
+	}
+	if report.Documents != 3 || report.Corrections != 6 {
+		t.Errorf("Expected 3 documents and 6 corrections, got %d and %d", report.Documents, report.Corrections)
+	}
+
+	counts := func(entries []dymean.CorpusEntry) map[string]int {
+		found := make(map[string]int)
+		for _, entry := range entries {
+			found[entry.Word] = entry.Count
+		}
+		return found
+	}
+	misspellings := counts(report.Misspellings)
+	if misspellings["Teh"] != 2 || report.Misspellings[0].Documents != 2 {
+		t.Errorf("Expected teh twice in 2 documents first, got %+v", report.Misspellings)
+	}
+	if unknown := counts(report.UnknownWords); unknown["qqqq"] != 1 || unknown["xyzzy"] != 0 {
+		t.Errorf("Expected only qqqq unknown, got %+v", report.UnknownWords)
+	}
+
+	// kubernetes spans three documents; teh loses to the more frequent "the"
+	additions := counts(report.SuggestedAdditions)
+	if len(additions) != 1 || additions["kubernetes"] != 3 {
+		t.Errorf("Expected kubernetes as the only addition, got %+v", report.SuggestedAdditions)
+	}
+
+	if _, err := dym.CheckCorpus(fsys, []string{"missing.txt"}); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,163 @@
+package dymean
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// recencyBoost is the largest share of the gap to a perfect score that
+// recency closes for a suggestion, so trending words win ties and near-ties
+// without outranking much closer words
+const recencyBoost = 0.2
+
+// decayedWeight is the weight of a word's occurrences at a point in time
+type decayedWeight struct {
+	value float64
+	at    time.Time
+}
+
+// valueAt returns the weight decayed, or grown back, to a point in time
+func (w decayedWeight) valueAt(t time.Time, halfLife time.Duration) float64 {
+	return w.value * math.Exp2(-t.Sub(w.at).Seconds()/halfLife.Seconds())
+}
+
+// recency holds the time-decayed weights of words added with AddWordsAt
+type recency struct {
+	mu       sync.Mutex
+	halfLife time.Duration
+	weights  map[Language]map[string]decayedWeight
+}
+
+// WithDecay weighs the words added with AddWordsAt by recency, for
+// dictionaries of trending terms such as hashtags or news entities: each
+// addition counts 1, halving every halfLife. The weights add to the word
+// frequencies used by segmentation and SuggestPhrase, and boost suggestions,
+// so recent words win over equally close stale ones; PruneStale removes the
+// words whose weight faded. Non-positive half-lives disable decay.
+func WithDecay(halfLife time.Duration) Option {
+	return func(dym *DidYouMean) {
+		if halfLife <= 0 {
+			dym.decay = nil
+			return
+		}
+		dym.decay = &recency{halfLife: halfLife, weights: make(map[Language]map[string]decayedWeight)}
+	}
+}
+
+// AddWordsAt adds words to a language's dictionary as seen at a time, such as
+// the time of the posts they were harvested from. With WithDecay each one
+// adds 1 to the word's weight at that time; without it this is
+// AddWordsForLanguage.
+func (dym *DidYouMean) AddWordsAt(words []string, lang Language, at time.Time) {
+	dym.AddWordsForLanguage(words, lang)
+	if dym.decay == nil {
+		return
+	}
+
+	dym.decay.mu.Lock()
+	defer dym.decay.mu.Unlock()
+	weights := dym.decay.weights[lang]
+	if weights == nil {
+		weights = make(map[string]decayedWeight)
+		dym.decay.weights[lang] = weights
+	}
+	for _, word := range words {
+		normalized := dym.normalize(word, lang)
+		current, ok := weights[normalized]
+		if !ok {
+			weights[normalized] = decayedWeight{value: 1, at: at}
+			continue
+		}
+		// The weight is kept at the later of the two times
+		if at.After(current.at) {
+			weights[normalized] = decayedWeight{value: current.valueAt(at, dym.decay.halfLife) + 1, at: at}
+		} else {
+			weights[normalized] = decayedWeight{value: current.value + decayedWeight{value: 1, at: at}.valueAt(current.at, dym.decay.halfLife), at: current.at}
+		}
+	}
+}
+
+// RecencyWeight returns the decayed weight of a word now, 0 for a word never
+// added with AddWordsAt or without WithDecay
+func (dym *DidYouMean) RecencyWeight(word string, lang Language) float64 {
+	if dym.decay == nil {
+		return 0
+	}
+	normalized := dym.normalize(word, lang)
+	dym.decay.mu.Lock()
+	defer dym.decay.mu.Unlock()
+	weight, ok := dym.decay.weights[lang][normalized]
+	if !ok {
+		return 0
+	}
+	return weight.valueAt(time.Now(), dym.decay.halfLife)
+}
+
+// PruneStale removes from the dictionaries the words added with AddWordsAt
+// whose decayed weight fell below minWeight, and returns them in sorted
+// order. Words added otherwise are never pruned.
+func (dym *DidYouMean) PruneStale(minWeight float64) []string {
+	if dym.decay == nil {
+		return make([]string, 0)
+	}
+
+	now := time.Now()
+	stale := make(map[Language][]string)
+	dym.decay.mu.Lock()
+	for lang, weights := range dym.decay.weights {
+		for word, weight := range weights {
+			if weight.valueAt(now, dym.decay.halfLife) < minWeight {
+				stale[lang] = append(stale[lang], word)
+				delete(weights, word)
+			}
+		}
+	}
+	dym.decay.mu.Unlock()
+
+	pruned := make([]string, 0)
+	for lang, words := range stale {
+		dym.RemoveWordsForLanguage(words, lang)
+		pruned = append(pruned, words...)
+	}
+	sort.Strings(pruned)
+	return pruned
+}
+
+// recencyWeights returns the decayed weights of a language's words now with
+// their sum, nil without WithDecay
+func (dym *DidYouMean) recencyWeights(lang Language) (map[string]float64, float64) {
+	if dym.decay == nil {
+		return nil, 0
+	}
+	now := time.Now()
+	dym.decay.mu.Lock()
+	defer dym.decay.mu.Unlock()
+	weights := make(map[string]float64, len(dym.decay.weights[lang]))
+	total := 0.0
+	for word, weight := range dym.decay.weights[lang] {
+		weights[word] = weight.valueAt(now, dym.decay.halfLife)
+		total += weights[word]
+	}
+	return weights, total
+}
+
+// applyRecency boosts the suggestions of recently added words by their
+// decayed weight
+func (dym *DidYouMean) applyRecency(lang Language, suggestions []Suggestion) []Suggestion {
+	if dym.decay == nil {
+		return suggestions
+	}
+	now := time.Now()
+	dym.decay.mu.Lock()
+	defer dym.decay.mu.Unlock()
+	weights := dym.decay.weights[lang]
+	for i := range suggestions {
+		if weight, ok := weights[suggestions[i].Word]; ok {
+			value := weight.valueAt(now, dym.decay.halfLife)
+			suggestions[i].Similarity += (1.0 - suggestions[i].Similarity) * recencyBoost * value / (value + 1)
+		}
+	}
+	return suggestions
+}
@@ -0,0 +1,43 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestDecay tests ranking trending words by recency and pruning stale ones
+func TestDecay(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithDecay(time.Hour))
+	now := time.Now()
+	dym.AddWordsAt([]string{"gophercon"}, dymean.English, now.Add(-10*time.Hour))
+	dym.AddWordsAt([]string{"gophercan", "gophercan"}, dymean.English, now)
+
+	if weight := dym.RecencyWeight("gophercan", dymean.English); math.Abs(weight-2) > 0.01 {
+		t.Errorf("Expected a weight of 2, got %v", weight)
+	}
+	if weight := dym.RecencyWeight("gophercon", dymean.English); math.Abs(weight-1.0/1024) > 1e-4 {
+		t.Errorf("Expected a weight of 1/1024, got %v", weight)
+	}
+
+	// Equally close, the recent word ranks first
+	suggestions := dym.GetSuggestions("gophercun", 2, 1)
+	if len(suggestions) != 2 || suggestions[0].Word != "gophercan" || suggestions[0].Similarity <= suggestions[1].Similarity {
+		t.Errorf("Expected the trending word first, got %v", suggestions)
+	}
+
+	// Older additions decay to the time of the latest
+	dym.AddWordsAt([]string{"gophercan"}, dymean.English, now.Add(-time.Hour))
+	if weight := dym.RecencyWeight("gophercan", dymean.English); math.Abs(weight-2.5) > 0.01 {
+		t.Errorf("Expected a weight of 2.5, got %v", weight)
+	}
+
+	if pruned := dym.PruneStale(0.01); !reflect.DeepEqual(pruned, []string{"gophercon"}) {
+		t.Errorf("Expected the stale word to be pruned, got %v", pruned)
+	}
+	if dym.IsCorrect("gophercon") || !dym.IsCorrect("gophercan") {
+		t.Error("Expected only the stale word to be removed")
+	}
+}
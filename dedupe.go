@@ -0,0 +1,92 @@
+package dymean
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// DuplicateGroup is a set of dictionary words considered spelling variants of each other
+type DuplicateGroup struct {
+	Canonical string   // Word kept when merging
+	Variants  []string // Other words of the group
+}
+
+// DeduplicateDictionary reports near-duplicate entries of a language's dictionary,
+// typically caused by inconsistent normalization (ZWNJ, accent or Arabic letter
+// variants). Words are compared after folding those differences away, and
+// grouped when their folded forms have a similarity of at least threshold;
+// a threshold of 1.0 only groups words that fold to the same form.
+func (dym *DidYouMean) DeduplicateDictionary(lang Language, threshold float64) []DuplicateGroup {
+	words := dym.GetDictionaryWords(lang)
+	folded := make([]string, len(words))
+	for i, word := range words {
+		folded[i] = foldWord(word)
+	}
+
+	// Union words with similar folded forms
+	parent := make([]int, len(words))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i, neighbors := range NearestNeighbors(folded, -1, threshold) {
+		for _, neighbor := range neighbors {
+			parent[find(neighbor.Index)] = find(i)
+		}
+	}
+
+	members := make(map[int][]string)
+	for i, word := range words {
+		root := find(i)
+		members[root] = append(members[root], word)
+	}
+
+	groups := make([]DuplicateGroup, 0)
+	for _, group := range members {
+		if len(group) < 2 {
+			continue
+		}
+
+		// Prefer the most complete spelling, which keeps its accents and ZWNJ
+		sort.Slice(group, func(a, b int) bool {
+			markedA, markedB := foldWord(group[a]) != group[a], foldWord(group[b]) != group[b]
+			if markedA != markedB {
+				return markedA
+			}
+			lenA, lenB := utf8.RuneCountInString(group[a]), utf8.RuneCountInString(group[b])
+			if lenA != lenB {
+				return lenA > lenB
+			}
+			return group[a] < group[b]
+		})
+		groups = append(groups, DuplicateGroup{Canonical: group[0], Variants: group[1:]})
+	}
+
+	sort.Slice(groups, func(a, b int) bool {
+		return groups[a].Canonical < groups[b].Canonical
+	})
+
+	return groups
+}
+
+// MergeDuplicates removes the variants of each group from a language's
+// dictionary, keeping the canonical words, and returns the number removed
+func (dym *DidYouMean) MergeDuplicates(lang Language, groups []DuplicateGroup) int {
+	removed := 0
+	for _, group := range groups {
+		for _, variant := range group.Variants {
+			if dym.hasWord(lang, variant) {
+				removed++
+			}
+		}
+		dym.RemoveWordsForLanguage(group.Variants, lang)
+	}
+	return removed
+}
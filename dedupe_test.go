@@ -0,0 +1,55 @@
+package dymean_test
+
+import (
+	"bytes"
+	"github.com/bi0dread/dymean"
+	"strings"
+	"testing"
+)
+
+// TestDeduplicateDictionary tests finding and merging spelling variants
+func TestDeduplicateDictionary(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	err := dym.LoadDictionaryFromReader(strings.NewReader("# French words\ncafé\ncafe\n\nthé\nthe\nmaison\n"), dymean.French)
+	if err != nil {
+		t.Fatalf("Failed to load dictionary: %v", err)
+	}
+
+	groups := dym.DeduplicateDictionary(dymean.French, 1.0)
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 duplicate groups, got %v", groups)
+	}
+	if groups[0].Canonical != "café" || len(groups[0].Variants) != 1 || groups[0].Variants[0] != "cafe" {
+		t.Errorf("Unexpected group %+v", groups[0])
+	}
+
+	if removed := dym.MergeDuplicates(dymean.French, groups); removed != 2 {
+		t.Errorf("Expected 2 variants removed, got %d", removed)
+	}
+	if dym.IsCorrectForLanguage("cafe", dymean.French) {
+		t.Error("Expected 'cafe' to be removed after merging")
+	}
+
+	var buf bytes.Buffer
+	if err := dym.WriteDictionary(&buf, dymean.French); err != nil {
+		t.Fatalf("Failed to write dictionary: %v", err)
+	}
+	if buf.String() != "café\nmaison\nthé\n" {
+		t.Errorf("Unexpected merged dictionary %q", buf.String())
+	}
+}
+
+// TestDeduplicateDictionaryThreshold tests fuzzy grouping of folded forms
+func TestDeduplicateDictionaryThreshold(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"color", "colour", "house"})
+
+	if groups := dym.DeduplicateDictionary(dymean.English, 1.0); len(groups) != 0 {
+		t.Errorf("Expected no exact duplicates, got %v", groups)
+	}
+
+	groups := dym.DeduplicateDictionary(dymean.English, 0.8)
+	if len(groups) != 1 || groups[0].Canonical != "colour" {
+		t.Errorf("Expected 'color' and 'colour' to be grouped, got %v", groups)
+	}
+}
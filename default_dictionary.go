@@ -0,0 +1,59 @@
+package dymean
+
+import (
+	"errors"
+	"fmt"
+)
+
+// embeddedDictionaryVersion is the version of the embedded word lists,
+// reported by DictionaryInfo. Bump it whenever the lists change.
+const embeddedDictionaryVersion = "2"
+
+// ErrNoDefaultDictionary is matched by errors.Is for every *NoDefaultDictionaryError
+var ErrNoDefaultDictionary = errors.New("no default dictionary")
+
+// NoDefaultDictionaryError reports that LoadDefaultDictionary has no embedded
+// word list to load for a language
+type NoDefaultDictionaryError struct {
+	Language Language
+	Reason   string
+}
+
+func (e *NoDefaultDictionaryError) Error() string {
+	return fmt.Sprintf("no default dictionary for %s: %s", e.Language, e.Reason)
+}
+
+// Is makes errors.Is(err, ErrNoDefaultDictionary) match
+func (e *NoDefaultDictionaryError) Is(target error) bool {
+	return target == ErrNoDefaultDictionary
+}
+
+// GetWordsForLanguage returns words for a specific language
+func GetWordsForLanguage(lang Language) []string {
+	switch lang {
+	case English:
+		return GetEnglishWords()
+	case Persian:
+		return GetPersianWords()
+	case Greek:
+		return GetGreekWords()
+	case Polish:
+		return GetPolishWords()
+	default:
+		return GetEnglishWords() // Default to English
+	}
+}
+
+// defaultWords returns the embedded word list of a language, or a
+// *NoDefaultDictionaryError when there is none to load
+func (dym *DidYouMean) defaultWords(lang Language) ([]string, error) {
+	switch {
+	case dym.withoutEmbedded:
+		return nil, &NoDefaultDictionaryError{Language: lang, Reason: "embedded dictionaries are disabled"}
+	case !embeddedDictionaries:
+		return nil, &NoDefaultDictionaryError{Language: lang, Reason: "built with the dymean_nodict tag"}
+	case lang != English && lang != Persian && lang != Greek && lang != Polish:
+		return nil, &NoDefaultDictionaryError{Language: lang, Reason: "no embedded word list"}
+	}
+	return GetWordsForLanguage(lang), nil
+}
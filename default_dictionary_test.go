@@ -0,0 +1,48 @@
+package dymean_test
+
+import (
+	"context"
+	"errors"
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestLoadDefaultDictionaryErrors tests the typed error for missing default dictionaries
+func TestLoadDefaultDictionaryErrors(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	if err := dym.LoadDefaultDictionary(dymean.English); err != nil {
+		t.Fatalf("Expected the English dictionary to load, got %v", err)
+	}
+
+	// Languages without an embedded list no longer fall back to English
+	err := dym.LoadDefaultDictionary(dymean.French)
+	var noDict *dymean.NoDefaultDictionaryError
+	if !errors.Is(err, dymean.ErrNoDefaultDictionary) || !errors.As(err, &noDict) || noDict.Language != dymean.French {
+		t.Errorf("Expected a NoDefaultDictionaryError for French, got %v", err)
+	}
+	if dym.GetWordCount(dymean.French) != 0 {
+		t.Error("Expected nothing to be loaded for French")
+	}
+}
+
+// TestWithoutEmbeddedDictionaries tests that embedded lists are refused
+func TestWithoutEmbeddedDictionaries(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithoutEmbeddedDictionaries())
+	if err := dym.LoadDefaultDictionary(dymean.English); !errors.Is(err, dymean.ErrNoDefaultDictionary) {
+		t.Errorf("Expected ErrNoDefaultDictionary, got %v", err)
+	}
+	if dym.GetWordCount(dymean.English) != 0 {
+		t.Error("Expected no embedded words to be loaded")
+	}
+
+	handle := dym.LoadDefaultDictionaryAsync(context.Background(), dymean.Persian, nil)
+	if err := handle.Wait(); !errors.Is(err, dymean.ErrNoDefaultDictionary) {
+		t.Errorf("Expected the async load to fail with ErrNoDefaultDictionary, got %v", err)
+	}
+
+	// Own data still loads
+	dym.AddWords([]string{"hello"})
+	if !dym.IsCorrect("hello") {
+		t.Error("Expected added words to be correct")
+	}
+}
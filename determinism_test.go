@@ -0,0 +1,81 @@
+package dymean_test
+
+import (
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// TestReproducibleSuggestions tests that equally similar suggestions always
+// come in the same order, whatever the order words were added in
+func TestReproducibleSuggestions(t *testing.T) {
+	words := []string{"cat", "bat", "hat", "mat", "rat", "sat", "fat", "pat", "vat"}
+	reversed := make([]string, len(words))
+	for i, word := range words {
+		reversed[len(words)-1-i] = word
+	}
+
+	var first string
+	for run := 0; run < 10; run++ {
+		dym := dymean.NewDidYouMean(10000, 7)
+		if run%2 == 0 {
+			dym.AddWords(words)
+		} else {
+			dym.AddWords(reversed)
+		}
+		output := fmt.Sprint(dym.GetSuggestions("xat", 5, 1), dym.CheckText("xat gat"))
+		if run == 0 {
+			first = output
+		} else if output != first {
+			t.Fatalf("Run %d differs:\n%s\n%s", run, output, first)
+		}
+	}
+
+	expected := "[{bat 0.6666666666666667 } {cat 0.6666666666666667 } {fat 0.6666666666666667 } {hat 0.6666666666666667 } {mat 0.6666666666666667 }]"
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords(reversed)
+	if suggestions := fmt.Sprint(dym.GetSuggestions("xat", 5, 1)); suggestions != expected {
+		t.Errorf("Expected ties in word order %s, got %s", expected, suggestions)
+	}
+}
+
+// TestReproducibleCandidates tests that generated candidates are sorted
+func TestReproducibleCandidates(t *testing.T) {
+	generator := dymean.NewCandidateGenerator()
+	candidates := generator.GenerateCandidates("cat", 1)
+	if !sort.StringsAreSorted(candidates) || !reflect.DeepEqual(candidates, generator.GenerateCandidates("cat", 1)) {
+		t.Error("Expected candidates in lexical order")
+	}
+	if typos := generator.GenerateCommonTypos("cat"); !sort.StringsAreSorted(typos) {
+		t.Errorf("Expected typos in lexical order, got %v", typos)
+	}
+}
+
+// TestRandomSeed tests that the seed selects the ValidateDictionary sample
+func TestRandomSeed(t *testing.T) {
+	words := make([]string, 0, 400)
+	for i := 0; i < 400; i++ {
+		words = append(words, fmt.Sprintf("w%c%c%c", 'a'+i%26, 'a'+i/26%26, 'a'+i%7))
+	}
+	report := func(opts ...dymean.Option) dymean.QualityReport {
+		dym := dymean.NewDidYouMean(10000, 7, append(opts, dymean.WithAutomatonSearch())...)
+		dym.AddWords(words)
+		report, err := dym.ValidateDictionary(dymean.English)
+		if err != nil {
+			t.Fatalf("Failed to validate: %v", err)
+		}
+		return report
+	}
+
+	if a, b := report(), report(dymean.WithRandomSeed(1)); !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected the default seed to be 1:\n%+v\n%+v", a, b)
+	}
+	if a, b := report(dymean.WithRandomSeed(7)), report(dymean.WithRandomSeed(7)); !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected equal reports with the same seed:\n%+v\n%+v", a, b)
+	}
+	if a, b := report(dymean.WithRandomSeed(1)), report(dymean.WithRandomSeed(2)); reflect.DeepEqual(a.Failures, b.Failures) && len(a.Failures) > 0 {
+		t.Error("Expected different samples with different seeds")
+	}
+}
@@ -0,0 +1,71 @@
+package dymean
+
+import (
+	"strings"
+	"unicode"
+)
+
+// virama suppresses the inherent vowel of a Devanagari consonant, joining it
+// to the next one in a conjunct
+const virama = '\u094D'
+
+// nuktaForms maps the precomposed Devanagari letters with nukta to their
+// letter followed by the nukta sign, so both encodings of "ज़" match
+var nuktaForms = map[rune]string{
+	'\u0929': "\u0928\u093C", '\u0931': "\u0930\u093C", '\u0934': "\u0933\u093C",
+	'\u0958': "\u0915\u093C", '\u0959': "\u0916\u093C", '\u095A': "\u0917\u093C", '\u095B': "\u091C\u093C",
+	'\u095C': "\u0921\u093C", '\u095D': "\u0922\u093C", '\u095E': "\u092B\u093C", '\u095F': "\u092F\u093C",
+}
+
+// normalizeNukta writes the letters with nukta in their decomposed form
+func normalizeNukta(word string) string {
+	if !strings.ContainsFunc(word, func(r rune) bool { _, ok := nuktaForms[r]; return ok }) {
+		return word
+	}
+	var builder strings.Builder
+	builder.Grow(len(word) + 4)
+	for _, r := range word {
+		if decomposed, ok := nuktaForms[r]; ok {
+			builder.WriteString(decomposed)
+		} else {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// Graphemes splits a word into the clusters read as one character: a letter
+// with its combining marks, such as a Devanagari consonant with its matra
+// ("कि") or an Arabic letter with its harakat, and Devanagari conjuncts joined
+// by a virama ("क्ष"). Highlighting or editing whole clusters avoids showing a
+// matra detached from its consonant.
+func Graphemes(word string) []string {
+	graphemes := make([]string, 0, len(word))
+	start := -1
+	joining := false // The previous rune was a virama, so the next letter continues the cluster
+	for i, r := range word {
+		switch {
+		case start < 0:
+			start = i
+		case unicode.IsMark(r) || r == zwj || r == zwnj:
+		case joining && unicode.IsLetter(r):
+		default:
+			graphemes = append(graphemes, word[start:i])
+			start = i
+		}
+		joining = r == virama || (joining && (r == zwj || r == zwnj))
+	}
+	if start >= 0 {
+		graphemes = append(graphemes, word[start:])
+	}
+	return graphemes
+}
+
+// startsWithMark reports whether a word begins with a combining mark, such as
+// a matra with no consonant to attach to
+func startsWithMark(word string) bool {
+	for _, r := range word {
+		return unicode.IsMark(r)
+	}
+	return false
+}
@@ -1,5 +1,10 @@
+//go:build !dymean_nodict
+
 package dymean
 
+// embeddedDictionaries reports whether the built-in word lists are compiled in
+const embeddedDictionaries = true
+
 // GetEnglishWords returns a comprehensive list of common English words
 func GetEnglishWords() []string {
 	return []string{
@@ -122,6 +127,9 @@ func GetEnglishWords() []string {
 // GetPersianWords returns a comprehensive list of common Persian words
 func GetPersianWords() []string {
 	return []string{
+		// Alef madda, hamza forms and ZWNJ compounds
+		"آب", "آسمان", "مسئله", "جزء", "مؤسسه", "تأثیر",
+		"می\u200cروم", "می\u200cخواهم", "نمی\u200cدانم", "می\u200cشود", "کتاب\u200cها",
 		// Basic Persian words
 		"سلام", "دنیا", "برنامه", "نویسی", "کامپیوتر", "علم",
 		"الگوریتم", "داده", "ساختار", "فیلتر", "بروم", "املا",
@@ -216,14 +224,34 @@ func GetPersianWords() []string {
 	}
 }
 
-// GetWordsForLanguage returns words for a specific language
-func GetWordsForLanguage(lang Language) []string {
-	switch lang {
-	case English:
-		return GetEnglishWords()
-	case Persian:
-		return GetPersianWords()
-	default:
-		return GetEnglishWords() // Default to English
+// GetGreekWords returns a list of common Greek words
+func GetGreekWords() []string {
+	return []string{
+		"και", "το", "η", "ο", "της", "του", "να", "σε", "με", "για",
+		"από", "την", "τα", "οι", "είναι", "δεν", "θα", "που", "αυτό", "ένα",
+		"καλημέρα", "καλησπέρα", "ευχαριστώ", "παρακαλώ", "γεια", "ναι", "όχι",
+		"άνθρωπος", "άνθρωποι", "γυναίκα", "παιδί", "σπίτι", "δρόμος", "οδός",
+		"πόλη", "χώρα", "θάλασσα", "ήλιος", "νερό", "ψωμί", "φαγητό", "βιβλίο",
+		"σχολείο", "δάσκαλος", "μαθητής", "γλώσσα", "λέξη", "κόσμος", "χρόνος",
+		"ημέρα", "νύχτα", "ώρα", "εβδομάδα", "μήνας", "καιρός", "αγάπη", "φίλος",
+		"οικογένεια", "δουλειά", "υπολογιστής", "πρόγραμμα", "λογισμικό",
+		"δεδομένα", "αρχείο", "σφάλμα", "δοκιμή", "έλεγχος", "ορθογραφία",
+		"λεξικό", "πρόταση", "απόσταση", "ομοιότητα", "πληκτρολόγιο",
+	}
+}
+
+// GetPolishWords returns a list of common Polish words
+func GetPolishWords() []string {
+	return []string{
+		"i", "w", "z", "na", "się", "nie", "do", "to", "że", "jest",
+		"a", "o", "jak", "ale", "po", "co", "tak", "od", "już", "być",
+		"dzień", "dobry", "dziękuję", "proszę", "cześć", "żółw", "koń",
+		"człowiek", "ludzie", "kobieta", "dziecko", "dom", "ulica", "miasto",
+		"kraj", "morze", "słońce", "woda", "chleb", "jedzenie", "książka",
+		"szkoła", "nauczyciel", "uczeń", "język", "słowo", "świat", "czas",
+		"noc", "godzina", "tydzień", "miesiąc", "pogoda", "miłość", "przyjaciel",
+		"rodzina", "praca", "komputer", "program", "oprogramowanie", "dane",
+		"plik", "błąd", "test", "sprawdzenie", "pisownia", "słownik", "zdanie",
+		"odległość", "podobieństwo", "klawiatura", "źródło", "gęś", "pięć",
 	}
 }
@@ -0,0 +1,27 @@
+//go:build dymean_nodict
+
+package dymean
+
+// embeddedDictionaries reports whether the built-in word lists are compiled in.
+// Builds with the dymean_nodict tag leave them out to keep binaries small.
+const embeddedDictionaries = false
+
+// GetEnglishWords returns nil: the embedded word lists were left out of this build
+func GetEnglishWords() []string {
+	return nil
+}
+
+// GetPersianWords returns nil: the embedded word lists were left out of this build
+func GetPersianWords() []string {
+	return nil
+}
+
+// GetGreekWords returns nil: the embedded word lists were left out of this build
+func GetGreekWords() []string {
+	return nil
+}
+
+// GetPolishWords returns nil: the embedded word lists were left out of this build
+func GetPolishWords() []string {
+	return nil
+}
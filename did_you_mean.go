@@ -1,31 +1,161 @@
 package dymean
 
 import (
+	"context"
+	"log/slog"
+	"math"
+	"regexp"
 	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 // Suggestion represents a word suggestion with its similarity score
 type Suggestion struct {
 	Word       string
 	Similarity float64
+	Reason     string // Why it was suggested, with SetExplainSuggestions
+}
+
+// defaultSeed seeds the random choices of instances without WithRandomSeed
+const defaultSeed = 1
+
+// sortSuggestions sorts suggestions by descending similarity, breaking ties
+// in the language's alphabetical order so that equal inputs always rank the
+// same way and tied words read naturally
+func sortSuggestions(suggestions []Suggestion, lang Language) {
+	collator := collatorFor(lang)
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Similarity != suggestions[j].Similarity {
+			return suggestions[i].Similarity > suggestions[j].Similarity
+		}
+		return collator.compare(suggestions[i].Word, suggestions[j].Word) < 0
+	})
 }
 
 // DidYouMean is the main struct for the spell checker
 type DidYouMean struct {
+	mu           sync.RWMutex              // Guards bloomFilters, dictionaries, compact, metadata, confusables, frequencies, bigrams and acronyms
 	bloomFilters map[Language]*BloomFilter // One Bloom filter per language
 	candidates   *CandidateGenerator
-	dictionaries map[Language]map[string]bool // One dictionary per language
+	dictionaries map[Language]map[string]bool               // One dictionary per language
+	compact      map[Language]*frontCodedSet                // Dictionaries of compact languages
+	compactLangs map[Language]bool                          // Languages using compact storage
+	pendingLoads map[Language]int                           // Running asynchronous loads
+	deferred     map[Language]int                           // Running DeferCompaction calls
+	phrases      map[Language]map[string]bool               // Dictionary entries containing spaces
+	phraseWords  map[Language]int                           // Most words in a dictionary phrase
+	tries        map[Language]*Trie                         // Search tries, built on demand
+	neighbors    map[Language]*NeighborIndex                // Set with BuildNeighborIndex
+	equivalences map[Language]map[string][]string           // Words by folded equivalent letters, built on demand
+	metadata     map[Language]DictionaryMetadata            // Provenance of each dictionary
+	tombstones   map[Language]map[string]bool               // Words removed since the last Compact
+	confusables  map[Language]map[string]map[string]float64 // word -> confusable -> weight
+	frequencies  map[Language]*wordFrequencies              // Set with SetWordFrequencies
+	bigrams      map[Language]*bigramFrequencies            // Set with SetBigramFrequencies
+	compaction   compactionState
 	currentLang  Language
+
+	acronyms            map[string]string // Acronym layer shared by all languages
+	acronymsEnabled     bool
+	expandAbbreviations bool
+	grammarChecks       bool
+	includeAlternatives bool
+	explainSuggestions  bool
+	layoutDetection     bool
+
+	errorModel    *ErrorModel    // Corrections learned from users
+	feedbackModel *FeedbackModel // Accept/reject feedback on suggestions
+
+	maxConcurrency int // Cap on workers per call, 0 for one per CPU
+	maxWordLength  int // Longest word in runes to generate suggestions for
+	maxTextLength  int // Longest text in bytes checked by CheckText
+	digitPolicy    DigitPolicy
+	seed           int64 // Seed of random choices, see WithRandomSeed
+
+	similarityCache *SimilarityCache // Shared scores, see WithSimilarityCache
+
+	chineseFolding bool                           // Fold Traditional Chinese to Simplified
+	chineseOutput  ChineseVariant                 // Variant of Chinese suggestions
+	stripTonos     bool                           // Ignore the tonos of Greek words
+	phraseConfig   PhraseConfig                   // Beam search of SuggestPhrase
+	related        map[Language][]relatedLanguage // Languages blended into suggestions, see WithRelatedLanguages
+
+	immutablePatterns []*regexp.Regexp // Text never checked or rewritten
+	textRules         TextRules        // Words text checks leave alone
+	tuning            SuggestionTuning // Set with WithSuggestionTuning
+	ranking           []RankingStage   // Set with WithRankingPipeline
+
+	validationMode  ValidationMode    // Characters accepted in words
+	automatonSearch bool              // Find candidates with a Levenshtein automaton
+	pruning         *CandidatePruning // Candidates restricted by first letter and length, nil to keep all
+	withoutEmbedded bool              // LoadDefaultDictionary refuses to load embedded lists
+
+	requiredLangs []Language // Languages that must be loaded before Ready
+
+	listenersMu  sync.Mutex // Guards listeners and nextListener
+	listeners    map[int]func(DictionaryEvent)
+	nextListener int
+
+	lazyLangs   map[Language]bool // Languages whose default dictionary loads on first use
+	lazyMu      sync.Mutex        // Guards lazy
+	lazy        map[Language]*lazyLoad
+	lazyPending atomic.Int32 // Lazy loads not finished, to skip lazyMu when zero
+
+	fallback *fallback         // Provider for weak local suggestions, nil without WithFallback
+	tracer   Tracer            // Observer of the stages of suggestions, nil without WithTracer
+	decay    *recency          // Time-decayed word weights, nil without WithDecay
+	recovery bool              // Recover panics, see WithRecovery
+	onPanic  func(*PanicError) // Called with recovered panics, may be nil
+
+	unknown   unknownWords // Words harvested by text checks
+	analytics *analytics   // Misspellings collected by WithAnalytics, nil without it
+
+	logger *slog.Logger
 }
 
 // NewDidYouMean creates a new DidYouMean instance
-func NewDidYouMean(dictionarySize uint, numHashFuncs int) *DidYouMean {
-	return &DidYouMean{
-		bloomFilters: make(map[Language]*BloomFilter),
-		candidates:   NewCandidateGenerator(),
-		dictionaries: make(map[Language]map[string]bool),
-		currentLang:  English, // Default to English
+func NewDidYouMean(dictionarySize uint, numHashFuncs int, opts ...Option) *DidYouMean {
+	dym := &DidYouMean{
+		bloomFilters:      make(map[Language]*BloomFilter),
+		candidates:        NewCandidateGenerator(),
+		dictionaries:      make(map[Language]map[string]bool),
+		compact:           make(map[Language]*frontCodedSet),
+		compactLangs:      make(map[Language]bool),
+		pendingLoads:      make(map[Language]int),
+		deferred:          make(map[Language]int),
+		phrases:           make(map[Language]map[string]bool),
+		phraseWords:       make(map[Language]int),
+		tries:             make(map[Language]*Trie),
+		neighbors:         make(map[Language]*NeighborIndex),
+		equivalences:      make(map[Language]map[string][]string),
+		metadata:          make(map[Language]DictionaryMetadata),
+		tombstones:        make(map[Language]map[string]bool),
+		confusables:       make(map[Language]map[string]map[string]float64),
+		frequencies:       make(map[Language]*wordFrequencies),
+		bigrams:           make(map[Language]*bigramFrequencies),
+		listeners:         make(map[int]func(DictionaryEvent)),
+		lazyLangs:         make(map[Language]bool),
+		related:           make(map[Language][]relatedLanguage),
+		lazy:              make(map[Language]*lazyLoad),
+		currentLang:       English, // Default to English
+		acronyms:          make(map[string]string),
+		acronymsEnabled:   true,
+		errorModel:        NewErrorModel(),
+		feedbackModel:     NewFeedbackModel(),
+		maxWordLength:     DefaultMaxWordLength,
+		maxTextLength:     DefaultMaxTextLength,
+		seed:              defaultSeed,
+		phraseConfig:      DefaultPhraseConfig,
+		immutablePatterns: DefaultImmutablePatterns,
+		logger:            slog.New(discardHandler{}),
 	}
+	dym.AddAcronyms(defaultAcronyms)
+	for _, opt := range opts {
+		opt(dym)
+	}
+	return dym
 }
 
 // AddWords adds words to the dictionary for the current language
@@ -33,23 +163,245 @@ func (dym *DidYouMean) AddWords(words []string) {
 	dym.AddWordsForLanguage(words, dym.currentLang)
 }
 
-// AddWordsForLanguage adds words to the dictionary for a specific language
+// AddWordsForLanguage adds words to the dictionary for a specific language.
+// It is safe to call while other goroutines look words up.
 func (dym *DidYouMean) AddWordsForLanguage(words []string, lang Language) {
-	// Initialize Bloom filter and dictionary for this language if not exists
-	if dym.bloomFilters[lang] == nil {
-		dym.bloomFilters[lang] = NewBloomFilter(10000, 7)
-		dym.dictionaries[lang] = make(map[string]bool)
+	dym.AddWordsReport(words, lang)
+}
+
+// DeferCompaction keeps the words added to a WithCompactStorage language in
+// the regular dictionary until the returned function is called, which
+// compacts them at once. Bulk loads adding words in batches use it so that
+// each batch does not rebuild the whole compact dictionary. The function must
+// be called exactly once; it does nothing for other languages.
+func (dym *DidYouMean) DeferCompaction(lang Language) func() {
+	dym.mu.Lock()
+	dym.deferred[lang]++
+	dym.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			dym.mu.Lock()
+			defer dym.mu.Unlock()
+			if dym.deferred[lang]--; dym.deferred[lang] == 0 {
+				delete(dym.deferred, lang)
+				dym.compactDictionary(lang)
+			}
+		})
 	}
+}
 
-	langInfo := GetLanguageInfo(lang)
+// compactDictionary moves the dictionary of a compact language into its
+// front-coded representation, merging it with the words already stored there.
+// It does nothing while compaction is deferred. The caller must hold the
+// write lock.
+func (dym *DidYouMean) compactDictionary(lang Language) {
+	if !dym.compactLangs[lang] || dym.deferred[lang] > 0 {
+		return
+	}
 
-	for _, word := range words {
-		normalized := langInfo.Normalizer(word)
-		if IsValidWordForLanguage(normalized, lang) {
-			dym.bloomFilters[lang].Add(normalized)
-			dym.dictionaries[lang][normalized] = true
+	if set := dym.compact[lang]; set != nil {
+		for _, word := range set.words() {
+			dym.dictionaries[lang][word] = true
 		}
 	}
+
+	words := make([]string, 0, len(dym.dictionaries[lang]))
+	for word := range dym.dictionaries[lang] {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+
+	dym.compact[lang] = newFrontCodedSet(words)
+	dym.dictionaries[lang] = make(map[string]bool)
+}
+
+// hasDictionary reports whether words were loaded for a language
+func (dym *DidYouMean) hasDictionary(lang Language) bool {
+	dym.ensureLoaded(lang)
+	dym.mu.RLock()
+	defer dym.mu.RUnlock()
+	return dym.dictionaries[lang] != nil || dym.compact[lang] != nil
+}
+
+// hasWord reports whether a normalized word is in a language's dictionary
+func (dym *DidYouMean) hasWord(lang Language, word string) bool {
+	dym.ensureLoaded(lang)
+	dym.mu.RLock()
+	defer dym.mu.RUnlock()
+	return dym.lookup(lang, word)
+}
+
+// contains reports whether a normalized word is in a language's dictionary,
+// consulting the Bloom filter first to reject most misses cheaply
+func (dym *DidYouMean) contains(lang Language, word string) bool {
+	dym.ensureLoaded(lang)
+	dym.mu.RLock()
+	defer dym.mu.RUnlock()
+	if dym.isTombstone(lang, word) {
+		return false
+	}
+	bf := dym.bloomFilters[lang]
+	return bf != nil && bf.Contains(word) && dym.lookup(lang, word)
+}
+
+// lookup checks the dictionary storage of a language, compact and regular
+// while compaction is deferred. The caller must hold the lock.
+func (dym *DidYouMean) lookup(lang Language, word string) bool {
+	if set := dym.compact[lang]; set != nil && set.contains(word) {
+		return true
+	}
+	return dym.dictionaries[lang][word]
+}
+
+// RemoveWords removes words from the dictionary for the current language
+func (dym *DidYouMean) RemoveWords(words []string) {
+	dym.RemoveWordsForLanguage(words, dym.currentLang)
+}
+
+// RemoveWordsForLanguage removes words from the dictionary for a specific language.
+// The Bloom filter cannot forget words, so removed words are kept as tombstones
+// that lookups reject until Compact rebuilds the filter.
+func (dym *DidYouMean) RemoveWordsForLanguage(words []string, lang Language) {
+	normalized := make([]string, len(words))
+	for i, word := range words {
+		normalized[i] = dym.normalize(word, lang)
+	}
+
+	removed := dym.removeWords(normalized, lang)
+	dym.notify(DictionaryEvent{Kind: EventWordsRemoved, Language: lang, Words: removed})
+}
+
+// removeWords removes normalized words from a language's dictionary and
+// returns the ones that were in it
+func (dym *DidYouMean) removeWords(normalized []string, lang Language) []string {
+	dym.mu.Lock()
+	defer dym.mu.Unlock()
+	delete(dym.tries, lang)
+	delete(dym.neighbors, lang)
+	delete(dym.equivalences, lang)
+
+	present := make([]string, 0, len(normalized))
+	for _, word := range normalized {
+		if dym.lookup(lang, word) {
+			dym.addTombstone(lang, word)
+			present = append(present, word)
+		}
+	}
+
+	for _, word := range normalized {
+		delete(dym.dictionaries[lang], word)
+		delete(dym.phrases[lang], word)
+	}
+
+	if set := dym.compact[lang]; set != nil {
+		removed := make(map[string]bool, len(normalized))
+		for _, word := range normalized {
+			removed[word] = true
+		}
+		kept := make([]string, 0, set.len())
+		for _, word := range set.words() {
+			if !removed[word] {
+				kept = append(kept, word)
+			}
+		}
+		dym.compact[lang] = newFrontCodedSet(kept)
+	}
+	return present
+}
+
+// ReplaceDictionary replaces the dictionary of a language with words, as a
+// reload from an updated source does. The new dictionary is built aside and
+// swapped in at once, so lookups see either the old or the new words, and its
+// Bloom filter is sized for the new word count.
+func (dym *DidYouMean) ReplaceDictionary(words []string, lang Language) AddReport {
+	staging := NewDidYouMean(0, 0, WithLogger(dym.logger), WithValidationMode(dym.validationMode), WithDigitPolicy(dym.digitPolicy))
+	if dym.compactLangs[lang] {
+		staging.compactLangs[lang] = true
+	}
+	report := staging.AddWordsReport(words, lang)
+
+	live := staging.dictionaryWords(lang)
+	size := uint(len(live) * bloomBitsPerWord)
+	if size < minBloomBits {
+		size = minBloomBits
+	}
+	bf := NewBloomFilter(size, bloomHashFuncs)
+	bf.AddWords(live)
+
+	dym.mu.Lock()
+	dym.bloomFilters[lang] = bf
+	dym.dictionaries[lang] = staging.dictionaries[lang]
+	dym.compact[lang] = staging.compact[lang]
+	dym.phrases[lang] = staging.phrases[lang]
+	dym.phraseWords[lang] = staging.phraseWords[lang]
+	delete(dym.tries, lang)
+	delete(dym.neighbors, lang)
+	delete(dym.equivalences, lang)
+	delete(dym.tombstones, lang)
+	dym.mu.Unlock()
+
+	dym.notify(DictionaryEvent{Kind: EventReloaded, Language: lang, Words: live})
+	return report
+}
+
+// GetDictionaryWords returns the words of a language's dictionary in sorted order
+func (dym *DidYouMean) GetDictionaryWords(lang Language) []string {
+	dym.ensureLoaded(lang)
+	dym.mu.RLock()
+	defer dym.mu.RUnlock()
+
+	words := dym.dictionaryWords(lang)
+	if dym.compact[lang] == nil {
+		sort.Strings(words)
+	}
+	return words
+}
+
+// dictionaryWords returns the words of a language's dictionary, sorted only
+// for compact storage. The caller must hold the lock.
+func (dym *DidYouMean) dictionaryWords(lang Language) []string {
+	set := dym.compact[lang]
+	if set != nil && len(dym.dictionaries[lang]) == 0 {
+		return set.words()
+	}
+
+	words := make([]string, 0, len(dym.dictionaries[lang]))
+	for word := range dym.dictionaries[lang] {
+		words = append(words, word)
+	}
+	if set != nil {
+		words = append(words, set.words()...) // While compaction is deferred
+		sort.Strings(words)
+	}
+	return words
+}
+
+// GetWordCount returns the number of words in a language's dictionary
+func (dym *DidYouMean) GetWordCount(lang Language) int {
+	dym.mu.RLock()
+	defer dym.mu.RUnlock()
+
+	if set := dym.compact[lang]; set != nil {
+		return set.len() + len(dym.dictionaries[lang])
+	}
+	return len(dym.dictionaries[lang])
+}
+
+// GetLoadedLanguages returns the languages with a dictionary, in sorted order
+func (dym *DidYouMean) GetLoadedLanguages() []Language {
+	dym.mu.RLock()
+	defer dym.mu.RUnlock()
+
+	languages := make([]Language, 0, len(dym.bloomFilters))
+	for lang := range dym.bloomFilters {
+		languages = append(languages, lang)
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		return languages[i] < languages[j]
+	})
+	return languages
 }
 
 // SetLanguage sets the current language
@@ -57,15 +409,35 @@ func (dym *DidYouMean) SetLanguage(lang Language) {
 	dym.currentLang = lang
 }
 
+// SetIncludeAlternativesForCorrectWords makes suggestion APIs return near
+// neighbors ranked below the exact match when the word is already correct,
+// as autocomplete-style consumers expect
+func (dym *DidYouMean) SetIncludeAlternativesForCorrectWords(enabled bool) {
+	dym.includeAlternatives = enabled
+}
+
 // GetCurrentLanguage returns the current language
 func (dym *DidYouMean) GetCurrentLanguage() Language {
 	return dym.currentLang
 }
 
-// LoadDefaultDictionary loads the default dictionary for a language
-func (dym *DidYouMean) LoadDefaultDictionary(lang Language) {
-	words := GetWordsForLanguage(lang)
+// LoadDefaultDictionary loads the embedded dictionary for a language. It
+// returns a *NoDefaultDictionaryError, and loads nothing, when the language has
+// no embedded word list, the instance was created with
+// WithoutEmbeddedDictionaries or the binary was built with the dymean_nodict tag.
+// For languages given to WithLazyLanguages, the words are only added on first use.
+func (dym *DidYouMean) LoadDefaultDictionary(lang Language) error {
+	words, err := dym.defaultWords(lang)
+	if err != nil {
+		return err
+	}
+	dym.SetDictionaryMetadata(lang, embeddedMetadata(lang))
+	if dym.lazyLangs[lang] {
+		dym.LoadDictionaryLazily(lang, func() ([]string, error) { return words, nil })
+		return nil
+	}
 	dym.AddWordsForLanguage(words, lang)
+	return nil
 }
 
 // IsCorrect checks if a word is in the dictionary for the current language
@@ -75,14 +447,13 @@ func (dym *DidYouMean) IsCorrect(word string) bool {
 
 // IsCorrectForLanguage checks if a word is in the dictionary for a specific language
 func (dym *DidYouMean) IsCorrectForLanguage(word string, lang Language) bool {
-	if dym.bloomFilters[lang] == nil || dym.dictionaries[lang] == nil {
-		return false
+	if dym.acronymsEnabled && dym.IsAcronym(word) {
+		return true
 	}
 
-	langInfo := GetLanguageInfo(lang)
-	normalized := langInfo.Normalizer(word)
+	normalized := dym.normalize(word, lang)
 
-	return dym.bloomFilters[lang].Contains(normalized) && dym.dictionaries[lang][normalized]
+	return dym.contains(lang, normalized)
 }
 
 // GetSuggestions returns suggestions for a misspelled word in the current language
@@ -92,57 +463,182 @@ func (dym *DidYouMean) GetSuggestions(word string, maxSuggestions int, maxEditDi
 
 // GetSuggestionsForLanguage returns suggestions for a misspelled word in a specific language
 func (dym *DidYouMean) GetSuggestionsForLanguage(word string, maxSuggestions int, maxEditDistance int, lang Language) []Suggestion {
-	if dym.bloomFilters[lang] == nil || dym.dictionaries[lang] == nil {
+	return dym.GetSuggestionsForLanguageContext(context.Background(), word, maxSuggestions, maxEditDistance, lang)
+}
+
+// GetSuggestionsForLanguageContext is GetSuggestionsForLanguage with a context
+// bounding the call to the fallback provider configured with WithFallback
+func (dym *DidYouMean) GetSuggestionsForLanguageContext(ctx context.Context, word string, maxSuggestions int, maxEditDistance int, lang Language) []Suggestion {
+	suggestions := dym.suggestions(ctx, word, maxSuggestions, maxEditDistance, lang, true)
+	if dym.analytics != nil || dym.explainSuggestions {
+		normalized := dym.normalize(word, lang)
+		dym.recordLookup(normalized, lang, suggestions)
+		if dym.explainSuggestions {
+			dym.explainAll(normalized, lang, suggestions)
+		}
+	}
+	return suggestions
+}
+
+// suggestions ranks the suggestions for a word, completing weak results with
+// the fallback provider when useFallback is set. Panics are recovered, with
+// no suggestions, under WithRecovery.
+func (dym *DidYouMean) suggestions(ctx context.Context, word string, maxSuggestions int, maxEditDistance int, lang Language, useFallback bool) (suggestions []Suggestion) {
+	if dym.recovery {
+		defer func() {
+			if value := recover(); value != nil {
+				dym.recovered(newPanicError("suggestions", word, value))
+				suggestions = nil
+			}
+		}()
+	}
+	return dym.collectSuggestions(ctx, word, maxSuggestions, maxEditDistance, lang, useFallback)
+}
+
+// collectSuggestions implements suggestions
+func (dym *DidYouMean) collectSuggestions(ctx context.Context, word string, maxSuggestions int, maxEditDistance int, lang Language, useFallback bool) []Suggestion {
+	// Negative counts and distances mean none
+	maxSuggestions = max(maxSuggestions, 0)
+	maxEditDistance = max(maxEditDistance, 0)
+
+	suggestions := dym.rankSuggestions(ctx, word, maxSuggestions, maxEditDistance, lang, useFallback)
+	suggestions = dym.blendRelated(ctx, word, maxSuggestions, maxEditDistance, lang, suggestions)
+	if lang == Chinese && dym.chineseFolding {
+		dym.convertChineseSuggestions(word, suggestions)
+	}
+	return suggestions
+}
+
+// rankSuggestions implements suggestions, in the dictionary's normalized forms
+func (dym *DidYouMean) rankSuggestions(ctx context.Context, word string, maxSuggestions int, maxEditDistance int, lang Language, useFallback bool) []Suggestion {
+	// Known abbreviations are answered from the acronym layer
+	if dym.expandAbbreviations {
+		if expansion, ok := dym.ExpandAbbreviation(word); ok {
+			return []Suggestion{{Word: expansion, Similarity: 1.0}}
+		}
+	}
+	if dym.acronymsEnabled && dym.IsAcronym(word) {
+		return []Suggestion{{Word: word, Similarity: 1.0}}
+	}
+
+	if !dym.hasDictionary(lang) {
+		// Without a dictionary the fallback provider is the only source
+		if normalized := dym.normalize(word, lang); useFallback && dym.fallback != nil && dym.isValidWord(normalized, lang) && !dym.isWordTooLong(normalized) {
+			return dym.applyFallback(ctx, normalized, lang, maxSuggestions, nil)
+		}
 		return nil
 	}
 
-	langInfo := GetLanguageInfo(lang)
-	normalized := langInfo.Normalizer(word)
+	normalized := dym.normalize(word, lang)
+
+	// Vietnamese typed with Telex or VNI keys is read as the letters meant
+	if lang == Vietnamese {
+		normalized = dym.decodeVietnameseTyping(normalized)
+	}
 
-	if !IsValidWordForLanguage(normalized, lang) {
+	if !dym.isValidWord(normalized, lang) {
 		return nil
 	}
 
-	// If the word is correct, return it
-	if dym.IsCorrectForLanguage(normalized, lang) {
-		return []Suggestion{{Word: normalized, Similarity: 1.0}}
+	// Pathological inputs would generate millions of candidates
+	if dym.isWordTooLong(normalized) {
+		dym.logger.Debug("skipped suggestions for overlong word",
+			"language", lang, "length", len(normalized), "limit", dym.maxWordLength)
+		return nil
 	}
 
-	// Generate candidates
-	candidates := dym.candidates.GenerateCandidates(normalized, maxEditDistance)
+	// Phrases are matched against the dictionary phrases
+	if strings.Contains(normalized, " ") {
+		return dym.phraseSuggestions(normalized, lang, maxSuggestions, maxEditDistance)
+	}
 
-	// Also include common typo candidates
-	typoCandidates := dym.candidates.GenerateCommonTypos(normalized)
-	candidates = append(candidates, typoCandidates...)
+	// If the word is correct, return it, unless alternatives were requested
+	isCorrect := dym.IsCorrectForLanguage(normalized, lang)
+	if isCorrect && !dym.includeAlternatives {
+		// Known confusions are still offered after the word itself
+		suggestions := append([]Suggestion{{Word: normalized, Similarity: 1.0}}, dym.confusableAlternatives(normalized, lang)...)
+		if len(suggestions) > maxSuggestions {
+			suggestions = suggestions[:maxSuggestions]
+		}
+		return suggestions
+	}
 
-	// Filter candidates that exist in the dictionary
-	validCandidates := make([]string, 0)
-	for _, candidate := range candidates {
-		if dym.bloomFilters[lang].Contains(candidate) && dym.dictionaries[lang][candidate] {
-			validCandidates = append(validCandidates, candidate)
+	// Find the dictionary words close to the word
+	var validCandidates []string
+	pruning := dym.candidatePruning(ctx, lang)
+	indexed := false
+	if isCorrect {
+		// The neighbors of dictionary words may be precomputed
+		validCandidates, indexed = dym.indexedCandidates(ctx, normalized, lang, maxEditDistance, pruning)
+	}
+	if !indexed {
+		if dym.automatonSearch {
+			end := dym.startStage(ctx, StageGenerate, lang, normalized)
+			validCandidates = dym.automatonCandidates(normalized, lang, maxEditDistance, pruning)
+			end()
+		} else {
+			validCandidates = dym.generatedCandidates(ctx, normalized, lang, maxEditDistance, pruning)
 		}
 	}
 
+	// Words spelled with equivalent letters, such as Arabic hamza forms, are
+	// found and compared with those letters folded
+	equivalences := GetLanguageInfo(lang).Equivalences
+	var table map[rune]rune
+	if len(equivalences) > 0 {
+		validCandidates = dym.withEquivalentCandidates(normalized, lang, validCandidates)
+		table = equivalenceTable(equivalences)
+	}
+
 	// Calculate similarity scores and create suggestions
+	endRank := dym.startStage(ctx, StageRank, lang, normalized)
+	memo := dym.newSimilarityMemo()
 	suggestions := make([]Suggestion, 0, len(validCandidates))
 	for _, candidate := range validCandidates {
-		similarity := CalculateSimilarity(normalized, candidate)
+		similarity := memo.similarity(normalized, candidate)
+		if table != nil {
+			similarity = math.Max(similarity, equivalenceSimilarity(normalized, candidate, lang, table, memo))
+		}
 		suggestions = append(suggestions, Suggestion{
 			Word:       candidate,
 			Similarity: similarity,
 		})
 	}
 
+	// Boost corrections users chose before for this misspelling
+	suggestions = dym.applyErrorModel(normalized, lang, suggestions, memo)
+
+	// Boost the words registered as confusable with this one
+	suggestions = dym.applyConfusables(normalized, lang, suggestions, memo)
+
+	// Re-rank using accept/reject feedback
+	suggestions = dym.applyFeedback(normalized, lang, suggestions)
+
+	// Prefer recently added words
+	suggestions = dym.applyRecency(lang, suggestions)
+
+	// Blend in the signals of the ranking pipeline
+	suggestions = dym.applyRanking(ctx, normalized, lang, suggestions)
+
 	// Sort by similarity (descending)
-	sort.Slice(suggestions, func(i, j int) bool {
-		return suggestions[i].Similarity > suggestions[j].Similarity
-	})
+	sortSuggestions(suggestions, lang)
+	endRank()
+
+	// A correct word always ranks above its alternatives
+	if isCorrect {
+		suggestions = append([]Suggestion{{Word: normalized, Similarity: 1.0}}, suggestions...)
+	}
 
 	// Return top suggestions
 	if len(suggestions) > maxSuggestions {
 		suggestions = suggestions[:maxSuggestions]
 	}
 
+	// Weak results are completed by the fallback provider
+	if !isCorrect && useFallback {
+		suggestions = dym.applyFallback(ctx, normalized, lang, maxSuggestions, suggestions)
+	}
+
 	return suggestions
 }
 
@@ -153,7 +649,8 @@ func (dym *DidYouMean) Suggest(word string) string {
 
 // SuggestForLanguage returns the best suggestion for a word in a specific language
 func (dym *DidYouMean) SuggestForLanguage(word string, lang Language) string {
-	suggestions := dym.GetSuggestionsForLanguage(word, 1, 2, lang)
+	tuning := dym.SuggestionTuning(lang)
+	suggestions := dym.GetSuggestionsForLanguage(word, 1, tuning.MaxEditDistance, lang)
 	if len(suggestions) > 0 {
 		return suggestions[0].Word
 	}
@@ -162,6 +659,7 @@ func (dym *DidYouMean) SuggestForLanguage(word string, lang Language) string {
 
 // GetSuggestionsWithThreshold returns suggestions above a similarity threshold
 func (dym *DidYouMean) GetSuggestionsWithThreshold(word string, threshold float64, maxSuggestions int) []Suggestion {
+	maxSuggestions = max(maxSuggestions, 0)
 	allSuggestions := dym.GetSuggestions(word, maxSuggestions*2, 2) // Get more to filter
 	filtered := make([]Suggestion, 0)
 
@@ -189,7 +687,8 @@ func (dym *DidYouMean) CheckAndSuggestForLanguage(word string, lang Language) (b
 		return true, nil
 	}
 
-	suggestions := dym.GetSuggestionsForLanguage(word, 5, 2, lang)
+	tuning := dym.SuggestionTuning(lang)
+	suggestions := dym.GetSuggestionsForLanguage(word, tuning.MaxSuggestions, tuning.MaxEditDistance, lang)
 	return false, suggestions
 }
 
@@ -199,3 +698,69 @@ func (dym *DidYouMean) AutoDetectAndSuggest(word string) (Language, bool, []Sugg
 	isCorrect, suggestions := dym.CheckAndSuggestForLanguage(word, detectedLang)
 	return detectedLang, isCorrect, suggestions
 }
+
+// ambiguousDetectionConfidence is the confidence below which SuggestAuto also
+// searches the second most likely language
+const ambiguousDetectionConfidence = 0.8
+
+// LanguageSuggestion is a suggestion tagged with its language and the
+// confidence that the input word belongs to that language
+type LanguageSuggestion struct {
+	Suggestion
+	Language   Language
+	Confidence float64
+}
+
+// SuggestAuto returns the top k suggestions for a word across its most likely
+// languages. Only languages with a loaded dictionary are considered. When
+// detection is ambiguous, suggestions from the two most likely languages are
+// merged and ranked by similarity weighted by detection confidence.
+func (dym *DidYouMean) SuggestAuto(word string, k int) []LanguageSuggestion {
+	candidates := make([]LanguageCandidate, 0)
+	total := 0.0
+	for _, candidate := range DetectLanguageCandidates(word) {
+		if dym.hasDictionary(candidate.Language) {
+			candidates = append(candidates, candidate)
+			total += candidate.Confidence
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	// Renormalize confidence over the loaded languages
+	for i := range candidates {
+		if total > 0 {
+			candidates[i].Confidence /= total
+		} else {
+			candidates[i].Confidence = 1.0 / float64(len(candidates))
+		}
+	}
+	if candidates[0].Confidence >= ambiguousDetectionConfidence {
+		candidates = candidates[:1]
+	} else if len(candidates) > 2 {
+		candidates = candidates[:2]
+	}
+
+	results := make([]LanguageSuggestion, 0)
+	for _, candidate := range candidates {
+		distance := dym.SuggestionTuning(candidate.Language).MaxEditDistance
+		for _, suggestion := range dym.GetSuggestionsForLanguage(word, k, distance, candidate.Language) {
+			results = append(results, LanguageSuggestion{
+				Suggestion: suggestion,
+				Language:   candidate.Language,
+				Confidence: candidate.Confidence,
+			})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Similarity*results[i].Confidence > results[j].Similarity*results[j].Confidence
+	})
+
+	if len(results) > k {
+		results = results[:k]
+	}
+
+	return results
+}
@@ -0,0 +1,44 @@
+package dymean
+
+// DictionaryDiff lists the words that differ between two dictionaries of a language
+type DictionaryDiff struct {
+	Language Language
+	OnlyInA  []string // Words only present in the first dictionary
+	OnlyInB  []string // Words only present in the second dictionary
+}
+
+// Equal reports whether both dictionaries contain the same words
+func (d DictionaryDiff) Equal() bool {
+	return len(d.OnlyInA) == 0 && len(d.OnlyInB) == 0
+}
+
+// MergeFrom adds the dictionaries of every language of other to dym, so a base
+// dictionary can be combined with team-specific additions
+func (dym *DidYouMean) MergeFrom(other *DidYouMean) {
+	for _, lang := range other.GetLoadedLanguages() {
+		dym.AddWordsForLanguage(other.GetDictionaryWords(lang), lang)
+	}
+}
+
+// DiffDictionaries compares the dictionaries of a language in two instances.
+// Both word lists of the result are sorted.
+func DiffDictionaries(a, b *DidYouMean, lang Language) DictionaryDiff {
+	diff := DictionaryDiff{
+		Language: lang,
+		OnlyInA:  make([]string, 0),
+		OnlyInB:  make([]string, 0),
+	}
+
+	for _, word := range a.GetDictionaryWords(lang) {
+		if !b.hasWord(lang, word) {
+			diff.OnlyInA = append(diff.OnlyInA, word)
+		}
+	}
+	for _, word := range b.GetDictionaryWords(lang) {
+		if !a.hasWord(lang, word) {
+			diff.OnlyInB = append(diff.OnlyInB, word)
+		}
+	}
+
+	return diff
+}
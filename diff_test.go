@@ -0,0 +1,38 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"testing"
+)
+
+// TestMergeFromAndDiff tests combining and comparing dictionaries of two instances
+func TestMergeFromAndDiff(t *testing.T) {
+	base := dymean.NewDidYouMean(10000, 7)
+	base.AddWords([]string{"hello", "world"})
+
+	team := dymean.NewDidYouMean(10000, 7)
+	team.AddWords([]string{"hello", "kubernetes"})
+	team.AddWordsForLanguage([]string{"bonjour"}, dymean.French)
+
+	diff := dymean.DiffDictionaries(base, team, dymean.English)
+	if !reflect.DeepEqual(diff.OnlyInA, []string{"world"}) || !reflect.DeepEqual(diff.OnlyInB, []string{"kubernetes"}) {
+		t.Errorf("Unexpected diff %+v", diff)
+	}
+	if diff.Equal() {
+		t.Error("Expected dictionaries to differ")
+	}
+
+	base.MergeFrom(team)
+	if !base.IsCorrect("kubernetes") || !base.IsCorrectForLanguage("bonjour", dymean.French) {
+		t.Error("Expected merged words to be correct")
+	}
+
+	diff = dymean.DiffDictionaries(base, team, dymean.English)
+	if !reflect.DeepEqual(diff.OnlyInA, []string{"world"}) || len(diff.OnlyInB) != 0 {
+		t.Errorf("Unexpected diff after merge %+v", diff)
+	}
+	if !dymean.DiffDictionaries(base, team, dymean.French).Equal() {
+		t.Error("Expected French dictionaries to be equal after merge")
+	}
+}
@@ -0,0 +1,89 @@
+package dymean
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DigitPolicy controls how digits in words are normalized before lookups,
+// dictionary changes and text checks
+type DigitPolicy int
+
+const (
+	// DigitsKeep leaves digits as the language's normalizer writes them:
+	// Persian converts every digit to Persian digits, other languages keep
+	// them as typed. This is the default.
+	DigitsKeep DigitPolicy = iota
+	// DigitsStrip removes digits, so "hello2" is looked up as "hello" and
+	// purely numeric tokens are skipped
+	DigitsStrip
+	// DigitsASCII converts every decimal digit, such as Persian "۳" or
+	// Arabic-Indic "٣", to its ASCII form
+	DigitsASCII
+)
+
+// NormalizeDigits applies a digit policy to a string
+func NormalizeDigits(s string, policy DigitPolicy) string {
+	switch policy {
+	case DigitsStrip:
+		if strings.IndexFunc(s, unicode.IsDigit) < 0 {
+			return s
+		}
+		return strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return -1
+			}
+			return r
+		}, s)
+	case DigitsASCII:
+		return convertDigits(s, '0')
+	default:
+		return s
+	}
+}
+
+// convertDigits rewrites every decimal digit in the digit block starting at zero
+func convertDigits(s string, zero rune) string {
+	return strings.Map(func(r rune) rune {
+		if value, ok := digitValue(r); ok {
+			return zero + rune(value)
+		}
+		return r
+	}, s)
+}
+
+// digitValue returns the value of a decimal digit of any script. Unicode
+// allocates decimal digits in contiguous blocks from zero to nine.
+func digitValue(r rune) (int, bool) {
+	if r >= '0' && r <= '9' {
+		return int(r - '0'), true
+	}
+	if r < 0x80 || !unicode.IsDigit(r) {
+		return 0, false
+	}
+	for _, rng := range unicode.Nd.R16 {
+		if r >= rune(rng.Lo) && r <= rune(rng.Hi) {
+			return int(r-rune(rng.Lo)) % 10, true
+		}
+	}
+	for _, rng := range unicode.Nd.R32 {
+		if r >= rune(rng.Lo) && r <= rune(rng.Hi) {
+			return int(r-rune(rng.Lo)) % 10, true
+		}
+	}
+	return 0, false
+}
+
+// normalize normalizes a word for a language and applies the digit policy.
+// Whitespace inside phrases is collapsed to single spaces, and Traditional
+// Chinese is folded to Simplified with WithChineseVariants.
+func (dym *DidYouMean) normalize(word string, lang Language) string {
+	normalized := collapseSpaces(NormalizeDigits(GetLanguageInfo(lang).Normalizer(word), dym.digitPolicy))
+	if lang == Chinese && dym.chineseFolding {
+		normalized = ToSimplified(normalized)
+	}
+	if lang == Greek && dym.stripTonos {
+		normalized = StripTonos(normalized)
+	}
+	return normalized
+}
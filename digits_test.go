@@ -0,0 +1,56 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestNormalizeDigits tests the digit policies
+func TestNormalizeDigits(t *testing.T) {
+	tests := []struct {
+		input  string
+		policy dymean.DigitPolicy
+		want   string
+	}{
+		{"abc123", dymean.DigitsKeep, "abc123"},
+		{"abc123", dymean.DigitsStrip, "abc"},
+		{"۱۲۳", dymean.DigitsStrip, ""},
+		{"۱۲۳", dymean.DigitsASCII, "123"},
+		{"٤٥٦", dymean.DigitsASCII, "456"},
+		{"１２３", dymean.DigitsASCII, "123"},
+		{"४२", dymean.DigitsASCII, "42"},
+		{"mp3", dymean.DigitsASCII, "mp3"},
+	}
+	for _, tc := range tests {
+		if got := dymean.NormalizeDigits(tc.input, tc.policy); got != tc.want {
+			t.Errorf("NormalizeDigits(%q, %d): expected %q, got %q", tc.input, tc.policy, tc.want, got)
+		}
+	}
+
+	// Persian writes every digit system as Persian digits
+	normalizer := dymean.GetLanguageInfo(dymean.Persian).Normalizer
+	if got := normalizer("12٣۴"); got != "۱۲۳۴" {
+		t.Errorf("Expected Persian digits, got %q", got)
+	}
+}
+
+// TestWithDigitPolicy tests that the policy applies to lookups and text checks
+func TestWithDigitPolicy(t *testing.T) {
+	strip := dymean.NewDidYouMean(10000, 7, dymean.WithDigitPolicy(dymean.DigitsStrip))
+	strip.AddWords([]string{"hello", "world"})
+	if !strip.IsCorrect("hello2") {
+		t.Error("Expected digits to be stripped before lookups")
+	}
+	if corrections := strip.CheckText("hello2 wrold 123"); len(corrections) != 1 || corrections[0].Original != "wrold" {
+		t.Errorf("Expected only wrold to be corrected, got %+v", corrections)
+	}
+
+	keep := dymean.NewDidYouMean(10000, 7)
+	keep.AddWords([]string{"hello", "world"})
+	if keep.IsCorrect("hello2") {
+		t.Error("Expected digits to be kept by default")
+	}
+	if corrections := keep.CheckText("hello2 wrold ۱۲۳"); len(corrections) != 1 || corrections[0].Original != "wrold" {
+		t.Errorf("Expected numeric tokens to be skipped, got %+v", corrections)
+	}
+}
@@ -0,0 +1,214 @@
+package dymean
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidEdit is returned by Document.Edit for a range outside the text or
+// splitting a character
+var ErrInvalidEdit = errors.New("invalid edit range")
+
+// Document is a text edited over time, such as the buffer of an editor, whose
+// corrections are kept up to date incrementally: an edit only rechecks the
+// lines it touches. Lines are checked on their own, so a full check and any
+// sequence of edits give the same corrections. The ignore-all decisions of
+// the document's session apply. A Document is not safe for concurrent use.
+type Document struct {
+	session     *Session
+	lang        Language // Empty to detect the language of each word
+	text        string
+	corrections []Correction // In text order
+}
+
+// DocumentChange is the difference in a document's corrections made by an
+// update. Removed corrections have their offsets before the update and added
+// ones their offsets after it; the others stay, shifted by the length change
+// of an edit when they follow it.
+type DocumentChange struct {
+	Removed []Correction
+	Added   []Correction
+}
+
+// Empty checks if an update left the corrections unchanged
+func (c DocumentChange) Empty() bool {
+	return len(c.Removed) == 0 && len(c.Added) == 0
+}
+
+// NewDocument creates an empty document checked in a language, or with the
+// language of each word detected when lang is empty
+func NewDocument(session *Session, lang Language) *Document {
+	return &Document{session: session, lang: lang, corrections: make([]Correction, 0)}
+}
+
+// Session returns the session of the document
+func (d *Document) Session() *Session {
+	return d.session
+}
+
+// Language returns the language of the document, empty when detected per word
+func (d *Document) Language() Language {
+	return d.lang
+}
+
+// Text returns the current text
+func (d *Document) Text() string {
+	return d.text
+}
+
+// Corrections returns the current corrections in text order
+func (d *Document) Corrections() []Correction {
+	return append([]Correction(nil), d.corrections...)
+}
+
+// SetText replaces the whole text and checks it again
+func (d *Document) SetText(text string) DocumentChange {
+	old := d.corrections
+	d.text = text
+	d.corrections = d.checkLines(0, len(text))
+	return diffCorrections(old, d.corrections, func(c Correction) (Correction, bool) { return c, true })
+}
+
+// Edit replaces the bytes from start to end of the text with text, as typed
+// or pasted by the user, and rechecks the lines the edit touches
+func (d *Document) Edit(start, end int, text string) (DocumentChange, error) {
+	if start < 0 || start > end || end > len(d.text) || !runeBoundary(d.text, start) || !runeBoundary(d.text, end) {
+		return DocumentChange{}, ErrInvalidEdit
+	}
+
+	lineStart := strings.LastIndexByte(d.text[:start], '\n') + 1
+	lineEnd := len(d.text)
+	if i := strings.IndexByte(d.text[end:], '\n'); i >= 0 {
+		lineEnd = end + i
+	}
+	delta := len(text) - (end - start)
+	d.text = d.text[:start] + text + d.text[end:]
+	checked := d.checkLines(lineStart, lineEnd+delta)
+
+	// Corrections never span lines, so those outside the lines stay
+	var before, stale, after []Correction
+	for _, correction := range d.corrections {
+		switch {
+		case correction.Start < lineStart:
+			before = append(before, correction)
+		case correction.Start > lineEnd:
+			after = append(after, correction)
+		default:
+			stale = append(stale, correction)
+		}
+	}
+
+	corrections := make([]Correction, 0, len(before)+len(checked)+len(after))
+	corrections = append(append(corrections, before...), checked...)
+	for _, correction := range after {
+		correction.Start += delta
+		correction.End += delta
+		corrections = append(corrections, correction)
+	}
+	d.corrections = corrections
+
+	return diffCorrections(stale, checked, func(c Correction) (Correction, bool) {
+		switch {
+		case c.End <= start:
+			return c, true
+		case c.Start >= end:
+			c.Start += delta
+			c.End += delta
+			return c, true
+		}
+		return c, false // Edited
+	}), nil
+}
+
+// IgnoreAll ignores every occurrence of a word in the document's session and
+// removes its spelling corrections
+func (d *Document) IgnoreAll(word string) DocumentChange {
+	d.session.IgnoreAll(word)
+	var change DocumentChange
+	kept := make([]Correction, 0, len(d.corrections))
+	for _, correction := range d.corrections {
+		if correction.Category == CategorySpelling && d.session.IsIgnored(correction.Original) {
+			change.Removed = append(change.Removed, correction)
+			continue
+		}
+		kept = append(kept, correction)
+	}
+	d.corrections = kept
+	return change
+}
+
+// checkLines checks the lines of the text from start to end, which must be
+// line boundaries
+func (d *Document) checkLines(start, end int) []Correction {
+	corrections := make([]Correction, 0)
+	for start <= end {
+		lineEnd := end
+		if i := strings.IndexByte(d.text[start:end], '\n'); i >= 0 {
+			lineEnd = start + i
+		}
+		if line := d.text[start:lineEnd]; strings.TrimSpace(line) != "" {
+			var found []Correction
+			if d.lang == "" {
+				found = d.session.CheckText(line)
+			} else {
+				found = d.session.CheckTextForLanguage(line, d.lang)
+			}
+			for _, correction := range found {
+				correction.Start += start
+				correction.End += start
+				corrections = append(corrections, correction)
+			}
+		}
+		start = lineEnd + 1
+	}
+	return corrections
+}
+
+// diffCorrections compares the corrections of a range before and after an
+// update. moved returns the offsets a previous correction would have after
+// the update, or false when the update changed its text.
+func diffCorrections(previous, current []Correction, moved func(Correction) (Correction, bool)) DocumentChange {
+	var change DocumentChange
+	matched := make([]bool, len(current))
+	for _, correction := range previous {
+		found := false
+		if shifted, ok := moved(correction); ok {
+			for i := range current {
+				if !matched[i] && sameCorrection(shifted, current[i]) {
+					matched[i], found = true, true
+					break
+				}
+			}
+		}
+		if !found {
+			change.Removed = append(change.Removed, correction)
+		}
+	}
+	for i, correction := range current {
+		if !matched[i] {
+			change.Added = append(change.Added, correction)
+		}
+	}
+	return change
+}
+
+// sameCorrection checks if two corrections report the same issue with the
+// same suggestions
+func sameCorrection(a, b Correction) bool {
+	if a.Start != b.Start || a.End != b.End || a.Original != b.Original || a.Category != b.Category ||
+		a.Language != b.Language || len(a.Suggestions) != len(b.Suggestions) {
+		return false
+	}
+	for i := range a.Suggestions {
+		if a.Suggestions[i].Word != b.Suggestions[i].Word {
+			return false
+		}
+	}
+	return true
+}
+
+// runeBoundary checks if an offset of a text falls between characters
+func runeBoundary(text string, offset int) bool {
+	return offset == len(text) || utf8.RuneStart(text[offset])
+}
@@ -0,0 +1,153 @@
+package dymean_test
+
+import (
+	"errors"
+	"github.com/bi0dread/dymean"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+// newDocument creates an English document over a small dictionary
+func newDocument() *dymean.Document {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithAutomatonSearch())
+	dym.AddWords([]string{"hello", "world", "the", "cat", "sat", "on", "mat", "with", "me"})
+	return dymean.NewDocument(dymean.NewSession(dym), dymean.English)
+}
+
+// originalsAt returns the originals of corrections with their offsets
+func originalsAt(corrections []dymean.Correction) []string {
+	located := make([]string, 0, len(corrections))
+	for _, correction := range corrections {
+		located = append(located, correction.Original+"@"+strconv.Itoa(correction.Start))
+	}
+	return located
+}
+
+// applyChange updates the corrections known to a client with a change, the
+// way an editor would after an edit
+func applyChange(corrections []dymean.Correction, start, end, delta int, change dymean.DocumentChange) []dymean.Correction {
+	removed := make(map[[2]int]bool)
+	for _, correction := range change.Removed {
+		removed[[2]int{correction.Start, correction.End}] = true
+	}
+	updated := make([]dymean.Correction, 0)
+	for _, correction := range corrections {
+		if removed[[2]int{correction.Start, correction.End}] {
+			continue
+		}
+		if correction.Start >= end {
+			correction.Start += delta
+			correction.End += delta
+		}
+		updated = append(updated, correction)
+	}
+	updated = append(updated, change.Added...)
+	sort.Slice(updated, func(i, j int) bool { return updated[i].Start < updated[j].Start })
+	return updated
+}
+
+// TestDocumentEdit tests that edits report only the corrections they change
+func TestDocumentEdit(t *testing.T) {
+	doc := newDocument()
+	change := doc.SetText("helo world\nthe cat sat\non teh mat")
+	if got := originalsAt(change.Added); !reflect.DeepEqual(got, []string{"helo@0", "teh@26"}) || len(change.Removed) != 0 {
+		t.Fatalf("Unexpected first check %+v", change)
+	}
+
+	// Typing in the middle line shifts the correction below without
+	// reporting it again
+	change, err := doc.Edit(15, 15, "z")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := originalsAt(change.Added); !reflect.DeepEqual(got, []string{"zcat@15"}) || len(change.Removed) != 0 {
+		t.Errorf("Expected only the new misspelling, got %+v", change)
+	}
+	if got := originalsAt(doc.Corrections()); !reflect.DeepEqual(got, []string{"helo@0", "zcat@15", "teh@27"}) {
+		t.Errorf("Unexpected corrections %v", got)
+	}
+
+	// Fixing a word removes its correction with its offsets before the edit
+	change, _ = doc.Edit(27, 30, "the")
+	if got := originalsAt(change.Removed); !reflect.DeepEqual(got, []string{"teh@27"}) || len(change.Added) != 0 {
+		t.Errorf("Expected the fixed word to be removed, got %+v", change)
+	}
+
+	// Joining lines rechecks both
+	change, _ = doc.Edit(10, 11, " ")
+	if !change.Empty() {
+		t.Errorf("Expected no change joining correct lines, got %+v", change)
+	}
+	if doc.Text() != "helo world the zcat sat\non the mat" {
+		t.Errorf("Unexpected text %q", doc.Text())
+	}
+
+	for _, edit := range [][2]int{{-1, 0}, {3, 2}, {0, 100}} {
+		if _, err := doc.Edit(edit[0], edit[1], ""); !errors.Is(err, dymean.ErrInvalidEdit) {
+			t.Errorf("Expected an invalid edit error for %v, got %v", edit, err)
+		}
+	}
+	doc.SetText("héllo")
+	if _, err := doc.Edit(2, 2, "x"); !errors.Is(err, dymean.ErrInvalidEdit) {
+		t.Errorf("Expected an invalid edit error inside a character, got %v", err)
+	}
+}
+
+// TestDocumentMatchesFullCheck tests that random edits keep the same
+// corrections as checking the resulting text from scratch, and that clients
+// applying the changes stay in sync
+func TestDocumentMatchesFullCheck(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	pieces := []string{"helo", "world", " ", "\n", "teh", "cat", "  ", "wiht", "me", "x"}
+	doc := newDocument()
+	client := doc.SetText("the cat sat\non the mat").Added
+
+	for i := 0; i < 300; i++ {
+		text := doc.Text()
+		start := random.Intn(len(text) + 1)
+		end := start + random.Intn(len(text)-start+1)
+		if random.Intn(3) > 0 {
+			end = start + (end-start)/4
+		}
+		insert := ""
+		for n := random.Intn(3); n > 0; n-- {
+			insert += pieces[random.Intn(len(pieces))]
+		}
+
+		change, err := doc.Edit(start, end, insert)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		client = applyChange(client, start, end, len(insert)-(end-start), change)
+
+		full := newDocument()
+		full.SetText(doc.Text())
+		if got, expected := originalsAt(doc.Corrections()), originalsAt(full.Corrections()); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("Edit %d of %q: expected %v, got %v", i, doc.Text(), expected, got)
+		}
+		if got, expected := originalsAt(client), originalsAt(doc.Corrections()); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("Edit %d of %q: expected the client to have %v, got %v", i, doc.Text(), expected, got)
+		}
+	}
+}
+
+// TestDocumentIgnoreAll tests that ignoring a word removes its corrections
+// and keeps it ignored in later edits
+func TestDocumentIgnoreAll(t *testing.T) {
+	doc := newDocument()
+	doc.SetText("helo world\nhelo teh")
+	change := doc.IgnoreAll("Helo")
+	if got := originalsAt(change.Removed); !reflect.DeepEqual(got, []string{"helo@0", "helo@11"}) {
+		t.Errorf("Expected both occurrences to be removed, got %v", got)
+	}
+	change, _ = doc.Edit(0, 0, "helo ")
+	if !change.Empty() {
+		t.Errorf("Expected the ignored word to stay ignored, got %+v", change)
+	}
+	if got := originalsAt(doc.Corrections()); !reflect.DeepEqual(got, []string{"teh@21"}) {
+		t.Errorf("Unexpected corrections %v", got)
+	}
+}
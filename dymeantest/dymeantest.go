@@ -0,0 +1,177 @@
+// Package dymeantest provides a deterministic in-memory checker and assertion
+// helpers for testing code that depends on dymean.Checker, without loading
+// real dictionaries:
+//
+//	checker := dymeantest.New("the", "cat").WithSuggestions("teh", "the")
+//	dymeantest.AssertSuggests(t, checker, "teh", "the")
+//	dymeantest.AssertFlags(t, checker, "teh cat", "teh")
+package dymeantest
+
+import (
+	"github.com/bi0dread/dymean"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// defaultMaxEditDistance is the edit distance of the suggestions computed
+// when a word has no fixture
+const defaultMaxEditDistance = 2
+
+var _ dymean.Checker = (*Checker)(nil)
+
+// Checker is a dymean.Checker over a fixed word list. Words are compared in
+// lowercase. Suggestions come from fixtures when one is set for the word,
+// otherwise from the word list within the edit distance asked, closest first
+// and then alphabetically, so results never vary between runs.
+type Checker struct {
+	mu       sync.RWMutex
+	words    map[string]bool
+	fixtures map[string][]dymean.Suggestion
+}
+
+// New creates a checker accepting the given words
+func New(words ...string) *Checker {
+	c := &Checker{words: make(map[string]bool), fixtures: make(map[string][]dymean.Suggestion)}
+	c.AddWords(words)
+	return c
+}
+
+// AddWords adds words to the word list
+func (c *Checker) AddWords(words []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, word := range words {
+		c.words[strings.ToLower(word)] = true
+	}
+}
+
+// WithSuggestions fixes the suggestions for a word, best first, and returns
+// the checker for chaining. Fixed suggestions have a similarity of 1 for the
+// first and decrease by 0.1 for each next one, down to 0.1.
+func (c *Checker) WithSuggestions(word string, suggestions ...string) *Checker {
+	fixture := make([]dymean.Suggestion, len(suggestions))
+	for i, suggestion := range suggestions {
+		fixture[i] = dymean.Suggestion{Word: suggestion, Similarity: max(1-0.1*float64(i), 0.1)}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fixtures[strings.ToLower(word)] = fixture
+	return c
+}
+
+// IsCorrect checks if a word is in the word list
+func (c *Checker) IsCorrect(word string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.words[strings.ToLower(word)]
+}
+
+// Suggest returns the best suggestion for a word, or the word itself
+func (c *Checker) Suggest(word string) string {
+	if suggestions := c.GetSuggestions(word, 1, defaultMaxEditDistance); len(suggestions) > 0 {
+		return suggestions[0].Word
+	}
+	return word
+}
+
+// GetSuggestions returns the fixture of a word, or the words of the list
+// within maxEditDistance of it
+func (c *Checker) GetSuggestions(word string, maxSuggestions int, maxEditDistance int) []dymean.Suggestion {
+	key := strings.ToLower(word)
+	c.mu.RLock()
+	fixture, fixed := c.fixtures[key]
+	suggestions := make([]dymean.Suggestion, 0)
+	if fixed {
+		suggestions = append(suggestions, fixture...)
+	} else {
+		candidates := make([]string, 0, len(c.words))
+		for candidate := range c.words {
+			candidates = append(candidates, candidate)
+		}
+		for _, candidate := range dymean.FilterByLengthWindow(key, candidates, maxEditDistance) {
+			if dymean.LevenshteinDistance(key, candidate) <= maxEditDistance {
+				suggestions = append(suggestions, dymean.Suggestion{Word: candidate, Similarity: dymean.CalculateSimilarity(key, candidate)})
+			}
+		}
+	}
+	c.mu.RUnlock()
+
+	if !fixed {
+		sort.Slice(suggestions, func(i, j int) bool {
+			if suggestions[i].Similarity != suggestions[j].Similarity {
+				return suggestions[i].Similarity > suggestions[j].Similarity
+			}
+			return suggestions[i].Word < suggestions[j].Word
+		})
+	}
+	if maxSuggestions > 0 && len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	return suggestions
+}
+
+// CheckText reports the words of a text missing from the word list as
+// spelling corrections, with their suggestions. Tokens with digits are
+// skipped, as dymean skips them.
+func (c *Checker) CheckText(text string) []dymean.Correction {
+	corrections := make([]dymean.Correction, 0)
+	for _, token := range dymean.Tokenize(text) {
+		if c.IsCorrect(token.Text) || strings.ContainsAny(token.Text, "0123456789") {
+			continue
+		}
+		corrections = append(corrections, dymean.Correction{
+			Start:       token.Start,
+			End:         token.End,
+			Original:    token.Text,
+			Suggestions: c.GetSuggestions(token.Text, 5, defaultMaxEditDistance),
+			Category:    dymean.CategorySpelling,
+			Language:    dymean.DetectLanguage(token.Text),
+		})
+	}
+	return corrections
+}
+
+// AssertSuggests fails the test unless the best suggestion of checker for
+// word is expected
+func AssertSuggests(t testing.TB, checker dymean.Checker, word, expected string) {
+	t.Helper()
+	if suggestion := checker.Suggest(word); suggestion != expected {
+		t.Errorf("Suggest(%q) = %q, expected %q", word, suggestion, expected)
+	}
+}
+
+// AssertCorrect fails the test unless checker accepts every word
+func AssertCorrect(t testing.TB, checker dymean.Checker, words ...string) {
+	t.Helper()
+	for _, word := range words {
+		if !checker.IsCorrect(word) {
+			t.Errorf("Expected %q to be correct", word)
+		}
+	}
+}
+
+// AssertMisspelled fails the test if checker accepts any of the words
+func AssertMisspelled(t testing.TB, checker dymean.Checker, words ...string) {
+	t.Helper()
+	for _, word := range words {
+		if checker.IsCorrect(word) {
+			t.Errorf("Expected %q to be misspelled", word)
+		}
+	}
+}
+
+// AssertFlags fails the test unless CheckText reports exactly the given
+// words of text, in order; no words asserts a clean text
+func AssertFlags(t testing.TB, checker dymean.Checker, text string, words ...string) {
+	t.Helper()
+	flagged := make([]string, 0)
+	for _, correction := range checker.CheckText(text) {
+		flagged = append(flagged, correction.Original)
+	}
+	if strings.Join(flagged, "\x00") != strings.Join(words, "\x00") || len(flagged) != len(words) {
+		t.Errorf("CheckText(%q) flagged %q, expected %q", text, flagged, words)
+	}
+}
@@ -0,0 +1,64 @@
+package dymeantest_test
+
+import (
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"github.com/bi0dread/dymean/dymeantest"
+	"testing"
+)
+
+// recorder is a testing.TB that records failures instead of failing
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// TestChecker tests the deterministic in-memory checker
+func TestChecker(t *testing.T) {
+	checker := dymeantest.New("the", "cat", "sat", "hat").WithSuggestions("teh", "the", "tea")
+
+	dymeantest.AssertCorrect(t, checker, "the", "Cat")
+	dymeantest.AssertMisspelled(t, checker, "teh", "dog")
+	dymeantest.AssertSuggests(t, checker, "teh", "the")
+	dymeantest.AssertSuggests(t, checker, "cst", "cat")
+	dymeantest.AssertSuggests(t, checker, "zzzz", "zzzz")
+	dymeantest.AssertFlags(t, checker, "Teh cat sat on 2 mats", "Teh", "on", "mats")
+	dymeantest.AssertFlags(t, checker, "the cat")
+
+	expected := []dymean.Suggestion{{Word: "the", Similarity: 1}, {Word: "tea", Similarity: 0.9}}
+	if suggestions := checker.GetSuggestions("TEH", 5, 2); fmt.Sprint(suggestions) != fmt.Sprint(expected) {
+		t.Errorf("Expected the fixture %v, got %v", expected, suggestions)
+	}
+
+	// Ties are broken alphabetically, the same way on every run
+	for i := 0; i < 10; i++ {
+		if suggestions := checker.GetSuggestions("xat", 3, 1); fmt.Sprint(suggestions) != "[{cat 0.6666666666666667 } {hat 0.6666666666666667 } {sat 0.6666666666666667 }]" {
+			t.Fatalf("Unexpected suggestions %v", suggestions)
+		}
+	}
+}
+
+// TestAssertionsFail tests that the assertions report mismatches
+func TestAssertionsFail(t *testing.T) {
+	checker := dymeantest.New("the")
+	r := &recorder{TB: t}
+
+	dymeantest.AssertSuggests(r, checker, "teh", "tea")
+	dymeantest.AssertCorrect(r, checker, "teh")
+	dymeantest.AssertMisspelled(r, checker, "the")
+	dymeantest.AssertFlags(r, checker, "teh the")
+	if len(r.failures) != 4 {
+		t.Errorf("Expected 4 failures, got %q", r.failures)
+	}
+
+	// The assertions accept any checker
+	dym := dymean.NewDidYouMean(1000, 5)
+	dym.AddWords([]string{"hello"})
+	dymeantest.AssertSuggests(t, dym, "helo", "hello")
+}
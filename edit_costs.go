@@ -0,0 +1,94 @@
+package dymean
+
+import "math"
+
+// EditCosts are the costs of the edit operations of a WeightedLevenshtein
+type EditCosts struct {
+	Insert     float64
+	Delete     float64
+	Substitute float64
+	Transpose  float64 // Swapping two adjacent characters
+}
+
+// DefaultEditCosts count every operation as one edit, as LevenshteinOps does
+var DefaultEditCosts = EditCosts{Insert: 1, Delete: 1, Substitute: 1, Transpose: 1}
+
+// WeightedLevenshtein computes edit distances with configurable operation
+// costs and per-character substitution costs, to tune distances for OCR
+// ("0" for "O"), phonetic or language-specific confusions. It works on runes.
+// Configure it before sharing it between goroutines.
+type WeightedLevenshtein struct {
+	costs         EditCosts
+	substitutions map[[2]rune]float64
+}
+
+// NewWeightedLevenshtein creates a distance with the given operation costs
+func NewWeightedLevenshtein(costs EditCosts) *WeightedLevenshtein {
+	return &WeightedLevenshtein{costs: costs, substitutions: make(map[[2]rune]float64)}
+}
+
+// SetSubstitutionCost overrides the cost of substituting one character for
+// the other, in either direction, and returns w for chaining
+func (w *WeightedLevenshtein) SetSubstitutionCost(a, b rune, cost float64) *WeightedLevenshtein {
+	w.substitutions[[2]rune{a, b}] = cost
+	w.substitutions[[2]rune{b, a}] = cost
+	return w
+}
+
+// Costs returns the operation costs
+func (w *WeightedLevenshtein) Costs() EditCosts {
+	return w.costs
+}
+
+// substitutionCost returns the cost of replacing a with b
+func (w *WeightedLevenshtein) substitutionCost(a, b rune) float64 {
+	if a == b {
+		return 0
+	}
+	if cost, ok := w.substitutions[[2]rune{a, b}]; ok {
+		return cost
+	}
+	return w.costs.Substitute
+}
+
+// Distance returns the cheapest total cost of the edits turning s1 into s2
+func (w *WeightedLevenshtein) Distance(s1, s2 string) float64 {
+	if s1 == s2 {
+		return 0
+	}
+	a, b := []rune(s1), []rune(s2)
+
+	// Three rows are kept, as a transposition looks two rows back
+	prevPrev := make([]float64, len(b)+1)
+	prev := make([]float64, len(b)+1)
+	curr := make([]float64, len(b)+1)
+	for j := 1; j <= len(b); j++ {
+		prev[j] = prev[j-1] + w.costs.Insert
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = prev[0] + w.costs.Delete
+		for j := 1; j <= len(b); j++ {
+			curr[j] = math.Min(prev[j]+w.costs.Delete, curr[j-1]+w.costs.Insert)
+			curr[j] = math.Min(curr[j], prev[j-1]+w.substitutionCost(a[i-1], b[j-1]))
+			if isTransposition(a, b, i, j) {
+				curr[j] = math.Min(curr[j], prevPrev[j-2]+w.costs.Transpose)
+			}
+		}
+		prevPrev, prev, curr = prev, curr, prevPrev
+	}
+
+	return prev[len(b)]
+}
+
+// Similarity returns a score between 0 and 1 where 1 means identical,
+// normalizing the distance by the cost of rewriting the longer string with
+// the most expensive of insertion, deletion and substitution
+func (w *WeightedLevenshtein) Similarity(s1, s2 string) float64 {
+	longest := max(len([]rune(s1)), len([]rune(s2)))
+	scale := float64(longest) * math.Max(w.costs.Substitute, math.Max(w.costs.Insert, w.costs.Delete))
+	if scale == 0 {
+		return 1.0
+	}
+	return math.Max(0, 1.0-w.Distance(s1, s2)/scale)
+}
@@ -0,0 +1,56 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestWeightedLevenshteinDefaults tests that default costs count edits like LevenshteinOps
+func TestWeightedLevenshteinDefaults(t *testing.T) {
+	distance := dymean.NewWeightedLevenshtein(dymean.DefaultEditCosts)
+	pairs := [][2]string{{"", ""}, {"abc", ""}, {"kitten", "sitting"}, {"form", "from"}, {"naïve", "naive"}, {"hello", "hello"}}
+
+	for _, pair := range pairs {
+		expected := float64(len(dymean.LevenshteinOps(pair[0], pair[1])))
+		if got := distance.Distance(pair[0], pair[1]); got != expected {
+			t.Errorf("Distance(%q, %q) = %v, expected %v", pair[0], pair[1], got, expected)
+		}
+	}
+	if similarity := distance.Similarity("hello", "helo"); similarity != 0.8 {
+		t.Errorf("Similarity(hello, helo) = %v, expected 0.8", similarity)
+	}
+}
+
+// TestWeightedLevenshteinCosts tests per-operation and per-pair costs
+func TestWeightedLevenshteinCosts(t *testing.T) {
+	ocr := dymean.NewWeightedLevenshtein(dymean.EditCosts{Insert: 2, Delete: 2, Substitute: 1, Transpose: 1.5}).
+		SetSubstitutionCost('0', 'O', 0.1).
+		SetSubstitutionCost('1', 'l', 0.2)
+
+	tests := []struct {
+		s1, s2   string
+		expected float64
+	}{
+		{"HELL0", "HELLO", 0.1},
+		{"HELLO", "HELL0", 0.1},
+		{"he1lo", "hello", 0.2},
+		{"cat", "cut", 1},
+		{"cat", "cats", 2},
+		{"form", "from", 1.5},
+		{"cat", "ca", 2},
+	}
+	for _, test := range tests {
+		if got := ocr.Distance(test.s1, test.s2); got < test.expected-1e-9 || got > test.expected+1e-9 {
+			t.Errorf("Distance(%q, %q) = %v, expected %v", test.s1, test.s2, got, test.expected)
+		}
+	}
+
+	// An expensive substitution falls back to deleting and inserting
+	strict := dymean.NewWeightedLevenshtein(dymean.EditCosts{Insert: 1, Delete: 1, Substitute: 5, Transpose: 5})
+	if got := strict.Distance("cat", "cut"); got != 2 {
+		t.Errorf("Distance(cat, cut) = %v, expected 2", got)
+	}
+	if similarity := strict.Similarity("abc", "xyz"); similarity < 0 || similarity > 1 {
+		t.Errorf("Similarity out of range: %v", similarity)
+	}
+}
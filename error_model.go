@@ -0,0 +1,175 @@
+package dymean
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"unicode/utf8"
+)
+
+// errorModelVersion is the current version of the persisted error model format
+const errorModelVersion = 1
+
+// ErrorModel records which corrections users chose for recurring misspellings.
+// It is safe for concurrent use, so corrections can be learned while
+// suggestions are ranked.
+type ErrorModel struct {
+	mu          sync.RWMutex                           // Guards corrections
+	corrections map[Language]map[string]map[string]int // misspelled -> chosen -> count
+}
+
+// errorModelFile is the persisted representation of an ErrorModel
+type errorModelFile struct {
+	Version     int                                    `json:"version"`
+	Corrections map[Language]map[string]map[string]int `json:"corrections"`
+}
+
+// NewErrorModel creates an empty error model
+func NewErrorModel() *ErrorModel {
+	return &ErrorModel{
+		corrections: make(map[Language]map[string]map[string]int),
+	}
+}
+
+// Learn records that chosen was picked as the correction of misspelled
+func (em *ErrorModel) Learn(misspelled, chosen string, lang Language) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	if em.corrections[lang] == nil {
+		em.corrections[lang] = make(map[string]map[string]int)
+	}
+	if em.corrections[lang][misspelled] == nil {
+		em.corrections[lang][misspelled] = make(map[string]int)
+	}
+	em.corrections[lang][misspelled][chosen]++
+}
+
+// Count returns how many times chosen was picked as the correction of misspelled
+func (em *ErrorModel) Count(misspelled, chosen string, lang Language) int {
+	em.mu.RLock()
+	defer em.mu.RUnlock()
+	return em.corrections[lang][misspelled][chosen]
+}
+
+// Corrections returns the corrections chosen for a misspelling with their counts
+func (em *ErrorModel) Corrections(misspelled string, lang Language) map[string]int {
+	em.mu.RLock()
+	defer em.mu.RUnlock()
+	result := make(map[string]int, len(em.corrections[lang][misspelled]))
+	for chosen, count := range em.corrections[lang][misspelled] {
+		result[chosen] = count
+	}
+	return result
+}
+
+// Boost returns a ranking boost between 0 and 1 for a candidate correction,
+// growing with the number of times it was chosen for the misspelling
+func (em *ErrorModel) Boost(misspelled, candidate string, lang Language) float64 {
+	return boost(em.Count(misspelled, candidate, lang))
+}
+
+// boost returns the ranking boost of a correction chosen count times
+func boost(count int) float64 {
+	return float64(count) / float64(count+2)
+}
+
+// FirstLetterErrorRate returns the share of the corrections learned for a
+// language that changed the first letter of the misspelling, with the number
+// of corrections; the rate is 0 without corrections
+func (em *ErrorModel) FirstLetterErrorRate(lang Language) (float64, int) {
+	em.mu.RLock()
+	defer em.mu.RUnlock()
+	changed, total := 0, 0
+	for misspelled, chosen := range em.corrections[lang] {
+		first, _ := utf8.DecodeRuneInString(misspelled)
+		for correction, count := range chosen {
+			if correctionFirst, _ := utf8.DecodeRuneInString(correction); correctionFirst != first {
+				changed += count
+			}
+			total += count
+		}
+	}
+	if total == 0 {
+		return 0, 0
+	}
+	return float64(changed) / float64(total), total
+}
+
+// Save writes the error model as JSON
+func (em *ErrorModel) Save(w io.Writer) error {
+	em.mu.RLock()
+	defer em.mu.RUnlock()
+	return json.NewEncoder(w).Encode(errorModelFile{
+		Version:     errorModelVersion,
+		Corrections: em.corrections,
+	})
+}
+
+// LoadErrorModel reads an error model previously written with Save
+func LoadErrorModel(r io.Reader) (*ErrorModel, error) {
+	var file errorModelFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("decode error model: %w", err)
+	}
+	if file.Version != errorModelVersion {
+		return nil, fmt.Errorf("unsupported error model version %d", file.Version)
+	}
+
+	em := NewErrorModel()
+	if file.Corrections != nil {
+		em.corrections = file.Corrections
+	}
+	return em, nil
+}
+
+// LearnCorrection records that the user chose a correction for a misspelled word
+// in the current language, so future suggestions for it rank the choice higher
+func (dym *DidYouMean) LearnCorrection(misspelled, chosen string) {
+	dym.LearnCorrectionForLanguage(misspelled, chosen, dym.currentLang)
+}
+
+// LearnCorrectionForLanguage records a chosen correction for a specific language
+func (dym *DidYouMean) LearnCorrectionForLanguage(misspelled, chosen string, lang Language) {
+	normalized, correction := dym.normalize(misspelled, lang), dym.normalize(chosen, lang)
+	dym.errorModel.Learn(normalized, correction, lang)
+	dym.recordChoice(normalized, correction, true, lang)
+}
+
+// SetErrorModel replaces the error model, e.g. with one loaded by LoadErrorModel
+func (dym *DidYouMean) SetErrorModel(em *ErrorModel) {
+	dym.errorModel = em
+}
+
+// GetErrorModel returns the error model so it can be persisted
+func (dym *DidYouMean) GetErrorModel() *ErrorModel {
+	return dym.errorModel
+}
+
+// applyErrorModel adds previously chosen corrections to the candidates and
+// boosts their similarity according to how often they were chosen
+func (dym *DidYouMean) applyErrorModel(normalized string, lang Language, suggestions []Suggestion, memo *similarityMemo) []Suggestion {
+	learned := dym.errorModel.Corrections(normalized, lang)
+	if len(learned) == 0 {
+		return suggestions
+	}
+
+	seen := make(map[string]bool, len(suggestions))
+	for _, suggestion := range suggestions {
+		seen[suggestion.Word] = true
+	}
+	for chosen := range learned {
+		if !seen[chosen] && dym.hasWord(lang, chosen) {
+			suggestions = append(suggestions, Suggestion{
+				Word:       chosen,
+				Similarity: memo.similarity(normalized, chosen),
+			})
+		}
+	}
+
+	for i := range suggestions {
+		suggestions[i].Similarity += (1.0 - suggestions[i].Similarity) * boost(learned[suggestions[i].Word])
+	}
+
+	return suggestions
+}
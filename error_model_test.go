@@ -0,0 +1,94 @@
+package dymean_test
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"sync"
+	"testing"
+)
+
+// TestLearnCorrection tests that learned corrections are ranked higher
+func TestLearnCorrection(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"cart", "card", "care"})
+
+	before := dym.GetSuggestions("carx", 3, 1)
+	if len(before) != 3 {
+		t.Fatalf("Expected 3 suggestions for 'carx', got %v", before)
+	}
+
+	for i := 0; i < 3; i++ {
+		dym.LearnCorrection("carx", "care")
+	}
+
+	after := dym.GetSuggestions("carx", 3, 1)
+	if len(after) == 0 || after[0].Word != "care" {
+		t.Errorf("Expected learned correction 'care' to rank first, got %v", after)
+	}
+
+	// Learned corrections are offered even when outside the edit distance
+	dym.LearnCorrection("krt", "cart")
+	suggestions := dym.GetSuggestions("krt", 3, 1)
+	if len(suggestions) == 0 || suggestions[0].Word != "cart" {
+		t.Errorf("Expected learned correction 'cart' for 'krt', got %v", suggestions)
+	}
+}
+
+// TestErrorModelPersistence tests saving and loading the error model
+func TestErrorModelPersistence(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.LearnCorrection("teh", "the")
+	dym.LearnCorrection("teh", "the")
+
+	var buf bytes.Buffer
+	if err := dym.GetErrorModel().Save(&buf); err != nil {
+		t.Fatalf("Failed to save error model: %v", err)
+	}
+
+	loaded, err := dymean.LoadErrorModel(&buf)
+	if err != nil {
+		t.Fatalf("Failed to load error model: %v", err)
+	}
+	if count := loaded.Count("teh", "the", dymean.English); count != 2 {
+		t.Errorf("Expected count 2 for 'teh' -> 'the', got %d", count)
+	}
+
+	if _, err := dymean.LoadErrorModel(bytes.NewBufferString(`{"version":99}`)); err == nil {
+		t.Error("Expected an error for an unsupported error model version")
+	}
+}
+
+// TestLearnCorrectionConcurrent tests learning corrections while suggestions
+// are ranked, for the race detector
+func TestLearnCorrectionConcurrent(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"cart", "card", "care"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				dym.LearnCorrection("carx", "card")
+				dym.LearnCorrection(fmt.Sprintf("carx%d", j), "cart")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				dym.GetSuggestions("carx", 3, 1)
+				dym.CheckText("carx")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if count := dym.GetErrorModel().Count("carx", "card", dymean.English); count != 800 {
+		t.Errorf("Expected 800 learned corrections, got %d", count)
+	}
+	if suggestions := dym.GetSuggestions("carx", 3, 1); suggestions[0].Word != "card" {
+		t.Errorf("Expected the learned correction first, got %v", suggestions)
+	}
+}
@@ -0,0 +1,58 @@
+package dymean
+
+// DictionaryEventKind describes a change to a dictionary
+type DictionaryEventKind string
+
+// Kinds of dictionary changes
+const (
+	EventWordsAdded   DictionaryEventKind = "added"    // Words were added
+	EventWordsRemoved DictionaryEventKind = "removed"  // Words were removed
+	EventReloaded     DictionaryEventKind = "reloaded" // The whole dictionary was replaced
+)
+
+// DictionaryEvent reports a change to a language's dictionary, so caches of
+// lookups and suggestions can drop exactly the entries it affects
+type DictionaryEvent struct {
+	Kind     DictionaryEventKind
+	Language Language
+	Words    []string // Normalized words added or removed; every word for EventReloaded
+}
+
+// OnDictionaryChange registers a callback run after every change that added
+// or removed at least one word, and returns a function that unregisters it.
+// Callbacks run synchronously in the goroutine making the change, after the
+// change is visible to lookups, so they must be quick and must not change
+// dictionaries themselves. Words of an event must not be modified.
+func (dym *DidYouMean) OnDictionaryChange(callback func(DictionaryEvent)) (unregister func()) {
+	dym.listenersMu.Lock()
+	defer dym.listenersMu.Unlock()
+
+	id := dym.nextListener
+	dym.nextListener++
+	dym.listeners[id] = callback
+
+	return func() {
+		dym.listenersMu.Lock()
+		defer dym.listenersMu.Unlock()
+		delete(dym.listeners, id)
+	}
+}
+
+// notify runs the registered callbacks for an event. It must be called
+// without dym.mu held.
+func (dym *DidYouMean) notify(event DictionaryEvent) {
+	if len(event.Words) == 0 && event.Kind != EventReloaded {
+		return
+	}
+
+	dym.listenersMu.Lock()
+	callbacks := make([]func(DictionaryEvent), 0, len(dym.listeners))
+	for _, callback := range dym.listeners {
+		callbacks = append(callbacks, callback)
+	}
+	dym.listenersMu.Unlock()
+
+	for _, callback := range callbacks {
+		callback(event)
+	}
+}
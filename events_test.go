@@ -0,0 +1,72 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// TestDictionaryEvents tests the change notifications of adds, removes and reloads
+func TestDictionaryEvents(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	var events []dymean.DictionaryEvent
+	unregister := dym.OnDictionaryChange(func(event dymean.DictionaryEvent) {
+		events = append(events, event)
+	})
+
+	dym.AddWords([]string{"Hello", "world", "hello"})
+	dym.AddWords([]string{"world"}) // Nothing new, no event
+	dym.RemoveWords([]string{"world", "missing"})
+	dym.RemoveWords([]string{"missing"}) // Nothing removed, no event
+	report := dym.ReplaceDictionary([]string{"bonjour", "maison"}, dymean.English)
+
+	want := []dymean.DictionaryEvent{
+		{Kind: dymean.EventWordsAdded, Language: dymean.English, Words: []string{"hello", "world"}},
+		{Kind: dymean.EventWordsRemoved, Language: dymean.English, Words: []string{"world"}},
+		{Kind: dymean.EventReloaded, Language: dymean.English, Words: []string{"bonjour", "maison"}},
+	}
+	for i := range events {
+		sort.Strings(events[i].Words)
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("Expected events %+v, got %+v", want, events)
+	}
+	if report.Added != 2 {
+		t.Errorf("Expected 2 words in the new dictionary, got %+v", report)
+	}
+
+	unregister()
+	dym.AddWords([]string{"help"})
+	if len(events) != 3 {
+		t.Errorf("Expected no events after unregistering, got %d", len(events))
+	}
+}
+
+// TestReplaceDictionary tests that a reload replaces the words of one language only
+func TestReplaceDictionary(t *testing.T) {
+	for _, compact := range []bool{false, true} {
+		var opts []dymean.Option
+		if compact {
+			opts = append(opts, dymean.WithCompactStorage(dymean.English))
+		}
+		dym := dymean.NewDidYouMean(10000, 7, opts...)
+		dym.AddWords([]string{"hello", "world"})
+		dym.AddWordsForLanguage([]string{"bonjour"}, dymean.French)
+		dym.RemoveWords([]string{"world"})
+
+		dym.ReplaceDictionary([]string{"help", "new york"}, dymean.English)
+		if dym.IsCorrect("hello") || !dym.IsCorrect("help") || !dym.IsCorrect("new york") {
+			t.Errorf("compact=%v: expected only the new English words", compact)
+		}
+		if !dym.IsCorrectForLanguage("bonjour", dymean.French) {
+			t.Errorf("compact=%v: expected other languages to be kept", compact)
+		}
+		if len(dym.CompactionStats().Tombstones) != 0 {
+			t.Errorf("compact=%v: expected a reload to clear tombstones", compact)
+		}
+		if suggestions := dym.GetSuggestions("hlep", 1, 2); len(suggestions) != 1 || suggestions[0].Word != "help" {
+			t.Errorf("compact=%v: expected help, got %v", compact, suggestions)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/bi0dread/dymean"
 	"math"
+	"strings"
 	"testing"
 )
 
@@ -60,6 +61,28 @@ func ExampleDidYouMean() {
 	// Did you mean: algorithm?
 }
 
+// misspelledWords lists the misspelled words of a text with their best
+// suggestion, using any checker
+func misspelledWords(checker dymean.Checker, text string) []string {
+	words := make([]string, 0)
+	for _, correction := range checker.CheckText(text) {
+		words = append(words, correction.Original+" -> "+checker.Suggest(correction.Original))
+	}
+	return words
+}
+
+// ExampleChecker demonstrates depending on the Checker interface, which
+// DidYouMean, the remote client and test fakes implement
+func ExampleChecker() {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello", "world"})
+
+	fmt.Println(misspelledWords(dym, "helo world"))
+
+	// Output:
+	// [helo -> hello]
+}
+
 // TestDidYouMeanBasic tests basic functionality
 func TestDidYouMeanBasic(t *testing.T) {
 	dym := dymean.NewDidYouMean(1000, 5)
@@ -113,6 +136,31 @@ func TestSuggestions(t *testing.T) {
 	}
 }
 
+// TestAlternativesForCorrectWords tests returning neighbors of correct words
+func TestAlternativesForCorrectWords(t *testing.T) {
+	dym := dymean.NewDidYouMean(1000, 5)
+	dym.AddWords([]string{"hello", "help", "hell", "world"})
+
+	suggestions := dym.GetSuggestions("hell", 5, 1)
+	if len(suggestions) != 1 || suggestions[0].Word != "hell" {
+		t.Errorf("Expected only the exact match by default, got %v", suggestions)
+	}
+
+	dym.SetIncludeAlternativesForCorrectWords(true)
+	suggestions = dym.GetSuggestions("hell", 5, 1)
+	if len(suggestions) != 3 {
+		t.Fatalf("Expected the exact match and 2 alternatives, got %v", suggestions)
+	}
+	if suggestions[0].Word != "hell" || suggestions[0].Similarity != 1.0 {
+		t.Errorf("Expected the exact match to rank first, got %v", suggestions)
+	}
+	for _, suggestion := range suggestions[1:] {
+		if suggestion.Similarity >= 1.0 {
+			t.Errorf("Expected alternatives to rank below the exact match, got %v", suggestion)
+		}
+	}
+}
+
 // TestLevenshteinDistance tests the Levenshtein distance calculation
 func TestLevenshteinDistance(t *testing.T) {
 	tests := []struct {
@@ -126,7 +174,19 @@ func TestLevenshteinDistance(t *testing.T) {
 		{"hello", "helo", 1},
 		{"hello", "world", 4},
 		{"kitten", "sitting", 3},
+		{"sitting", "kitten", 3},
 		{"saturday", "sunday", 3},
+		{"sunday", "saturday", 3},
+		{"abc", "bca", 2},
+		{"ab", "ba", 2},
+		{"hello", "hallo", 1},
+		{"form", "from", 2},
+		{"reading", "redaing", 2},
+		{"prefixABCsuffix", "prefixCABsuffix", 2},
+		{"aaaa", "aaa", 1},
+		{"abcabc", "abc", 3},
+		{strings.Repeat("ab", 1000), strings.Repeat("ab", 999) + "b", 1},
+		{"https://example.com/" + strings.Repeat("x", 2000), "https://example.org/" + strings.Repeat("x", 1990), 13},
 	}
 
 	for _, test := range tests {
@@ -224,3 +284,84 @@ func BenchmarkDidYouMean(b *testing.B) {
 		dym.GetSuggestions("helo", 5, 2)
 	}
 }
+
+// BenchmarkLevenshteinDistance benchmarks distance computation allocations
+func BenchmarkLevenshteinDistance(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		dymean.LevenshteinDistance("accommodation", "acommodation")
+	}
+}
+
+// BenchmarkLevenshteinDistanceEqualLength benchmarks the Hamming fast path
+// taken for transpositions and substitutions
+func BenchmarkLevenshteinDistanceEqualLength(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		dymean.LevenshteinDistance("accommodation", "accommodatoin")
+	}
+}
+
+// BenchmarkLevenshteinDistanceLong benchmarks distance computation for long
+// inputs such as a pasted URL against a short word
+func BenchmarkLevenshteinDistanceLong(b *testing.B) {
+	url := "https://example.com/" + strings.Repeat("path/", 400)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		dymean.LevenshteinDistance(url, "example")
+	}
+}
+
+// BenchmarkGetSuggestionsParallel benchmarks suggestions under concurrent load,
+// where per-call allocations turn into GC pressure
+func BenchmarkGetSuggestionsParallel(b *testing.B) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	words := make([]string, 1000)
+	for i := range words {
+		words[i] = fmt.Sprintf("word%d", i)
+	}
+	dym.AddWords(words)
+	dym.AddWords([]string{"hello", "help", "world", "would", "word"})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			dym.GetSuggestions("helo", 5, 2)
+		}
+	})
+}
+
+// TestLevenshteinOps tests the edit script between two strings
+func TestLevenshteinOps(t *testing.T) {
+	tests := []struct {
+		s1, s2   string
+		expected []dymean.EditOp
+	}{
+		{"hello", "hello", []dymean.EditOp{}},
+		{"helo", "hello", []dymean.EditOp{{Type: dymean.EditInsert, SourcePos: 2, TargetPos: 2, To: "l"}}},
+		{"hello", "helo", []dymean.EditOp{{Type: dymean.EditDelete, SourcePos: 2, TargetPos: 2, From: "l"}}},
+		{"cat", "cut", []dymean.EditOp{{Type: dymean.EditSubstitute, SourcePos: 1, TargetPos: 1, From: "a", To: "u"}}},
+		{"teh", "the", []dymean.EditOp{{Type: dymean.EditTranspose, SourcePos: 1, TargetPos: 1, From: "eh", To: "he"}}},
+		{"سلا", "سلام", []dymean.EditOp{{Type: dymean.EditInsert, SourcePos: 3, TargetPos: 3, To: "م"}}},
+	}
+
+	for _, test := range tests {
+		ops := dymean.LevenshteinOps(test.s1, test.s2)
+		if len(ops) != len(test.expected) {
+			t.Errorf("dymean.LevenshteinOps(%q, %q) = %+v, expected %+v", test.s1, test.s2, ops, test.expected)
+			continue
+		}
+		for i := range ops {
+			if ops[i] != test.expected[i] {
+				t.Errorf("dymean.LevenshteinOps(%q, %q)[%d] = %+v, expected %+v", test.s1, test.s2, i, ops[i], test.expected[i])
+			}
+		}
+	}
+
+	// Multiple operations are returned in order
+	ops := dymean.LevenshteinOps("kitten", "sitting")
+	if len(ops) != 3 || ops[0].SourcePos != 0 || ops[2].Type != dymean.EditInsert {
+		t.Errorf("Unexpected edit script for 'kitten' -> 'sitting': %+v", ops)
+	}
+}
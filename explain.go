@@ -0,0 +1,130 @@
+package dymean
+
+import (
+	"unicode"
+)
+
+// ExplanationKind identifies why a suggestion was made
+type ExplanationKind string
+
+// Kinds of suggestion explanations
+const (
+	ExplainMissingLetter  ExplanationKind = "missing-letter"  // The word lacks one letter
+	ExplainExtraLetter    ExplanationKind = "extra-letter"    // The word has one letter too many
+	ExplainWrongLetter    ExplanationKind = "wrong-letter"    // One letter differs
+	ExplainAdjacentKey    ExplanationKind = "adjacent-key"    // One letter was typed with a neighboring key
+	ExplainSwappedLetters ExplanationKind = "swapped-letters" // Two adjacent letters are swapped
+	ExplainMissingAccent  ExplanationKind = "missing-accent"  // Only accents or diacritics are missing
+	ExplainVariant        ExplanationKind = "variant"         // Another spelling of the same letters, such as ZWNJ or letter variants
+	ExplainEdits          ExplanationKind = "edits"           // Several changes
+	ExplainLearned        ExplanationKind = "learned"         // The correction was chosen before
+	ExplainConfusable     ExplanationKind = "confusable"      // A registered confusion pair
+)
+
+// explanationTexts holds the English message of each kind, translated with
+// Translate
+var explanationTexts = map[ExplanationKind]string{
+	ExplainMissingLetter:  "1 character missing",
+	ExplainExtraLetter:    "1 extra character",
+	ExplainWrongLetter:    "1 wrong character",
+	ExplainAdjacentKey:    "neighboring key pressed",
+	ExplainSwappedLetters: "adjacent letters swapped",
+	ExplainMissingAccent:  "accent missing",
+	ExplainVariant:        "spelling variant",
+	ExplainEdits:          "%d changes",
+	ExplainLearned:        "chosen before",
+	ExplainConfusable:     "often confused",
+}
+
+// SetExplainSuggestions makes suggestion APIs fill the Reason of each
+// suggestion with a short message, in the suggestion's language, on how it
+// differs from the word, for correction UIs. Messages are translated with
+// Translate; see RegisterMessages for languages other than English and Persian.
+func (dym *DidYouMean) SetExplainSuggestions(enabled bool) {
+	dym.explainSuggestions = enabled
+}
+
+// ExplainSuggestion returns the kind of difference between a word and a
+// suggestion for it, and its message in the language. Both are empty when
+// the suggestion is the word itself.
+func (dym *DidYouMean) ExplainSuggestion(word, suggestion string, lang Language) (ExplanationKind, string) {
+	normalized := dym.normalize(word, lang)
+	kind, n := dym.explanationKind(normalized, suggestion, lang)
+	if kind == "" {
+		return "", ""
+	}
+	return kind, explanationMessage(kind, n, lang)
+}
+
+// explainAll fills the Reason of suggestions for a normalized word
+func (dym *DidYouMean) explainAll(normalized string, lang Language, suggestions []Suggestion) {
+	for i := range suggestions {
+		// Suggestions may be converted to another variant, as Chinese ones are
+		if kind, n := dym.explanationKind(normalized, dym.normalize(suggestions[i].Word, lang), lang); kind != "" {
+			suggestions[i].Reason = explanationMessage(kind, n, lang)
+		}
+	}
+}
+
+// explanationKind classifies the difference between a normalized word and a
+// suggestion, returning the number of edits for ExplainEdits
+func (dym *DidYouMean) explanationKind(normalized, suggestion string, lang Language) (ExplanationKind, int) {
+	if normalized == suggestion {
+		return "", 0
+	}
+	if dym.errorModel.Count(normalized, suggestion, lang) > 0 {
+		return ExplainLearned, 0
+	}
+	if _, ok := dym.Confusables(normalized, lang)[suggestion]; ok {
+		return ExplainConfusable, 0
+	}
+	if foldWord(normalized) == foldWord(suggestion) {
+		if countAccents(suggestion) > countAccents(normalized) {
+			return ExplainMissingAccent, 0
+		}
+		return ExplainVariant, 0
+	}
+	if table := equivalenceTable(GetLanguageInfo(lang).Equivalences); len(table) > 0 && foldEquivalences(normalized, table) == foldEquivalences(suggestion, table) {
+		return ExplainVariant, 0
+	}
+
+	ops := LevenshteinOps(normalized, suggestion)
+	if len(ops) != 1 {
+		return ExplainEdits, len(ops)
+	}
+	switch op := ops[0]; op.Type {
+	case EditInsert:
+		return ExplainMissingLetter, 1
+	case EditDelete:
+		return ExplainExtraLetter, 1
+	case EditTranspose:
+		return ExplainSwappedLetters, 1
+	default:
+		from, to := []rune(op.From), []rune(op.To)
+		for _, neighbor := range GetLanguageInfo(lang).KeyboardLayout[from[0]] {
+			if neighbor == to[0] {
+				return ExplainAdjacentKey, 1
+			}
+		}
+		return ExplainWrongLetter, 1
+	}
+}
+
+// explanationMessage returns the message of a kind in a language
+func explanationMessage(kind ExplanationKind, n int, lang Language) string {
+	if kind == ExplainEdits {
+		return Translate(lang, explanationTexts[kind], n)
+	}
+	return Translate(lang, explanationTexts[kind])
+}
+
+// countAccents counts the accented letters and combining marks of a word
+func countAccents(word string) int {
+	count := 0
+	for _, r := range word {
+		if _, ok := latinFolds[r]; ok || unicode.Is(unicode.Mn, r) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,61 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestExplainSuggestion tests the explanation of each kind of difference
+func TestExplainSuggestion(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddConfusables([]dymean.ConfusablePair{{A: "affect", B: "effect", Weight: 0.5}})
+	dym.LearnCorrection("teh", "the")
+
+	tests := []struct {
+		word       string
+		suggestion string
+		lang       dymean.Language
+		kind       dymean.ExplanationKind
+		message    string
+	}{
+		{"helo", "hello", dymean.English, dymean.ExplainMissingLetter, "1 character missing"},
+		{"helllo", "hello", dymean.English, dymean.ExplainExtraLetter, "1 extra character"},
+		{"hwllo", "hello", dymean.English, dymean.ExplainAdjacentKey, "neighboring key pressed"},
+		{"hxllo", "hello", dymean.English, dymean.ExplainWrongLetter, "1 wrong character"},
+		{"hlelo", "hello", dymean.English, dymean.ExplainSwappedLetters, "adjacent letters swapped"},
+		{"hxlo", "hello", dymean.English, dymean.ExplainEdits, "2 changes"},
+		{"teh", "the", dymean.English, dymean.ExplainLearned, "chosen before"},
+		{"affect", "effect", dymean.English, dymean.ExplainConfusable, "often confused"},
+		{"cafe", "café", dymean.French, dymean.ExplainMissingAccent, "accent missing"},
+		{"کتاب", "کتب", dymean.Persian, dymean.ExplainExtraLetter, "یک حرف اضافه"},
+		{"hello", "hello", dymean.English, "", ""},
+	}
+	for _, test := range tests {
+		kind, message := dym.ExplainSuggestion(test.word, test.suggestion, test.lang)
+		if kind != test.kind || message != test.message {
+			t.Errorf("ExplainSuggestion(%q, %q) = %q, %q, expected %q, %q",
+				test.word, test.suggestion, kind, message, test.kind, test.message)
+		}
+	}
+
+	if _, message := dym.ExplainSuggestion("کتاب", "کتابهایی", dymean.Persian); message != "4 تغییر" {
+		t.Errorf("Expected a translated count, got %q", message)
+	}
+}
+
+// TestExplainedSuggestions tests that suggestions carry reasons when enabled
+func TestExplainedSuggestions(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello"})
+	if suggestions := dym.GetSuggestions("helo", 1, 2); suggestions[0].Reason != "" {
+		t.Errorf("Expected no reason by default, got %q", suggestions[0].Reason)
+	}
+
+	dym.SetExplainSuggestions(true)
+	if suggestions := dym.GetSuggestions("helo", 1, 2); suggestions[0].Reason != "1 character missing" {
+		t.Errorf("Expected a reason, got %+v", suggestions)
+	}
+	if suggestions := dym.GetSuggestions("hello", 1, 2); suggestions[0].Reason != "" {
+		t.Errorf("Expected no reason for a correct word, got %+v", suggestions)
+	}
+}
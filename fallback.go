@@ -0,0 +1,268 @@
+package dymean
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Defaults for the zero fields of a FallbackConfig
+const (
+	DefaultFallbackTimeout          = 200 * time.Millisecond
+	DefaultFallbackFailureThreshold = 5
+	DefaultFallbackCooldown         = 30 * time.Second
+)
+
+// SuggestionProvider supplies suggestions from outside the local
+// dictionaries, such as a remote spelling API or a larger model
+type SuggestionProvider interface {
+	Suggest(ctx context.Context, word string, lang Language, maxSuggestions int) ([]Suggestion, error)
+}
+
+// SuggestionProviderFunc adapts a function to a SuggestionProvider
+type SuggestionProviderFunc func(ctx context.Context, word string, lang Language, maxSuggestions int) ([]Suggestion, error)
+
+// Suggest calls f
+func (f SuggestionProviderFunc) Suggest(ctx context.Context, word string, lang Language, maxSuggestions int) ([]Suggestion, error) {
+	return f(ctx, word, lang, maxSuggestions)
+}
+
+// FallbackConfig configures the provider consulted when local suggestions
+// are missing or weak
+type FallbackConfig struct {
+	Provider SuggestionProvider
+
+	// MinSimilarity is the best local similarity below which the provider is
+	// called. With 0 it is only called when there are no local suggestions.
+	MinSimilarity float64
+
+	// Timeout bounds each provider call. Defaults to DefaultFallbackTimeout.
+	Timeout time.Duration
+
+	// FailureThreshold is the number of consecutive failed calls, including
+	// timeouts, that opens the circuit. Defaults to DefaultFallbackFailureThreshold.
+	FailureThreshold int
+
+	// Cooldown is how long the circuit stays open before a single trial call
+	// is let through. Defaults to DefaultFallbackCooldown.
+	Cooldown time.Duration
+
+	// RemoteWeight scales the similarity of provider suggestions when they are
+	// merged with local ones, to trust either source more. Defaults to 1.
+	RemoteWeight float64
+}
+
+// CircuitState is the state of the fallback circuit breaker
+type CircuitState string
+
+// States of the fallback circuit breaker
+const (
+	CircuitClosed   CircuitState = "closed"    // Calls go through
+	CircuitOpen     CircuitState = "open"      // Calls are skipped until the cooldown passes
+	CircuitHalfOpen CircuitState = "half-open" // One trial call decides whether to close again
+)
+
+// FallbackStats counts the calls made to the fallback provider
+type FallbackStats struct {
+	Calls         int // Provider calls made
+	Failures      int // Calls that returned an error, including timeouts
+	Timeouts      int
+	ShortCircuits int // Calls skipped while the circuit was open
+	State         CircuitState
+}
+
+// fallback holds the provider and its circuit breaker
+type fallback struct {
+	config FallbackConfig
+
+	mu       sync.Mutex // Guards the fields below
+	state    CircuitState
+	failures int       // Consecutive failures
+	openedAt time.Time // When the circuit last opened
+	probing  bool      // Whether the half-open trial call is running
+	stats    FallbackStats
+}
+
+// newFallback applies the defaults of a configuration
+func newFallback(config FallbackConfig) *fallback {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultFallbackTimeout
+	}
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = DefaultFallbackFailureThreshold
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = DefaultFallbackCooldown
+	}
+	if config.RemoteWeight <= 0 {
+		config.RemoteWeight = 1
+	}
+	return &fallback{config: config, state: CircuitClosed}
+}
+
+// allow reports whether a call may go through, moving an open circuit whose
+// cooldown passed to half-open
+func (f *fallback) allow() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch f.state {
+	case CircuitOpen:
+		if time.Since(f.openedAt) < f.config.Cooldown {
+			f.stats.ShortCircuits++
+			return false
+		}
+		f.state = CircuitHalfOpen
+		f.probing = true
+		return true
+	case CircuitHalfOpen:
+		if f.probing {
+			f.stats.ShortCircuits++
+			return false
+		}
+		f.probing = true
+		return true
+	}
+	return true
+}
+
+// release ends a call without an outcome for the breaker
+func (f *fallback) release() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.probing = false
+}
+
+// record updates the breaker with the outcome of a call
+func (f *fallback) record(err error, timedOut bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.stats.Calls++
+	f.probing = false
+	if err == nil {
+		f.failures = 0
+		f.state = CircuitClosed
+		return
+	}
+
+	f.stats.Failures++
+	if timedOut {
+		f.stats.Timeouts++
+	}
+	f.failures++
+	if f.state == CircuitHalfOpen || f.failures >= f.config.FailureThreshold {
+		f.state = CircuitOpen
+		f.openedAt = time.Now()
+	}
+}
+
+// WithFallback consults a provider when a word gets no local suggestions, or
+// none at least config.MinSimilarity similar, and merges its suggestions
+// with the local ones. Words found in the dictionary never reach the
+// provider, so most lookups pay no remote latency. A circuit breaker skips
+// the provider after repeated failures, returning the local suggestions.
+func WithFallback(config FallbackConfig) Option {
+	return func(dym *DidYouMean) {
+		if config.Provider == nil {
+			dym.fallback = nil
+			return
+		}
+		dym.fallback = newFallback(config)
+	}
+}
+
+// FallbackStats returns the fallback provider's call counters and circuit
+// state. It is the zero value without WithFallback.
+func (dym *DidYouMean) FallbackStats() FallbackStats {
+	if dym.fallback == nil {
+		return FallbackStats{}
+	}
+	dym.fallback.mu.Lock()
+	defer dym.fallback.mu.Unlock()
+	stats := dym.fallback.stats
+	stats.State = dym.fallback.state
+	return stats
+}
+
+// needsFallback reports whether local suggestions are too weak to return alone
+func (dym *DidYouMean) needsFallback(suggestions []Suggestion) bool {
+	if dym.fallback == nil {
+		return false
+	}
+	if len(suggestions) == 0 {
+		return true
+	}
+	return suggestions[0].Similarity < dym.fallback.config.MinSimilarity
+}
+
+// applyFallback calls the provider for a word whose local suggestions are
+// weak and merges the results. On failure the local suggestions are returned.
+func (dym *DidYouMean) applyFallback(ctx context.Context, word string, lang Language, maxSuggestions int, local []Suggestion) []Suggestion {
+	if !dym.needsFallback(local) {
+		return local
+	}
+	f := dym.fallback
+	if !f.allow() {
+		dym.logger.Debug("skipped fallback with open circuit", "language", lang)
+		return local
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, f.config.Timeout)
+	defer cancel()
+	remote, err := f.config.Provider.Suggest(callCtx, word, lang, maxSuggestions)
+	// Providers that ignore ctx still count as timed out when they are late
+	if err == nil && callCtx.Err() != nil {
+		err = callCtx.Err()
+	}
+	if err != nil && ctx.Err() != nil {
+		// The caller gave up, which says nothing about the provider
+		f.release()
+		return local
+	}
+	f.record(err, errors.Is(err, context.DeadlineExceeded))
+	if err != nil {
+		dym.logger.Warn("fallback suggestions failed", "language", lang, "error", err)
+		return local
+	}
+
+	return mergeSuggestions(local, remote, f.config.RemoteWeight, maxSuggestions)
+}
+
+// mergeSuggestions combines local and remote suggestions, keeping the higher
+// similarity of words found by both. Local suggestions win ties.
+func mergeSuggestions(local, remote []Suggestion, remoteWeight float64, maxSuggestions int) []Suggestion {
+	merged := make([]Suggestion, 0, len(local)+len(remote))
+	index := make(map[string]int, len(local)+len(remote))
+	for _, suggestion := range local {
+		index[suggestion.Word] = len(merged)
+		merged = append(merged, suggestion)
+	}
+	for _, suggestion := range remote {
+		if suggestion.Word == "" {
+			continue
+		}
+		suggestion.Similarity *= remoteWeight
+		if suggestion.Similarity > 1.0 {
+			suggestion.Similarity = 1.0
+		}
+		if i, ok := index[suggestion.Word]; ok {
+			if suggestion.Similarity > merged[i].Similarity {
+				merged[i].Similarity = suggestion.Similarity
+			}
+			continue
+		}
+		index[suggestion.Word] = len(merged)
+		merged = append(merged, suggestion)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Similarity > merged[j].Similarity
+	})
+	if len(merged) > maxSuggestions {
+		merged = merged[:maxSuggestions]
+	}
+	return merged
+}
@@ -0,0 +1,93 @@
+package dymean_test
+
+import (
+	"context"
+	"github.com/bi0dread/dymean"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestFallback tests that the provider is only consulted for weak local suggestions
+func TestFallback(t *testing.T) {
+	var calls atomic.Int32
+	provider := dymean.SuggestionProviderFunc(func(ctx context.Context, word string, lang dymean.Language, maxSuggestions int) ([]dymean.Suggestion, error) {
+		calls.Add(1)
+		return []dymean.Suggestion{{Word: "kubernetes", Similarity: 0.9}, {Word: "help", Similarity: 0.95}}, nil
+	})
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithFallback(dymean.FallbackConfig{Provider: provider, MinSimilarity: 0.8}))
+	dym.AddWords([]string{"hello", "help"})
+
+	if suggestions := dym.GetSuggestions("helo", 3, 2); calls.Load() != 0 || suggestions[0].Word != "hello" {
+		t.Errorf("Expected strong local suggestions without a provider call, got %v", suggestions)
+	}
+	dym.GetSuggestions("hello", 3, 2)
+	if calls.Load() != 0 {
+		t.Error("Expected correct words to skip the provider")
+	}
+
+	suggestions := dym.GetSuggestions("kubernets", 3, 2)
+	if calls.Load() != 1 || len(suggestions) != 2 || suggestions[0].Word != "help" || suggestions[1].Word != "kubernetes" {
+		t.Errorf("Expected merged remote suggestions, got %v", suggestions)
+	}
+	if stats := dym.FallbackStats(); stats.Calls != 1 || stats.State != dymean.CircuitClosed {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+// TestFallbackCircuitBreaker tests that failing providers are skipped until the cooldown passes
+func TestFallbackCircuitBreaker(t *testing.T) {
+	var failing atomic.Bool
+	failing.Store(true)
+	provider := dymean.SuggestionProviderFunc(func(ctx context.Context, word string, lang dymean.Language, maxSuggestions int) ([]dymean.Suggestion, error) {
+		if failing.Load() {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return []dymean.Suggestion{{Word: "remote", Similarity: 0.9}}, nil
+	})
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithFallback(dymean.FallbackConfig{
+		Provider:         provider,
+		Timeout:          time.Millisecond,
+		FailureThreshold: 2,
+		Cooldown:         20 * time.Millisecond,
+	}))
+	dym.AddWords([]string{"hello"})
+
+	for i := 0; i < 4; i++ {
+		if suggestions := dym.GetSuggestions("zzzz", 3, 1); len(suggestions) != 0 {
+			t.Errorf("Expected no suggestions from a failing provider, got %v", suggestions)
+		}
+	}
+	stats := dym.FallbackStats()
+	if stats.Calls != 2 || stats.Timeouts != 2 || stats.ShortCircuits != 2 || stats.State != dymean.CircuitOpen {
+		t.Errorf("Expected the circuit to open after two timeouts, got %+v", stats)
+	}
+
+	failing.Store(false)
+	time.Sleep(30 * time.Millisecond)
+	if suggestions := dym.GetSuggestions("zzzz", 3, 1); len(suggestions) != 1 || suggestions[0].Word != "remote" {
+		t.Errorf("Expected the trial call to go through, got %v", suggestions)
+	}
+	if state := dym.FallbackStats().State; state != dymean.CircuitClosed {
+		t.Errorf("Expected a successful trial to close the circuit, got %s", state)
+	}
+}
+
+// TestFallbackCallerCancel tests that a caller giving up does not count against the provider
+func TestFallbackCallerCancel(t *testing.T) {
+	provider := dymean.SuggestionProviderFunc(func(ctx context.Context, word string, lang dymean.Language, maxSuggestions int) ([]dymean.Suggestion, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithFallback(dymean.FallbackConfig{Provider: provider, FailureThreshold: 1}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if suggestions := dym.GetSuggestionsForLanguageContext(ctx, "hello", 3, 2, dymean.English); suggestions != nil {
+		t.Errorf("Expected no suggestions, got %v", suggestions)
+	}
+	if stats := dym.FallbackStats(); stats.Failures != 0 || stats.State != dymean.CircuitClosed {
+		t.Errorf("Expected the breaker to ignore the cancellation, got %+v", stats)
+	}
+}
@@ -0,0 +1,151 @@
+package dymean
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// feedbackModelVersion is the current version of the persisted feedback model format
+const feedbackModelVersion = 1
+
+// feedbackWeight controls how far feedback can move a suggestion's similarity
+const feedbackWeight = 0.5
+
+// FeedbackCounts holds how often a suggestion was accepted or rejected for a query
+type FeedbackCounts struct {
+	Accepted int `json:"accepted"`
+	Rejected int `json:"rejected"`
+}
+
+// AcceptanceRate returns the posterior mean acceptance rate using a uniform
+// Beta(1, 1) prior, so suggestions without feedback have a neutral rate of 0.5
+func (fc FeedbackCounts) AcceptanceRate() float64 {
+	return float64(fc.Accepted+1) / float64(fc.Accepted+fc.Rejected+2)
+}
+
+// FeedbackModel re-ranks suggestions using accept/reject feedback from users.
+// It is safe for concurrent use, so feedback can be recorded while
+// suggestions are ranked.
+type FeedbackModel struct {
+	mu     sync.RWMutex                                      // Guards counts
+	counts map[Language]map[string]map[string]FeedbackCounts // query -> suggestion -> counts
+}
+
+// feedbackModelFile is the persisted representation of a FeedbackModel
+type feedbackModelFile struct {
+	Version int                                               `json:"version"`
+	Counts  map[Language]map[string]map[string]FeedbackCounts `json:"counts"`
+}
+
+// NewFeedbackModel creates an empty feedback model
+func NewFeedbackModel() *FeedbackModel {
+	return &FeedbackModel{
+		counts: make(map[Language]map[string]map[string]FeedbackCounts),
+	}
+}
+
+// Record records whether a suggestion shown for a query was accepted
+func (fm *FeedbackModel) Record(query, suggestion string, accepted bool, lang Language) {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	if fm.counts[lang] == nil {
+		fm.counts[lang] = make(map[string]map[string]FeedbackCounts)
+	}
+	if fm.counts[lang][query] == nil {
+		fm.counts[lang][query] = make(map[string]FeedbackCounts)
+	}
+
+	counts := fm.counts[lang][query][suggestion]
+	if accepted {
+		counts.Accepted++
+	} else {
+		counts.Rejected++
+	}
+	fm.counts[lang][query][suggestion] = counts
+}
+
+// Counts returns the feedback recorded for a suggestion of a query
+func (fm *FeedbackModel) Counts(query, suggestion string, lang Language) FeedbackCounts {
+	fm.mu.RLock()
+	defer fm.mu.RUnlock()
+	return fm.counts[lang][query][suggestion]
+}
+
+// Adjust returns the similarity of a suggestion adjusted by its acceptance rate
+func (fm *FeedbackModel) Adjust(query, suggestion string, similarity float64, lang Language) float64 {
+	fm.mu.RLock()
+	counts, exists := fm.counts[lang][query][suggestion]
+	fm.mu.RUnlock()
+	if !exists {
+		return similarity
+	}
+
+	adjusted := similarity + feedbackWeight*(counts.AcceptanceRate()-0.5)
+	if adjusted > 1.0 {
+		return 1.0
+	}
+	if adjusted < 0.0 {
+		return 0.0
+	}
+	return adjusted
+}
+
+// Save writes the feedback model as JSON
+func (fm *FeedbackModel) Save(w io.Writer) error {
+	fm.mu.RLock()
+	defer fm.mu.RUnlock()
+	return json.NewEncoder(w).Encode(feedbackModelFile{
+		Version: feedbackModelVersion,
+		Counts:  fm.counts,
+	})
+}
+
+// LoadFeedbackModel reads a feedback model previously written with Save
+func LoadFeedbackModel(r io.Reader) (*FeedbackModel, error) {
+	var file feedbackModelFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("decode feedback model: %w", err)
+	}
+	if file.Version != feedbackModelVersion {
+		return nil, fmt.Errorf("unsupported feedback model version %d", file.Version)
+	}
+
+	fm := NewFeedbackModel()
+	if file.Counts != nil {
+		fm.counts = file.Counts
+	}
+	return fm, nil
+}
+
+// RecordFeedback records whether a suggestion shown for a query in the current
+// language was accepted, re-ranking future suggestions for that query
+func (dym *DidYouMean) RecordFeedback(query, suggestion string, accepted bool) {
+	dym.RecordFeedbackForLanguage(query, suggestion, accepted, dym.currentLang)
+}
+
+// RecordFeedbackForLanguage records suggestion feedback for a specific language
+func (dym *DidYouMean) RecordFeedbackForLanguage(query, suggestion string, accepted bool, lang Language) {
+	normalized, chosen := dym.normalize(query, lang), dym.normalize(suggestion, lang)
+	dym.feedbackModel.Record(normalized, chosen, accepted, lang)
+	dym.recordChoice(normalized, chosen, accepted, lang)
+}
+
+// SetFeedbackModel replaces the feedback model, e.g. with one loaded by LoadFeedbackModel
+func (dym *DidYouMean) SetFeedbackModel(fm *FeedbackModel) {
+	dym.feedbackModel = fm
+}
+
+// GetFeedbackModel returns the feedback model so it can be persisted
+func (dym *DidYouMean) GetFeedbackModel() *FeedbackModel {
+	return dym.feedbackModel
+}
+
+// applyFeedback adjusts suggestion similarities using recorded feedback
+func (dym *DidYouMean) applyFeedback(normalized string, lang Language, suggestions []Suggestion) []Suggestion {
+	for i := range suggestions {
+		suggestions[i].Similarity = dym.feedbackModel.Adjust(normalized, suggestions[i].Word, suggestions[i].Similarity, lang)
+	}
+	return suggestions
+}
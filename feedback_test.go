@@ -0,0 +1,85 @@
+package dymean_test
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"sync"
+	"testing"
+)
+
+// TestRecordFeedback tests that feedback re-ranks suggestions
+func TestRecordFeedback(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"cart", "card"})
+
+	for i := 0; i < 5; i++ {
+		dym.RecordFeedback("carx", "card", true)
+		dym.RecordFeedback("carx", "cart", false)
+	}
+
+	suggestions := dym.GetSuggestions("carx", 2, 1)
+	if len(suggestions) != 2 || suggestions[0].Word != "card" {
+		t.Fatalf("Expected accepted suggestion 'card' to rank first, got %v", suggestions)
+	}
+	if suggestions[0].Similarity <= suggestions[1].Similarity {
+		t.Errorf("Expected 'card' to score higher than 'cart', got %v", suggestions)
+	}
+}
+
+// TestFeedbackModelPersistence tests saving and loading the feedback model
+func TestFeedbackModelPersistence(t *testing.T) {
+	fm := dymean.NewFeedbackModel()
+	fm.Record("helo", "hello", true, dymean.English)
+	fm.Record("helo", "hello", false, dymean.English)
+	fm.Record("helo", "hello", true, dymean.English)
+
+	var buf bytes.Buffer
+	if err := fm.Save(&buf); err != nil {
+		t.Fatalf("Failed to save feedback model: %v", err)
+	}
+
+	loaded, err := dymean.LoadFeedbackModel(&buf)
+	if err != nil {
+		t.Fatalf("Failed to load feedback model: %v", err)
+	}
+
+	counts := loaded.Counts("helo", "hello", dymean.English)
+	if counts.Accepted != 2 || counts.Rejected != 1 {
+		t.Errorf("Expected 2 accepted and 1 rejected, got %+v", counts)
+	}
+	if rate := counts.AcceptanceRate(); rate != 0.6 {
+		t.Errorf("Expected acceptance rate 0.6, got %.2f", rate)
+	}
+}
+
+// TestRecordFeedbackConcurrent tests recording feedback while suggestions are
+// ranked, for the race detector
+func TestRecordFeedbackConcurrent(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"cart", "card"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				dym.RecordFeedback("carx", "card", true)
+				dym.RecordFeedback(fmt.Sprintf("carx%d", j), "cart", false)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				dym.GetSuggestions("carx", 2, 1)
+				dym.CheckText("carx")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if counts := dym.GetFeedbackModel().Counts("carx", "card", dymean.English); counts.Accepted != 800 {
+		t.Errorf("Expected 800 accepted suggestions, got %+v", counts)
+	}
+}
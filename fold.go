@@ -0,0 +1,65 @@
+package dymean
+
+import (
+	"strings"
+	"unicode"
+)
+
+// latinFolds maps accented Latin letters to their unaccented form
+var latinFolds = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'ç': "c", 'ć': "c", 'č': "c",
+	'ď': "d", 'đ': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+	'ğ': "g",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i", 'ı': "i",
+	'ł': "l",
+	'ñ': "n", 'ń': "n", 'ň': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ő': "o",
+	'ř': "r",
+	'ś': "s", 'š': "s", 'ş': "s",
+	'ť': "t",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ū': "u", 'ů': "u", 'ű': "u",
+	'ý': "y", 'ÿ': "y",
+	'ź': "z", 'ż': "z", 'ž': "z",
+	'ß': "ss", 'æ': "ae", 'œ': "oe",
+}
+
+// arabicFolds maps Arabic letter variants to the form used in Persian dictionaries
+var arabicFolds = map[rune]string{
+	'ي': "ی", 'ى': "ی", 'ك': "ک",
+}
+
+// Invisible characters that commonly differ between spellings of the same word
+const (
+	zwnj    = '\u200c' // Zero-width non-joiner
+	zwj     = '\u200d' // Zero-width joiner
+	tatweel = '\u0640' // Arabic elongation character
+)
+
+// foldWord reduces a word to a key shared by its spelling variants: lowercase,
+// without accents, diacritics, ZWNJ, tatweel or bidi controls, and with Arabic
+// letter variants mapped to their Persian forms
+func foldWord(word string) string {
+	var builder strings.Builder
+	builder.Grow(len(word))
+
+	for _, r := range strings.ToLower(normalizePresentationForms(word)) {
+		switch {
+		case r == zwnj || r == zwj || isIgnorable(r):
+			continue
+		case unicode.Is(unicode.Mn, r):
+			continue // Combining accents and Arabic harakat
+		}
+
+		if folded, ok := latinFolds[r]; ok {
+			builder.WriteString(folded)
+		} else if folded, ok := arabicFolds[r]; ok {
+			builder.WriteString(folded)
+		} else {
+			builder.WriteRune(r)
+		}
+	}
+
+	return builder.String()
+}
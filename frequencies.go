@@ -0,0 +1,123 @@
+package dymean
+
+import (
+	"math"
+	"strings"
+)
+
+// wordFrequencies holds the frequencies of one language. It is replaced, not
+// modified, when frequencies change, so readers can use it without the lock.
+type wordFrequencies struct {
+	counts map[string]int
+	total  int
+	max    int // Highest count
+}
+
+// SetWordFrequencies records how often words of a language occur, e.g. counts
+// from a corpus or query logs. Segmentation prefers frequent words. Words not
+// given keep their frequency, and a frequency of 0 or less forgets one.
+func (dym *DidYouMean) SetWordFrequencies(frequencies map[string]int, lang Language) {
+	dym.mu.Lock()
+	defer dym.mu.Unlock()
+
+	updated := &wordFrequencies{counts: make(map[string]int)}
+	if current := dym.frequencies[lang]; current != nil {
+		for word, count := range current.counts {
+			updated.counts[word] = count
+		}
+	}
+	for word, count := range frequencies {
+		normalized := dym.normalize(word, lang)
+		if count <= 0 {
+			delete(updated.counts, normalized)
+		} else {
+			updated.counts[normalized] = count
+		}
+	}
+	for _, count := range updated.counts {
+		updated.total += count
+		updated.max = max(updated.max, count)
+	}
+	dym.frequencies[lang] = updated
+}
+
+// WordFrequency returns the frequency recorded for a word, or 0
+func (dym *DidYouMean) WordFrequency(word string, lang Language) int {
+	normalized := dym.normalize(word, lang)
+	dym.mu.RLock()
+	defer dym.mu.RUnlock()
+	if frequencies := dym.frequencies[lang]; frequencies != nil {
+		return frequencies.counts[normalized]
+	}
+	return 0
+}
+
+// wordCosts returns the negative log probability of the words of a language,
+// smoothed so words without a frequency count once, and the cost of a word
+// never seen. Recency weights of WithDecay add to the frequencies.
+func (dym *DidYouMean) wordCosts(lang Language) (cost func(word string) float64, unseen float64) {
+	vocabulary := dym.GetWordCount(lang)
+	dym.mu.RLock()
+	frequencies := dym.frequencies[lang]
+	dym.mu.RUnlock()
+	if frequencies == nil {
+		frequencies = &wordFrequencies{}
+	}
+	recent, recentTotal := dym.recencyWeights(lang)
+
+	total := math.Log(float64(frequencies.total+vocabulary+1) + recentTotal)
+	return func(word string) float64 {
+		return total - math.Log(float64(frequencies.counts[word]+1)+recent[word])
+	}, total
+}
+
+// bigramFrequencies holds the word pair frequencies of one language, replaced
+// like wordFrequencies when they change
+type bigramFrequencies struct {
+	counts map[[2]string]int
+	firsts map[string]int // Total count of the pairs starting with each word
+}
+
+// SetBigramFrequencies records how often pairs of words of a language occur
+// in a row, keyed by the two words separated by a space ("new york"), e.g.
+// counts from query logs. SuggestPhrase prefers corrections forming frequent
+// pairs. Pairs not given keep their frequency, and a frequency of 0 or less
+// forgets one.
+func (dym *DidYouMean) SetBigramFrequencies(frequencies map[string]int, lang Language) {
+	dym.mu.Lock()
+	defer dym.mu.Unlock()
+
+	updated := &bigramFrequencies{counts: make(map[[2]string]int), firsts: make(map[string]int)}
+	if current := dym.bigrams[lang]; current != nil {
+		for pair, count := range current.counts {
+			updated.counts[pair] = count
+		}
+	}
+	for pair, count := range frequencies {
+		first, second, ok := strings.Cut(strings.TrimSpace(pair), " ")
+		if !ok {
+			continue
+		}
+		key := [2]string{dym.normalize(first, lang), dym.normalize(second, lang)}
+		if count <= 0 {
+			delete(updated.counts, key)
+		} else {
+			updated.counts[key] = count
+		}
+	}
+	for pair, count := range updated.counts {
+		updated.firsts[pair[0]] += count
+	}
+	dym.bigrams[lang] = updated
+}
+
+// BigramFrequency returns the frequency recorded for a pair of words, or 0
+func (dym *DidYouMean) BigramFrequency(first, second string, lang Language) int {
+	key := [2]string{dym.normalize(first, lang), dym.normalize(second, lang)}
+	dym.mu.RLock()
+	defer dym.mu.RUnlock()
+	if bigrams := dym.bigrams[lang]; bigrams != nil {
+		return bigrams.counts[key]
+	}
+	return 0
+}
@@ -2,3 +2,9 @@ module github.com/bi0dread/dymean
 
 go 1.21
 
+require (
+	github.com/fsnotify/fsnotify v1.7.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require golang.org/x/sys v0.4.0 // indirect
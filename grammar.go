@@ -0,0 +1,153 @@
+package dymean
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CategoryGrammar marks corrections produced by the grammar-lite rules
+const CategoryGrammar CorrectionCategory = "grammar"
+
+// SetGrammarChecks enables or disables the English grammar-lite pass in CheckText
+func (dym *DidYouMean) SetGrammarChecks(enabled bool) {
+	dym.grammarChecks = enabled
+}
+
+// CheckGrammar flags a/an misuse and basic subject-verb disagreements in English text.
+// Misspelled words are replaced by their best suggestion before the rules run,
+// so "a aple" is reported as needing "an".
+func (dym *DidYouMean) CheckGrammar(text string) []Correction {
+	tokens := Tokenize(text)
+	corrected := make(map[int]string)
+
+	for _, token := range tokens {
+		if DetectLanguage(token.Text) != English || dym.IsCorrectForLanguage(token.Text, English) {
+			continue
+		}
+		if suggestions := dym.GetSuggestionsForLanguage(token.Text, 1, 2, English); len(suggestions) > 0 {
+			corrected[token.Start] = suggestions[0].Word
+		}
+	}
+
+	return checkGrammarTokens(text, tokens, corrected)
+}
+
+// subjectVerbAgreement maps a subject pronoun to the verb forms it must not be
+// followed by, and the form to use instead
+var subjectVerbAgreement = map[string]map[string]string{
+	"i":    {"is": "am", "are": "am", "has": "have", "does": "do"},
+	"he":   {"am": "is", "are": "is", "have": "has", "do": "does", "were": "was"},
+	"she":  {"am": "is", "are": "is", "have": "has", "do": "does", "were": "was"},
+	"it":   {"am": "is", "are": "is", "have": "has", "do": "does", "were": "was"},
+	"we":   {"am": "are", "is": "are", "has": "have", "does": "do", "was": "were"},
+	"you":  {"am": "are", "is": "are", "has": "have", "does": "do", "was": "were"},
+	"they": {"am": "are", "is": "are", "has": "have", "does": "do", "was": "were"},
+}
+
+// checkGrammarTokens applies the grammar rules to adjacent English tokens.
+// corrected maps the start offset of misspelled tokens to their best suggestion.
+func checkGrammarTokens(text string, tokens []Token, corrected map[int]string) []Correction {
+	corrections := make([]Correction, 0)
+
+	wordAt := func(i int) string {
+		if word, ok := corrected[tokens[i].Start]; ok {
+			return word
+		}
+		return tokens[i].Text
+	}
+
+	for i := 0; i+1 < len(tokens); i++ {
+		current, next := tokens[i], tokens[i+1]
+		if DetectLanguage(current.Text) != English || DetectLanguage(next.Text) != English {
+			continue
+		}
+		// Rules only apply to words separated by whitespace
+		if strings.TrimSpace(text[current.End:next.Start]) != "" {
+			continue
+		}
+
+		word := strings.ToLower(wordAt(i))
+		nextWord := wordAt(i + 1)
+		vowel, known := startsWithVowelSound(nextWord)
+
+		switch {
+		case word == "a" && known && vowel:
+			corrections = append(corrections, grammarCorrection(current, "an"))
+		case word == "an" && known && !vowel:
+			corrections = append(corrections, grammarCorrection(current, "a"))
+		default:
+			verbs, isSubject := subjectVerbAgreement[word]
+			if !isSubject {
+				continue
+			}
+			// "if it were" is a valid subjunctive
+			if i > 0 && strings.ToLower(tokens[i-1].Text) == "if" {
+				continue
+			}
+			if replacement, ok := verbs[strings.ToLower(nextWord)]; ok {
+				corrections = append(corrections, grammarCorrection(next, replacement))
+			}
+		}
+	}
+
+	return corrections
+}
+
+// grammarCorrection creates a grammar correction for a token, keeping its capitalization
+func grammarCorrection(token Token, replacement string) Correction {
+	if first := []rune(token.Text)[0]; unicode.IsUpper(first) {
+		runes := []rune(replacement)
+		runes[0] = unicode.ToUpper(runes[0])
+		replacement = string(runes)
+	}
+
+	return Correction{
+		Start:       token.Start,
+		End:         token.End,
+		Original:    token.Text,
+		Suggestions: []Suggestion{{Word: replacement, Similarity: 1.0}},
+		Category:    CategoryGrammar,
+		Language:    English,
+	}
+}
+
+// consonantSoundPrefixes are vowel-initial prefixes pronounced with a consonant
+// sound. The un- of "unimportant" or "unusual" is not one of them.
+var consonantSoundPrefixes = []string{"unio", "unit", "univ", "unif", "unic", "uniq", "unan", "use", "usu", "uti", "eu", "ubiq"}
+
+// consonantSoundWords are vowel-initial words pronounced with a consonant
+// sound, unlike words they prefix ("an onerous")
+var consonantSoundWords = map[string]bool{"one": true, "once": true}
+
+// vowelSoundPrefixes are consonant-initial prefixes pronounced with a vowel sound
+var vowelSoundPrefixes = []string{"hour", "honest", "honor", "honour", "heir"}
+
+// startsWithVowelSound estimates whether "an" should precede a word. known is
+// false for words it cannot tell, such as numbers ("an 8", "an 11", "a 1").
+func startsWithVowelSound(word string) (vowel bool, known bool) {
+	if word == "" || !unicode.IsLetter([]rune(word)[0]) {
+		return false, false
+	}
+
+	// Short acronyms are read letter by letter: "an FBI agent", "a UFO"
+	if looksLikeAcronym(word) && len(word) <= 3 {
+		return strings.ContainsRune("AEFHILMNORSX", rune(word[0])), true
+	}
+
+	lower := strings.ToLower(word)
+	if consonantSoundWords[lower] {
+		return false, true
+	}
+	for _, prefix := range consonantSoundPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return false, true
+		}
+	}
+	for _, prefix := range vowelSoundPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return true, true
+		}
+	}
+
+	return strings.ContainsRune("aeiou", rune(lower[0])), true
+}
@@ -0,0 +1,70 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestCheckGrammar tests the English grammar-lite rules
+func TestCheckGrammar(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.LoadDefaultDictionary(dymean.English)
+	dym.AddWords([]string{"apple", "hour", "user", "car", "uninformed", "unimportant", "unusual", "onerous", "university", "one", "once"})
+
+	testCases := []struct {
+		text     string
+		expected []string
+	}{
+		{"a apple", []string{"an"}},
+		{"An car", []string{"A"}},
+		{"an hour", nil},
+		{"a user", nil},
+		{"an FBI agent", nil},
+		{"a aple", []string{"an"}}, // Uses the corrected word
+		{"he have it", []string{"has"}},
+		{"they is here", []string{"are"}},
+		{"if it were", nil},
+		{"a, apple", nil},
+		{"an uninformed", nil},
+		{"an unimportant", nil},
+		{"an unusual", nil},
+		{"an onerous", nil},
+		{"a university", nil},
+		{"an university", []string{"a"}},
+		{"a one", nil},
+		{"a once", nil},
+		{"an one", []string{"a"}},
+		{"an 8", nil},
+		{"a 11", nil},
+		{"a 1", nil},
+	}
+
+	for _, tc := range testCases {
+		corrections := dym.CheckGrammar(tc.text)
+		if len(corrections) != len(tc.expected) {
+			t.Errorf("Expected %d grammar corrections for '%s', got %d: %v", len(tc.expected), tc.text, len(corrections), corrections)
+			continue
+		}
+		for i, correction := range corrections {
+			if correction.Suggestions[0].Word != tc.expected[i] || correction.Category != dymean.CategoryGrammar {
+				t.Errorf("Expected grammar correction '%s' for '%s', got %+v", tc.expected[i], tc.text, correction)
+			}
+		}
+	}
+}
+
+// TestCheckTextGrammarOption tests that grammar checks are optional in CheckText
+func TestCheckTextGrammarOption(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"a", "an", "apple"})
+
+	if corrections := dym.CheckText("a apple"); len(corrections) != 0 {
+		t.Errorf("Expected no corrections with grammar checks disabled, got %v", corrections)
+	}
+
+	dym.SetGrammarChecks(true)
+	corrections := dym.CheckText("a apple")
+	if len(corrections) != 1 || corrections[0].Category != dymean.CategoryGrammar {
+		t.Errorf("Expected one grammar correction, got %v", corrections)
+	}
+}
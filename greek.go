@@ -0,0 +1,56 @@
+package dymean
+
+import (
+	"strings"
+	"unicode"
+)
+
+// tonosForms maps the Greek vowels with tonos to the vowel without it, keeping
+// the dialytika of "ΐ" and "ΰ"
+var tonosForms = map[rune]rune{
+	'ά': 'α', 'έ': 'ε', 'ή': 'η', 'ί': 'ι', 'ό': 'ο', 'ύ': 'υ', 'ώ': 'ω', 'ΐ': 'ϊ', 'ΰ': 'ϋ',
+	'Ά': 'Α', 'Έ': 'Ε', 'Ή': 'Η', 'Ί': 'Ι', 'Ό': 'Ο', 'Ύ': 'Υ', 'Ώ': 'Ω',
+}
+
+// combiningTonos is the accent of decomposed Greek text
+const combiningTonos = '\u0301'
+
+// StripTonos removes the tonos (accent) from the Greek vowels of a string,
+// precomposed or combining, leaving other characters unchanged
+func StripTonos(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == combiningTonos {
+			return -1
+		}
+		if stripped, ok := tonosForms[r]; ok {
+			return stripped
+		}
+		return r
+	}, s)
+}
+
+// finalSigma writes sigma as "ς" at the end of a word and "σ" elsewhere, so
+// "ΟΔΟΣ" lowercased and "οδοσ" typed both match "οδος"
+func finalSigma(word string) string {
+	runes := []rune(word)
+	for i, r := range runes {
+		if r != 'σ' && r != 'ς' {
+			continue
+		}
+		if i+1 == len(runes) || !unicode.IsLetter(runes[i+1]) {
+			runes[i] = 'ς'
+		} else {
+			runes[i] = 'σ'
+		}
+	}
+	return string(runes)
+}
+
+// WithGreekTonosStripping makes Greek dictionaries and lookups ignore the
+// tonos, so "καλημερα" typed without accents matches "καλημέρα". Suggestions
+// are then written without tonos too.
+func WithGreekTonosStripping() Option {
+	return func(dym *DidYouMean) {
+		dym.stripTonos = true
+	}
+}
@@ -0,0 +1,90 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestGreekFinalSigma tests that sigma matches in final and medial form by position
+func TestGreekFinalSigma(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWordsForLanguage([]string{"οδός", "κόσμος"}, dymean.Greek)
+
+	for _, word := range []string{"ΟΔΌΣ", "οδόσ", "κόσμοσ", "ΚΌΣΜΟΣ"} {
+		if !dym.IsCorrectForLanguage(word, dymean.Greek) {
+			t.Errorf("Expected %q to be correct", word)
+		}
+	}
+	if !dym.IsCorrectForLanguage("κόςμος", dymean.Greek) {
+		t.Error("Expected a medial final sigma to be written as σ")
+	}
+}
+
+// TestGreekTonosStripping tests that WithGreekTonosStripping ignores accents
+func TestGreekTonosStripping(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWordsForLanguage([]string{"καλημέρα"}, dymean.Greek)
+	if dym.IsCorrectForLanguage("καλημερα", dymean.Greek) {
+		t.Error("Expected the tonos to count without WithGreekTonosStripping")
+	}
+
+	dym = dymean.NewDidYouMean(10000, 7, dymean.WithGreekTonosStripping())
+	dym.AddWordsForLanguage([]string{"καλημέρα"}, dymean.Greek)
+	for _, word := range []string{"καλημερα", "καλημέρα", "ΚΑΛΗΜΕΡΑ"} {
+		if !dym.IsCorrectForLanguage(word, dymean.Greek) {
+			t.Errorf("Expected %q to be correct", word)
+		}
+	}
+	if got := dymean.StripTonos("ΐ Άλφα"); got != "ϊ Αλφα" {
+		t.Errorf("StripTonos = %q", got)
+	}
+}
+
+// TestPolishAltGrTypos tests suggestions for Polish letters typed without AltGr
+func TestPolishAltGrTypos(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	if err := dym.LoadDefaultDictionary(dymean.Polish); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"zolw":       "żółw",     // AltGr missed on every letter
+		"dziekuje":   "dziękuję", // AltGr missed
+		"zrodlo":     "źródło",
+		"klawiarura": "klawiatura",
+		"żródło":     "źródło", // ż and ź share AltGr on adjacent keys
+	}
+	for typo, expected := range tests {
+		suggestions := dym.GetSuggestionsForLanguage(typo, 5, 2, dymean.Polish)
+		if len(suggestions) == 0 || suggestions[0].Word != expected {
+			t.Errorf("Expected %q first for %q, got %v", expected, typo, suggestions)
+		}
+	}
+}
+
+// TestDetectGreekAndPolish tests detection of the Greek script and Polish letters
+func TestDetectGreekAndPolish(t *testing.T) {
+	if lang := dymean.DetectLanguage("καλημέρα"); lang != dymean.Greek {
+		t.Errorf("Expected Greek, got %s", lang)
+	}
+	candidates := dymean.DetectLanguageCandidates("źródło")
+	if len(candidates) == 0 || candidates[0].Language != dymean.Polish {
+		t.Errorf("Expected Polish first, got %v", candidates)
+	}
+}
+
+// TestGreekDefaultDictionary tests suggestions from the embedded Greek word list
+func TestGreekDefaultDictionary(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithAutomatonSearch())
+	if err := dym.LoadDefaultDictionary(dymean.Greek); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{"ευχαριστό": "ευχαριστώ", "βιβλιο": "βιβλίο", "θαλασα": "θάλασσα"}
+	for typo, expected := range tests {
+		suggestions := dym.GetSuggestionsForLanguage(typo, 5, 2, dymean.Greek)
+		if len(suggestions) == 0 || suggestions[0].Word != expected {
+			t.Errorf("Expected %q first for %q, got %v", expected, typo, suggestions)
+		}
+	}
+}
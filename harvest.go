@@ -0,0 +1,162 @@
+package dymean
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Parameters of unknown-word harvesting
+const (
+	maxHarvestContexts  = 3  // Contexts kept per unknown word
+	harvestContextBytes = 40 // Text kept on each side of a word in a context
+)
+
+// UnknownWord is a word found by CheckText in no loaded dictionary
+type UnknownWord struct {
+	Word     string   // Normalized word
+	Language Language // Language it was checked against
+	Count    int      // Times it was found
+	Contexts []string // Up to three snippets of text around it
+}
+
+// harvestKey identifies an unknown word
+type harvestKey struct {
+	word string
+	lang Language
+}
+
+// unknownWords collects the words not found by text checks
+type unknownWords struct {
+	mu      sync.Mutex
+	enabled bool
+	words   map[harvestKey]*UnknownWord
+}
+
+// SetUnknownWordHarvesting enables or disables collecting the words that
+// CheckText finds in no loaded dictionary, with their counts and contexts,
+// for review with UnknownWords. Words are still reported as corrections.
+// Disabling keeps the words collected so far.
+func (dym *DidYouMean) SetUnknownWordHarvesting(enabled bool) {
+	dym.unknown.mu.Lock()
+	defer dym.unknown.mu.Unlock()
+	dym.unknown.enabled = enabled
+	if enabled && dym.unknown.words == nil {
+		dym.unknown.words = make(map[harvestKey]*UnknownWord)
+	}
+}
+
+// harvest records an unknown word found in a text, unless some loaded
+// dictionary knows it
+func (dym *DidYouMean) harvest(text string, token Token, word string, lang Language) {
+	dym.unknown.mu.Lock()
+	enabled := dym.unknown.enabled
+	dym.unknown.mu.Unlock()
+	if !enabled {
+		return
+	}
+	for _, other := range dym.GetLoadedLanguages() {
+		if other != lang && dym.IsCorrectForLanguage(word, other) {
+			return
+		}
+	}
+
+	key := harvestKey{word: dym.normalize(word, lang), lang: lang}
+	context := harvestContext(text, token)
+
+	dym.unknown.mu.Lock()
+	defer dym.unknown.mu.Unlock()
+	entry := dym.unknown.words[key]
+	if entry == nil {
+		entry = &UnknownWord{Word: key.word, Language: lang}
+		dym.unknown.words[key] = entry
+	}
+	entry.Count++
+	if len(entry.Contexts) < maxHarvestContexts {
+		for _, existing := range entry.Contexts {
+			if existing == context {
+				return
+			}
+		}
+		entry.Contexts = append(entry.Contexts, context)
+	}
+}
+
+// harvestContext returns the text around a token on a single line
+func harvestContext(text string, token Token) string {
+	start, end := max(0, token.Start-harvestContextBytes), token.End+harvestContextBytes
+	if end > len(text) {
+		end = len(text)
+	}
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+	if i := strings.LastIndexByte(text[start:token.Start], '\n'); i >= 0 {
+		start += i + 1
+	}
+	if i := strings.IndexByte(text[token.End:end], '\n'); i >= 0 {
+		end = token.End + i
+	}
+	return strings.Join(strings.Fields(text[start:end]), " ")
+}
+
+// UnknownWords returns the words collected by harvesting, most frequent first
+func (dym *DidYouMean) UnknownWords() []UnknownWord {
+	dym.unknown.mu.Lock()
+	defer dym.unknown.mu.Unlock()
+
+	words := make([]UnknownWord, 0, len(dym.unknown.words))
+	for _, entry := range dym.unknown.words {
+		word := *entry
+		word.Contexts = append([]string(nil), entry.Contexts...)
+		words = append(words, word)
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if words[i].Count != words[j].Count {
+			return words[i].Count > words[j].Count
+		}
+		if words[i].Language != words[j].Language {
+			return words[i].Language < words[j].Language
+		}
+		return words[i].Word < words[j].Word
+	})
+	return words
+}
+
+// ClearUnknownWords forgets the words collected by harvesting
+func (dym *DidYouMean) ClearUnknownWords() {
+	dym.unknown.mu.Lock()
+	defer dym.unknown.mu.Unlock()
+	if dym.unknown.words != nil {
+		dym.unknown.words = make(map[harvestKey]*UnknownWord)
+	}
+}
+
+// PromoteUnknowns adds the harvested words found at least minCount times to
+// the dictionaries of their languages, forgets them, and returns them so they
+// can be saved to a project glossary. Words less frequent are kept for review.
+func (dym *DidYouMean) PromoteUnknowns(minCount int) []UnknownWord {
+	promoted := make([]UnknownWord, 0)
+	for _, word := range dym.UnknownWords() {
+		if word.Count >= minCount {
+			promoted = append(promoted, word)
+		}
+	}
+
+	byLanguage := make(map[Language][]string)
+	dym.unknown.mu.Lock()
+	for _, word := range promoted {
+		delete(dym.unknown.words, harvestKey{word: word.Word, lang: word.Language})
+		byLanguage[word.Language] = append(byLanguage[word.Language], word.Word)
+	}
+	dym.unknown.mu.Unlock()
+
+	for lang, words := range byLanguage {
+		dym.AddWordsForLanguage(words, lang)
+	}
+	return promoted
+}
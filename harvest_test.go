@@ -0,0 +1,54 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"testing"
+)
+
+// TestUnknownWordHarvesting tests collecting and promoting unknown words
+func TestUnknownWordHarvesting(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"deploy", "the", "cluster", "with", "and", "restart"})
+	dym.AddWordsForLanguage([]string{"سلام"}, dymean.Persian)
+
+	dym.CheckTextForLanguage("deploy the cluster with kubectl", dymean.English)
+	if words := dym.UnknownWords(); len(words) != 0 {
+		t.Fatalf("Expected nothing harvested while disabled, got %+v", words)
+	}
+
+	dym.SetUnknownWordHarvesting(true)
+	dym.CheckText("deploy the cluster with kubectl\nrestart kubectl and helm")
+	dym.CheckText("Kubectl sucks")
+	dym.CheckText("deploy the cluster with kubectl")
+
+	words := dym.UnknownWords()
+	if len(words) != 3 {
+		t.Fatalf("Expected kubectl, helm and sucks, got %+v", words)
+	}
+	expected := dymean.UnknownWord{
+		Word:     "kubectl",
+		Language: dymean.English,
+		Count:    4,
+		Contexts: []string{"deploy the cluster with kubectl", "restart kubectl and helm", "Kubectl sucks"},
+	}
+	if !reflect.DeepEqual(words[0], expected) {
+		t.Errorf("Expected %+v, got %+v", expected, words[0])
+	}
+
+	promoted := dym.PromoteUnknowns(2)
+	if len(promoted) != 1 || promoted[0].Word != "kubectl" {
+		t.Errorf("Expected kubectl promoted, got %+v", promoted)
+	}
+	if !dym.IsCorrect("kubectl") {
+		t.Error("Expected the promoted word in the dictionary")
+	}
+	if words := dym.UnknownWords(); len(words) != 2 {
+		t.Errorf("Expected the rare words kept for review, got %+v", words)
+	}
+
+	dym.ClearUnknownWords()
+	if words := dym.UnknownWords(); len(words) != 0 {
+		t.Errorf("Expected no words after clearing, got %+v", words)
+	}
+}
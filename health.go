@@ -0,0 +1,50 @@
+package dymean
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotReady is matched by errors.Is for every error returned by Ready
+var ErrNotReady = errors.New("not ready")
+
+// Ready returns nil once the instance can answer queries: every language
+// required with WithRequiredLanguages is loaded with no asynchronous load
+// still running, or without required languages, at least one language is.
+// Languages loaded lazily count as loaded, since their first use loads them.
+// Servers use it to hold traffic back while large dictionaries are loading.
+func (dym *DidYouMean) Ready() error {
+	for _, lang := range dym.requiredLangs {
+		if dym.isLazy(lang) {
+			continue
+		}
+		if !dym.hasDictionary(lang) {
+			return fmt.Errorf("%w: dictionary for %s not loaded", ErrNotReady, lang)
+		}
+		if dym.isLoading(lang) {
+			return fmt.Errorf("%w: dictionary for %s still loading", ErrNotReady, lang)
+		}
+	}
+
+	for _, lang := range dym.GetLoadedLanguages() {
+		if !dym.isLoading(lang) {
+			return nil
+		}
+	}
+	if len(dym.LazyLanguages()) > 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: no dictionary loaded", ErrNotReady)
+}
+
+// Healthy returns nil unless the instance is in a state it cannot recover from
+// without a restart, such as a missing learning model
+func (dym *DidYouMean) Healthy() error {
+	if dym.bloomFilters == nil || dym.dictionaries == nil {
+		return errors.New("checker not initialized, use NewDidYouMean")
+	}
+	if dym.errorModel == nil || dym.feedbackModel == nil {
+		return errors.New("learning models not attached")
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package dymean_test
+
+import (
+	"errors"
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestReady tests readiness tied to dictionary load state
+func TestReady(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithRequiredLanguages(dymean.English, dymean.Persian))
+	if err := dym.Healthy(); err != nil {
+		t.Errorf("Expected a new instance to be healthy, got %v", err)
+	}
+	if err := dym.Ready(); !errors.Is(err, dymean.ErrNotReady) {
+		t.Errorf("Expected not ready without dictionaries, got %v", err)
+	}
+
+	dym.AddWords([]string{"hello"})
+	if err := dym.Ready(); !errors.Is(err, dymean.ErrNotReady) {
+		t.Errorf("Expected not ready without the Persian dictionary, got %v", err)
+	}
+
+	dym.AddWordsForLanguage([]string{"سلام"}, dymean.Persian)
+	if err := dym.Ready(); err != nil {
+		t.Errorf("Expected ready once required dictionaries are loaded, got %v", err)
+	}
+
+	dym.SetErrorModel(nil)
+	if err := dym.Healthy(); err == nil {
+		t.Error("Expected an instance without error model to be unhealthy")
+	}
+}
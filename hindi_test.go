@@ -0,0 +1,66 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"testing"
+)
+
+// TestHindiDetection tests that Devanagari words are detected as Hindi
+func TestHindiDetection(t *testing.T) {
+	if lang := dymean.DetectLanguage("नमस्ते"); lang != dymean.Hindi {
+		t.Errorf("Expected Hindi, got %s", lang)
+	}
+}
+
+// TestHindiNukta tests that precomposed and decomposed nukta letters match
+func TestHindiNukta(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWordsForLanguage([]string{"\u095Bरूर"}, dymean.Hindi) // Precomposed ज़
+
+	if !dym.IsCorrectForLanguage("ज\u093Cरूर", dymean.Hindi) {
+		t.Error("Expected the decomposed spelling to match")
+	}
+	if !dym.IsCorrectForLanguage("ज\u093Cरू\u200Dर", dymean.Hindi) {
+		t.Error("Expected zero-width joiners to be ignored")
+	}
+}
+
+// TestHindiSuggestions tests matra typos and InScript adjacent keys
+func TestHindiSuggestions(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithAutomatonSearch())
+	dym.AddWordsForLanguage([]string{"किताब", "नमस्ते", "भारत", "पानी"}, dymean.Hindi)
+
+	tests := map[string]string{
+		"कताब":  "किताब", // Missing matra
+		"कीताब": "किताब", // Long instead of short i
+		"नमसते": "नमस्ते", // Missing virama
+		"पानु":  "पानी",  // ु is next to ी on InScript
+	}
+	for typo, expected := range tests {
+		suggestions := dym.GetSuggestionsForLanguage(typo, 5, 1, dymean.Hindi)
+		if len(suggestions) == 0 || suggestions[0].Word != expected {
+			t.Errorf("Expected %q first for %q, got %v", expected, typo, suggestions)
+		}
+	}
+
+	if dymean.IsValidWordForLanguage("िकताब", dymean.Hindi) {
+		t.Error("Expected a word starting with a matra to be invalid")
+	}
+}
+
+// TestGraphemes tests splitting words into clusters with their marks
+func TestGraphemes(t *testing.T) {
+	tests := map[string][]string{
+		"किताब":  {"कि", "ता", "ब"},
+		"क्षमा":  {"क्ष", "मा"},
+		"नमस्ते": {"न", "म", "स्ते"},
+		"café":   {"c", "a", "f", "é"},
+		"":       {},
+	}
+	for word, expected := range tests {
+		if graphemes := dymean.Graphemes(word); !reflect.DeepEqual(graphemes, expected) {
+			t.Errorf("Graphemes(%q) = %q, expected %q", word, graphemes, expected)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package dymean
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// TextRange is a span of a text given as byte offsets
+type TextRange struct {
+	Start int // Byte offset where the range starts
+	End   int // Byte offset just after the range
+}
+
+// Inline directives that switch checking off and back on, in any comment
+// syntax: <!-- dymean:off -->, // dymean:off, # dymean:off
+const (
+	directiveOff = "dymean:off"
+	directiveOn  = "dymean:on"
+)
+
+// DirectiveRanges returns the regions of a text switched off with inline
+// directives. A region runs from a dymean:off marker to the end of the line
+// holding the next dymean:on marker, so the rest of the closing comment is
+// ignored too; without a dymean:on marker it runs to the end of the text.
+func DirectiveRanges(text string) []TextRange {
+	ranges := make([]TextRange, 0)
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], directiveOff)
+		if i < 0 {
+			return ranges
+		}
+		start := offset + i
+		rest := start + len(directiveOff)
+
+		j := strings.Index(text[rest:], directiveOn)
+		if j < 0 {
+			return append(ranges, TextRange{Start: start, End: len(text)})
+		}
+		end := rest + j + len(directiveOn)
+		if k := strings.IndexByte(text[end:], '\n'); k >= 0 {
+			end += k
+		} else {
+			end = len(text)
+		}
+		ranges = append(ranges, TextRange{Start: start, End: end})
+		offset = end
+	}
+}
+
+var (
+	// markdownFence matches the opening line of a fenced code block
+	markdownFence = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	// markdownInline matches HTML comments, autolinks and link destinations
+	markdownInline = regexp.MustCompile(`(?s)<!--.*?-->|<[a-zA-Z][a-zA-Z0-9+.-]*:[^<>\s]*>|\]\([^)\s]*(?:\s+"[^"]*")?\)`)
+)
+
+// MarkdownIgnoreRanges returns the regions of a Markdown document that are not
+// prose: fenced code blocks, inline code spans, HTML comments, autolinks and
+// link destinations, plus the regions switched off with inline directives
+func MarkdownIgnoreRanges(text string) []TextRange {
+	ranges := DirectiveRanges(text)
+
+	// Fenced code blocks, line by line
+	fenced := make([]TextRange, 0)
+	var fence string
+	fenceStart := 0
+	for offset := 0; offset < len(text); {
+		end := strings.IndexByte(text[offset:], '\n')
+		if end < 0 {
+			end = len(text)
+		} else {
+			end += offset + 1
+		}
+		line := text[offset:end]
+		if fence == "" {
+			if m := markdownFence.FindStringSubmatch(line); m != nil {
+				fence, fenceStart = m[1], offset
+			}
+		} else if closesFence(line, fence) {
+			fenced = append(fenced, TextRange{Start: fenceStart, End: end})
+			fence = ""
+		}
+		offset = end
+	}
+	if fence != "" {
+		fenced = append(fenced, TextRange{Start: fenceStart, End: len(text)}) // Unclosed fences run to the end
+	}
+	ranges = append(ranges, fenced...)
+
+	// Inline code spans: a run of backticks closed by a run of the same length
+	for i := 0; i < len(text); {
+		if text[i] != '`' || inRanges(fenced, i) {
+			i++
+			continue
+		}
+		n := backtickRun(text, i)
+		closing := -1
+		for j := i + n; j < len(text); {
+			if text[j] != '`' {
+				j++
+				continue
+			}
+			m := backtickRun(text, j)
+			if m == n {
+				closing = j + m
+				break
+			}
+			j += m
+		}
+		if closing < 0 {
+			i += n
+			continue
+		}
+		ranges = append(ranges, TextRange{Start: i, End: closing})
+		i = closing
+	}
+
+	for _, m := range markdownInline.FindAllStringIndex(text, -1) {
+		if !inRanges(fenced, m[0]) {
+			ranges = append(ranges, TextRange{Start: m[0], End: m[1]})
+		}
+	}
+
+	return mergeRanges(ranges)
+}
+
+// closesFence checks if a line closes a fenced code block: a run of the
+// fence character at least as long as the opening fence, and nothing else
+func closesFence(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+	return len(trimmed) >= len(fence) && strings.Trim(trimmed, fence[:1]) == ""
+}
+
+// backtickRun returns the number of backticks starting at i
+func backtickRun(text string, i int) int {
+	n := 0
+	for i+n < len(text) && text[i+n] == '`' {
+		n++
+	}
+	return n
+}
+
+// mergeRanges sorts ranges and merges the overlapping ones
+func mergeRanges(ranges []TextRange) []TextRange {
+	if len(ranges) == 0 {
+		return ranges
+	}
+	sorted := append([]TextRange(nil), ranges...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	merged := sorted[:1]
+	for _, r := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if r.Start <= last.End {
+			if r.End > last.End {
+				last.End = r.End
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// inRanges checks if an offset lies inside any of the ranges
+func inRanges(ranges []TextRange, offset int) bool {
+	for _, r := range ranges {
+		if offset >= r.Start && offset < r.End {
+			return true
+		}
+	}
+	return false
+}
+
+// overlapsRanges checks if a span overlaps any of the merged, sorted ranges
+func overlapsRanges(ranges []TextRange, start, end int) bool {
+	i := sort.Search(len(ranges), func(i int) bool { return ranges[i].End > start })
+	return i < len(ranges) && ranges[i].Start < end
+}
+
+// CheckTextIgnoring checks a text like CheckText, skipping the given ranges.
+// Inline dymean:off and dymean:on directives are honored as well.
+func (dym *DidYouMean) CheckTextIgnoring(text string, ignore []TextRange) []Correction {
+	return dym.checkText(text, ignore, func(word string) Language {
+		return DetectLanguage(word)
+	})
+}
+
+// CheckTextIgnoringForLanguage checks a text against a specific language,
+// skipping the given ranges
+func (dym *DidYouMean) CheckTextIgnoringForLanguage(text string, lang Language, ignore []TextRange) []Correction {
+	return dym.checkText(text, ignore, func(string) Language {
+		return lang
+	})
+}
+
+// CheckMarkdown checks the prose of a Markdown document, skipping code, HTML
+// comments, link destinations and regions switched off with
+// <!-- dymean:off --> and <!-- dymean:on -->
+func (dym *DidYouMean) CheckMarkdown(text string) []Correction {
+	return dym.CheckTextIgnoring(text, MarkdownIgnoreRanges(text))
+}
+
+// CheckMarkdownForLanguage checks the prose of a Markdown document against a
+// specific language
+func (dym *DidYouMean) CheckMarkdownForLanguage(text string, lang Language) []Correction {
+	return dym.CheckTextIgnoringForLanguage(text, lang, MarkdownIgnoreRanges(text))
+}
@@ -0,0 +1,57 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"testing"
+)
+
+// TestCheckTextDirectives tests regions switched off with inline directives
+func TestCheckTextDirectives(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello", "world"})
+
+	text := "hello wrold\n<!-- dymean:off -->\nxyzzy plugh\n<!-- dymean:on -->\nhelo"
+	corrections := dym.CheckText(text)
+	if len(corrections) != 2 || corrections[0].Original != "wrold" || corrections[1].Original != "helo" {
+		t.Errorf("Expected only wrold and helo to be reported, got %+v", corrections)
+	}
+
+	// Without dymean:on the rest of the text is skipped
+	if corrections := dym.CheckText("helo // dymean:off\nwrold"); len(corrections) != 1 || corrections[0].Original != "helo" {
+		t.Errorf("Expected only helo to be reported, got %+v", corrections)
+	}
+}
+
+// TestCheckTextIgnoring tests caller-supplied ignore ranges
+func TestCheckTextIgnoring(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello", "world"})
+
+	text := "helo wrold  helo"
+	corrections := dym.CheckTextIgnoringForLanguage(text, dymean.English, []dymean.TextRange{{Start: 5, End: 12}})
+	if len(corrections) != 2 || corrections[0].Start != 0 || corrections[1].Start != 12 {
+		t.Errorf("Expected the ignored word and spaces to be skipped, got %+v", corrections)
+	}
+}
+
+// TestCheckMarkdown tests that code and markup in Markdown are not checked
+func TestCheckMarkdown(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"see", "the", "docs", "and", "run", "it", "hello"})
+
+	text := "See the [docs](https://exmple.com/dcs) and run `fmtt.Prntln` it.\n" +
+		"```go\nfmt.Prntln(\"helo\")\n```\n" +
+		"<!-- generated: xyzzy -->\n" +
+		"hello wrld\n"
+	corrections := dym.CheckMarkdown(text)
+	if len(corrections) != 1 || corrections[0].Original != "wrld" {
+		t.Errorf("Expected only wrld to be reported, got %+v", corrections)
+	}
+
+	ranges := dymean.MarkdownIgnoreRanges("a `b` c\n~~~\nd\n~~~\n")
+	expected := []dymean.TextRange{{Start: 2, End: 5}, {Start: 8, End: 18}}
+	if !reflect.DeepEqual(ranges, expected) {
+		t.Errorf("Expected ranges %v, got %v", expected, ranges)
+	}
+}
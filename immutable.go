@@ -0,0 +1,73 @@
+package dymean
+
+import "regexp"
+
+// DefaultImmutablePatterns match the tokens that text checks leave alone by
+// default: runs of letters and digits joined by '-', '_', '.', '/', '#' or ':'
+// that hold a digit, such as versions ("v1.2.3"), model numbers ("A-42",
+// "RTX4090") and IDs ("INV-2024-0042")
+var DefaultImmutablePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?:[\p{L}\p{N}]+[-_./#:])*\p{L}*\p{N}[\p{L}\p{N}]*(?:[-_./#:][\p{L}\p{N}]+)*`),
+}
+
+// TokenClass describes how text checks treat a token
+type TokenClass int
+
+const (
+	// TokenWord is a word that is spell checked and may be corrected
+	TokenWord TokenClass = iota
+	// TokenNumber is a token of digits only, never checked
+	TokenNumber
+	// TokenImmutable is part of a text matched by an immutable pattern, such
+	// as the "A" of "A-42", never checked or rewritten
+	TokenImmutable
+)
+
+// WithImmutablePatterns replaces the patterns of text that CheckText never
+// reports and Correct never rewrites, DefaultImmutablePatterns by default.
+// Append to DefaultImmutablePatterns to keep them; no patterns turn the
+// protection off, leaving only tokens with digits unchecked.
+func WithImmutablePatterns(patterns ...*regexp.Regexp) Option {
+	return func(dym *DidYouMean) {
+		dym.immutablePatterns = append([]*regexp.Regexp{}, patterns...)
+	}
+}
+
+// ImmutableRanges returns the regions of a text matched by the immutable
+// patterns, which text checks skip
+func (dym *DidYouMean) ImmutableRanges(text string) []TextRange {
+	ranges := make([]TextRange, 0)
+	for _, re := range dym.immutablePatterns {
+		for _, match := range re.FindAllStringIndex(text, -1) {
+			ranges = append(ranges, TextRange{Start: match[0], End: match[1]})
+		}
+	}
+	return mergeRanges(ranges)
+}
+
+// ClassifyTokens returns the class of each token of a text, in the order
+// returned by Tokenize
+func (dym *DidYouMean) ClassifyTokens(text string) []TokenClass {
+	immutable := dym.ImmutableRanges(text)
+	tokens := Tokenize(text)
+	classes := make([]TokenClass, len(tokens))
+	for i, token := range tokens {
+		switch {
+		case isNumber(token.Text):
+			classes[i] = TokenNumber
+		case overlapsRanges(immutable, token.Start, token.End):
+			classes[i] = TokenImmutable
+		}
+	}
+	return classes
+}
+
+// isNumber checks if a token holds only digits
+func isNumber(token string) bool {
+	for _, r := range token {
+		if _, ok := digitValue(r); !ok {
+			return false
+		}
+	}
+	return token != ""
+}
@@ -0,0 +1,63 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// TestClassifyTokens tests classifying numbers and immutable tokens
+func TestClassifyTokens(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+
+	text := "Upgrade to v1.2.3 of the A-42 on INV-2024-0042, not 2023"
+	expected := []dymean.TokenClass{
+		dymean.TokenWord,      // Upgrade
+		dymean.TokenWord,      // to
+		dymean.TokenImmutable, // v1
+		dymean.TokenNumber,    // 2
+		dymean.TokenNumber,    // 3
+		dymean.TokenWord,      // of
+		dymean.TokenWord,      // the
+		dymean.TokenImmutable, // A
+		dymean.TokenNumber,    // 42
+		dymean.TokenWord,      // on
+		dymean.TokenImmutable, // INV
+		dymean.TokenNumber,    // 2024
+		dymean.TokenNumber,    // 0042
+		dymean.TokenWord,      // not
+		dymean.TokenNumber,    // 2023
+	}
+	if classes := dym.ClassifyTokens(text); !reflect.DeepEqual(classes, expected) {
+		t.Errorf("ClassifyTokens(%q) = %v, expected %v", text, classes, expected)
+	}
+}
+
+// TestCorrectKeepsImmutableTokens tests that Correct never rewrites model
+// numbers, versions or IDs
+func TestCorrectKeepsImmutableTokens(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"the", "model", "version", "is", "an", "ax", "abc"})
+
+	text := "teh model ABX-42 is versoin v1.2.3-rc1 not AX"
+	expected := "the model ABX-42 is version v1.2.3-rc1 not AX"
+	if corrected := dym.Correct(text); corrected != expected {
+		t.Errorf("Correct(%q) = %q, expected %q", text, corrected, expected)
+	}
+
+	// Without patterns only the tokens with digits are left alone
+	unprotected := dymean.NewDidYouMean(10000, 7, dymean.WithImmutablePatterns())
+	unprotected.AddWords([]string{"the", "model", "abc"})
+	if corrected := unprotected.Correct("the model ABX-42"); corrected != "the model ABC-42" {
+		t.Errorf("Expected ABX to be corrected without patterns, got %q", corrected)
+	}
+
+	// Custom patterns extend the defaults
+	custom := dymean.NewDidYouMean(10000, 7, dymean.WithImmutablePatterns(
+		append(dymean.DefaultImmutablePatterns, regexp.MustCompile(`\bSKU[A-Z]+\b`))...))
+	custom.AddWords([]string{"the", "sku", "skus", "model"})
+	if corrected := custom.Correct("the SKUQ moedl A-1"); corrected != "the SKUQ model A-1" {
+		t.Errorf("Expected the custom pattern to be kept, got %q", corrected)
+	}
+}
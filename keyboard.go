@@ -0,0 +1,105 @@
+package dymean
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// Keyboard layouts of the built-in languages, mapping each key to its
+// adjacent keys
+var (
+	qwertyNeighbors   = keyboardFromRows("qwertyuiop", "asdfghjkl", "zxcvbnm")
+	qwertzNeighbors   = keyboardFromRows("qwertzuiopü", "asdfghjklöä", "yxcvbnm")
+	azertyNeighbors   = keyboardFromRows("azertyuiop", "qsdfghjklm", "wxcvbn")
+	spanishNeighbors  = keyboardFromRows("qwertyuiop", "asdfghjklñ", "zxcvbnm")
+	russianNeighbors  = keyboardFromRows("йцукенгшщзхъ", "фывапролджэ", "ячсмитьбю")
+	persianNeighbors  = keyboardFromRows("ضصثقفغعهخحجچ", "شسیبلاتنمکگ", "ظطزرذدپو")
+	turkishNeighbors  = keyboardFromRows("qwertyuıopğü", "asdfghjklşi", "zxcvbnmöç")
+	inscriptNeighbors = keyboardFromRows("ौैाीूबहगदजड\u093C", "ोे\u094Dिुपरकतचट", "ॆंमनवलस")
+	greekNeighbors    = keyboardFromRows("ςερτυθιοπ", "ασδφγηξκλ", "ζχψωβνμ")
+	polishNeighbors   = withAltGr(qwertyNeighbors, map[rune]rune{'a': 'ą', 'c': 'ć', 'e': 'ę', 'l': 'ł', 'n': 'ń', 'o': 'ó', 's': 'ś', 'z': 'ż', 'x': 'ź'})
+)
+
+// Rows of the built-in keyboard layouts by physical key, top row first,
+// starting at the keys of Q, A and Z on a US keyboard
+var (
+	qwertyRows   = []string{"qwertyuiop[]", "asdfghjkl;'", "zxcvbnm,./"}
+	qwertzRows   = []string{"qwertzuiopü+", "asdfghjklöä", "yxcvbnm,.-"}
+	azertyRows   = []string{"azertyuiop^$", "qsdfghjklmù", "wxcvbn,;:!"}
+	spanishRows  = []string{"qwertyuiop`+", "asdfghjklñ´", "zxcvbnm,.-"}
+	italianRows  = []string{"qwertyuiopè+", "asdfghjklòà", "zxcvbnm,.-"}
+	russianRows  = []string{"йцукенгшщзхъ", "фывапролджэ", "ячсмитьбю."}
+	persianRows  = []string{"ضصثقفغعهخحجچ", "شسیبلاتنمکگ", "ظطزرذدپو./"}
+	turkishRows  = []string{"qwertyuıopğü", "asdfghjklşi,", "zxcvbnmöç."}
+	inscriptRows = []string{"ौैाीूबहगदजड\u093C", "ोे\u094Dिुपरकतचट", "ॆंमनवलस,.य"}
+	greekRows    = []string{";ςερτυθιοπ[]", "ασδφγηξκλ΄'", "ζχψωβνμ,./"}
+)
+
+// keyboardFromRows builds a neighbor map from the letter rows of a keyboard,
+// top row first. Rows are staggered, so the key at index i touches keys i and
+// i+1 of the row above and keys i-1 and i of the row below.
+func keyboardFromRows(rows ...string) map[rune][]rune {
+	keys := make([][]rune, len(rows))
+	for i, row := range rows {
+		keys[i] = []rune(row)
+	}
+	at := func(row, i int) (rune, bool) {
+		if row < 0 || row >= len(keys) || i < 0 || i >= len(keys[row]) {
+			return 0, false
+		}
+		return keys[row][i], true
+	}
+
+	neighbors := make(map[rune][]rune)
+	for row := range keys {
+		for i, key := range keys[row] {
+			for _, pos := range [][2]int{{row, i - 1}, {row, i + 1}, {row - 1, i}, {row - 1, i + 1}, {row + 1, i - 1}, {row + 1, i}} {
+				if neighbor, ok := at(pos[0], pos[1]); ok {
+					neighbors[key] = append(neighbors[key], neighbor)
+				}
+			}
+		}
+	}
+	return neighbors
+}
+
+// withAltGr adds the letters typed with AltGr to a layout: each is a neighbor
+// of its key's letter, and slips onto the keys around it give their letters
+// and AltGr letters, so "ż" is next to "z", "ź", "a", "ą" and "s"
+func withAltGr(layout map[rune][]rune, altGr map[rune]rune) map[rune][]rune {
+	neighbors := make(map[rune][]rune, len(layout)+len(altGr))
+	for key, around := range layout {
+		neighbors[key] = append([]rune(nil), around...)
+	}
+	for key, letter := range altGr {
+		neighbors[key] = append(neighbors[key], letter)
+		neighbors[letter] = append(neighbors[letter], key)
+		for _, near := range layout[key] {
+			neighbors[letter] = append(neighbors[letter], near)
+			if nearLetter, ok := altGr[near]; ok {
+				neighbors[letter] = append(neighbors[letter], nearLetter)
+			}
+		}
+	}
+	return neighbors
+}
+
+// keyboardTypos returns the words obtained by replacing one letter of a word
+// with an adjacent key of a layout, in lexical order
+func keyboardTypos(word string, layout map[rune][]rune) []string {
+	candidates := getStringSet()
+	defer putStringSet(candidates)
+
+	for i, char := range word {
+		for _, neighbor := range layout[char] {
+			candidates[word[:i]+string(neighbor)+word[i+utf8.RuneLen(char):]] = true
+		}
+	}
+
+	result := make([]string, 0, len(candidates))
+	for candidate := range candidates {
+		result = append(result, candidate)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -1,7 +1,10 @@
 package dymean
 
 import (
+	"errors"
+	"sort"
 	"strings"
+	"sync"
 	"unicode"
 )
 
@@ -9,17 +12,22 @@ import (
 type Language string
 
 const (
-	English  Language = "en"
-	Persian  Language = "fa"
-	Arabic   Language = "ar"
-	French   Language = "fr"
-	Spanish  Language = "es"
-	German   Language = "de"
-	Italian  Language = "it"
-	Russian  Language = "ru"
-	Chinese  Language = "zh"
-	Japanese Language = "ja"
-	Korean   Language = "ko"
+	English    Language = "en"
+	Persian    Language = "fa"
+	Arabic     Language = "ar"
+	French     Language = "fr"
+	Spanish    Language = "es"
+	German     Language = "de"
+	Italian    Language = "it"
+	Russian    Language = "ru"
+	Chinese    Language = "zh"
+	Japanese   Language = "ja"
+	Korean     Language = "ko"
+	Turkish    Language = "tr"
+	Hindi      Language = "hi"
+	Vietnamese Language = "vi"
+	Greek      Language = "el"
+	Polish     Language = "pl"
 )
 
 // LanguageInfo contains information about a language
@@ -28,122 +36,348 @@ type LanguageInfo struct {
 	Name       string
 	Direction  string // "ltr" or "rtl"
 	Alphabet   string
+	Extended   string                // Legitimate characters outside Alphabet, such as ligatures and hamza forms
+	Joiners    string                // Non-letters allowed between two letters, such as ZWNJ
+	Scripts    []*unicode.RangeTable // Scripts accepted by ValidationLenient
 	IsRTL      bool
 	Normalizer func(string) string
+
+	KeyboardLayout       map[rune][]rune  // Adjacent keys, for keyboard typo candidates
+	KeyboardRows         []string         // Characters of the key rows by physical key, for layout mismatch detection
+	Stopwords            []string         // Function words never reported by CheckText
+	CharacterFrequencies map[rune]float64 // Letter frequencies, for detection among languages sharing a script
+	Equivalences         []string         // Classes of interchangeable letters, such as hamza forms, for lookup and ranking
+	Collation            []string         // Letters in sort order, grouping variants of a letter such as "aá"; by default Alphabet order with letters like "ä" sorted as variants of "a"
+	SuggestionTuning     SuggestionTuning // Defaults for CheckText and auto-detection
 }
 
-// GetLanguageInfo returns information about a language
-func GetLanguageInfo(lang Language) LanguageInfo {
-	switch lang {
-	case English:
-		return LanguageInfo{
-			Code:       English,
-			Name:       "English",
-			Direction:  "ltr",
-			Alphabet:   "abcdefghijklmnopqrstuvwxyz",
-			IsRTL:      false,
-			Normalizer: normalizeEnglish,
-		}
-	case Persian:
-		return LanguageInfo{
-			Code:       Persian,
-			Name:       "Persian",
-			Direction:  "rtl",
-			Alphabet:   "ابپتثجچحخدذرزژسشصضطظعغفقکگلمنوهی",
-			IsRTL:      true,
-			Normalizer: normalizePersian,
-		}
-	case Arabic:
-		return LanguageInfo{
-			Code:       Arabic,
-			Name:       "Arabic",
-			Direction:  "rtl",
-			Alphabet:   "ابتثجحخدذرزسشصضطظعغفقكلمنهوي",
-			IsRTL:      true,
-			Normalizer: normalizeArabic,
-		}
-	case French:
-		return LanguageInfo{
-			Code:       French,
-			Name:       "French",
-			Direction:  "ltr",
-			Alphabet:   "abcdefghijklmnopqrstuvwxyzàâäéèêëïîôöùûüÿç",
-			IsRTL:      false,
-			Normalizer: normalizeFrench,
-		}
-	case Spanish:
-		return LanguageInfo{
-			Code:       Spanish,
-			Name:       "Spanish",
-			Direction:  "ltr",
-			Alphabet:   "abcdefghijklmnopqrstuvwxyzñáéíóúü",
-			IsRTL:      false,
-			Normalizer: normalizeSpanish,
-		}
-	case German:
-		return LanguageInfo{
-			Code:       German,
-			Name:       "German",
-			Direction:  "ltr",
-			Alphabet:   "abcdefghijklmnopqrstuvwxyzäöüß",
-			IsRTL:      false,
-			Normalizer: normalizeGerman,
-		}
-	case Italian:
-		return LanguageInfo{
-			Code:       Italian,
-			Name:       "Italian",
-			Direction:  "ltr",
-			Alphabet:   "abcdefghijklmnopqrstuvwxyzàèéìíîòóùú",
-			IsRTL:      false,
-			Normalizer: normalizeItalian,
-		}
-	case Russian:
-		return LanguageInfo{
-			Code:       Russian,
-			Name:       "Russian",
-			Direction:  "ltr",
-			Alphabet:   "абвгдеёжзийклмнопрстуфхцчшщъыьэюя",
-			IsRTL:      false,
-			Normalizer: normalizeRussian,
-		}
-	case Chinese:
-		return LanguageInfo{
-			Code:       Chinese,
-			Name:       "Chinese",
-			Direction:  "ltr",
-			Alphabet:   "", // Chinese doesn't use alphabet
-			IsRTL:      false,
-			Normalizer: normalizeChinese,
-		}
-	case Japanese:
-		return LanguageInfo{
-			Code:       Japanese,
-			Name:       "Japanese",
-			Direction:  "ltr",
-			Alphabet:   "あいうえおかきくけこさしすせそたちつてとなにぬねのはひふへほまみむめもやゆよらりるれろわをん",
-			IsRTL:      false,
-			Normalizer: normalizeJapanese,
+// SuggestionTuning holds a language's default suggestion parameters
+type SuggestionTuning struct {
+	MaxSuggestions  int
+	MaxEditDistance int
+}
+
+// defaultSuggestionTuning is used by languages that do not set their own
+var defaultSuggestionTuning = SuggestionTuning{MaxSuggestions: 5, MaxEditDistance: 2}
+
+// builtinLanguages holds the information of the supported languages
+var builtinLanguages = map[Language]LanguageInfo{
+	English: {
+		Code:       English,
+		Name:       "English",
+		Direction:  "ltr",
+		Alphabet:   "abcdefghijklmnopqrstuvwxyz",
+		Scripts:    []*unicode.RangeTable{unicode.Latin},
+		IsRTL:      false,
+		Normalizer: normalizeEnglish,
+
+		KeyboardLayout:       qwertyNeighbors,
+		KeyboardRows:         qwertyRows,
+		Stopwords:            englishStopwords,
+		CharacterFrequencies: englishFrequencies,
+		SuggestionTuning:     defaultSuggestionTuning,
+	},
+	Persian: {
+		Code:       Persian,
+		Name:       "Persian",
+		Direction:  "rtl",
+		Alphabet:   "آأإئؤءابپتثجچحخدذرزژسشصضطظعغفقکگلمنوهی",
+		Joiners:    "\u200c", // ZWNJ, as in "می‌روم"
+		Scripts:    []*unicode.RangeTable{unicode.Arabic},
+		IsRTL:      true,
+		Normalizer: normalizePersian,
+
+		KeyboardLayout:   persianNeighbors,
+		KeyboardRows:     persianRows,
+		Stopwords:        persianStopwords,
+		Collation:        strings.Fields("آ اأإ ب پ ت ث ج چ ح خ د ذ ر ز ژ س ش ص ض ط ظ ع غ ف ق ک گ ل م ن وؤ ه یئ ء"),
+		SuggestionTuning: defaultSuggestionTuning,
+	},
+	Arabic: {
+		Code:       Arabic,
+		Name:       "Arabic",
+		Direction:  "rtl",
+		Alphabet:   "ابتثجحخدذرزسشصضطظعغفقكلمنهوي",
+		Extended:   "آأإئؤءةى",
+		Scripts:    []*unicode.RangeTable{unicode.Arabic},
+		IsRTL:      true,
+		Normalizer: normalizeArabic,
+
+		Stopwords:        arabicStopwords,
+		Equivalences:     arabicEquivalences,
+		SuggestionTuning: defaultSuggestionTuning,
+	},
+	French: {
+		Code:       French,
+		Name:       "French",
+		Direction:  "ltr",
+		Alphabet:   "abcdefghijklmnopqrstuvwxyzàâäéèêëïîôöùûüÿç",
+		Extended:   "œæ",
+		Scripts:    []*unicode.RangeTable{unicode.Latin},
+		IsRTL:      false,
+		Normalizer: normalizeFrench,
+
+		KeyboardLayout:       azertyNeighbors,
+		KeyboardRows:         azertyRows,
+		Stopwords:            frenchStopwords,
+		CharacterFrequencies: frenchFrequencies,
+		SuggestionTuning:     defaultSuggestionTuning,
+	},
+	Spanish: {
+		Code:       Spanish,
+		Name:       "Spanish",
+		Direction:  "ltr",
+		Alphabet:   "abcdefghijklmnopqrstuvwxyzñáéíóúü",
+		Scripts:    []*unicode.RangeTable{unicode.Latin},
+		IsRTL:      false,
+		Normalizer: normalizeSpanish,
+
+		KeyboardLayout:       spanishNeighbors,
+		KeyboardRows:         spanishRows,
+		Stopwords:            spanishStopwords,
+		CharacterFrequencies: spanishFrequencies,
+		Collation:            strings.Fields("aá b c d eé f g h ií j k l m n ñ oó p q r s t uúü v w x y z"),
+		SuggestionTuning:     defaultSuggestionTuning,
+	},
+	German: {
+		Code:       German,
+		Name:       "German",
+		Direction:  "ltr",
+		Alphabet:   "abcdefghijklmnopqrstuvwxyzäöüß",
+		Scripts:    []*unicode.RangeTable{unicode.Latin},
+		IsRTL:      false,
+		Normalizer: normalizeGerman,
+
+		KeyboardLayout:       qwertzNeighbors,
+		KeyboardRows:         qwertzRows,
+		Stopwords:            germanStopwords,
+		CharacterFrequencies: germanFrequencies,
+		SuggestionTuning:     defaultSuggestionTuning,
+	},
+	Italian: {
+		Code:       Italian,
+		Name:       "Italian",
+		Direction:  "ltr",
+		Alphabet:   "abcdefghijklmnopqrstuvwxyzàèéìíîòóùú",
+		Scripts:    []*unicode.RangeTable{unicode.Latin},
+		IsRTL:      false,
+		Normalizer: normalizeItalian,
+
+		KeyboardLayout:       qwertyNeighbors,
+		KeyboardRows:         italianRows,
+		Stopwords:            italianStopwords,
+		CharacterFrequencies: italianFrequencies,
+		SuggestionTuning:     defaultSuggestionTuning,
+	},
+	Russian: {
+		Code:       Russian,
+		Name:       "Russian",
+		Direction:  "ltr",
+		Alphabet:   "абвгдеёжзийклмнопрстуфхцчшщъыьэюя",
+		Scripts:    []*unicode.RangeTable{unicode.Cyrillic},
+		IsRTL:      false,
+		Normalizer: normalizeRussian,
+
+		KeyboardLayout:   russianNeighbors,
+		KeyboardRows:     russianRows,
+		Stopwords:        russianStopwords,
+		SuggestionTuning: defaultSuggestionTuning,
+	},
+	Turkish: {
+		Code:       Turkish,
+		Name:       "Turkish",
+		Direction:  "ltr",
+		Alphabet:   "abcçdefgğhıijklmnoöprsştuüvyz",
+		Extended:   "âîûqwx", // Circumflexed vowels and letters of loanwords
+		Scripts:    []*unicode.RangeTable{unicode.Latin},
+		IsRTL:      false,
+		Normalizer: normalizeTurkish,
+
+		KeyboardLayout:       turkishNeighbors,
+		KeyboardRows:         turkishRows,
+		Stopwords:            turkishStopwords,
+		CharacterFrequencies: turkishFrequencies,
+		Equivalences:         turkishEquivalences,
+		Collation:            strings.Fields("aâ b c ç d e f g ğ h ı iî j k l m n o ö p q r s ş t uû ü v w x y z"),
+		SuggestionTuning:     defaultSuggestionTuning,
+	},
+	Hindi: {
+		Code:      Hindi,
+		Name:      "Hindi",
+		Direction: "ltr",
+		// Vowels, consonants, then matras and signs; Extended has the vowels of
+		// English loanwords, the nukta and rare vowels
+		Alphabet:   "अआइईउऊऋएऐओऔकखगघङचछजझञटठडढणतथदधनपफबभमयरलवशषसहािीुूृेैोौंँः\u094D",
+		Extended:   "ऑॉॅऍ\u093Cॠॄऌ",
+		Scripts:    []*unicode.RangeTable{unicode.Devanagari},
+		IsRTL:      false,
+		Normalizer: normalizeHindi,
+
+		KeyboardLayout:   inscriptNeighbors,
+		KeyboardRows:     inscriptRows,
+		Stopwords:        hindiStopwords,
+		SuggestionTuning: defaultSuggestionTuning,
+	},
+	Vietnamese: {
+		Code:      Vietnamese,
+		Name:      "Vietnamese",
+		Direction: "ltr",
+		// Each vowel in its six tones; f, j, w and z are in Extended for
+		// loanwords and Telex typing
+		Alphabet:   "aàáảãạăằắẳẵặâầấẩẫậbcdđeèéẻẽẹêềếểễệghiìíỉĩịklmnoòóỏõọôồốổỗộơờớởỡợpqrstuùúủũụưừứửữựvxyỳýỷỹỵ",
+		Extended:   "fjwz",
+		Scripts:    []*unicode.RangeTable{unicode.Latin},
+		IsRTL:      false,
+		Normalizer: normalizeVietnamese,
+
+		// Vietnamese is typed on a US layout, whose rows stay English's so
+		// layout detection never confuses the two
+		KeyboardLayout:   qwertyNeighbors,
+		Stopwords:        vietnameseStopwords,
+		Equivalences:     vietnameseEquivalences,
+		Collation:        strings.Fields("aàáảãạ ăằắẳẵặ âầấẩẫậ b c d đ eèéẻẽẹ êềếểễệ f g h iìíỉĩị j k l m n oòóỏõọ ôồốổỗộ ơờớởỡợ p q r s t uùúủũụ ưừứửữự v w x yỳýỷỹỵ z"),
+		SuggestionTuning: defaultSuggestionTuning,
+	},
+	Greek: {
+		Code:       Greek,
+		Name:       "Greek",
+		Direction:  "ltr",
+		Alphabet:   "αβγδεζηθικλμνξοπρσςτυφχψωάέήίόύώϊϋΐΰ",
+		Scripts:    []*unicode.RangeTable{unicode.Greek},
+		IsRTL:      false,
+		Normalizer: normalizeGreek,
+
+		KeyboardLayout:   greekNeighbors,
+		KeyboardRows:     greekRows,
+		Stopwords:        greekStopwords,
+		Collation:        strings.Fields("αά β γ δ εέ ζ ηή θ ιίϊΐ κ λ μ ν ξ οό π ρ σς τ υύϋΰ φ χ ψ ωώ"),
+		SuggestionTuning: defaultSuggestionTuning,
+	},
+	Polish: {
+		Code:       Polish,
+		Name:       "Polish",
+		Direction:  "ltr",
+		Alphabet:   "aąbcćdeęfghijklłmnńoóprsśtuwyzźż",
+		Extended:   "qvx", // Letters of loanwords
+		Scripts:    []*unicode.RangeTable{unicode.Latin},
+		IsRTL:      false,
+		Normalizer: normalizePolish,
+
+		// Polish programmers' layout is the US one with AltGr letters, so its
+		// rows stay English's and only the neighbors add the AltGr slips
+		KeyboardLayout:       polishNeighbors,
+		Stopwords:            polishStopwords,
+		CharacterFrequencies: polishFrequencies,
+		Equivalences:         polishEquivalences,
+		Collation:            strings.Fields("a ą b c ć d e ę f g h i j k l ł m n ń o ó p q r s ś t u v w x y z ź ż"),
+		SuggestionTuning:     defaultSuggestionTuning,
+	},
+	Chinese: {
+		Code:       Chinese,
+		Name:       "Chinese",
+		Direction:  "ltr",
+		Alphabet:   "", // Chinese doesn't use alphabet
+		Scripts:    []*unicode.RangeTable{unicode.Han},
+		IsRTL:      false,
+		Normalizer: normalizeChinese,
+
+		SuggestionTuning: defaultSuggestionTuning,
+	},
+	Japanese: {
+		Code:       Japanese,
+		Name:       "Japanese",
+		Direction:  "ltr",
+		Alphabet:   "あいうえおかきくけこさしすせそたちつてとなにぬねのはひふへほまみむめもやゆよらりるれろわをん",
+		Scripts:    []*unicode.RangeTable{unicode.Hiragana, unicode.Katakana, unicode.Han},
+		IsRTL:      false,
+		Normalizer: normalizeJapanese,
+
+		SuggestionTuning: defaultSuggestionTuning,
+	},
+	Korean: {
+		Code:       Korean,
+		Name:       "Korean",
+		Direction:  "ltr",
+		Alphabet:   "ㄱㄴㄷㄹㅁㅂㅅㅇㅈㅊㅋㅌㅍㅎㅏㅑㅓㅕㅗㅛㅜㅠㅡㅣ",
+		Scripts:    []*unicode.RangeTable{unicode.Hangul},
+		IsRTL:      false,
+		Normalizer: normalizeKorean,
+
+		SuggestionTuning: defaultSuggestionTuning,
+	},
+}
+
+// registry holds the information of every language, built-in or registered
+var (
+	registryMu sync.RWMutex
+	registry   = func() map[Language]LanguageInfo {
+		languages := make(map[Language]LanguageInfo, len(builtinLanguages))
+		for lang, info := range builtinLanguages {
+			languages[lang] = info
 		}
-	case Korean:
-		return LanguageInfo{
-			Code:       Korean,
-			Name:       "Korean",
-			Direction:  "ltr",
-			Alphabet:   "ㄱㄴㄷㄹㅁㅂㅅㅇㅈㅊㅋㅌㅍㅎㅏㅑㅓㅕㅗㅛㅜㅠㅡㅣ",
-			IsRTL:      false,
-			Normalizer: normalizeKorean,
+		return languages
+	}()
+)
+
+// GetLanguageInfo returns information about a language. Generic languages
+// (ScriptLanguage) get information built from their script, and other unknown
+// languages the information of English. The returned maps and slices are shared and must
+// not be modified.
+func GetLanguageInfo(lang Language) LanguageInfo {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if info, ok := registry[lang]; ok {
+		return info
+	}
+	if script, ok := GenericScript(lang); ok {
+		return genericLanguageInfo(script)
+	}
+	return registry[English]
+}
+
+// RegisterLanguage adds a language or replaces the information of a built-in
+// one, so dictionaries, validation, normalization, keyboard typos, stopwords
+// and suggestion defaults follow it. Code is required; a missing Normalizer
+// trims spaces and lowercases, and zero SuggestionTuning fields take the
+// defaults. Languages must be registered before instances use them.
+func RegisterLanguage(info LanguageInfo) error {
+	if info.Code == "" {
+		return errors.New("language code is required")
+	}
+	if info.Name == "" {
+		info.Name = string(info.Code)
+	}
+	if info.Direction == "" {
+		info.Direction = "ltr"
+		if info.IsRTL {
+			info.Direction = "rtl"
 		}
-	default:
-		return LanguageInfo{
-			Code:       English,
-			Name:       "English",
-			Direction:  "ltr",
-			Alphabet:   "abcdefghijklmnopqrstuvwxyz",
-			IsRTL:      false,
-			Normalizer: normalizeEnglish,
+	}
+	if info.Normalizer == nil {
+		info.Normalizer = normalizeEnglish
+	}
+	if info.SuggestionTuning.MaxSuggestions <= 0 {
+		info.SuggestionTuning.MaxSuggestions = defaultSuggestionTuning.MaxSuggestions
+	}
+	if info.SuggestionTuning.MaxEditDistance <= 0 {
+		info.SuggestionTuning.MaxEditDistance = defaultSuggestionTuning.MaxEditDistance
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[info.Code] = info
+	collators.Delete(info.Code)
+	return nil
+}
+
+// isStopword checks if a normalized word is one of a language's stopwords
+func (info LanguageInfo) isStopword(word string) bool {
+	for _, stopword := range info.Stopwords {
+		if stopword == word {
+			return true
 		}
 	}
+	return false
 }
 
 // Normalization functions for different languages
@@ -151,25 +385,45 @@ func normalizeEnglish(word string) string {
 	return strings.ToLower(strings.TrimSpace(word))
 }
 
+// persianLetters replaces the Arabic forms of yeh and kaf, also produced by
+// presentation forms, with the Persian letters
+var persianLetters = strings.NewReplacer("ي", "ی", "ى", "ی", "ك", "ک")
+
 func normalizePersian(word string) string {
 	// Remove diacritics and normalize Persian text
-	word = strings.TrimSpace(word)
-	// Convert Arabic numerals to Persian numerals if needed
-	word = strings.ReplaceAll(word, "0", "۰")
-	word = strings.ReplaceAll(word, "1", "۱")
-	word = strings.ReplaceAll(word, "2", "۲")
-	word = strings.ReplaceAll(word, "3", "۳")
-	word = strings.ReplaceAll(word, "4", "۴")
-	word = strings.ReplaceAll(word, "5", "۵")
-	word = strings.ReplaceAll(word, "6", "۶")
-	word = strings.ReplaceAll(word, "7", "۷")
-	word = strings.ReplaceAll(word, "8", "۸")
-	word = strings.ReplaceAll(word, "9", "۹")
-	return word
+	word = persianLetters.Replace(normalizePresentationForms(strings.TrimSpace(word)))
+	word = normalizeZWNJ(stripIgnorable(word))
+	// Write ASCII and Arabic-Indic digits as Persian digits
+	return convertDigits(word, '۰')
+}
+
+// normalizeZWNJ collapses runs of zero-width non-joiners and drops those
+// that do not sit between two letters, as left by editors at word edges or
+// next to spaces
+func normalizeZWNJ(word string) string {
+	if !strings.ContainsRune(word, zwnj) {
+		return word
+	}
+	runes := []rune(word)
+	var b strings.Builder
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != zwnj {
+			b.WriteRune(runes[i])
+			continue
+		}
+		before := i > 0 && (unicode.IsLetter(runes[i-1]) || unicode.IsMark(runes[i-1]))
+		for i+1 < len(runes) && runes[i+1] == zwnj {
+			i++
+		}
+		if before && i+1 < len(runes) && unicode.IsLetter(runes[i+1]) {
+			b.WriteRune(zwnj)
+		}
+	}
+	return b.String()
 }
 
 func normalizeArabic(word string) string {
-	return strings.TrimSpace(word)
+	return stripIgnorable(normalizePresentationForms(strings.TrimSpace(word)))
 }
 
 func normalizeFrench(word string) string {
@@ -192,6 +446,28 @@ func normalizeRussian(word string) string {
 	return strings.ToLower(strings.TrimSpace(word))
 }
 
+// normalizeTurkish lowercases with Turkish rules, where "I" is the capital
+// of dotless "ı" and "İ" the capital of "i"
+func normalizeTurkish(word string) string {
+	return strings.ToLowerSpecial(unicode.TurkishCase, strings.TrimSpace(word))
+}
+
+// normalizeHindi decomposes letters with nukta and drops the zero-width
+// joiners that only select the rendering of conjuncts
+func normalizeHindi(word string) string {
+	return strings.NewReplacer(string(zwj), "", string(zwnj), "").Replace(normalizeNukta(strings.TrimSpace(word)))
+}
+
+func normalizePolish(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
+
+// normalizeGreek lowercases a word and writes its sigmas in final or medial
+// form by position
+func normalizeGreek(word string) string {
+	return finalSigma(strings.ToLower(strings.TrimSpace(word)))
+}
+
 func normalizeChinese(word string) string {
 	return strings.TrimSpace(word)
 }
@@ -236,6 +512,20 @@ func DetectLanguage(word string) Language {
 		}
 	}
 
+	// Check for Devanagari characters (Hindi)
+	for _, r := range word {
+		if r >= 0x0900 && r <= 0x097F { // Devanagari block
+			return Hindi
+		}
+	}
+
+	// Check for Greek characters
+	for _, r := range word {
+		if (r >= 0x0370 && r <= 0x03FF) || (r >= 0x1F00 && r <= 0x1FFF) { // Greek and Coptic, Greek Extended
+			return Greek
+		}
+	}
+
 	// Check for Chinese characters
 	for _, r := range word {
 		if r >= 0x4E00 && r <= 0x9FFF { // CJK Unified Ideographs
@@ -257,49 +547,128 @@ func DetectLanguage(word string) Language {
 		}
 	}
 
+	// Letters of other scripts get a language using the script, or the
+	// script's generic language
+	for _, r := range word {
+		if unicode.IsLetter(r) && !unicode.Is(unicode.Latin, r) {
+			return scriptLanguage(r)
+		}
+	}
+
 	// Default to English for Latin script
 	return English
 }
 
-// IsValidWordForLanguage checks if a word contains only valid characters for a language
+// LanguageCandidate is a possible language of a word with its detection confidence
+type LanguageCandidate struct {
+	Language   Language
+	Confidence float64
+}
+
+// scriptFamilies lists the languages sharing a script with a detected language.
+// The detected language comes first and is preferred when the script is ambiguous.
+var scriptFamilies = map[Language][]Language{
+	English: {English, French, Spanish, German, Italian, Turkish, Vietnamese, Polish},
+	Persian: {Persian, Arabic},
+}
+
+// DetectLanguageCandidates returns the possible languages of a word ranked by
+// confidence. Languages sharing a script are scored by how many of the word's
+// letters belong to their alphabet, so "سلام" is ambiguous between Persian and
+// Arabic while "پدر" is confidently Persian.
+// Letter frequencies and stopwords of the languages refine the scores.
+func DetectLanguageCandidates(word string) []LanguageCandidate {
+	detected := DetectLanguage(word)
+	family, exists := scriptFamilies[detected]
+	if !exists {
+		return []LanguageCandidate{{Language: detected, Confidence: 1.0}}
+	}
+
+	runes := []rune(strings.ToLower(strings.TrimSpace(word)))
+	scores := make([]float64, len(family))
+	total := 0.0
+
+	for i, lang := range family {
+		info := GetLanguageInfo(lang)
+		valid := 0
+		for _, r := range runes {
+			if strings.ContainsRune(info.Alphabet, r) {
+				valid++
+			}
+		}
+
+		fraction := 1.0
+		if len(runes) > 0 {
+			fraction = float64(valid) / float64(len(runes))
+		}
+		prior := 1.0
+		if i == 0 {
+			prior = 2.0
+		}
+
+		scores[i] = prior * fraction * fraction * fraction * fraction * frequencyWeight(runes, info.CharacterFrequencies)
+		if fraction < 1.0 {
+			scores[i] *= 0.1 // A single foreign letter is strong evidence against a language
+		}
+		if info.isStopword(string(runes)) {
+			scores[i] *= 4
+		}
+		total += scores[i]
+	}
+
+	candidates := make([]LanguageCandidate, 0, len(family))
+	for i, lang := range family {
+		confidence := 0.0
+		if total > 0 {
+			confidence = scores[i] / total
+		} else if i == 0 {
+			confidence = 1.0
+		}
+		candidates = append(candidates, LanguageCandidate{Language: lang, Confidence: confidence})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+
+	return candidates
+}
+
+// IsValidWordForLanguage checks if a word contains only valid characters for a
+// language: its alphabet and extended characters. See IsValidWordForLanguageMode
+// for lenient validation.
 func IsValidWordForLanguage(word string, lang Language) bool {
 	if len(word) == 0 {
 		return false
 	}
 
+	// A matra or other combining mark needs a letter to attach to
+	if startsWithMark(word) {
+		return false
+	}
+
 	langInfo := GetLanguageInfo(lang)
 
-	// For languages without alphabet (like Chinese), check for valid Unicode ranges
+	// For languages without alphabet (like Chinese), accept the letters of their
+	// scripts and the marks of generic languages (Thai vowel signs)
 	if langInfo.Alphabet == "" {
-		switch lang {
-		case Chinese:
-			for _, r := range word {
-				if !unicode.Is(unicode.Han, r) && !unicode.IsLetter(r) {
-					return false
-				}
-			}
-			return true
-		case Japanese:
-			for _, r := range word {
-				if !unicode.Is(unicode.Hiragana, r) && !unicode.Is(unicode.Katakana, r) &&
-					!unicode.Is(unicode.Han, r) && !unicode.IsLetter(r) {
-					return false
-				}
-			}
-			return true
-		case Korean:
-			for _, r := range word {
-				if !unicode.Is(unicode.Hangul, r) && !unicode.IsLetter(r) {
-					return false
-				}
+		for _, r := range word {
+			switch {
+			case unicode.IsMark(r):
+			case !unicode.IsLetter(r):
+				return false
+			case len(langInfo.Scripts) > 0 && !unicode.In(r, langInfo.Scripts...):
+				return false
 			}
-			return true
 		}
+		return true
 	}
 
 	// For languages with alphabet, check if all characters are in the alphabet
-	for _, r := range word {
-		if !strings.ContainsRune(langInfo.Alphabet, r) && !unicode.IsSpace(r) {
+	runes := []rune(word)
+	for i, r := range runes {
+		if !strings.ContainsRune(langInfo.Alphabet, r) && !strings.ContainsRune(langInfo.Extended, r) &&
+			!unicode.IsSpace(r) && !isJoinerAt(runes, i, langInfo.Joiners) {
 			return false
 		}
 	}
@@ -307,10 +676,44 @@ func IsValidWordForLanguage(word string, lang Language) bool {
 	return true
 }
 
-// GetSupportedLanguages returns a list of all supported languages
+// frequencyWeight scores how typical the letters of a word are for a
+// language, from 0.5 for letters it never uses to 1.5 for its most frequent
+// letter throughout. Languages without frequencies weigh 1.
+func frequencyWeight(runes []rune, frequencies map[rune]float64) float64 {
+	if len(frequencies) == 0 || len(runes) == 0 {
+		return 1.0
+	}
+	highest := 0.0
+	for _, frequency := range frequencies {
+		if frequency > highest {
+			highest = frequency
+		}
+	}
+	total := 0.0
+	for _, r := range runes {
+		total += frequencies[r] / highest
+	}
+	return 0.5 + total/float64(len(runes))
+}
+
+// GetSupportedLanguages returns a list of all supported languages: the
+// built-in ones followed by registered languages in sorted order
 func GetSupportedLanguages() []Language {
-	return []Language{
+	languages := []Language{
 		English, Persian, Arabic, French, Spanish, German,
-		Italian, Russian, Chinese, Japanese, Korean,
+		Italian, Russian, Chinese, Japanese, Korean, Turkish, Hindi, Vietnamese,
+		Greek, Polish,
 	}
+
+	registryMu.RLock()
+	registered := make([]Language, 0)
+	for lang := range registry {
+		if _, builtin := builtinLanguages[lang]; !builtin {
+			registered = append(registered, lang)
+		}
+	}
+	registryMu.RUnlock()
+
+	sort.Slice(registered, func(i, j int) bool { return registered[i] < registered[j] })
+	return append(languages, registered...)
 }
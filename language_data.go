@@ -0,0 +1,79 @@
+package dymean
+
+// Stopwords of the built-in languages: frequent function words that CheckText
+// never reports and that count as evidence for a language during detection
+var (
+	englishStopwords    = []string{"the", "a", "an", "and", "or", "but", "of", "to", "in", "on", "at", "for", "with", "is", "are", "was", "were", "be", "it", "this", "that"}
+	persianStopwords    = []string{"و", "در", "به", "از", "که", "این", "را", "با", "است", "آن", "برای"}
+	arabicStopwords     = []string{"و", "في", "من", "على", "إلى", "أن", "هذا", "مع", "عن", "التي"}
+	frenchStopwords     = []string{"le", "la", "les", "un", "une", "des", "et", "ou", "de", "du", "à", "en", "est", "sont", "que", "qui", "dans", "pour"}
+	spanishStopwords    = []string{"el", "la", "los", "las", "un", "una", "y", "o", "de", "del", "en", "es", "son", "que", "por", "para", "con"}
+	germanStopwords     = []string{"der", "die", "das", "ein", "eine", "und", "oder", "ist", "sind", "zu", "mit", "von", "den", "dem", "nicht"}
+	italianStopwords    = []string{"il", "lo", "la", "gli", "le", "un", "una", "e", "o", "di", "da", "è", "sono", "che", "per", "con"}
+	russianStopwords    = []string{"и", "в", "не", "на", "я", "что", "с", "он", "а", "как", "это", "по"}
+	hindiStopwords      = []string{"और", "का", "की", "के", "में", "है", "हैं", "को", "से", "पर", "यह", "वह", "एक", "भी", "नहीं"}
+	turkishStopwords    = []string{"ve", "bir", "bu", "da", "de", "için", "ile", "mi", "ne", "çok", "gibi", "ama", "veya"}
+	greekStopwords      = []string{"και", "το", "η", "ο", "της", "του", "να", "σε", "με", "για", "από", "την", "τα", "οι", "είναι"}
+	polishStopwords     = []string{"i", "w", "z", "na", "się", "nie", "do", "to", "że", "jest", "a", "o", "jak", "ale", "po"}
+	vietnameseStopwords = []string{"và", "của", "là", "có", "không", "được", "cho", "với", "các", "những", "một", "này", "trong", "để"}
+)
+
+// Letter frequencies of the built-in Latin-script languages, in percent of
+// letters in running text
+var (
+	englishFrequencies = map[rune]float64{
+		'e': 12.7, 't': 9.1, 'a': 8.2, 'o': 7.5, 'i': 7.0, 'n': 6.7, 's': 6.3, 'h': 6.1, 'r': 6.0,
+		'd': 4.3, 'l': 4.0, 'c': 2.8, 'u': 2.8, 'm': 2.4, 'w': 2.4, 'f': 2.2, 'g': 2.0, 'y': 2.0,
+		'p': 1.9, 'b': 1.5, 'v': 1.0, 'k': 0.8, 'j': 0.15, 'x': 0.15, 'q': 0.1, 'z': 0.07,
+	}
+	frenchFrequencies = map[rune]float64{
+		'e': 14.7, 's': 7.9, 'a': 7.6, 'i': 7.5, 't': 7.2, 'n': 7.1, 'r': 6.6, 'u': 6.3, 'l': 5.5,
+		'o': 5.4, 'd': 3.7, 'c': 3.3, 'm': 3.0, 'p': 3.0, 'é': 1.9, 'v': 1.8, 'q': 1.4, 'f': 1.1,
+		'b': 0.9, 'g': 0.9, 'h': 0.7, 'j': 0.6, 'à': 0.5, 'x': 0.4, 'è': 0.3, 'y': 0.3, 'ê': 0.2,
+		'z': 0.1, 'ç': 0.1, 'k': 0.05, 'w': 0.05,
+	}
+	spanishFrequencies = map[rune]float64{
+		'e': 13.7, 'a': 12.5, 'o': 8.7, 's': 8.0, 'r': 6.9, 'n': 6.7, 'i': 6.2, 'd': 5.9, 'l': 5.0,
+		'c': 4.7, 't': 4.6, 'u': 3.9, 'm': 3.2, 'p': 2.5, 'b': 1.4, 'g': 1.0, 'v': 0.9, 'y': 0.9,
+		'q': 0.9, 'ó': 0.8, 'h': 0.7, 'f': 0.7, 'í': 0.7, 'z': 0.5, 'á': 0.5, 'j': 0.4, 'é': 0.4,
+		'ñ': 0.3, 'ú': 0.2, 'x': 0.2, 'k': 0.01, 'w': 0.01,
+	}
+	germanFrequencies = map[rune]float64{
+		'e': 16.4, 'n': 9.8, 'i': 7.6, 's': 7.3, 'r': 7.0, 'a': 6.5, 't': 6.2, 'd': 5.1, 'h': 4.6,
+		'u': 4.2, 'l': 3.4, 'g': 3.0, 'c': 2.7, 'o': 2.6, 'm': 2.5, 'b': 1.9, 'w': 1.9, 'f': 1.7,
+		'k': 1.4, 'z': 1.1, 'v': 0.8, 'p': 0.7, 'ü': 0.7, 'ä': 0.6, 'ö': 0.3, 'ß': 0.3, 'j': 0.3,
+		'y': 0.04, 'x': 0.03, 'q': 0.02,
+	}
+	italianFrequencies = map[rune]float64{
+		'e': 11.8, 'a': 11.7, 'i': 11.3, 'o': 9.8, 'n': 6.9, 'l': 6.5, 'r': 6.4, 't': 5.6, 's': 5.0,
+		'c': 4.5, 'd': 3.7, 'u': 3.0, 'p': 3.0, 'm': 2.5, 'v': 2.1, 'g': 1.6, 'h': 1.5, 'f': 1.2,
+		'b': 0.9, 'à': 0.6, 'z': 0.5, 'q': 0.5, 'è': 0.3, 'ò': 0.2, 'ù': 0.2, 'ì': 0.1,
+	}
+	turkishFrequencies = map[rune]float64{
+		'a': 11.9, 'e': 8.9, 'i': 8.6, 'n': 7.5, 'r': 7.0, 'l': 5.9, 'ı': 5.1, 'd': 4.7, 'k': 4.7,
+		'm': 3.8, 'y': 3.3, 'u': 3.2, 't': 3.0, 's': 3.0, 'b': 2.8, 'o': 2.5, 'ü': 1.9, 'ş': 1.8,
+		'z': 1.5, 'g': 1.3, 'h': 1.2, 'ç': 1.2, 'ğ': 1.1, 'v': 1.0, 'c': 1.0, 'p': 0.9, 'ö': 0.8,
+		'f': 0.5, 'j': 0.03,
+	}
+	polishFrequencies = map[rune]float64{
+		'a': 8.9, 'i': 8.2, 'o': 7.8, 'e': 7.7, 'z': 5.6, 'n': 5.5, 'r': 4.7, 'w': 4.7, 's': 4.3,
+		't': 4.0, 'c': 4.0, 'y': 3.8, 'k': 3.5, 'd': 3.3, 'p': 3.1, 'm': 2.8, 'u': 2.5, 'j': 2.3,
+		'l': 2.1, 'ł': 1.8, 'b': 1.5, 'g': 1.4, 'ę': 1.1, 'h': 1.1, 'ą': 1.0, 'ó': 0.9, 'ż': 0.8,
+		'ś': 0.7, 'ć': 0.4, 'f': 0.3, 'ń': 0.2, 'ź': 0.06,
+	}
+)
+
+// turkishEquivalences pair each Turkish letter with the ASCII letter it is
+// typed as without a Turkish keyboard, so "cagri" finds "çağrı"
+var turkishEquivalences = []string{"cç", "gğ", "iı", "oö", "sş", "uü"}
+
+// polishEquivalences pair each Polish letter with the letter of its key,
+// typed when AltGr is missed, so "zolw" finds "żółw"
+var polishEquivalences = []string{"aą", "cć", "eę", "lł", "nń", "oó", "sś", "zżź"}
+
+// vietnameseEquivalences group the letters that are written alike without
+// diacritics, so "truong" finds "trường" and "trưởng"
+var vietnameseEquivalences = []string{
+	"aàáảãạăằắẳẵặâầấẩẫậ", "eèéẻẽẹêềếểễệ", "iìíỉĩị",
+	"oòóỏõọôồốổỗộơờớởỡợ", "uùúủũụưừứửữự", "yỳýỷỹỵ", "dđ",
+}
@@ -0,0 +1,121 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestDetectLanguageCandidates tests ranked language detection
+func TestDetectLanguageCandidates(t *testing.T) {
+	testCases := []struct {
+		word      string
+		top       dymean.Language
+		ambiguous bool
+	}{
+		{"سلام", dymean.Persian, true},
+		{"پدر", dymean.Persian, false},
+		{"كتاب", dymean.Arabic, false},
+		{"привет", dymean.Russian, false},
+		{"hello", dymean.English, true},
+	}
+
+	for _, tc := range testCases {
+		candidates := dymean.DetectLanguageCandidates(tc.word)
+		if len(candidates) == 0 || candidates[0].Language != tc.top {
+			t.Errorf("Expected top language %s for '%s', got %v", tc.top, tc.word, candidates)
+			continue
+		}
+		if ambiguous := candidates[0].Confidence < 0.8; ambiguous != tc.ambiguous {
+			t.Errorf("Expected ambiguous=%t for '%s', got %v", tc.ambiguous, tc.word, candidates)
+		}
+	}
+}
+
+// TestSuggestAuto tests merging suggestions across ambiguous languages
+func TestSuggestAuto(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWordsForLanguage([]string{"سلام"}, dymean.Persian)
+	dym.AddWordsForLanguage([]string{"سلمت"}, dymean.Arabic)
+
+	suggestions := dym.SuggestAuto("سلامت", 5)
+	languages := make(map[dymean.Language]bool)
+	for _, suggestion := range suggestions {
+		languages[suggestion.Language] = true
+		if suggestion.Confidence <= 0 || suggestion.Confidence >= 1 {
+			t.Errorf("Expected partial confidence for ambiguous word, got %+v", suggestion)
+		}
+	}
+	if !languages[dymean.Persian] || !languages[dymean.Arabic] {
+		t.Errorf("Expected suggestions from Persian and Arabic, got %v", suggestions)
+	}
+
+	// Only loaded languages are considered
+	dym = dymean.NewDidYouMean(10000, 7)
+	dym.LoadDefaultDictionary(dymean.English)
+	suggestions = dym.SuggestAuto("helo", 3)
+	if len(suggestions) == 0 || suggestions[0].Word != "hello" || suggestions[0].Confidence != 1.0 {
+		t.Errorf("Expected confident English suggestion 'hello', got %v", suggestions)
+	}
+}
+
+// TestLanguageInfoHooks tests keyboard layouts, stopwords and detection hooks of built-in languages
+func TestLanguageInfoHooks(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWordsForLanguage([]string{"привет"}, dymean.Russian)
+
+	// "т" is next to "и" on the Russian keyboard
+	suggestions := dym.GetSuggestionsForLanguage("пртвет", 3, 2, dymean.Russian)
+	if len(suggestions) == 0 || suggestions[0].Word != "привет" {
+		t.Errorf("Expected a keyboard typo suggestion, got %v", suggestions)
+	}
+
+	// Stopwords are never reported, even when missing from the dictionary
+	dym.AddWordsForLanguage([]string{"hello", "world"}, dymean.English)
+	if corrections := dym.CheckTextForLanguage("the hello of wrld", dymean.English); len(corrections) != 1 ||
+		corrections[0].Original != "wrld" {
+		t.Errorf("Expected only wrld to be reported, got %+v", corrections)
+	}
+
+	// A stopword is evidence for its language
+	if candidates := dymean.DetectLanguageCandidates("und"); candidates[0].Language != dymean.German {
+		t.Errorf("Expected und to be detected as German, got %v", candidates)
+	}
+}
+
+// TestRegisterLanguage tests adding a language at runtime
+func TestRegisterLanguage(t *testing.T) {
+	dutch := dymean.Language("nl")
+	if err := dymean.RegisterLanguage(dymean.LanguageInfo{}); err == nil {
+		t.Error("Expected an error for a language without code")
+	}
+	err := dymean.RegisterLanguage(dymean.LanguageInfo{
+		Code:      dutch,
+		Name:      "Dutch",
+		Alphabet:  "abcdefghijklmnopqrstuvwxyzëï",
+		Stopwords: []string{"de", "het", "een"},
+	})
+	if err != nil {
+		t.Fatalf("Expected registration to succeed, got %v", err)
+	}
+
+	info := dymean.GetLanguageInfo(dutch)
+	if info.Name != "Dutch" || info.Direction != "ltr" || info.Normalizer == nil ||
+		info.SuggestionTuning.MaxSuggestions != 5 || info.SuggestionTuning.MaxEditDistance != 2 {
+		t.Errorf("Expected defaults to be filled in, got %+v", info)
+	}
+	supported := dymean.GetSupportedLanguages()
+	if supported[len(supported)-1] != dutch {
+		t.Errorf("Expected Dutch among supported languages, got %v", supported)
+	}
+
+	dym := dymean.NewDidYouMean(10000, 7)
+	if report := dym.AddWordsReport([]string{"Huis", "ruïne", "straße"}, dutch); report.Added != 2 {
+		t.Errorf("Expected 2 Dutch words added, got %+v", report)
+	}
+	if !dym.IsCorrectForLanguage("huis", dutch) {
+		t.Error("Expected huis to be correct")
+	}
+	if corrections := dym.CheckTextForLanguage("het huis van de hius", dutch); len(corrections) != 2 {
+		t.Errorf("Expected van and hius to be reported, got %+v", corrections)
+	}
+}
@@ -0,0 +1,91 @@
+package dymean
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// CategoryLayoutSwitch marks words typed with the keyboard layout of another
+// language, such as "ghbdtn" for "привет"
+const CategoryLayoutSwitch CorrectionCategory = "layout"
+
+// minLayoutSwitchRunes is the shortest word checked for a layout switch;
+// shorter ones too often remap to some word by chance
+const minLayoutSwitchRunes = 3
+
+// LayoutSwitch is a word typed with the wrong keyboard layout
+type LayoutSwitch struct {
+	Word string   // The word meant, as typed on the right layout
+	From Language // The language of the layout used
+	To   Language // The language of the layout meant
+}
+
+// SetLayoutDetection enables or disables reporting words typed with the
+// keyboard layout of another language in CheckText, as corrections of
+// CategoryLayoutSwitch whose suggestion is the remapped word
+func (dym *DidYouMean) SetLayoutDetection(enabled bool) {
+	dym.layoutDetection = enabled
+}
+
+// RemapLayout returns the characters typed by the keys of a word typed on the
+// layout of one language when the layout of another is active, and whether
+// every character of the word has a key on both layouts. Layouts are the
+// KeyboardRows of the languages.
+func RemapLayout(word string, from, to Language) (string, bool) {
+	fromRows, toRows := GetLanguageInfo(from).KeyboardRows, GetLanguageInfo(to).KeyboardRows
+	if len(fromRows) == 0 || len(toRows) == 0 {
+		return "", false
+	}
+
+	type key struct{ row, column int }
+	keys := make(map[rune]key)
+	for row, characters := range fromRows {
+		for column, r := range []rune(characters) {
+			keys[r] = key{row, column}
+		}
+	}
+	targets := make([][]rune, len(toRows))
+	for row, characters := range toRows {
+		targets[row] = []rune(characters)
+	}
+
+	var builder strings.Builder
+	for _, r := range strings.ToLower(word) {
+		k, ok := keys[r]
+		if !ok || k.row >= len(targets) || k.column >= len(targets[k.row]) {
+			return "", false
+		}
+		builder.WriteRune(targets[k.row][k.column])
+	}
+	return builder.String(), true
+}
+
+// DetectLayoutSwitch reports whether a word not found in the dictionary of
+// the layout it was typed with spells a dictionary word of another loaded
+// language on that language's layout. Words with keys outside the letter
+// rows, and words shorter than three letters, are not checked.
+func (dym *DidYouMean) DetectLayoutSwitch(word string) (LayoutSwitch, bool) {
+	if utf8.RuneCountInString(word) < minLayoutSwitchRunes {
+		return LayoutSwitch{}, false
+	}
+
+	languages := dym.GetLoadedLanguages()
+	for _, from := range GetSupportedLanguages() {
+		if _, ok := RemapLayout(word, from, from); !ok {
+			continue // Not typed on this layout
+		}
+		if dym.hasDictionary(from) && dym.IsCorrectForLanguage(word, from) {
+			return LayoutSwitch{}, false
+		}
+		for _, to := range languages {
+			if to == from {
+				continue
+			}
+			remapped, ok := RemapLayout(word, from, to)
+			if ok && dym.contains(to, dym.normalize(remapped, to)) {
+				return LayoutSwitch{Word: remapped, From: from, To: to}, true
+			}
+		}
+	}
+	return LayoutSwitch{}, false
+}
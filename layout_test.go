@@ -0,0 +1,83 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestRemapLayout tests mapping characters between keyboard layouts
+func TestRemapLayout(t *testing.T) {
+	tests := []struct {
+		word     string
+		from, to dymean.Language
+		expected string
+		ok       bool
+	}{
+		{"ghbdtn", dymean.English, dymean.Russian, "привет", true},
+		{"руддщ", dymean.Russian, dymean.English, "hello", true},
+		{"sghl", dymean.English, dymean.Persian, "سلام", true},
+		{"Ghbdtn", dymean.English, dymean.Russian, "привет", true},
+		{"a1", dymean.English, dymean.Russian, "", false},
+		{"hello", dymean.English, dymean.Japanese, "", false},
+	}
+	for _, test := range tests {
+		remapped, ok := dymean.RemapLayout(test.word, test.from, test.to)
+		if remapped != test.expected || ok != test.ok {
+			t.Errorf("RemapLayout(%q, %s, %s) = %q, %v, expected %q, %v", test.word, test.from, test.to, remapped, ok, test.expected, test.ok)
+		}
+	}
+}
+
+// TestDetectLayoutSwitch tests finding words typed with the wrong layout
+func TestDetectLayoutSwitch(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello", "world"})
+	dym.AddWordsForLanguage([]string{"привет", "мир"}, dymean.Russian)
+	dym.AddWordsForLanguage([]string{"سلام", "دنیا"}, dymean.Persian)
+
+	tests := []struct {
+		word     string
+		expected dymean.LayoutSwitch
+	}{
+		{"ghbdtn", dymean.LayoutSwitch{Word: "привет", From: dymean.English, To: dymean.Russian}},
+		{"sghl", dymean.LayoutSwitch{Word: "سلام", From: dymean.English, To: dymean.Persian}},
+		{"руддщ", dymean.LayoutSwitch{Word: "hello", From: dymean.Russian, To: dymean.English}},
+	}
+	for _, test := range tests {
+		switched, ok := dym.DetectLayoutSwitch(test.word)
+		if !ok || switched != test.expected {
+			t.Errorf("DetectLayoutSwitch(%q) = %+v, %v, expected %+v", test.word, switched, ok, test.expected)
+		}
+	}
+
+	for _, word := range []string{"hello", "helo", "gh"} {
+		if switched, ok := dym.DetectLayoutSwitch(word); ok {
+			t.Errorf("Expected no layout switch for %q, got %+v", word, switched)
+		}
+	}
+}
+
+// TestCheckTextLayoutSwitch tests reporting layout switches in texts
+func TestCheckTextLayoutSwitch(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello", "world"})
+	dym.AddWordsForLanguage([]string{"привет", "мир"}, dymean.Russian)
+
+	if corrections := dym.CheckText("hello ghbdtn"); len(corrections) != 1 || corrections[0].Category != dymean.CategorySpelling {
+		t.Fatalf("Expected a spelling correction without layout detection, got %+v", corrections)
+	}
+
+	dym.SetLayoutDetection(true)
+	corrections := dym.CheckText("hello ghbdtn")
+	if len(corrections) != 1 {
+		t.Fatalf("Expected 1 correction, got %+v", corrections)
+	}
+	correction := corrections[0]
+	if correction.Category != dymean.CategoryLayoutSwitch || correction.Language != dymean.Russian ||
+		len(correction.Suggestions) != 1 || correction.Suggestions[0].Word != "привет" {
+		t.Errorf("Expected a layout switch to привет, got %+v", correction)
+	}
+	if corrected := dym.Correct("Ghbdtn world"); corrected != "Привет world" {
+		t.Errorf("Expected the remapped word in the corrected text, got %q", corrected)
+	}
+}
@@ -0,0 +1,139 @@
+package dymean
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// lazyLoad is a dictionary load deferred until the language is first used
+type lazyLoad struct {
+	load func() ([]string, error)
+	done chan struct{} // Closed when the load finished
+	err  error         // Set before done is closed
+	run  bool          // Whether a goroutine started the load
+}
+
+// LoadDictionaryLazily registers a word list to load into a language's
+// dictionary the first time the language is used: when a word of it is
+// looked up, suggested for or checked, or by Warmup. Concurrent first uses
+// share one load and wait for it. load must not look words of lang up.
+//
+// A failed load is logged and the language stays without a dictionary;
+// Warmup returns the error.
+func (dym *DidYouMean) LoadDictionaryLazily(lang Language, load func() ([]string, error)) {
+	dym.lazyMu.Lock()
+	defer dym.lazyMu.Unlock()
+	if dym.lazy[lang] == nil || dym.lazy[lang].run {
+		dym.lazyPending.Add(1)
+	}
+	dym.lazy[lang] = &lazyLoad{load: load, done: make(chan struct{})}
+}
+
+// ensureLoaded runs the pending lazy load of a language, or waits for it
+// when another goroutine is running it. It must be called without dym.mu held.
+func (dym *DidYouMean) ensureLoaded(lang Language) error {
+	if dym.lazyPending.Load() == 0 {
+		return nil
+	}
+	return dym.runLazy(lang)
+}
+
+// runLazy runs or waits for the lazy load of a language and returns its error
+func (dym *DidYouMean) runLazy(lang Language) error {
+	dym.lazyMu.Lock()
+	l := dym.lazy[lang]
+	if l == nil {
+		dym.lazyMu.Unlock()
+		return nil
+	}
+	if l.run {
+		dym.lazyMu.Unlock()
+		<-l.done
+		return l.err
+	}
+	l.run = true
+	dym.lazyMu.Unlock()
+
+	words, err := l.load()
+	if err == nil {
+		dym.AddWordsForLanguage(words, lang)
+	} else {
+		err = fmt.Errorf("lazy load of %s: %w", lang, err)
+		dym.logger.Error("lazy dictionary load failed", "language", lang, "error", err)
+	}
+	l.err = err
+	dym.lazyPending.Add(-1)
+	close(l.done)
+	return err
+}
+
+// isLazy reports whether a language has a lazy load that has not finished
+func (dym *DidYouMean) isLazy(lang Language) bool {
+	if dym.lazyPending.Load() == 0 {
+		return false
+	}
+	dym.lazyMu.Lock()
+	l := dym.lazy[lang]
+	dym.lazyMu.Unlock()
+	if l == nil {
+		return false
+	}
+	select {
+	case <-l.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// LazyLanguages returns the languages whose lazy loads have not finished, in
+// sorted order
+func (dym *DidYouMean) LazyLanguages() []Language {
+	dym.lazyMu.Lock()
+	langs := make([]Language, 0, len(dym.lazy))
+	for lang := range dym.lazy {
+		langs = append(langs, lang)
+	}
+	dym.lazyMu.Unlock()
+
+	pending := langs[:0]
+	for _, lang := range langs {
+		if dym.isLazy(lang) {
+			pending = append(pending, lang)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i] < pending[j] })
+	return pending
+}
+
+// Warmup runs the lazy loads of the given languages, or of every lazy
+// language when none are given, in parallel, so the first requests do not
+// pay for them. It returns the joined load errors, or the context error when
+// ctx is done first; the loads then finish in the background.
+func (dym *DidYouMean) Warmup(ctx context.Context, langs ...Language) error {
+	if len(langs) == 0 {
+		langs = dym.LazyLanguages()
+	}
+
+	errs := make(chan error, len(langs))
+	for _, lang := range langs {
+		go func(lang Language) {
+			errs <- dym.runLazy(lang)
+		}(lang)
+	}
+
+	var failed []error
+	for range langs {
+		select {
+		case err := <-errs:
+			if err != nil {
+				failed = append(failed, err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return errors.Join(failed...)
+}
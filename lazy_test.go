@@ -0,0 +1,91 @@
+package dymean_test
+
+import (
+	"context"
+	"errors"
+	"github.com/bi0dread/dymean"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TestLoadDictionaryLazily tests that a lazy dictionary loads once, on first use
+func TestLoadDictionaryLazily(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	var loads atomic.Int32
+	dym.LoadDictionaryLazily(dymean.French, func() ([]string, error) {
+		loads.Add(1)
+		return []string{"bonjour", "maison"}, nil
+	})
+
+	if loads.Load() != 0 || dym.GetWordCount(dymean.French) != 0 {
+		t.Fatal("Expected nothing to load before first use")
+	}
+	if langs := dym.LazyLanguages(); len(langs) != 1 || langs[0] != dymean.French {
+		t.Errorf("Expected French to be pending, got %v", langs)
+	}
+	if err := dym.Ready(); err != nil {
+		t.Errorf("Expected a lazy language to count as ready, got %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !dym.IsCorrectForLanguage("bonjour", dymean.French) {
+				t.Error("Expected concurrent first uses to wait for the load")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if loads.Load() != 1 {
+		t.Errorf("Expected one load, got %d", loads.Load())
+	}
+	if suggestions := dym.GetSuggestionsForLanguage("maisn", 1, 2, dymean.French); len(suggestions) != 1 || suggestions[0].Word != "maison" {
+		t.Errorf("Expected maison, got %v", suggestions)
+	}
+	if len(dym.LazyLanguages()) != 0 {
+		t.Errorf("Expected no pending languages, got %v", dym.LazyLanguages())
+	}
+}
+
+// TestWithLazyLanguages tests deferring the default dictionaries and warming them up
+func TestWithLazyLanguages(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithLazyLanguages(dymean.Persian))
+	if err := dym.LoadDefaultDictionary(dymean.English); err != nil {
+		t.Fatal(err)
+	}
+	if err := dym.LoadDefaultDictionary(dymean.Persian); err != nil {
+		t.Fatal(err)
+	}
+	if dym.GetWordCount(dymean.English) == 0 || dym.GetWordCount(dymean.Persian) != 0 {
+		t.Fatalf("Expected only English to be loaded, got %d and %d words",
+			dym.GetWordCount(dymean.English), dym.GetWordCount(dymean.Persian))
+	}
+
+	if err := dym.Warmup(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if dym.GetWordCount(dymean.Persian) == 0 {
+		t.Error("Expected Warmup to load Persian")
+	}
+	if info := dym.DictionaryInfo(dymean.Persian); info.License != "MIT" {
+		t.Errorf("Expected embedded provenance, got %+v", info)
+	}
+}
+
+// TestLazyLoadFailure tests that failed lazy loads are reported by Warmup
+func TestLazyLoadFailure(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	failure := errors.New("bucket unreachable")
+	dym.LoadDictionaryLazily(dymean.German, func() ([]string, error) { return nil, failure })
+
+	if dym.IsCorrectForLanguage("haus", dymean.German) {
+		t.Error("Expected no German words after a failed load")
+	}
+	if err := dym.Warmup(context.Background(), dymean.German); !errors.Is(err, failure) {
+		t.Errorf("Expected the load error, got %v", err)
+	}
+}
@@ -1,46 +1,102 @@
 package dymean
 
+// maxPooledRowLength is the longest DP row kept in the buffer pool; longer
+// inputs, such as a pasted URL, get rows of their own
+const maxPooledRowLength = 1024
+
+// maxExactHamming is the largest Hamming distance between equal-length strings
+// that is also their edit distance: one differing byte needs a substitution
+// and two can't be fixed by fewer edits, but three may be ("abc", "bca")
+const maxExactHamming = 2
+
 // LevenshteinDistance calculates the minimum edit distance between two strings
-// using dynamic programming
+// using dynamic programming. Only the previous and current rows of the table
+// are kept, sized by the shorter string, so memory is O(min(m, n)).
+// Identical strings, a common prefix and suffix, and equal-length strings
+// differing in at most two bytes are resolved without filling the table.
 func LevenshteinDistance(s1, s2 string) int {
-	if len(s1) == 0 {
-		return len(s2)
+	if s1 == s2 {
+		return 0
+	}
+	if len(s1) == len(s2) {
+		if distance, ok := hammingDistance(s1, s2, maxExactHamming); ok {
+			return distance
+		}
+	}
+	s1, s2 = trimCommonAffixes(s1, s2)
+
+	// Iterate over the longer string so the rows follow the shorter one
+	if len(s2) > len(s1) {
+		s1, s2 = s2, s1
 	}
 	if len(s2) == 0 {
 		return len(s1)
 	}
 
-	// Create a 2D slice to store distances
-	matrix := make([][]int, len(s1)+1)
-	for i := range matrix {
-		matrix[i] = make([]int, len(s2)+1)
+	var prev, curr []int
+	if len(s2) < maxPooledRowLength {
+		var buf *[]int
+		buf, prev, curr = getIntRows(len(s2) + 1)
+		defer putIntRows(buf)
+	} else {
+		prev, curr = make([]int, len(s2)+1), make([]int, len(s2)+1)
 	}
 
-	// Initialize first row and column
-	for i := 0; i <= len(s1); i++ {
-		matrix[i][0] = i
-	}
+	// Initialize first row
 	for j := 0; j <= len(s2); j++ {
-		matrix[0][j] = j
+		prev[j] = j
 	}
 
-	// Fill the matrix
+	// Fill the table row by row
 	for i := 1; i <= len(s1); i++ {
+		curr[0] = i
 		for j := 1; j <= len(s2); j++ {
 			cost := 0
 			if s1[i-1] != s2[j-1] {
 				cost = 1
 			}
 
-			matrix[i][j] = min(
-				matrix[i-1][j]+1,      // deletion
-				matrix[i][j-1]+1,      // insertion
-				matrix[i-1][j-1]+cost, // substitution
+			curr[j] = min(
+				prev[j]+1,      // deletion
+				curr[j-1]+1,    // insertion
+				prev[j-1]+cost, // substitution
 			)
 		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(s2)]
+}
+
+// hammingDistance counts the differing bytes of equal-length strings, giving
+// up once there are more than limit
+func hammingDistance(s1, s2 string, limit int) (int, bool) {
+	distance := 0
+	for i := 0; i < len(s1); i++ {
+		if s1[i] != s2[i] {
+			distance++
+			if distance > limit {
+				return 0, false
+			}
+		}
+	}
+	return distance, true
+}
+
+// trimCommonAffixes strips the prefix and suffix two strings share, which
+// never change their edit distance
+func trimCommonAffixes(s1, s2 string) (string, string) {
+	prefix := 0
+	for prefix < len(s1) && prefix < len(s2) && s1[prefix] == s2[prefix] {
+		prefix++
 	}
+	s1, s2 = s1[prefix:], s2[prefix:]
 
-	return matrix[len(s1)][len(s2)]
+	suffix := 0
+	for suffix < len(s1) && suffix < len(s2) && s1[len(s1)-1-suffix] == s2[len(s2)-1-suffix] {
+		suffix++
+	}
+	return s1[:len(s1)-suffix], s2[:len(s2)-suffix]
 }
 
 // min returns the minimum of three integers
@@ -70,6 +126,28 @@ func CalculateSimilarity(s1, s2 string) float64 {
 	return 1.0 - float64(distance)/float64(maxLen)
 }
 
+// withinLengthWindow reports whether two strings are close enough in length
+// to be within maxDistance edits: each edit changes the length by at most one
+// byte, so the difference of their lengths bounds their distance from below
+func withinLengthWindow(s1, s2 string, maxDistance int) bool {
+	diff := len(s1) - len(s2)
+	return diff <= maxDistance && -diff <= maxDistance
+}
+
+// FilterByLengthWindow returns the candidates whose length differs from the
+// word's by at most maxDistance bytes, the only ones whose LevenshteinDistance
+// to it can be at most maxDistance, without computing any distance. Order is
+// kept and candidates is not modified.
+func FilterByLengthWindow(word string, candidates []string, maxDistance int) []string {
+	kept := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		if withinLengthWindow(word, candidate, maxDistance) {
+			kept = append(kept, candidate)
+		}
+	}
+	return kept
+}
+
 // max returns the maximum of two integers
 func max(a, b int) int {
 	if a > b {
@@ -77,3 +155,92 @@ func max(a, b int) int {
 	}
 	return b
 }
+
+// EditOpType identifies the kind of an edit operation
+type EditOpType string
+
+const (
+	EditInsert     EditOpType = "insert"
+	EditDelete     EditOpType = "delete"
+	EditSubstitute EditOpType = "substitute"
+	EditTranspose  EditOpType = "transpose"
+)
+
+// EditOp is a single step of an edit script turning one string into another.
+// Positions are rune indexes, so they can be used to highlight characters in a UI.
+type EditOp struct {
+	Type      EditOpType
+	SourcePos int    // Position in the source string
+	TargetPos int    // Position in the target string
+	From      string // Characters removed from the source ("" for insertions)
+	To        string // Characters added to the target ("" for deletions)
+}
+
+// LevenshteinOps returns an optimal edit script turning s1 into s2. Unlike
+// LevenshteinDistance it works on runes and counts swapping two adjacent
+// characters as a single transposition. Unchanged characters are omitted.
+func LevenshteinOps(s1, s2 string) []EditOp {
+	a, b := []rune(s1), []rune(s2)
+	m, n := len(a), len(b)
+
+	matrix := make([][]int, m+1)
+	for i := range matrix {
+		matrix[i] = make([]int, n+1)
+		matrix[i][0] = i
+	}
+	for j := 0; j <= n; j++ {
+		matrix[0][j] = j
+	}
+
+	for i := 1; i <= m; i++ {
+		for j := 1; j <= n; j++ {
+			cost := 0
+			if a[i-1] != b[j-1] {
+				cost = 1
+			}
+
+			matrix[i][j] = min(
+				matrix[i-1][j]+1,      // deletion
+				matrix[i][j-1]+1,      // insertion
+				matrix[i-1][j-1]+cost, // substitution
+			)
+			if isTransposition(a, b, i, j) && matrix[i-2][j-2]+1 < matrix[i][j] {
+				matrix[i][j] = matrix[i-2][j-2] + 1 // transposition
+			}
+		}
+	}
+
+	// Walk back from the bottom-right corner to recover the operations
+	ops := make([]EditOp, 0, matrix[m][n])
+	i, j := m, n
+	for i > 0 || j > 0 {
+		switch {
+		case i > 0 && j > 0 && a[i-1] == b[j-1] && matrix[i][j] == matrix[i-1][j-1]:
+			i, j = i-1, j-1
+		case isTransposition(a, b, i, j) && matrix[i][j] == matrix[i-2][j-2]+1:
+			ops = append(ops, EditOp{Type: EditTranspose, SourcePos: i - 2, TargetPos: j - 2, From: string(a[i-2 : i]), To: string(b[j-2 : j])})
+			i, j = i-2, j-2
+		case i > 0 && j > 0 && matrix[i][j] == matrix[i-1][j-1]+1:
+			ops = append(ops, EditOp{Type: EditSubstitute, SourcePos: i - 1, TargetPos: j - 1, From: string(a[i-1]), To: string(b[j-1])})
+			i, j = i-1, j-1
+		case i > 0 && matrix[i][j] == matrix[i-1][j]+1:
+			ops = append(ops, EditOp{Type: EditDelete, SourcePos: i - 1, TargetPos: j, From: string(a[i-1])})
+			i--
+		default:
+			ops = append(ops, EditOp{Type: EditInsert, SourcePos: i, TargetPos: j - 1, To: string(b[j-1])})
+			j--
+		}
+	}
+
+	// Operations were collected from the end of the strings
+	for left, right := 0, len(ops)-1; left < right; left, right = left+1, right-1 {
+		ops[left], ops[right] = ops[right], ops[left]
+	}
+
+	return ops
+}
+
+// isTransposition checks if the two runes before a[i] and b[j] are swapped
+func isTransposition(a, b []rune, i, j int) bool {
+	return i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && a[i-1] != a[i-2]
+}
@@ -0,0 +1,80 @@
+package dymean
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Default input limits. Real words are far shorter than DefaultMaxWordLength;
+// longer tokens are pasted identifiers or garbage whose candidate generation
+// would cost seconds of CPU.
+const (
+	DefaultMaxWordLength = 64      // Runes per word
+	DefaultMaxTextLength = 1 << 20 // Bytes per text
+)
+
+// ErrInputTooLong is matched by errors.Is for every *InputTooLongError
+var ErrInputTooLong = errors.New("input too long")
+
+// InputTooLongError reports an input exceeding a configured length limit
+type InputTooLongError struct {
+	Input  string // "word" or "text"
+	Length int    // Length of the input (runes for words, bytes for texts)
+	Limit  int
+}
+
+func (e *InputTooLongError) Error() string {
+	return fmt.Sprintf("%s of length %d exceeds limit of %d", e.Input, e.Length, e.Limit)
+}
+
+// Is makes errors.Is(err, ErrInputTooLong) match
+func (e *InputTooLongError) Is(target error) bool {
+	return target == ErrInputTooLong
+}
+
+// CheckWordLength returns an *InputTooLongError if word exceeds the maximum
+// word length, so callers can reject it instead of getting no suggestions
+func (dym *DidYouMean) CheckWordLength(word string) error {
+	if dym.maxWordLength <= 0 {
+		return nil
+	}
+	if length := utf8.RuneCountInString(word); length > dym.maxWordLength {
+		return &InputTooLongError{Input: "word", Length: length, Limit: dym.maxWordLength}
+	}
+	return nil
+}
+
+// CheckTextLength returns an *InputTooLongError if text exceeds the maximum
+// text length, so callers can reject it instead of having it truncated
+func (dym *DidYouMean) CheckTextLength(text string) error {
+	if dym.maxTextLength > 0 && len(text) > dym.maxTextLength {
+		return &InputTooLongError{Input: "text", Length: len(text), Limit: dym.maxTextLength}
+	}
+	return nil
+}
+
+// truncateText cuts text to the maximum text length, backing off to the last
+// word boundary so no word is checked half-cut
+func (dym *DidYouMean) truncateText(text string) string {
+	if dym.CheckTextLength(text) == nil {
+		return text
+	}
+
+	dym.logger.Warn("truncated overlong text", "length", len(text), "limit", dym.maxTextLength)
+
+	end := dym.maxTextLength
+	for end > 0 && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	if boundary := strings.LastIndexFunc(text[:end], func(r rune) bool { return !isWordRune(r) }); boundary >= 0 {
+		end = boundary
+	}
+	return text[:end]
+}
+
+// isWordTooLong reports whether word exceeds the maximum word length
+func (dym *DidYouMean) isWordTooLong(word string) bool {
+	return dym.CheckWordLength(word) != nil
+}
@@ -0,0 +1,65 @@
+package dymean_test
+
+import (
+	"errors"
+	"github.com/bi0dread/dymean"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMaxWordLength tests that overlong words are rejected quickly
+func TestMaxWordLength(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello", "world"})
+
+	long := strings.Repeat("a", 10000)
+	start := time.Now()
+	if suggestions := dym.GetSuggestions(long, 5, 2); suggestions != nil {
+		t.Errorf("Expected no suggestions for an overlong word, got %d", len(suggestions))
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected overlong word to be rejected quickly, took %v", elapsed)
+	}
+
+	err := dym.CheckWordLength(long)
+	var tooLong *dymean.InputTooLongError
+	if !errors.Is(err, dymean.ErrInputTooLong) || !errors.As(err, &tooLong) {
+		t.Fatalf("Expected InputTooLongError, got %v", err)
+	}
+	if tooLong.Input != "word" || tooLong.Length != 10000 || tooLong.Limit != dymean.DefaultMaxWordLength {
+		t.Errorf("Unexpected error %+v", tooLong)
+	}
+	if err := dym.CheckWordLength("hello"); err != nil {
+		t.Errorf("Expected no error for a short word, got %v", err)
+	}
+
+	// The limit is configurable
+	short := dymean.NewDidYouMean(10000, 7, dymean.WithMaxWordLength(5))
+	short.AddWords([]string{"hello", "world"})
+	if suggestions := short.GetSuggestions("helo", 5, 2); len(suggestions) == 0 {
+		t.Error("Expected suggestions for a word within the limit")
+	}
+	if suggestions := short.GetSuggestions("wroldss", 5, 2); suggestions != nil {
+		t.Errorf("Expected no suggestions above the limit, got %v", suggestions)
+	}
+}
+
+// TestMaxTextLength tests that overlong texts are truncated at a word boundary
+func TestMaxTextLength(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithMaxTextLength(12))
+	dym.AddWords([]string{"hello", "world"})
+
+	corrections := dym.CheckTextForLanguage("helo wrold wrold", dymean.English)
+	if len(corrections) != 2 || corrections[1].Original != "wrold" || corrections[1].End != 10 {
+		t.Errorf("Expected only the first two words to be checked, got %+v", corrections)
+	}
+
+	if corrected := dym.Correct("helo wrold wrold"); corrected != "hello world wrold" {
+		t.Errorf("Expected the truncated remainder to be left unchanged, got %q", corrected)
+	}
+
+	if err := dym.CheckTextLength("helo wrold wrold"); !errors.Is(err, dymean.ErrInputTooLong) {
+		t.Errorf("Expected ErrInputTooLong, got %v", err)
+	}
+}
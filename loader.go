@@ -0,0 +1,44 @@
+package dymean
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LoadDictionaryFromReader adds the words read from r to a language's dictionary.
+// The input has one word per line; empty lines and lines starting with '#' are skipped.
+func (dym *DidYouMean) LoadDictionaryFromReader(r io.Reader, lang Language) error {
+	scanner := bufio.NewScanner(r)
+	words := make([]string, 0)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read dictionary: %w", err)
+	}
+
+	dym.AddWordsForLanguage(words, lang)
+	return nil
+}
+
+// WriteDictionary writes the words of a language's dictionary to w, one per
+// line in sorted order, in the format read by LoadDictionaryFromReader
+func (dym *DidYouMean) WriteDictionary(w io.Writer, lang Language) error {
+	writer := bufio.NewWriter(w)
+	for _, word := range dym.GetDictionaryWords(lang) {
+		if _, err := writer.WriteString(word + "\n"); err != nil {
+			return fmt.Errorf("write dictionary: %w", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("write dictionary: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package dymean
+
+import (
+	"context"
+	"log/slog"
+)
+
+// maxLoggedWords is the number of example words included in load warnings
+const maxLoggedWords = 5
+
+// discardHandler is a slog.Handler that drops every record
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// sample returns up to maxLoggedWords words for log messages
+func sample(words []string) []string {
+	if len(words) > maxLoggedWords {
+		return words[:maxLoggedWords]
+	}
+	return words
+}
@@ -0,0 +1,45 @@
+package dymean_test
+
+import (
+	"bytes"
+	"github.com/bi0dread/dymean"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// TestLogger tests that load warnings reach a configured logger
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithLogger(logger))
+
+	dym.AddWords([]string{"hello", "Hello", "123", "wörld!"})
+	output := buf.String()
+	if !strings.Contains(output, "skipped invalid dictionary words") || !strings.Contains(output, "count=2") {
+		t.Errorf("Expected a warning about 2 invalid words, got %q", output)
+	}
+	if !strings.Contains(output, "dictionary words collide after normalization") {
+		t.Errorf("Expected a warning about colliding words, got %q", output)
+	}
+
+	buf.Reset()
+	dym.GetSuggestions(strings.Repeat("a", 100), 5, 2)
+	if !strings.Contains(buf.String(), "skipped suggestions for overlong word") {
+		t.Errorf("Expected a debug message for an overlong word, got %q", buf.String())
+	}
+}
+
+// TestLoggerSilentByDefault tests that nothing is logged without a logger
+func TestLoggerSilentByDefault(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	defer slog.SetDefault(previous)
+
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello", "123"})
+	if buf.Len() != 0 {
+		t.Errorf("Expected no log output by default, got %q", buf.String())
+	}
+}
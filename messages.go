@@ -0,0 +1,110 @@
+package dymean
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// persianMessages translates the user-facing messages of the package, its
+// command-line tool and its server to Persian
+var persianMessages = map[string]string{
+	// Suggestion explanations
+	"1 character missing":      "یک حرف جا افتاده",
+	"1 extra character":        "یک حرف اضافه",
+	"1 wrong character":        "یک حرف نادرست",
+	"neighboring key pressed":  "کلید کناری زده شده",
+	"adjacent letters swapped": "جای دو حرف کنار هم عوض شده",
+	"accent missing":           "اعراب جا افتاده",
+	"spelling variant":         "شکل دیگر نوشتن",
+	"%d changes":               "%d تغییر",
+	"chosen before":            "پیش‌تر انتخاب شده",
+	"often confused":           "اغلب به جای هم به کار می‌روند",
+
+	// Command-line prompts
+	"[number] replace, r) type replacement, a) add to dictionary, i) ignore, I) ignore all, q) quit: ": "[شماره] جایگزینی، r) نوشتن جایگزین، a) افزودن به واژه‌نامه، i) نادیده گرفتن، I) نادیده گرفتن همه، q) خروج: ",
+	"replacement: ":                "جایگزین: ",
+	"%d corrected, %d added to %s": "%d مورد اصلاح شد، %d واژه به %s افزوده شد",
+
+	// Server errors
+	"missing word parameter":                  "پارامتر word فرستاده نشده",
+	"max must be a positive integer":          "max باید عدد صحیح مثبت باشد",
+	"offset must be a non-negative integer":   "offset باید عدد صحیح نامنفی باشد",
+	"min_freq must be a non-negative integer": "min_freq باید عدد صحیح نامنفی باشد",
+	"use POST":               "از POST استفاده کنید",
+	"request body too large": "بدنهٔ درخواست بیش از اندازه بزرگ است",
+	"invalid gzip body":      "بدنهٔ gzip نامعتبر است",
+	"body must be a JSON object with a words array": "بدنه باید شیء JSON با آرایهٔ words باشد",
+	"no words given":                             "هیچ واژه‌ای فرستاده نشده",
+	"missing bearer token":                       "توکن فرستاده نشده",
+	"invalid token":                              "توکن نامعتبر است",
+	"not allowed to modify dictionaries":         "اجازهٔ تغییر واژه‌نامه‌ها را ندارید",
+	"rate limit exceeded":                        "از سقف شمار درخواست‌ها گذشته‌اید",
+	"too many concurrent requests":               "درخواست‌های هم‌زمان بیش از اندازه است",
+	"completions must be a non-negative integer": "completions باید عدد صحیح نامنفی باشد",
+	"invalid stream message":                     "پیام نامعتبر در جریان",
+	"unknown operation":                          "عملیات ناشناخته",
+	"invalid edit range":                         "بازهٔ ویرایش نامعتبر است",
+	"text too large":                             "متن بیش از اندازه بزرگ است",
+	"stream line too long":                       "خط جریان بیش از اندازه بلند است",
+}
+
+// catalog holds the translations of user-facing messages per language, keyed
+// by their English text
+var (
+	catalogMu sync.RWMutex
+	catalog   = map[Language]map[string]string{
+		Persian: persianMessages,
+	}
+)
+
+// RegisterMessages adds or replaces translations of user-facing messages for
+// a language. Messages are keyed by their English text, as returned without a
+// translation, including any fmt verbs ("%d changes"); translations must use
+// the same verbs, indexed ("%[2]d") when they change their order. The built-in
+// translations cover Persian.
+func RegisterMessages(lang Language, translations map[string]string) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+
+	messages := make(map[string]string, len(catalog[lang])+len(translations))
+	for message, translation := range catalog[lang] {
+		messages[message] = translation
+	}
+	for message, translation := range translations {
+		messages[message] = translation
+	}
+	catalog[lang] = messages
+}
+
+// Translate returns a user-facing message in a language, formatted with args
+// as by fmt.Sprintf when there are any. Messages without a translation for
+// the language are returned in English.
+func Translate(lang Language, message string, args ...any) string {
+	catalogMu.RLock()
+	if translation, ok := catalog[lang][message]; ok {
+		message = translation
+	}
+	catalogMu.RUnlock()
+
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// ParseLocale returns the language of a locale such as "fa_IR.UTF-8" (from
+// LANG or LC_MESSAGES) or "fa-IR" (from Accept-Language), and whether it is
+// a supported language. Only the primary subtag is used.
+func ParseLocale(locale string) (Language, bool) {
+	locale = strings.TrimSpace(locale)
+	if i := strings.IndexAny(locale, "_-.@;"); i >= 0 {
+		locale = locale[:i]
+	}
+	lang := Language(strings.ToLower(locale))
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	_, ok := registry[lang]
+	return lang, ok
+}
@@ -0,0 +1,53 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestTranslate tests translating messages with the built-in and registered catalogs
+func TestTranslate(t *testing.T) {
+	if message := dymean.Translate(dymean.English, "%d changes", 3); message != "3 changes" {
+		t.Errorf("Expected the English message, got %q", message)
+	}
+	if message := dymean.Translate(dymean.Persian, "%d changes", 3); message != "3 تغییر" {
+		t.Errorf("Expected the Persian message, got %q", message)
+	}
+	if message := dymean.Translate(dymean.Persian, "no such message"); message != "no such message" {
+		t.Errorf("Expected untranslated messages in English, got %q", message)
+	}
+
+	dymean.RegisterMessages(dymean.French, map[string]string{"%d corrected, %d added to %s": "%[3]s : %[2]d ajoutés, %[1]d corrigés"})
+	if message := dymean.Translate(dymean.French, "%d corrected, %d added to %s", 1, 2, "mots.txt"); message != "mots.txt : 2 ajoutés, 1 corrigés" {
+		t.Errorf("Expected reordered arguments, got %q", message)
+	}
+	dymean.RegisterMessages(dymean.French, map[string]string{"accent missing": "accent manquant"})
+	dym := dymean.NewDidYouMean(10000, 7)
+	if _, message := dym.ExplainSuggestion("cafe", "café", dymean.French); message != "accent manquant" {
+		t.Errorf("Expected a registered explanation, got %q", message)
+	}
+	if message := dymean.Translate(dymean.French, "%d corrected, %d added to %s", 1, 2, "mots.txt"); message != "mots.txt : 2 ajoutés, 1 corrigés" {
+		t.Errorf("Expected registering to keep earlier translations, got %q", message)
+	}
+}
+
+// TestParseLocale tests finding the language of locale names
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		locale string
+		lang   dymean.Language
+		ok     bool
+	}{
+		{"fa_IR.UTF-8", dymean.Persian, true},
+		{"fa-IR", dymean.Persian, true},
+		{" en;q=0.8", dymean.English, true},
+		{"DE", dymean.German, true},
+		{"C.UTF-8", "c", false},
+		{"", "", false},
+	}
+	for _, test := range tests {
+		if lang, ok := dymean.ParseLocale(test.locale); lang != test.lang || ok != test.ok {
+			t.Errorf("ParseLocale(%q) = %q, %v, expected %q, %v", test.locale, lang, ok, test.lang, test.ok)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package dymean
+
+import "time"
+
+// DictionaryMetadata records where a dictionary's word list came from, so
+// the word lists shipped with an application can be audited
+type DictionaryMetadata struct {
+	Language  Language
+	Source    string    // Where the word list came from, such as a URL or project name
+	License   string    // License of the word list, such as an SPDX identifier
+	Version   string    // Version of the word list
+	WordCount int       // Words in the dictionary
+	BuildDate time.Time // When the word list was compiled, zero when unknown
+}
+
+// embeddedMetadata describes the word lists returned by GetWordsForLanguage
+func embeddedMetadata(lang Language) DictionaryMetadata {
+	return DictionaryMetadata{
+		Language: lang,
+		Source:   "dymean embedded word list",
+		License:  "MIT",
+		Version:  embeddedDictionaryVersion,
+	}
+}
+
+// SetDictionaryMetadata records the provenance of a language's dictionary,
+// for word lists loaded with AddWordsForLanguage or LoadDictionaryFromReader.
+// LoadDefaultDictionary and LoadCompiled record it themselves. The metadata
+// of the most recent load replaces earlier metadata; Language and WordCount
+// are filled in by DictionaryInfo.
+func (dym *DidYouMean) SetDictionaryMetadata(lang Language, metadata DictionaryMetadata) {
+	dym.mu.Lock()
+	defer dym.mu.Unlock()
+	dym.metadata[lang] = metadata
+}
+
+// DictionaryInfo returns the provenance of a language's dictionary with its
+// current word count. Source, License and Version are empty when no
+// metadata was recorded.
+func (dym *DidYouMean) DictionaryInfo(lang Language) DictionaryMetadata {
+	dym.mu.RLock()
+	metadata := dym.metadata[lang]
+	dym.mu.RUnlock()
+
+	metadata.Language = lang
+	metadata.WordCount = dym.GetWordCount(lang)
+	return metadata
+}
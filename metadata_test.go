@@ -0,0 +1,96 @@
+package dymean_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/bi0dread/dymean"
+	"hash/crc32"
+	"testing"
+	"time"
+)
+
+// TestDictionaryInfo tests the metadata of default and custom dictionaries
+func TestDictionaryInfo(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.LoadDefaultDictionary(dymean.English)
+
+	info := dym.DictionaryInfo(dymean.English)
+	if info.Language != dymean.English || info.License != "MIT" || info.Source == "" || info.Version == "" {
+		t.Errorf("Expected embedded provenance, got %+v", info)
+	}
+	if info.WordCount != dym.GetWordCount(dymean.English) {
+		t.Errorf("Expected the current word count %d, got %d", dym.GetWordCount(dymean.English), info.WordCount)
+	}
+
+	dym.AddWordsForLanguage([]string{"bonjour"}, dymean.French)
+	if info := dym.DictionaryInfo(dymean.French); info.Source != "" || info.WordCount != 1 {
+		t.Errorf("Expected no provenance for French, got %+v", info)
+	}
+	dym.SetDictionaryMetadata(dymean.French, dymean.DictionaryMetadata{Source: "lexique.org", License: "CC-BY-SA-4.0", Version: "3.83"})
+	if info := dym.DictionaryInfo(dymean.French); info.License != "CC-BY-SA-4.0" || info.Language != dymean.French {
+		t.Errorf("Expected the recorded provenance, got %+v", info)
+	}
+}
+
+// TestCompiledMetadata tests that metadata travels with compiled dictionaries
+func TestCompiledMetadata(t *testing.T) {
+	built := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	src := dymean.NewDidYouMean(10000, 7)
+	src.AddWordsForLanguage([]string{"bonjour", "maison"}, dymean.French)
+	src.SetDictionaryMetadata(dymean.French, dymean.DictionaryMetadata{
+		Source: "lexique.org", License: "CC-BY-SA-4.0", Version: "3.83", BuildDate: built,
+	})
+
+	var buf bytes.Buffer
+	if err := src.WriteCompiled(&buf, dymean.French, true); err != nil {
+		t.Fatalf("Failed to write compiled dictionary: %v", err)
+	}
+
+	dst := dymean.NewDidYouMean(10000, 7)
+	header, err := dst.LoadCompiled(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Failed to load compiled dictionary: %v", err)
+	}
+	expected := dymean.DictionaryMetadata{
+		Language: dymean.French, Source: "lexique.org", License: "CC-BY-SA-4.0", Version: "3.83", WordCount: 2, BuildDate: built,
+	}
+	if header.Metadata != expected {
+		t.Errorf("Expected header metadata %+v, got %+v", expected, header.Metadata)
+	}
+	if info := dst.DictionaryInfo(dymean.French); info != expected {
+		t.Errorf("Expected loaded metadata %+v, got %+v", expected, info)
+	}
+
+	// Without a recorded build date the write time is used
+	src.SetDictionaryMetadata(dymean.French, dymean.DictionaryMetadata{Source: "lexique.org"})
+	buf.Reset()
+	src.WriteCompiled(&buf, dymean.French, false)
+	header, _ = dymean.ValidateCompiled(bytes.NewReader(buf.Bytes()))
+	if time.Since(header.Metadata.BuildDate) > time.Minute {
+		t.Errorf("Expected the build date to be now, got %v", header.Metadata.BuildDate)
+	}
+}
+
+// TestCompiledVersion1 tests that artifacts without metadata still load
+func TestCompiledVersion1(t *testing.T) {
+	payload := []byte("hello\nworld")
+	var buf bytes.Buffer
+	buf.WriteString("DYMC")
+	binary.Write(&buf, binary.BigEndian, uint16(1))
+	buf.WriteByte(0)
+	buf.WriteByte(2)
+	buf.WriteString("en")
+	binary.Write(&buf, binary.BigEndian, uint32(2))
+	binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(payload))
+	binary.Write(&buf, binary.BigEndian, uint64(len(payload)))
+	buf.Write(payload)
+
+	dym := dymean.NewDidYouMean(10000, 7)
+	header, err := dym.LoadCompiled(&buf)
+	if err != nil {
+		t.Fatalf("Failed to load a version 1 artifact: %v", err)
+	}
+	if header.Version != 1 || header.Metadata.Source != "" || !dym.IsCorrect("world") {
+		t.Errorf("Unexpected version 1 header %+v", header)
+	}
+}
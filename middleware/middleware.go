@@ -0,0 +1,98 @@
+// Package middleware provides net/http helpers that add "did you mean"
+// corrections to query and form parameters.
+package middleware
+
+import (
+	"context"
+	"github.com/bi0dread/dymean"
+	"net/http"
+)
+
+// Config configures which parameters are corrected and how
+type Config struct {
+	// Params lists the query or form parameters to spell check, e.g. "q"
+	Params []string
+
+	// Redirect redirects GET requests to the corrected URL when a query
+	// parameter was rewritten, instead of passing the rewrites to the handler
+	Redirect bool
+
+	// SkipParam disables correction when present in the query, so users can
+	// insist on their original input. Defaults to "nocorrect".
+	SkipParam string
+}
+
+// Rewrite describes a parameter value that was corrected
+type Rewrite struct {
+	Param     string
+	Original  string
+	Corrected string
+}
+
+// contextKey is the type of the context key holding rewrites
+type contextKey struct{}
+
+// Rewrites returns the rewrites attached to a request context by the middleware
+func Rewrites(ctx context.Context) []Rewrite {
+	rewrites, _ := ctx.Value(contextKey{}).([]Rewrite)
+	return rewrites
+}
+
+// New returns a middleware that spell checks the configured parameters and
+// attaches the rewrites to the request context, optionally redirecting
+func New(dym *dymean.DidYouMean, cfg Config) func(http.Handler) http.Handler {
+	if cfg.SkipParam == "" {
+		cfg.SkipParam = "nocorrect"
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			if query.Has(cfg.SkipParam) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rewrites := CorrectRequest(dym, r, cfg.Params)
+			if len(rewrites) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if cfg.Redirect && r.Method == http.MethodGet {
+				for _, rewrite := range rewrites {
+					query.Set(rewrite.Param, rewrite.Corrected)
+				}
+				target := *r.URL
+				target.RawQuery = query.Encode()
+				http.Redirect(w, r, target.String(), http.StatusFound)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), contextKey{}, rewrites)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// CorrectRequest spell checks the given query or form parameters of a request
+// and returns the values that would be rewritten
+func CorrectRequest(dym *dymean.DidYouMean, r *http.Request, params []string) []Rewrite {
+	rewrites := make([]Rewrite, 0)
+
+	for _, param := range params {
+		value := r.FormValue(param)
+		if value == "" {
+			continue
+		}
+		if corrected := dym.Correct(value); corrected != value {
+			rewrites = append(rewrites, Rewrite{
+				Param:     param,
+				Original:  value,
+				Corrected: corrected,
+			})
+		}
+	}
+
+	return rewrites
+}
@@ -0,0 +1,65 @@
+package middleware_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"github.com/bi0dread/dymean/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newChecker creates a checker with the default English dictionary
+func newChecker() *dymean.DidYouMean {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.LoadDefaultDictionary(dymean.English)
+	return dym
+}
+
+// TestMiddlewareRewrites tests that rewrites are attached to the request context
+func TestMiddlewareRewrites(t *testing.T) {
+	var rewrites []middleware.Rewrite
+	handler := middleware.New(newChecker(), middleware.Config{Params: []string{"q"}})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rewrites = middleware.Rewrites(r.Context())
+		}),
+	)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/search?q=Helo+world", nil))
+
+	if len(rewrites) != 1 {
+		t.Fatalf("Expected 1 rewrite, got %v", rewrites)
+	}
+	if rewrites[0].Param != "q" || rewrites[0].Original != "Helo world" || rewrites[0].Corrected != "Hello world" {
+		t.Errorf("Unexpected rewrite %+v", rewrites[0])
+	}
+}
+
+// TestMiddlewareRedirect tests redirecting to the corrected URL
+func TestMiddlewareRedirect(t *testing.T) {
+	called := false
+	handler := middleware.New(newChecker(), middleware.Config{Params: []string{"q"}, Redirect: true})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}),
+	)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/search?q=helo&page=2", nil))
+
+	if recorder.Code != http.StatusFound {
+		t.Fatalf("Expected status %d, got %d", http.StatusFound, recorder.Code)
+	}
+	if location := recorder.Header().Get("Location"); location != "/search?page=2&q=hello" {
+		t.Errorf("Unexpected redirect location '%s'", location)
+	}
+	if called {
+		t.Error("Expected the handler not to be called when redirecting")
+	}
+
+	// The skip parameter keeps the original input
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/search?q=helo&nocorrect=1", nil))
+	if recorder.Code != http.StatusOK || !called {
+		t.Errorf("Expected the handler to be called with the skip parameter, got status %d", recorder.Code)
+	}
+}
@@ -0,0 +1,117 @@
+package dymean
+
+import (
+	"context"
+	"sort"
+)
+
+// NeighborIndex holds the words of a dictionary within a small edit distance
+// of each other, as adjacency lists packed into flat arrays: the neighbors of
+// the word numbered i are targets[offsets[i]:offsets[i+1]].
+type NeighborIndex struct {
+	maxDistance int
+	words       []string          // Words in sorted order
+	ids         map[string]uint32 // Number of each word
+	offsets     []uint32
+	targets     []uint32
+	distances   []uint8 // Edit distance in runes of each target
+}
+
+// BuildNeighborIndex precomputes the neighbors of every word of a language's
+// dictionary within maxDistance edits, clamped to 1 or 2, and returns the
+// index. Until the dictionary changes, suggestions for inputs that are
+// dictionary words, the alternatives of real-word errors and autocomplete
+// with SetIncludeAlternativesForCorrectWords, are then looked up instead of
+// searched for. Building searches the dictionary once per word, so it belongs
+// to startup.
+func (dym *DidYouMean) BuildNeighborIndex(lang Language, maxDistance int) *NeighborIndex {
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+	if maxDistance > 2 {
+		maxDistance = 2
+	}
+
+	trie := dym.trie(lang)
+	dym.mu.RLock()
+	words := dym.dictionaryWords(lang)
+	dym.mu.RUnlock()
+	sort.Strings(words)
+
+	index := &NeighborIndex{
+		maxDistance: maxDistance,
+		words:       words,
+		ids:         make(map[string]uint32, len(words)),
+		offsets:     make([]uint32, 1, len(words)+1),
+	}
+	for i, word := range words {
+		index.ids[word] = uint32(i)
+	}
+	for _, word := range words {
+		matches := trie.Search(word, maxDistance)
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].Distance < matches[j].Distance })
+		for _, match := range matches {
+			if id, ok := index.ids[match.Word]; ok && match.Word != word {
+				index.targets = append(index.targets, id)
+				index.distances = append(index.distances, uint8(match.Distance))
+			}
+		}
+		index.offsets = append(index.offsets, uint32(len(index.targets)))
+	}
+
+	// The index is only kept if the dictionary did not change meanwhile, which
+	// would have dropped the trie
+	dym.mu.Lock()
+	if dym.tries[lang] == trie {
+		dym.neighbors[lang] = index
+	}
+	dym.mu.Unlock()
+	return index
+}
+
+// Len returns the number of words in the index
+func (n *NeighborIndex) Len() int {
+	return len(n.words)
+}
+
+// MaxDistance returns the edit distance the index was built for
+func (n *NeighborIndex) MaxDistance() int {
+	return n.maxDistance
+}
+
+// Neighbors returns the words within the index's distance of a word, closest
+// first and then in sorted order, or nil for a word not in the index
+func (n *NeighborIndex) Neighbors(word string) []TrieMatch {
+	id, ok := n.ids[word]
+	if !ok {
+		return nil
+	}
+	start, end := n.offsets[id], n.offsets[id+1]
+	neighbors := make([]TrieMatch, 0, end-start)
+	for i := start; i < end; i++ {
+		neighbors = append(neighbors, TrieMatch{Word: n.words[n.targets[i]], Distance: int(n.distances[i])})
+	}
+	return neighbors
+}
+
+// indexedCandidates returns the neighbors of a dictionary word within
+// maxEditDistance from the language's neighbor index, and false when there is
+// no index covering the distance
+func (dym *DidYouMean) indexedCandidates(ctx context.Context, normalized string, lang Language, maxEditDistance int, pruning *CandidatePruning) ([]string, bool) {
+	dym.mu.RLock()
+	index := dym.neighbors[lang]
+	dym.mu.RUnlock()
+	if index == nil || maxEditDistance > index.maxDistance {
+		return nil, false
+	}
+	defer dym.startStage(ctx, StageGenerate, lang, normalized)()
+
+	candidates := make([]string, 0)
+	for _, neighbor := range index.Neighbors(normalized) {
+		if neighbor.Distance <= maxEditDistance && (pruning == nil || pruning.keeps(normalized, neighbor.Word)) {
+			candidates = append(candidates, neighbor.Word)
+		}
+	}
+	sort.Strings(candidates)
+	return candidates, true
+}
@@ -0,0 +1,45 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"testing"
+)
+
+// TestNeighborIndex tests precomputing the neighbors of dictionary words
+func TestNeighborIndex(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"cat", "cart", "cap", "cut", "dog", "carts"})
+
+	index := dym.BuildNeighborIndex(dymean.English, 5)
+	if index.Len() != 6 || index.MaxDistance() != 2 {
+		t.Fatalf("Expected 6 words within 2 edits, got %d within %d", index.Len(), index.MaxDistance())
+	}
+	want := []dymean.TrieMatch{{Word: "cap", Distance: 1}, {Word: "cart", Distance: 1}, {Word: "cut", Distance: 1}, {Word: "carts", Distance: 2}}
+	if got := index.Neighbors("cat"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if got := index.Neighbors("dog"); len(got) != 0 {
+		t.Errorf("Expected no neighbors of dog, got %v", got)
+	}
+	if index.Neighbors("cow") != nil {
+		t.Error("Expected nil for a word not in the index")
+	}
+
+	// Alternatives of correct words come from the index
+	dym.SetIncludeAlternativesForCorrectWords(true)
+	suggestions := dym.GetSuggestions("cat", 10, 1)
+	words := make([]string, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		words = append(words, suggestion.Word)
+	}
+	if len(words) != 4 || words[0] != "cat" {
+		t.Errorf("Expected cat and its 3 neighbors, got %v", words)
+	}
+
+	// Changing the dictionary drops the index, and suggestions are searched
+	dym.AddWords([]string{"bat"})
+	if suggestions := dym.GetSuggestions("cat", 10, 1); !containsWord(suggestions, "bat") {
+		t.Errorf("Expected bat after the dictionary changed, got %v", suggestions)
+	}
+}
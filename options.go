@@ -0,0 +1,163 @@
+package dymean
+
+import (
+	"log/slog"
+)
+
+// Option configures a DidYouMean instance at construction time
+type Option func(*DidYouMean)
+
+// WithCompactStorage stores the dictionaries of the given languages as a
+// front-coded sorted array instead of a map. This uses several times less
+// memory at the cost of slightly slower lookups, and every call that adds or
+// removes words rebuilds the language's dictionary, so it suits read-mostly
+// deployments that load their dictionaries in a few large batches, or that
+// add batches under DeferCompaction.
+func WithCompactStorage(langs ...Language) Option {
+	return func(dym *DidYouMean) {
+		for _, lang := range langs {
+			dym.compactLangs[lang] = true
+		}
+	}
+}
+
+// WithMaxConcurrency caps the number of goroutines a single CheckText or batch
+// call uses. By default calls use one worker per CPU as reported by
+// runtime.GOMAXPROCS at call time, which follows container CPU limits when
+// GOMAXPROCS is set for them. A value of 1 disables parallelism.
+func WithMaxConcurrency(n int) Option {
+	return func(dym *DidYouMean) {
+		dym.maxConcurrency = n
+	}
+}
+
+// WithMaxWordLength sets the longest word, in runes, for which suggestions are
+// generated. Longer words get no suggestions and are skipped by CheckText.
+// A value of 0 or less removes the limit.
+func WithMaxWordLength(n int) Option {
+	return func(dym *DidYouMean) {
+		dym.maxWordLength = n
+	}
+}
+
+// WithMaxTextLength sets the longest text, in bytes, checked by CheckText.
+// Longer texts are truncated at the last word boundary before the limit and
+// only that part is checked. A value of 0 or less removes the limit.
+func WithMaxTextLength(n int) Option {
+	return func(dym *DidYouMean) {
+		dym.maxTextLength = n
+	}
+}
+
+// WithSuggestionTuning overrides the suggestion defaults of every language,
+// used by CheckText, Suggest, CheckAndSuggest and the server. Zero fields keep
+// each language's own SuggestionTuning.
+func WithSuggestionTuning(tuning SuggestionTuning) Option {
+	return func(dym *DidYouMean) {
+		dym.tuning = tuning
+	}
+}
+
+// SuggestionTuning returns the suggestion defaults of a language: its
+// SuggestionTuning, overridden by WithSuggestionTuning
+func (dym *DidYouMean) SuggestionTuning(lang Language) SuggestionTuning {
+	tuning := GetLanguageInfo(lang).SuggestionTuning
+	if dym.tuning.MaxSuggestions > 0 {
+		tuning.MaxSuggestions = dym.tuning.MaxSuggestions
+	}
+	if dym.tuning.MaxEditDistance > 0 {
+		tuning.MaxEditDistance = dym.tuning.MaxEditDistance
+	}
+	return tuning
+}
+
+// WithValidationMode sets which characters words may contain when they are
+// added or looked up. ValidationLenient accepts words with letters outside the
+// built-in alphabets, such as loanwords and rare letter forms, as long as they
+// belong to the language's script.
+func WithValidationMode(mode ValidationMode) Option {
+	return func(dym *DidYouMean) {
+		dym.validationMode = mode
+	}
+}
+
+// WithAutomatonSearch finds suggestion candidates by walking a trie of each
+// dictionary with a Levenshtein automaton instead of generating every edit of
+// the word. The search finds all words within the edit distance, counted in
+// runes, and is much faster for distance 2 and non-Latin scripts on large
+// dictionaries. The trie is rebuilt on the first lookup after words are added
+// or removed.
+func WithAutomatonSearch() Option {
+	return func(dym *DidYouMean) {
+		dym.automatonSearch = true
+	}
+}
+
+// WithoutEmbeddedDictionaries makes LoadDefaultDictionary return a
+// *NoDefaultDictionaryError instead of loading the built-in word lists, for
+// applications that must only ever serve their own data. Build with the
+// dymean_nodict tag to also leave the lists out of the binary.
+func WithoutEmbeddedDictionaries() Option {
+	return func(dym *DidYouMean) {
+		dym.withoutEmbedded = true
+	}
+}
+
+// WithRequiredLanguages makes Ready report the instance as not ready until
+// dictionaries for all the given languages are loaded
+func WithRequiredLanguages(langs ...Language) Option {
+	return func(dym *DidYouMean) {
+		dym.requiredLangs = append(dym.requiredLangs, langs...)
+	}
+}
+
+// WithLazyLanguages defers LoadDefaultDictionary for the given languages
+// until each is first used, as LoadDictionaryLazily does. Startup stays fast
+// for deployments that configure many languages but mostly serve one; the
+// first request in another language pays for its load, unless Warmup ran.
+func WithLazyLanguages(langs ...Language) Option {
+	return func(dym *DidYouMean) {
+		for _, lang := range langs {
+			dym.lazyLangs[lang] = true
+		}
+	}
+}
+
+// WithLogger makes the instance log load warnings (invalid words skipped,
+// normalization collisions), degraded lookups (overlong input), failed
+// background loads and compactions. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(dym *DidYouMean) {
+		if logger != nil {
+			dym.logger = logger
+		}
+	}
+}
+
+// WithDigitPolicy sets how digits in words are normalized. The policy applies
+// to lookups, dictionary changes, learned corrections and text checks alike;
+// text tokens that still contain digits after normalization are not checked.
+func WithDigitPolicy(policy DigitPolicy) Option {
+	return func(dym *DidYouMean) {
+		dym.digitPolicy = policy
+	}
+}
+
+// WithRandomSeed seeds the random choices of the instance, such as the words
+// sampled by ValidateDictionary, so they can be varied between runs while
+// staying reproducible. Instances with the same seed and dictionaries make
+// the same choices. The default seed is 1.
+func WithRandomSeed(seed int64) Option {
+	return func(dym *DidYouMean) {
+		dym.seed = seed
+	}
+}
+
+// WithSimilarityCache scores suggestions through a shared cache of word-pair
+// similarities, so words misspelled again and again are not scored again.
+// One cache may be shared by several instances.
+func WithSimilarityCache(cache *SimilarityCache) Option {
+	return func(dym *DidYouMean) {
+		dym.similarityCache = cache
+	}
+}
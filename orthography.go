@@ -0,0 +1,110 @@
+package dymean
+
+import (
+	"sort"
+	"strings"
+)
+
+// arabicEquivalences are the letters informal Arabic spells interchangeably:
+// alef with or without hamza or madda, final ya and alef maqsura (and ya with
+// hamza), ta marbuta and ha, and waw with or without hamza
+var arabicEquivalences = []string{"اأإآٱ", "يىئ", "هة", "وؤ"}
+
+// equivalenceWeight scales the similarity of words compared with equivalent
+// letters folded, so a variant spelling ranks first among the suggestions
+// but below a correct word
+const equivalenceWeight = 0.99
+
+// equivalenceTable maps each letter of the classes to the first letter of
+// its class
+func equivalenceTable(classes []string) map[rune]rune {
+	table := make(map[rune]rune)
+	for _, class := range classes {
+		runes := []rune(class)
+		for _, r := range runes {
+			table[r] = runes[0]
+		}
+	}
+	return table
+}
+
+// foldEquivalences writes a word with the first letter of each class
+func foldEquivalences(word string, table map[rune]rune) string {
+	return strings.Map(func(r rune) rune {
+		if folded, ok := table[r]; ok {
+			return folded
+		}
+		return r
+	}, word)
+}
+
+// equivalenceSimilarity scores a candidate with the equivalent letters of
+// its language folded, scaled by equivalenceWeight. Vietnamese words spelled
+// alike up to diacritics are told apart by VietnameseMarkDistance, so the
+// marks a user did type pick among "trường", "trưởng" and "trương".
+func equivalenceSimilarity(normalized, candidate string, lang Language, table map[rune]rune, memo *similarityMemo) float64 {
+	a, b := foldEquivalences(normalized, table), foldEquivalences(candidate, table)
+	if lang == Vietnamese && a == b {
+		return equivalenceWeight * vietnameseSimilarity(normalized, candidate)
+	}
+	return equivalenceWeight * memo.similarity(a, b)
+}
+
+// equivalenceIndex returns the words of a language's dictionary by their
+// folded forms, built on demand like the search trie
+func (dym *DidYouMean) equivalenceIndex(lang Language) map[string][]string {
+	dym.mu.RLock()
+	index := dym.equivalences[lang]
+	dym.mu.RUnlock()
+	if index != nil {
+		return index
+	}
+
+	dym.mu.Lock()
+	defer dym.mu.Unlock()
+	if index := dym.equivalences[lang]; index != nil {
+		return index
+	}
+	table := equivalenceTable(GetLanguageInfo(lang).Equivalences)
+	index = make(map[string][]string)
+	for _, word := range dym.dictionaryWords(lang) {
+		folded := foldEquivalences(word, table)
+		index[folded] = append(index[folded], word)
+	}
+	dym.equivalences[lang] = index
+	return index
+}
+
+// OrthographicVariants returns the dictionary words spelled like a word up to
+// the equivalent letters of its language (LanguageInfo.Equivalences), such as
+// "إلى" for "الى" in Arabic, in sorted order. The word itself is included
+// when it is in the dictionary.
+func (dym *DidYouMean) OrthographicVariants(word string, lang Language) []string {
+	classes := GetLanguageInfo(lang).Equivalences
+	if len(classes) == 0 || !dym.hasDictionary(lang) {
+		return nil
+	}
+	folded := foldEquivalences(dym.normalize(word, lang), equivalenceTable(classes))
+	variants := append([]string(nil), dym.equivalenceIndex(lang)[folded]...)
+	sort.Strings(variants)
+	return variants
+}
+
+// withEquivalentCandidates adds the orthographic variants of a word to its
+// candidates
+func (dym *DidYouMean) withEquivalentCandidates(normalized string, lang Language, candidates []string) []string {
+	variants := dym.OrthographicVariants(normalized, lang)
+	if len(variants) == 0 {
+		return candidates
+	}
+	seen := make(map[string]bool, len(candidates))
+	for _, candidate := range candidates {
+		seen[candidate] = true
+	}
+	for _, variant := range variants {
+		if !seen[variant] && variant != normalized {
+			candidates = append(candidates, variant)
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,51 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"testing"
+)
+
+// TestArabicOrthographicVariants tests finding standard entries for informal spellings
+func TestArabicOrthographicVariants(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWordsForLanguage([]string{"إلى", "أحمد", "مدرسة", "مسؤول", "على"}, dymean.Arabic)
+
+	tests := []struct {
+		informal string
+		standard string
+	}{
+		{"الى", "إلى"},   // Hamza and alef maqsura
+		{"احمد", "أحمد"}, // Missing hamza
+		{"مدرسه", "مدرسة"},
+		{"مسوول", "مسؤول"},
+		{"علي", "على"},
+	}
+	for _, tc := range tests {
+		if variants := dym.OrthographicVariants(tc.informal, dymean.Arabic); !reflect.DeepEqual(variants, []string{tc.standard}) {
+			t.Errorf("OrthographicVariants(%q) = %v, expected %q", tc.informal, variants, tc.standard)
+		}
+		suggestions := dym.GetSuggestionsForLanguage(tc.informal, 5, 1, dymean.Arabic)
+		if len(suggestions) == 0 || suggestions[0].Word != tc.standard {
+			t.Errorf("Expected %q first for %q, got %v", tc.standard, tc.informal, suggestions)
+		}
+	}
+
+	// Dictionary changes rebuild the index
+	dym.AddWordsForLanguage([]string{"أمل"}, dymean.Arabic)
+	if variants := dym.OrthographicVariants("امل", dymean.Arabic); len(variants) != 1 {
+		t.Errorf("Expected the added word to be found, got %v", variants)
+	}
+	if variants := dym.OrthographicVariants("hello", dymean.English); variants != nil {
+		t.Errorf("Expected no variants without equivalences, got %v", variants)
+	}
+}
+
+// TestExplainOrthographicVariant tests that variant spellings are explained as such
+func TestExplainOrthographicVariant(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWordsForLanguage([]string{"إلى"}, dymean.Arabic)
+	if kind, _ := dym.ExplainSuggestion("الى", "إلى", dymean.Arabic); kind != dymean.ExplainVariant {
+		t.Errorf("Expected %q, got %q", dymean.ExplainVariant, kind)
+	}
+}
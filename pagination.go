@@ -0,0 +1,96 @@
+package dymean
+
+// SuggestionIterator walks the full ranked suggestion list of a word page by
+// page. The list is computed once when the iterator is created, so asking for
+// more suggestions does not search the dictionary again. An iterator is a
+// snapshot: dictionary changes made afterwards are not reflected.
+type SuggestionIterator struct {
+	suggestions []Suggestion
+	offset      int
+}
+
+// SuggestionPage is a window of a ranked suggestion list
+type SuggestionPage struct {
+	Suggestions []Suggestion
+	Offset      int  // Position of the first suggestion in the ranked list
+	Total       int  // Number of suggestions in the ranked list
+	HasMore     bool // Suggestions remain after this page
+}
+
+// IterateSuggestions returns an iterator over every suggestion for a word in
+// the current language, ranked as GetSuggestions ranks them
+func (dym *DidYouMean) IterateSuggestions(word string, maxEditDistance int) *SuggestionIterator {
+	return dym.IterateSuggestionsForLanguage(word, maxEditDistance, dym.currentLang)
+}
+
+// IterateSuggestionsForLanguage returns an iterator over every suggestion for
+// a word in a specific language
+func (dym *DidYouMean) IterateSuggestionsForLanguage(word string, maxEditDistance int, lang Language) *SuggestionIterator {
+	suggestions := dym.GetSuggestionsForLanguage(word, int(^uint(0)>>1), maxEditDistance, lang)
+	return &SuggestionIterator{suggestions: suggestions}
+}
+
+// Next returns up to n more suggestions, or nil once the list is exhausted
+func (it *SuggestionIterator) Next(n int) []Suggestion {
+	if n <= 0 || it.offset >= len(it.suggestions) {
+		return nil
+	}
+	end := it.offset + n
+	if end > len(it.suggestions) || end < it.offset {
+		end = len(it.suggestions)
+	}
+	page := it.suggestions[it.offset:end:end]
+	it.offset = end
+	return page
+}
+
+// Remaining returns the number of suggestions not yet returned by Next
+func (it *SuggestionIterator) Remaining() int {
+	return len(it.suggestions) - it.offset
+}
+
+// Offset returns the position of the next suggestion in the ranked list
+func (it *SuggestionIterator) Offset() int {
+	return it.offset
+}
+
+// Seek moves the iterator to a position in the ranked list, clamped to its bounds
+func (it *SuggestionIterator) Seek(offset int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(it.suggestions) {
+		offset = len(it.suggestions)
+	}
+	it.offset = offset
+}
+
+// Page returns the suggestions from offset to offset+limit without moving the iterator
+func (it *SuggestionIterator) Page(offset int, limit int) SuggestionPage {
+	page := &SuggestionIterator{suggestions: it.suggestions}
+	page.Seek(offset)
+	suggestions := page.Next(limit)
+	if suggestions == nil {
+		suggestions = []Suggestion{}
+	}
+	return SuggestionPage{
+		Suggestions: suggestions,
+		Offset:      page.offset - len(suggestions),
+		Total:       len(it.suggestions),
+		HasMore:     page.Remaining() > 0,
+	}
+}
+
+// GetSuggestionsPage returns one page of the ranked suggestions for a word in
+// the current language. Stateless clients pass the offset of the page they
+// want; clients that page repeatedly should keep a SuggestionIterator instead,
+// which ranks the candidates only once.
+func (dym *DidYouMean) GetSuggestionsPage(word string, offset int, limit int, maxEditDistance int) SuggestionPage {
+	return dym.GetSuggestionsPageForLanguage(word, offset, limit, maxEditDistance, dym.currentLang)
+}
+
+// GetSuggestionsPageForLanguage returns one page of the ranked suggestions for
+// a word in a specific language
+func (dym *DidYouMean) GetSuggestionsPageForLanguage(word string, offset int, limit int, maxEditDistance int, lang Language) SuggestionPage {
+	return dym.IterateSuggestionsForLanguage(word, maxEditDistance, lang).Page(offset, limit)
+}
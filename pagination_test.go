@@ -0,0 +1,71 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"testing"
+)
+
+// TestSuggestionIterator tests paging through the ranked suggestions
+func TestSuggestionIterator(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"cat", "bat", "hat", "mat", "rat", "cart", "chat", "coat"})
+
+	it := dym.IterateSuggestions("cxt", 2)
+	total := it.Remaining()
+	if total < 6 {
+		t.Fatalf("Expected at least 6 suggestions, got %d", total)
+	}
+
+	var all []dymean.Suggestion
+	for page := it.Next(3); page != nil; page = it.Next(3) {
+		if len(page) > 3 {
+			t.Errorf("Expected pages of at most 3, got %d", len(page))
+		}
+		all = append(all, page...)
+	}
+	if len(all) != total || it.Remaining() != 0 {
+		t.Errorf("Expected %d suggestions in total, got %d", total, len(all))
+	}
+	if all[0].Word != "cat" {
+		t.Errorf("Expected cat to rank first, got %v", all[0])
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i].Similarity > all[i-1].Similarity {
+			t.Errorf("Expected suggestions in ranked order, got %v", all)
+		}
+	}
+
+	// The first page matches GetSuggestions
+	if first := dym.GetSuggestions("cxt", 1, 2); first[0].Word != all[0].Word {
+		t.Errorf("Expected the first page to start with %s, got %v", first[0].Word, all[0])
+	}
+
+	// Separately computed pages line up
+	page := dym.GetSuggestionsPage("cxt", 3, 3, 2)
+	if !reflect.DeepEqual(page.Suggestions, all[3:6]) || page.Offset != 3 || page.Total != total || page.HasMore != (total > 6) {
+		t.Errorf("Expected the second page %v, got %+v", all[3:6], page)
+	}
+	if page := dym.GetSuggestionsPage("cxt", total, 3, 2); len(page.Suggestions) != 0 || page.HasMore {
+		t.Errorf("Expected an empty last page, got %+v", page)
+	}
+}
+
+// TestSuggestionPagesCollated tests that pages break ties in the language's
+// alphabetical order, as GetSuggestions does
+func TestSuggestionPagesCollated(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithAutomatonSearch())
+	dym.AddWordsForLanguage([]string{"لدرام", "گدرام", "تدرام", "پدرام"}, dymean.Persian)
+
+	expected := dym.GetSuggestionsForLanguage("درام", 10, 1, dymean.Persian)
+	if len(expected) != 4 || expected[0].Word != "پدرام" {
+		t.Fatalf("Expected پ to sort first, got %v", expected)
+	}
+	var all []dymean.Suggestion
+	for offset := 0; offset < len(expected); offset += 2 {
+		all = append(all, dym.GetSuggestionsPageForLanguage("درام", offset, 2, 1, dymean.Persian).Suggestions...)
+	}
+	if !reflect.DeepEqual(all, expected) {
+		t.Errorf("Expected the pages %v, got %v", expected, all)
+	}
+}
@@ -0,0 +1,98 @@
+package dymean
+
+import (
+	"context"
+	"sync"
+)
+
+// Dictionary attaches a payload of type T, such as a product ID or a
+// documentation URL, to each of its words in a language's dictionary of a
+// DidYouMean, so fuzzy lookups resolve to domain objects directly. Words are
+// added to the underlying dictionary; payloads are keyed by the normalized
+// word. A Dictionary is safe for concurrent use.
+type Dictionary[T any] struct {
+	dym      *DidYouMean
+	lang     Language
+	mu       sync.RWMutex
+	payloads map[string]T
+}
+
+// PayloadSuggestion is a suggestion of a Dictionary with the payload of its word
+type PayloadSuggestion[T any] struct {
+	Suggestion
+	Payload T
+}
+
+// NewDictionary creates a payload layer over a language's dictionary of dym
+func NewDictionary[T any](dym *DidYouMean, lang Language) *Dictionary[T] {
+	return &Dictionary[T]{dym: dym, lang: lang, payloads: make(map[string]T)}
+}
+
+// Add adds a word with its payload, replacing the payload of a word added before
+func (d *Dictionary[T]) Add(word string, payload T) {
+	d.AddAll(map[string]T{word: payload})
+}
+
+// AddAll adds words with their payloads
+func (d *Dictionary[T]) AddAll(entries map[string]T) {
+	words := make([]string, 0, len(entries))
+	d.mu.Lock()
+	for word, payload := range entries {
+		words = append(words, word)
+		d.payloads[d.dym.normalize(word, d.lang)] = payload
+	}
+	d.mu.Unlock()
+	d.dym.AddWordsForLanguage(words, d.lang)
+}
+
+// Remove removes a word and its payload
+func (d *Dictionary[T]) Remove(word string) {
+	d.mu.Lock()
+	delete(d.payloads, d.dym.normalize(word, d.lang))
+	d.mu.Unlock()
+	d.dym.RemoveWordsForLanguage([]string{word}, d.lang)
+}
+
+// Get returns the payload of a word, in any spelling normalizing to it
+func (d *Dictionary[T]) Get(word string) (T, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	payload, ok := d.payloads[d.dym.normalize(word, d.lang)]
+	return payload, ok
+}
+
+// Len returns the number of words with payloads
+func (d *Dictionary[T]) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.payloads)
+}
+
+// Suggest returns the suggestions for a word among the words with payloads,
+// with their payloads, ranked as GetSuggestionsForLanguage ranks them. Other
+// words of the language's dictionary are skipped, so they never take the
+// places of words with payloads.
+func (d *Dictionary[T]) Suggest(word string, maxSuggestions int, maxEditDistance int) []PayloadSuggestion[T] {
+	return d.SuggestContext(context.Background(), word, maxSuggestions, maxEditDistance)
+}
+
+// SuggestContext is Suggest with a context, see GetSuggestionsForLanguageContext
+func (d *Dictionary[T]) SuggestContext(ctx context.Context, word string, maxSuggestions int, maxEditDistance int) []PayloadSuggestion[T] {
+	// Every candidate is ranked anyway, so asking for all of them only costs
+	// the filtering below
+	all := d.dym.GetWordCount(d.lang) + 1
+	suggestions := d.dym.GetSuggestionsForLanguageContext(ctx, word, all, maxEditDistance, d.lang)
+
+	results := make([]PayloadSuggestion[T], 0)
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, suggestion := range suggestions {
+		if len(results) >= maxSuggestions {
+			break
+		}
+		if payload, ok := d.payloads[suggestion.Word]; ok {
+			results = append(results, PayloadSuggestion[T]{Suggestion: suggestion, Payload: payload})
+		}
+	}
+	return results
+}
@@ -0,0 +1,45 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// product is a domain object attached to dictionary words
+type product struct {
+	ID  int
+	URL string
+}
+
+// TestDictionaryPayloads tests resolving fuzzy lookups to payloads
+func TestDictionaryPayloads(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"keyboard", "keyboards"})
+	products := dymean.NewDictionary[product](dym, dymean.English)
+	products.AddAll(map[string]product{
+		"Keyboard": {ID: 1, URL: "/keyboard"},
+		"monitor":  {ID: 2, URL: "/monitor"},
+	})
+	products.Add("mouse", product{ID: 3})
+
+	if products.Len() != 3 || !dym.IsCorrect("mouse") {
+		t.Fatalf("Expected 3 words added to the dictionary, got %d", products.Len())
+	}
+	if p, ok := products.Get("KEYBOARD"); !ok || p.ID != 1 {
+		t.Errorf("Expected the keyboard payload, got %v, %v", p, ok)
+	}
+
+	// Words without payloads are skipped
+	suggestions := products.Suggest("keybord", 5, 2)
+	if len(suggestions) != 1 || suggestions[0].Word != "keyboard" || suggestions[0].Payload.URL != "/keyboard" {
+		t.Errorf("Expected the keyboard product, got %v", suggestions)
+	}
+	if suggestions := products.Suggest("monitr", 0, 2); len(suggestions) != 0 {
+		t.Errorf("Expected no suggestions, got %v", suggestions)
+	}
+
+	products.Remove("mouse")
+	if _, ok := products.Get("mouse"); ok || dym.IsCorrect("mouse") {
+		t.Error("Expected mouse to be removed")
+	}
+}
@@ -75,6 +75,93 @@ func TestPersianWordValidation(t *testing.T) {
 	}
 }
 
+// TestPersianHamzaAndZWNJ tests validation of alef madda, hamza forms and ZWNJ compounds
+func TestPersianHamzaAndZWNJ(t *testing.T) {
+	validWords := []string{
+		"آب", "آسمان", "مسئله", "جزء", "مؤسسه", "تأثیر", "إیمان",
+		"می\u200cروم", "نمی\u200cدانم", "کتاب\u200cها",
+	}
+	invalidWords := []string{
+		"\u200cمی", "می\u200c", "می\u200c\u200cروم", "می\u200c روم",
+	}
+
+	for _, word := range validWords {
+		if !dymean.IsValidWordForLanguage(word, dymean.Persian) {
+			t.Errorf("Expected '%s' to be valid Persian word", word)
+		}
+	}
+	for _, word := range invalidWords {
+		if dymean.IsValidWordForLanguage(word, dymean.Persian) {
+			t.Errorf("Expected %q to be invalid Persian word", word)
+		}
+	}
+
+	// Stray ZWNJs are removed by normalization
+	normalizer := dymean.GetLanguageInfo(dymean.Persian).Normalizer
+	if got := normalizer("\u200cمی\u200c\u200cروم\u200c"); got != "می\u200cروم" {
+		t.Errorf("Expected stray ZWNJs to be removed, got %q", got)
+	}
+}
+
+// TestPersianCompoundVerbs tests dictionary lookups and text checks of ZWNJ compounds
+func TestPersianCompoundVerbs(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.LoadDefaultDictionary(dymean.Persian)
+
+	for _, word := range []string{"می\u200cروم", "می\u200cخواهم", "نمی\u200cدانم"} {
+		if !dym.IsCorrectForLanguage(word, dymean.Persian) {
+			t.Errorf("Expected %q to be correct in Persian dictionary", word)
+		}
+	}
+
+	// A compound verb is a single token
+	text := "من می\u200cروم"
+	tokens := dymean.Tokenize(text)
+	if len(tokens) != 2 || tokens[1].Text != "می\u200cروم" || tokens[1].End != len(text) {
+		t.Errorf("Expected the compound verb as one token, got %+v", tokens)
+	}
+	if corrections := dym.CheckTextForLanguage("می\u200cروم نمی\u200cدانم", dymean.Persian); len(corrections) != 0 {
+		t.Errorf("Expected no corrections for compound verbs, got %+v", corrections)
+	}
+
+	// A misspelled compound gets the compound as suggestion
+	suggestions := dym.GetSuggestionsForLanguage("می\u200cرووم", 3, 2, dymean.Persian)
+	if len(suggestions) == 0 || suggestions[0].Word != "می\u200cروم" {
+		t.Errorf("Expected می‌روم to be suggested, got %v", getSuggestionWords(suggestions))
+	}
+}
+
+// TestPresentationForms tests lookups of words typed with Arabic presentation forms
+func TestPresentationForms(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.LoadDefaultDictionary(dymean.Persian)
+	dym.AddWordsForLanguage([]string{"كتاب"}, dymean.Arabic)
+
+	salam := "\uFEB3\uFEFC\uFEE1" // سلام with initial seen, final lam-alef and isolated meem
+	if dymean.DetectLanguage(salam) != dymean.Persian {
+		t.Errorf("Expected presentation forms to be detected as Persian")
+	}
+	if !dym.IsCorrectForLanguage(salam, dymean.Persian) {
+		t.Error("Expected presentation forms to match the standard spelling")
+	}
+	if got := dymean.GetLanguageInfo(dymean.Persian).Normalizer(salam); got != "سلام" {
+		t.Errorf("Expected standard letters, got %q", got)
+	}
+
+	// Arabic keeps Arabic kaf and yeh, Persian writes Persian letters
+	kitab := "\uFEDB\uFE98\uFE8E\uFE8F" // كتاب
+	if !dym.IsCorrectForLanguage(kitab, dymean.Arabic) {
+		t.Error("Expected Arabic presentation forms to match the standard spelling")
+	}
+	if got := dymean.GetLanguageInfo(dymean.Persian).Normalizer(kitab); got != "کتاب" {
+		t.Errorf("Expected Persian kaf, got %q", got)
+	}
+
+	if corrections := dym.CheckTextForLanguage("\uFEB3\uFEFC\uFEE1 \uFEA9\uFEE7\uFEF4\uFE8E", dymean.Persian); len(corrections) != 0 {
+		t.Errorf("Expected no corrections for presentation forms, got %+v", corrections)
+	}
+}
+
 // TestPersianDictionary tests Persian dictionary functionality
 func TestPersianDictionary(t *testing.T) {
 	dym := dymean.NewDidYouMean(10000, 7)
@@ -0,0 +1,161 @@
+package dymean
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Fixed parameters of SuggestPhrase
+const (
+	phraseCandidates   = 5   // Candidates considered per token
+	phraseEditDistance = 2   // Edit distance of the candidates
+	bigramWeight       = 0.8 // Share of the bigram model in word probabilities
+)
+
+// PhraseConfig tunes the beam search of SuggestPhrase, trading latency for
+// quality. Zero fields take the values of DefaultPhraseConfig.
+type PhraseConfig struct {
+	BeamWidth           int     // Partial rewrites kept after each token
+	MaxEdits            int     // Most edits over the whole phrase; tokens beyond are kept as typed
+	LanguageModelWeight float64 // Weight of word and pair frequencies against spelling similarity
+}
+
+// DefaultPhraseConfig is the configuration of SuggestPhrase unless changed
+// with WithPhraseConfig
+var DefaultPhraseConfig = PhraseConfig{BeamWidth: 8, MaxEdits: 4, LanguageModelWeight: 0.25}
+
+// withDefaults fills the zero fields of a configuration from another
+func (c PhraseConfig) withDefaults(defaults PhraseConfig) PhraseConfig {
+	if c.BeamWidth <= 0 {
+		c.BeamWidth = defaults.BeamWidth
+	}
+	if c.MaxEdits <= 0 {
+		c.MaxEdits = defaults.MaxEdits
+	}
+	if c.LanguageModelWeight <= 0 {
+		c.LanguageModelWeight = defaults.LanguageModelWeight
+	}
+	return c
+}
+
+// WithPhraseConfig sets the configuration of SuggestPhrase and
+// SuggestPhraseForLanguage; zero fields keep the defaults
+func WithPhraseConfig(config PhraseConfig) Option {
+	return func(dym *DidYouMean) {
+		dym.phraseConfig = config.withDefaults(DefaultPhraseConfig)
+	}
+}
+
+// PhraseSuggestion is a rewrite of a phrase by SuggestPhrase
+type PhraseSuggestion struct {
+	Tokens []string // The corrected tokens
+	Phrase string   // The corrected tokens joined by spaces
+	Score  float64  // Log-probability of the rewrite, higher is better
+}
+
+// phraseHypothesis is a partial rewrite of a phrase during the beam search
+type phraseHypothesis struct {
+	tokens []string
+	cost   float64 // Negative log-probability so far
+	edits  int     // Edits made so far
+}
+
+// SuggestPhrase corrects a short phrase of the current language as a whole
+// rather than token by token; see SuggestPhraseForLanguage
+func (dym *DidYouMean) SuggestPhrase(tokens []string) PhraseSuggestion {
+	return dym.SuggestPhraseForLanguage(tokens, dym.currentLang)
+}
+
+// SuggestPhraseForLanguage corrects a short phrase as a whole: each token's
+// candidates are its suggestions, and a beam search picks the rewrite that
+// best combines their similarity with the word pair frequencies set with
+// SetBigramFrequencies (and the word frequencies of SetWordFrequencies), so
+// "hot rad" becomes "hot rod" when "hot rod" is frequent although "red" is
+// the first suggestion for "rad" alone. Tokens without suggestions are kept.
+func (dym *DidYouMean) SuggestPhraseForLanguage(tokens []string, lang Language) PhraseSuggestion {
+	return dym.SuggestPhraseWithConfig(tokens, lang, dym.phraseConfig)
+}
+
+// SuggestPhraseWithConfig is SuggestPhraseForLanguage with a configuration
+// for this call, such as a narrow beam for a latency-sensitive endpoint; zero
+// fields take the instance's configuration. Equally likely rewrites are
+// ordered by phrase, so results do not depend on the beam's internal order.
+func (dym *DidYouMean) SuggestPhraseWithConfig(tokens []string, lang Language, config PhraseConfig) PhraseSuggestion {
+	config = config.withDefaults(dym.phraseConfig)
+	if len(tokens) == 0 {
+		return PhraseSuggestion{Tokens: make([]string, 0)}
+	}
+
+	wordCost, _ := dym.wordCosts(lang)
+	dym.mu.RLock()
+	bigrams := dym.bigrams[lang]
+	dym.mu.RUnlock()
+	if bigrams == nil {
+		bigrams = &bigramFrequencies{}
+	}
+	// The cost of a word after another, interpolating the bigram and word models
+	languageCost := func(previous, word string) float64 {
+		probability := math.Exp(-wordCost(word))
+		if total := bigrams.firsts[previous]; total > 0 {
+			probability = bigramWeight*float64(bigrams.counts[[2]string{previous, word}])/float64(total) + (1-bigramWeight)*probability
+		}
+		return -math.Log(probability)
+	}
+
+	beam := []phraseHypothesis{{tokens: make([]string, 0, len(tokens))}}
+	for _, token := range tokens {
+		candidates := dym.suggestions(context.Background(), token, phraseCandidates, phraseEditDistance, lang, false)
+		normalized := dym.normalize(token, lang)
+		edits := make([]int, len(candidates))
+		for i, candidate := range candidates {
+			edits[i] = LevenshteinDistance(normalized, candidate.Word)
+		}
+
+		// Hypotheses ending in the same word only differ by their cost, so
+		// the cheapest is kept
+		best := make(map[string]phraseHypothesis)
+		for _, hypothesis := range beam {
+			previous := ""
+			if len(hypothesis.tokens) > 0 {
+				previous = hypothesis.tokens[len(hypothesis.tokens)-1]
+			}
+			extend := func(word string, similarity float64, edits int) {
+				cost := hypothesis.cost + config.LanguageModelWeight*languageCost(previous, word) - math.Log(math.Max(similarity, 1e-9))
+				if current, ok := best[word]; ok && current.cost <= cost {
+					return
+				}
+				extended := append(append(make([]string, 0, len(tokens)), hypothesis.tokens...), word)
+				best[word] = phraseHypothesis{tokens: extended, cost: cost, edits: edits}
+			}
+			extended := false
+			for i, candidate := range candidates {
+				if hypothesis.edits+edits[i] <= config.MaxEdits {
+					extend(candidate.Word, candidate.Similarity, hypothesis.edits+edits[i])
+					extended = true
+				}
+			}
+			// Without suggestions, or with the edits spent, the token stays as typed
+			if !extended {
+				extend(token, 1.0, hypothesis.edits)
+			}
+		}
+
+		beam = beam[:0]
+		for _, hypothesis := range best {
+			beam = append(beam, hypothesis)
+		}
+		sort.Slice(beam, func(i, j int) bool {
+			if beam[i].cost != beam[j].cost {
+				return beam[i].cost < beam[j].cost
+			}
+			return strings.Join(beam[i].tokens, " ") < strings.Join(beam[j].tokens, " ")
+		})
+		if len(beam) > config.BeamWidth {
+			beam = beam[:config.BeamWidth]
+		}
+	}
+
+	return PhraseSuggestion{Tokens: beam[0].tokens, Phrase: strings.Join(beam[0].tokens, " "), Score: -beam[0].cost}
+}
@@ -0,0 +1,77 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"testing"
+)
+
+// TestSuggestPhrase tests that phrases are corrected jointly with the bigram model
+func TestSuggestPhrase(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hot", "red", "rod", "wine", "glass"})
+
+	// Token by token, "red" ranks first for "rad"
+	if suggestions := dym.GetSuggestions("rad", 5, 1); len(suggestions) == 0 || suggestions[0].Word != "red" {
+		t.Fatalf("Expected red first for rad alone, got %v", suggestions)
+	}
+
+	dym.SetBigramFrequencies(map[string]int{"hot rod": 20, "red wine": 30, "wine glass": 10}, dymean.English)
+	if got := dym.BigramFrequency("Hot", "Rod", dymean.English); got != 20 {
+		t.Errorf("Expected the normalized pair frequency 20, got %d", got)
+	}
+
+	tests := map[string][]string{
+		"hot rod":          {"hot", "rad"},
+		"red wine":         {"rad", "wine"},
+		"red wine glass":   {"rad", "wnie", "glass"},
+		"hot rod xyzzyqwv": {"hot", "rad", "xyzzyqwv"}, // Unknown tokens are kept
+	}
+	for expected, tokens := range tests {
+		suggestion := dym.SuggestPhrase(tokens)
+		if suggestion.Phrase != expected {
+			t.Errorf("SuggestPhrase(%v) = %q, want %q", tokens, suggestion.Phrase, expected)
+		}
+		if suggestion.Score >= 0 {
+			t.Errorf("Expected a negative log-probability, got %v", suggestion.Score)
+		}
+	}
+
+	if suggestion := dym.SuggestPhrase(nil); !reflect.DeepEqual(suggestion.Tokens, []string{}) || suggestion.Phrase != "" {
+		t.Errorf("Expected an empty rewrite, got %+v", suggestion)
+	}
+}
+
+// TestSuggestPhraseConfig tests the beam search configuration per instance and per call
+func TestSuggestPhraseConfig(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithPhraseConfig(dymean.PhraseConfig{BeamWidth: 2}))
+	dym.AddWords([]string{"hot", "red", "rod", "wine", "glass"})
+	dym.SetBigramFrequencies(map[string]int{"hot rod": 20, "red wine": 30, "wine glass": 10}, dymean.English)
+
+	tokens := []string{"rad", "wnie", "glass"}
+	if got := dym.SuggestPhrase(tokens).Phrase; got != "red wine glass" {
+		t.Errorf("Expected red wine glass with a narrow beam, got %q", got)
+	}
+
+	// The edits of the whole phrase are bounded; tokens beyond stay as typed
+	limited := dym.SuggestPhraseWithConfig(tokens, dymean.English, dymean.PhraseConfig{MaxEdits: 1})
+	if limited.Tokens[0] == "rad" || limited.Tokens[1] != "wnie" {
+		t.Errorf("Expected a single edit, got %q", limited.Phrase)
+	}
+
+	// The language model's weight decides between a closer word and a likelier pair
+	if got := dym.SuggestPhrase([]string{"hot", "redd"}).Phrase; got != "hot rod" {
+		t.Errorf("Expected hot rod by default, got %q", got)
+	}
+	light := dym.SuggestPhraseWithConfig([]string{"hot", "redd"}, dymean.English, dymean.PhraseConfig{LanguageModelWeight: 0.01})
+	if light.Phrase != "hot red" {
+		t.Errorf("Expected hot red with a light language model, got %q", light.Phrase)
+	}
+
+	// Equal inputs give equal rewrites
+	for i := 0; i < 5; i++ {
+		if got := dym.SuggestPhrase([]string{"hot", "rad"}); got.Phrase != "hot rod" {
+			t.Fatalf("Expected a deterministic hot rod, got %q", got.Phrase)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package dymean
+
+import (
+	"strings"
+	"unicode"
+)
+
+// collapseSpaces trims a phrase and collapses the whitespace between its
+// words to single spaces
+func collapseSpaces(phrase string) string {
+	if strings.IndexFunc(phrase, unicode.IsSpace) < 0 {
+		return phrase
+	}
+	return strings.Join(strings.Fields(phrase), " ")
+}
+
+// addPhrase records a dictionary entry containing spaces. The caller must
+// hold the write lock.
+func (dym *DidYouMean) addPhrase(lang Language, word string) {
+	n := strings.Count(word, " ") + 1
+	if n < 2 {
+		return
+	}
+	if dym.phrases[lang] == nil {
+		dym.phrases[lang] = make(map[string]bool)
+	}
+	dym.phrases[lang][word] = true
+	if n > dym.phraseWords[lang] {
+		dym.phraseWords[lang] = n
+	}
+}
+
+// phraseLength returns the most words in a phrase of a language's dictionary
+func (dym *DidYouMean) phraseLength(lang Language) int {
+	dym.mu.RLock()
+	defer dym.mu.RUnlock()
+	return dym.phraseWords[lang]
+}
+
+// joinTokens joins consecutive tokens into a phrase. Tokens must be separated
+// by whitespace only, so phrases never span punctuation.
+func joinTokens(text string, tokens []Token) (string, bool) {
+	words := make([]string, len(tokens))
+	for i, token := range tokens {
+		if i > 0 && strings.TrimSpace(text[tokens[i-1].End:token.Start]) != "" {
+			return "", false
+		}
+		words[i] = stripIgnorable(token.Text)
+	}
+	return strings.Join(words, " "), true
+}
+
+// phraseSuggestions returns the dictionary phrases with as many words as a
+// normalized phrase within an edit distance, ranked by similarity. Phrases are
+// few, so they are compared directly instead of generating candidates.
+func (dym *DidYouMean) phraseSuggestions(normalized string, lang Language, maxSuggestions int, maxEditDistance int) []Suggestion {
+	words := strings.Count(normalized, " ") + 1
+
+	memo := dym.newSimilarityMemo()
+	dym.mu.RLock()
+	suggestions := make([]Suggestion, 0)
+	for candidate := range dym.phrases[lang] {
+		if strings.Count(candidate, " ")+1 != words {
+			continue
+		}
+		if !withinLengthWindow(normalized, candidate, maxEditDistance) {
+			continue // Too long or short to be close enough
+		}
+		if LevenshteinDistance(normalized, candidate) <= maxEditDistance {
+			suggestions = append(suggestions, Suggestion{Word: candidate, Similarity: memo.similarity(normalized, candidate)})
+		}
+	}
+	dym.mu.RUnlock()
+
+	sortSuggestions(suggestions, lang)
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	return suggestions
+}
+
+// matchPhrases marks the tokens that form correct dictionary phrases, trying
+// the longest phrase starting at each token first
+func (dym *DidYouMean) matchPhrases(text string, tokens []Token, languageOf func(string) Language) []bool {
+	covered := make([]bool, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		lang := languageOf(stripIgnorable(tokens[i].Text))
+		longest := dym.phraseLength(lang)
+		if longest > len(tokens)-i {
+			longest = len(tokens) - i
+		}
+		for n := longest; n >= 2; n-- {
+			phrase, ok := joinTokens(text, tokens[i:i+n])
+			if !ok || !dym.IsCorrectForLanguage(phrase, lang) {
+				continue
+			}
+			for k := i; k < i+n; k++ {
+				covered[k] = true
+			}
+			i += n - 1
+			break
+		}
+	}
+	return covered
+}
+
+// correctPhrases replaces the corrections of misspelled words that belong to a
+// misspelled dictionary phrase with a single correction spanning the phrase,
+// when the phrase suggestion is at least as close as the word suggestion
+func (dym *DidYouMean) correctPhrases(text string, tokens []Token, covered []bool, spelling []*Correction) {
+	emitted := 0 // First token after the last phrase correction, so spans never overlap
+	for i := 0; i < len(spelling); i++ {
+		correction := spelling[i]
+		if correction == nil {
+			continue
+		}
+		lang := correction.Language
+		longest := dym.phraseLength(lang)
+		if longest < 2 {
+			continue
+		}
+
+		best := 0.0
+		if len(correction.Suggestions) > 0 {
+			best = correction.Suggestions[0].Similarity
+		}
+		var span *Correction
+		spanStart, spanEnd := 0, 0
+		tuning := dym.SuggestionTuning(lang)
+
+		for n := 2; n <= longest; n++ {
+			for start := i - n + 1; start <= i; start++ {
+				end := start + n
+				if start < emitted || end > len(tokens) || anyCovered(covered[start:end]) {
+					continue
+				}
+				phrase, ok := joinTokens(text, tokens[start:end])
+				if !ok {
+					continue
+				}
+				suggestions := dym.phraseSuggestions(dym.normalize(phrase, lang), lang, tuning.MaxSuggestions, tuning.MaxEditDistance)
+				if len(suggestions) == 0 || suggestions[0].Similarity < best {
+					continue
+				}
+				best = suggestions[0].Similarity
+				span = &Correction{
+					Start:       tokens[start].Start,
+					End:         tokens[end-1].End,
+					Original:    text[tokens[start].Start:tokens[end-1].End],
+					Suggestions: suggestions,
+					Category:    CategorySpelling,
+					Language:    lang,
+				}
+				spanStart, spanEnd = start, end
+			}
+		}
+
+		if span == nil {
+			continue
+		}
+		for k := spanStart; k < spanEnd; k++ {
+			spelling[k] = nil
+		}
+		spelling[spanStart] = span
+		i = spanEnd - 1
+		emitted = spanEnd
+	}
+}
+
+// anyCovered checks if any token of a range is part of a correct phrase
+func anyCovered(covered []bool) bool {
+	for _, c := range covered {
+		if c {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"strings"
+	"testing"
+)
+
+// TestPhraseLookup tests dictionary entries containing spaces
+func TestPhraseLookup(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"machine learning", "new  york city", "learning"})
+	dym.AddWordsForLanguage([]string{"قوه قضاییه"}, dymean.Persian)
+
+	for _, phrase := range []string{"machine learning", "Machine   Learning", "new york city"} {
+		if !dym.IsCorrect(phrase) {
+			t.Errorf("Expected %q to be correct", phrase)
+		}
+	}
+	if dym.IsCorrect("machine") {
+		t.Error("Expected the words of a phrase not to be correct on their own")
+	}
+	if !dym.IsCorrectForLanguage("قوه  قضاییه", dymean.Persian) {
+		t.Error("Expected the Persian phrase to be correct")
+	}
+}
+
+// TestCheckTextPhrases tests greedy phrase matching and phrase suggestions in texts
+func TestCheckTextPhrases(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"machine learning", "new york city", "new", "i", "love", "in", "york"})
+	dym.AddWordsForLanguage([]string{"قوه قضاییه", "از"}, dymean.Persian)
+
+	if corrections := dym.CheckText("I love machine learning in new york city"); len(corrections) != 0 {
+		t.Errorf("Expected phrases to be recognized, got %+v", corrections)
+	}
+	if corrections := dym.CheckTextForLanguage("از قوه قضاییه", dymean.Persian); len(corrections) != 0 {
+		t.Errorf("Expected the Persian phrase to be recognized, got %+v", corrections)
+	}
+
+	// Punctuation breaks a phrase
+	if corrections := dym.CheckText("machine, learning"); len(corrections) != 2 {
+		t.Errorf("Expected both words to be reported, got %+v", corrections)
+	}
+
+	// A misspelled phrase is corrected as a whole
+	text := "I love machine lerning"
+	corrections := dym.CheckText(text)
+	if len(corrections) != 1 {
+		t.Fatalf("Expected one phrase correction, got %+v", corrections)
+	}
+	if corrections[0].Original != "machine lerning" || text[corrections[0].Start:corrections[0].End] != "machine lerning" ||
+		corrections[0].Suggestions[0].Word != "machine learning" {
+		t.Errorf("Expected a correction spanning the phrase, got %+v", corrections[0])
+	}
+	if corrected := dym.Correct(text); corrected != "I love machine learning" {
+		t.Errorf("Expected the phrase to be corrected, got %q", corrected)
+	}
+}
+
+// TestCheckTextOverlappingPhrases tests that phrases sharing a misspelled
+// word are corrected without overlapping spans
+func TestCheckTextOverlappingPhrases(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"machine learning", "learning rate"})
+
+	text := "machne lerning rte"
+	corrections := dym.CheckText(text)
+	for i := 1; i < len(corrections); i++ {
+		if corrections[i].Start < corrections[i-1].End {
+			t.Errorf("Expected corrections not to overlap, got %+v", corrections)
+		}
+	}
+	if len(corrections) == 0 || corrections[0].Original != "machne lerning" {
+		t.Errorf("Expected the first phrase to be corrected, got %+v", corrections)
+	}
+	if corrected := dym.Correct(text); !strings.HasPrefix(corrected, "machine learning ") {
+		t.Errorf("Expected the first phrase to be corrected, got %q", corrected)
+	}
+}
+
+// TestPhraseSuggestions tests suggestions for misspelled phrases
+func TestPhraseSuggestions(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"machine learning", "machine", "learning"})
+
+	suggestions := dym.GetSuggestions("machine  lerning", 5, 2)
+	if len(suggestions) != 1 || suggestions[0].Word != "machine learning" {
+		t.Errorf("Expected the phrase to be suggested, got %v", suggestions)
+	}
+
+	dym.RemoveWords([]string{"machine learning"})
+	if dym.IsCorrect("machine learning") || len(dym.GetSuggestions("machine lerning", 5, 2)) != 0 {
+		t.Error("Expected the removed phrase to be forgotten")
+	}
+}
@@ -0,0 +1,49 @@
+package dymean
+
+import (
+	"sync"
+)
+
+// maxPooledSetSize bounds the sets kept for reuse, so one huge lookup does not
+// pin its memory for the lifetime of the process
+const maxPooledSetSize = 1 << 16
+
+// stringSetPool recycles the sets used to deduplicate candidates
+var stringSetPool = sync.Pool{
+	New: func() any { return make(map[string]bool) },
+}
+
+// getStringSet returns an empty set from the pool
+func getStringSet() map[string]bool {
+	return stringSetPool.Get().(map[string]bool)
+}
+
+// putStringSet empties a set and returns it to the pool
+func putStringSet(set map[string]bool) {
+	if len(set) > maxPooledSetSize {
+		return
+	}
+	clear(set)
+	stringSetPool.Put(set)
+}
+
+// intRowPool recycles the rows of the Levenshtein dynamic programming table
+var intRowPool = sync.Pool{
+	New: func() any { return new([]int) },
+}
+
+// getIntRows returns a pooled buffer holding two rows of n ints each. The
+// contents are unspecified; release it with putIntRows.
+func getIntRows(n int) (*[]int, []int, []int) {
+	buf := intRowPool.Get().(*[]int)
+	if cap(*buf) < 2*n {
+		*buf = make([]int, 2*n)
+	}
+	rows := (*buf)[:2*n]
+	return buf, rows[:n], rows[n:]
+}
+
+// putIntRows returns a buffer from getIntRows to the pool
+func putIntRows(buf *[]int) {
+	intRowPool.Put(buf)
+}
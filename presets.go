@@ -0,0 +1,174 @@
+package dymean
+
+import "regexp"
+
+// Preset is a configuration tuned for a kind of text, for
+// NewDidYouMeanPreset
+type Preset string
+
+// Presets
+const (
+	// PresetShortCopy tunes checks for email subject lines and marketing
+	// copy: merge tags, links, handles and hashtags are left alone, as are
+	// short words, words in capitals and capitalized brand names, unknown
+	// words are only reported with a close suggestion, and English homophones
+	// are offered as alternatives
+	PresetShortCopy Preset = "short-copy"
+	// PresetChat tunes checks for messaging: common English slang and
+	// abbreviations ("lol", "brb", "gonna") are added to the dictionary,
+	// elongated words ("soooo") are checked with their repeated letters
+	// collapsed, and @mentions, hashtags, emoji shortcodes and links are
+	// left alone
+	PresetChat Preset = "chat"
+	// PresetAcademic tunes checks for scientific writing: Latin terms ("in
+	// vitro", "et al.") and the names of Greek letters are added to the
+	// dictionary, quantities with SI units ("5 kΩ", "10 µm", "9.8 m/s²") and
+	// author-year citations ("(Smith et al., 2020)") are left alone, as are
+	// single letters such as "α" and acronyms such as "PCR"
+	PresetAcademic Preset = "academic"
+)
+
+// Dictionary size and hash functions of the Bloom filters of presets
+const (
+	presetDictionarySize = 1000000
+	presetHashFuncs      = 7
+)
+
+// Patterns of tokens of informal and marketing text that are not prose
+var (
+	// Merge tags of common email platforms ("{{first_name}}", "*|FNAME|*",
+	// "%%Name%%", "{name}")
+	mergeTagPattern = regexp.MustCompile(`\{\{[^{}]*\}\}|\{[\pL_]+\}|\*\|[\pL\pN_]+\|\*|%%[^%\s]+%%`)
+	linkPattern     = regexp.MustCompile(`(?:https?://|www\.)\S+`)
+	emailPattern    = regexp.MustCompile(`[\pL\pN._%+-]+@[\pL\pN-]+(?:\.[\pL\pN-]+)+`)
+	handlePattern   = regexp.MustCompile(`[@#][\pL\pN_]+`) // @mentions and #hashtags
+	// Emoji shortcodes of chat apps (":thumbsup:", ":+1:")
+	shortcodePattern = regexp.MustCompile(`:[\pL\pN_+-]+:`)
+)
+
+// SI units with their prefixes, longest alternatives first since the first
+// matching one wins
+const (
+	siPrefix = `(?:da|Y|Z|E|P|T|G|M|k|h|d|c|m|µ|μ|n|p|f|a|z|y)`
+	siUnit   = `(?:mol|kat|cd|Hz|Pa|Wb|lm|lx|Bq|Gy|Sv|eV|Da|°C|m|g|s|A|K|N|J|W|C|V|F|Ω|S|T|H|L|l|Å)(?:[²³]|\^?-?[1-3]|⁻[¹²³])?`
+)
+
+// Patterns of scientific notation that is not prose
+var (
+	// Quantities such as "5 kΩ", "10µm" or "9.8 m/s²", up to the character
+	// after the unit so that words starting with a unit are not matched
+	quantityPattern = regexp.MustCompile(`\pN\s?` + siPrefix + `?` + siUnit + `(?:[/·]` + siPrefix + `?` + siUnit + `)*(?:[^\pL\pN]|$)`)
+	// Units written without a quantity that no dictionary holds ("µm", "kΩ")
+	unitPattern = regexp.MustCompile(`[µμ](?:mol|m|g|s|A|L|l|V|W|F|H)|` + siPrefix + `?[ΩÅ]|°[CF]`)
+	// Author-year citations, in parentheses ("(Smith & Jones, 2019a)") or
+	// narrative ("Smith et al. (2020)")
+	citationPattern = regexp.MustCompile(`\(\p{Lu}[^()]*?\d{4}[a-z]?\)|\p{Lu}[\pL'-]+(?:\s+(?:and|&)\s+\p{Lu}[\pL'-]+|\s+et\s+al\.)?\s+\(\d{4}[a-z]?\)`)
+)
+
+// withPatterns keeps the default immutable patterns and adds a preset's
+func withPatterns(patterns ...*regexp.Regexp) Option {
+	return WithImmutablePatterns(append(append([]*regexp.Regexp{}, DefaultImmutablePatterns...), patterns...)...)
+}
+
+// englishHomophones are words often written for each other in hurried copy.
+// Contractions such as "you're" are left out, since English dictionaries do
+// not accept apostrophes.
+var englishHomophones = []ConfusablePair{
+	{A: "their", B: "there", Weight: 0.5},
+	{A: "then", B: "than", Weight: 0.5},
+	{A: "affect", B: "effect", Weight: 0.5},
+	{A: "accept", B: "except", Weight: 0.5},
+	{A: "advice", B: "advise", Weight: 0.5},
+	{A: "lose", B: "loose", Weight: 0.5},
+	{A: "complement", B: "compliment", Weight: 0.5},
+	{A: "stationary", B: "stationery", Weight: 0.5},
+	{A: "principal", B: "principle", Weight: 0.5},
+	{A: "discreet", B: "discrete", Weight: 0.5},
+	{A: "peak", B: "peek", Weight: 0.5},
+	{A: "brake", B: "break", Weight: 0.5},
+}
+
+// englishSlang is the informal English of messaging, added to the dictionary
+// by PresetChat. Spellings with digits ("gr8") are left out, since words with
+// digits are never checked.
+var englishSlang = []string{
+	"lol", "lmao", "rofl", "brb", "btw", "idk", "imo", "imho", "tbh", "omg",
+	"fyi", "irl", "afk", "ttyl", "lmk", "nvm", "rn", "jk", "smh", "ikr",
+	"gonna", "wanna", "gotta", "kinda", "sorta", "dunno", "lemme", "gimme",
+	"ya", "yeah", "yep", "yup", "nope", "nah", "ok", "okay", "pls", "plz",
+	"thx", "ty", "np", "ur", "u", "bc", "cuz", "tho", "ppl", "msg", "gg",
+	"haha", "hahaha", "hehe", "ugh", "meh", "omw", "bday", "convo", "selfie",
+}
+
+// latinTerms are the Latin words of academic English, added to the
+// dictionary by PresetAcademic
+var latinTerms = []string{
+	"et", "al", "ibid", "idem", "op", "cit", "cf", "viz", "etc", "vs", "sic",
+	"via", "vice", "versa", "per", "se", "circa", "ad", "hoc", "infinitum",
+	"vivo", "vitro", "situ", "silico", "priori", "posteriori", "facto", "jure",
+	"bona", "fide", "de", "novo", "inter", "alia", "ergo", "quasi", "status",
+	"quo", "sine", "qua", "non", "mutatis", "mutandis", "ceteris", "paribus",
+	"locus", "loci", "corpus", "corpora", "erratum", "errata", "addendum",
+	"addenda", "sui", "generis", "en", "masse", "vis", "post", "ante",
+}
+
+// greekLetters are the names of the Greek letters, added to the dictionary
+// by PresetAcademic
+var greekLetters = []string{
+	"alpha", "beta", "gamma", "delta", "epsilon", "zeta", "eta", "theta",
+	"iota", "kappa", "lambda", "mu", "nu", "xi", "omicron", "pi", "rho",
+	"sigma", "tau", "upsilon", "phi", "chi", "psi", "omega",
+}
+
+// Options returns the options of a preset, nil for an unknown preset
+func (p Preset) Options() []Option {
+	switch p {
+	case PresetShortCopy:
+		return []Option{
+			withPatterns(mergeTagPattern, linkPattern, emailPattern, handlePattern),
+			WithTextRules(TextRules{
+				MinWordLength:           3,
+				SkipAllCaps:             true,
+				SkipCapitalized:         true,
+				MinSuggestionSimilarity: 0.7,
+			}),
+			WithMaxTextLength(4096),
+			func(dym *DidYouMean) {
+				dym.AddConfusablesForLanguage(englishHomophones, English)
+			},
+		}
+	case PresetChat:
+		return []Option{
+			withPatterns(linkPattern, emailPattern, handlePattern, shortcodePattern),
+			WithTextRules(TextRules{
+				MinWordLength:           2,
+				SkipAllCaps:             true,
+				SkipCapitalized:         true,
+				MinSuggestionSimilarity: 0.7,
+				CollapseElongation:      true,
+			}),
+			func(dym *DidYouMean) {
+				dym.AddWordsForLanguage(englishSlang, English)
+			},
+		}
+	case PresetAcademic:
+		return []Option{
+			withPatterns(quantityPattern, unitPattern, citationPattern, linkPattern, emailPattern),
+			WithTextRules(TextRules{
+				MinWordLength: 2,
+				SkipAllCaps:   true,
+			}),
+			func(dym *DidYouMean) {
+				dym.AddWordsForLanguage(latinTerms, English)
+				dym.AddWordsForLanguage(greekLetters, English)
+			},
+		}
+	}
+	return nil
+}
+
+// NewDidYouMeanPreset creates an instance configured by a preset, since the
+// defaults are tuned for prose; options given after the preset override it
+func NewDidYouMeanPreset(preset Preset, opts ...Option) *DidYouMean {
+	return NewDidYouMean(presetDictionarySize, presetHashFuncs, append(preset.Options(), opts...)...)
+}
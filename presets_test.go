@@ -0,0 +1,108 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// originals returns the original text of corrections
+func originals(corrections []dymean.Correction) []string {
+	words := make([]string, 0, len(corrections))
+	for _, correction := range corrections {
+		words = append(words, correction.Original)
+	}
+	return words
+}
+
+// TestPresetShortCopy tests the preset for subject lines and marketing copy
+func TestPresetShortCopy(t *testing.T) {
+	words := []string{"hi", "your", "their", "there", "exclusive", "offer", "ends", "today", "sale", "shop", "now", "with", "free", "shipping", "the", "new", "collection"}
+	subject := "Hi {{first_name}}, your exclusiv offer ends today! FREE shiping on the new Zalando collection: shop.example.com/sale #sale"
+
+	plain := dymean.NewDidYouMean(10000, 7)
+	plain.AddWords(words)
+	if got := originals(plain.CheckTextForLanguage(subject, dymean.English)); len(got) < 4 {
+		t.Errorf("Expected the prose defaults to over-flag, got %v", got)
+	}
+
+	dym := dymean.NewDidYouMeanPreset(dymean.PresetShortCopy)
+	dym.AddWords(words)
+	got := originals(dym.CheckTextForLanguage(subject, dymean.English))
+	if len(got) != 2 || got[0] != "exclusiv" || got[1] != "shiping" {
+		t.Errorf("Expected only the misspellings, got %v", got)
+	}
+
+	// Capitalized words starting a sentence are still checked
+	if got := originals(dym.CheckTextForLanguage("Exclusiv deals. Shiping today", dymean.English)); len(got) != 2 {
+		t.Errorf("Expected both sentence starts to be checked, got %v", got)
+	}
+
+	// Homophones are offered as alternatives
+	suggestions := dym.GetSuggestions("their", 3, 1)
+	if len(suggestions) < 2 || suggestions[0].Word != "their" || suggestions[1].Word != "there" {
+		t.Errorf("Expected there as an alternative, got %v", suggestions)
+	}
+
+	// Options after the preset override it
+	strict := dymean.NewDidYouMeanPreset(dymean.PresetShortCopy, dymean.WithTextRules(dymean.TextRules{}))
+	strict.AddWords(words)
+	if got := originals(strict.CheckTextForLanguage("FREE Zalando shiping", dymean.English)); len(got) != 2 {
+		t.Errorf("Expected the override to check capitalized words, got %v", got)
+	}
+}
+
+// TestPresetChat tests the preset for messaging
+func TestPresetChat(t *testing.T) {
+	dym := dymean.NewDidYouMeanPreset(dymean.PresetChat)
+	dym.AddWords([]string{"that", "was", "so", "good", "hello", "grab", "coffee", "yes", "see", "you", "later"})
+
+	message := "lol @bobsmith that was soooo gooood, brb gonna grab cofee :thumbsup: https://chat.example.com/r/42"
+	got := originals(dym.CheckTextForLanguage(message, dymean.English))
+	if len(got) != 1 || got[0] != "cofee" {
+		t.Errorf("Expected only cofee to be flagged, got %v", got)
+	}
+
+	// Each run collapses to two letters or one, whichever is a word
+	if got := originals(dym.CheckTextForLanguage("helllooo yesss", dymean.English)); len(got) != 0 {
+		t.Errorf("Expected elongated words to be accepted, got %v", got)
+	}
+
+	// Elongated misspellings are reported with suggestions for the collapsed word
+	corrections := dym.CheckTextForLanguage("see youuu laterrr, cofeeee", dymean.English)
+	if len(corrections) != 1 || corrections[0].Original != "cofeeee" {
+		t.Fatalf("Expected only cofeeee to be flagged, got %v", originals(corrections))
+	}
+	if len(corrections[0].Suggestions) == 0 || corrections[0].Suggestions[0].Word != "coffee" {
+		t.Errorf("Expected coffee for cofeeee, got %v", corrections[0].Suggestions)
+	}
+
+	if !dym.IsCorrectForLanguage("gonna", dymean.English) {
+		t.Error("Expected slang in the dictionary")
+	}
+}
+
+// TestPresetAcademic tests the preset for scientific writing
+func TestPresetAcademic(t *testing.T) {
+	dym := dymean.NewDidYouMeanPreset(dymean.PresetAcademic)
+	dym.AddWords([]string{"the", "resistor", "of", "was", "measured", "in", "cells", "with", "a", "diameter", "as", "shown", "by", "and", "at", "velocity", "mice", "were", "treated", "decay", "rate", "using", "samples", "see"})
+
+	text := "The resistor of 5 kΩ was measured in vitro with cells of 10µm diameter, as shown by Smith et al. (2020) and (Jones & Lee, 2019a). " +
+		"The β decay rate at 9.8 m/s² and 3 mol was measured in situ using PCR samples (see α) at 20 µm."
+	if got := originals(dym.CheckTextForLanguage(text, dymean.English)); len(got) != 0 {
+		t.Errorf("Expected no false positives, got %v", got)
+	}
+
+	// Words after a quantity are still checked
+	if got := originals(dym.CheckTextForLanguage("5 mice were traeted", dymean.English)); len(got) != 1 || got[0] != "traeted" {
+		t.Errorf("Expected only traeted to be flagged, got %v", got)
+	}
+	if got := originals(dym.CheckTextForLanguage("5 mcie were treated", dymean.English)); len(got) != 1 || got[0] != "mcie" {
+		t.Errorf("Expected mcie to be flagged after a quantity, got %v", got)
+	}
+
+	for _, word := range []string{"ibid", "vivo", "lambda"} {
+		if !dym.IsCorrectForLanguage(word, dymean.English) {
+			t.Errorf("Expected %s in the dictionary", word)
+		}
+	}
+}
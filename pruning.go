@@ -0,0 +1,85 @@
+package dymean
+
+import (
+	"context"
+	"unicode/utf8"
+)
+
+// CandidatePruning restricts the candidates of a misspelling to the words
+// sharing its first letter and about its length, since first letters are
+// rarely mistyped. This cuts the candidates to search and score at a small
+// loss of recall. Zero fields take the values of DefaultCandidatePruning.
+type CandidatePruning struct {
+	LengthWindow            int     // Most runes a candidate's length differs from the word's
+	MaxFirstLetterErrorRate float64 // Share of learned corrections of the first letter above which pruning is off
+}
+
+// DefaultCandidatePruning is the configuration of WithCandidatePruning for
+// zero fields
+var DefaultCandidatePruning = CandidatePruning{LengthWindow: 2, MaxFirstLetterErrorRate: 0.1}
+
+// withDefaults fills the zero fields of a configuration from another
+func (c CandidatePruning) withDefaults(defaults CandidatePruning) CandidatePruning {
+	if c.LengthWindow <= 0 {
+		c.LengthWindow = defaults.LengthWindow
+	}
+	if c.MaxFirstLetterErrorRate <= 0 {
+		c.MaxFirstLetterErrorRate = defaults.MaxFirstLetterErrorRate
+	}
+	return c
+}
+
+// keeps reports whether a candidate survives pruning for a word
+func (c CandidatePruning) keeps(word, candidate string) bool {
+	first, _ := utf8.DecodeRuneInString(word)
+	candidateFirst, _ := utf8.DecodeRuneInString(candidate)
+	if first != candidateFirst {
+		return false
+	}
+	difference := utf8.RuneCountInString(candidate) - utf8.RuneCountInString(word)
+	return difference <= c.LengthWindow && -difference <= c.LengthWindow
+}
+
+// WithCandidatePruning prunes the candidates of suggestions to the words
+// sharing the first letter of the misspelling and within a length window of
+// it. Pruning is skipped for a language while the corrections learned by the
+// error model change the first letter more often than the configured rate,
+// and can be turned on or off per call with ContextWithCandidatePruning.
+func WithCandidatePruning(config CandidatePruning) Option {
+	return func(dym *DidYouMean) {
+		config = config.withDefaults(DefaultCandidatePruning)
+		dym.pruning = &config
+	}
+}
+
+// candidatePruningKey is the context key of ContextWithCandidatePruning
+type candidatePruningKey struct{}
+
+// ContextWithCandidatePruning returns a context that turns candidate pruning
+// on or off for the suggestions asked with it, overriding WithCandidatePruning;
+// pruning turned on without the option uses DefaultCandidatePruning
+func ContextWithCandidatePruning(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, candidatePruningKey{}, enabled)
+}
+
+// candidatePruning returns the pruning of the candidates of a word of lang,
+// nil when the candidates are kept
+func (dym *DidYouMean) candidatePruning(ctx context.Context, lang Language) *CandidatePruning {
+	pruning := dym.pruning
+	if enabled, ok := ctx.Value(candidatePruningKey{}).(bool); ok {
+		switch {
+		case !enabled:
+			return nil
+		case pruning == nil:
+			pruning = &DefaultCandidatePruning
+		}
+	}
+	if pruning == nil {
+		return nil
+	}
+
+	if rate, _ := dym.errorModel.FirstLetterErrorRate(lang); rate > pruning.MaxFirstLetterErrorRate {
+		return nil
+	}
+	return pruning
+}
@@ -0,0 +1,75 @@
+package dymean_test
+
+import (
+	"context"
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// containsWord reports whether a word is among the suggestions
+func containsWord(suggestions []dymean.Suggestion, word string) bool {
+	for _, suggestion := range suggestions {
+		if suggestion.Word == word {
+			return true
+		}
+	}
+	return false
+}
+
+// TestCandidatePruning tests restricting candidates by first letter and length
+func TestCandidatePruning(t *testing.T) {
+	words := []string{"hello", "jello", "help", "helloween"}
+	for _, automaton := range []bool{false, true} {
+		opts := []dymean.Option{dymean.WithCandidatePruning(dymean.CandidatePruning{})}
+		if automaton {
+			opts = append(opts, dymean.WithAutomatonSearch())
+		}
+		dym := dymean.NewDidYouMean(10000, 7, opts...)
+		dym.AddWords(words)
+
+		suggestions := dym.GetSuggestions("hallo", 5, 2)
+		if !containsWord(suggestions, "hello") {
+			t.Errorf("Expected hello, got %v", suggestions)
+		}
+		if containsWord(suggestions, "jello") {
+			t.Errorf("Expected jello to be pruned, got %v", suggestions)
+		}
+
+		// The override of a call keeps every candidate
+		ctx := dymean.ContextWithCandidatePruning(context.Background(), false)
+		if suggestions := dym.GetSuggestionsForLanguageContext(ctx, "hallo", 5, 2, dymean.English); !containsWord(suggestions, "jello") {
+			t.Errorf("Expected jello without pruning, got %v", suggestions)
+		}
+	}
+
+	// A narrow length window drops longer words
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithAutomatonSearch(), dymean.WithCandidatePruning(dymean.CandidatePruning{LengthWindow: 1}))
+	dym.AddWords(words)
+	if suggestions := dym.GetSuggestions("hellowen", 5, 2); containsWord(suggestions, "hello") || !containsWord(suggestions, "helloween") {
+		t.Errorf("Expected only helloween within the window, got %v", suggestions)
+	}
+
+	// Pruning is turned on for a call without the option
+	plain := dymean.NewDidYouMean(10000, 7)
+	plain.AddWords(words)
+	ctx := dymean.ContextWithCandidatePruning(context.Background(), true)
+	if suggestions := plain.GetSuggestionsForLanguageContext(ctx, "hallo", 5, 2, dymean.English); containsWord(suggestions, "jello") {
+		t.Errorf("Expected jello to be pruned for the call, got %v", suggestions)
+	}
+}
+
+// TestCandidatePruningErrorModel tests that pruning stops when users often
+// correct first letters
+func TestCandidatePruningErrorModel(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithCandidatePruning(dymean.CandidatePruning{MaxFirstLetterErrorRate: 0.3}))
+	dym.AddWords([]string{"hello", "jello"})
+
+	dym.LearnCorrection("hallo", "hello")
+	dym.LearnCorrection("kello", "jello")
+	if rate, samples := dym.GetErrorModel().FirstLetterErrorRate(dymean.English); rate != 0.5 || samples != 2 {
+		t.Fatalf("Expected a rate of 0.5 over 2 corrections, got %v over %d", rate, samples)
+	}
+	if suggestions := dym.GetSuggestions("jallo", 5, 2); !containsWord(suggestions, "hello") {
+		t.Errorf("Expected pruning to be off, got %v", suggestions)
+	}
+}
@@ -0,0 +1,146 @@
+package dymean
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"unicode/utf8"
+)
+
+// Parameters of the ValidateDictionary self-test
+const (
+	qualitySampleSize   = 200 // Dictionary words sampled
+	qualityMinWordRunes = 4   // Shorter words have too many neighbors to recover reliably
+	qualityMaxFailures  = 10  // Failures kept as examples in a report
+)
+
+// QualityReport is the result of ValidateDictionary
+type QualityReport struct {
+	Language Language
+	Sampled  int // Words sampled and given a synthetic typo
+
+	// Sampled words not reported correct: a sign that normalization at lookup
+	// differs from normalization at load, or that the index is broken
+	NotFound int
+
+	Recovered int     // Typos whose word was among the suggestions
+	Top1      int     // Typos whose word was the first suggestion
+	Accuracy  float64 // Recovered / Sampled
+	Failures  []QualityFailure
+}
+
+// QualityFailure is a synthetic typo the checker did not recover
+type QualityFailure struct {
+	Word        string
+	Typo        string
+	Suggestions []string
+}
+
+// ValidateDictionary is a smoke test to run after loading a dictionary. It
+// samples words of the language's dictionary, checks that each is reported
+// correct, then introduces one synthetic typo per word (a deleted, inserted,
+// substituted or swapped letter, preferring adjacent keys of the language's
+// keyboard) and measures how often the suggestions recover the word. The
+// sample is the same on every run for the same dictionary and seed (see
+// WithRandomSeed), so scores can be compared across configurations. Only local dictionaries are consulted,
+// never the fallback provider. A low accuracy or any NotFound word usually
+// means a normalization or validation setting does not fit the word list, or
+// a non-Latin language is searched without WithAutomatonSearch.
+func (dym *DidYouMean) ValidateDictionary(lang Language) (QualityReport, error) {
+	report := QualityReport{Language: lang}
+	if !dym.hasDictionary(lang) {
+		return report, fmt.Errorf("no %s dictionary loaded", lang)
+	}
+
+	words := make([]string, 0)
+	for _, word := range dym.GetDictionaryWords(lang) {
+		if !strings.Contains(word, " ") && utf8.RuneCountInString(word) >= qualityMinWordRunes {
+			words = append(words, word)
+		}
+	}
+	if len(words) == 0 {
+		return report, fmt.Errorf("no %s words of at least %d letters to sample", lang, qualityMinWordRunes)
+	}
+
+	random := rand.New(rand.NewSource(dym.seed))
+	random.Shuffle(len(words), func(i, j int) { words[i], words[j] = words[j], words[i] })
+	if len(words) > qualitySampleSize {
+		words = words[:qualitySampleSize]
+	}
+
+	tuning := GetLanguageInfo(lang).SuggestionTuning
+	for _, word := range words {
+		report.Sampled++
+		if !dym.IsCorrectForLanguage(word, lang) {
+			report.NotFound++
+		}
+
+		typo := dym.syntheticTypo(word, lang, random)
+		suggestions := dym.suggestions(context.Background(), typo, tuning.MaxSuggestions, tuning.MaxEditDistance, lang, false)
+		found := false
+		for i, suggestion := range suggestions {
+			if suggestion.Word == word {
+				found = true
+				if i == 0 {
+					report.Top1++
+				}
+				break
+			}
+		}
+		if found {
+			report.Recovered++
+			continue
+		}
+		if len(report.Failures) < qualityMaxFailures {
+			failure := QualityFailure{Word: word, Typo: typo, Suggestions: make([]string, 0, len(suggestions))}
+			for _, suggestion := range suggestions {
+				failure.Suggestions = append(failure.Suggestions, suggestion.Word)
+			}
+			report.Failures = append(report.Failures, failure)
+		}
+	}
+
+	report.Accuracy = float64(report.Recovered) / float64(report.Sampled)
+	return report, nil
+}
+
+// syntheticTypo applies one random edit to a word that does not turn it into
+// another dictionary word, trying a few times before settling for any edit
+func (dym *DidYouMean) syntheticTypo(word string, lang Language, random *rand.Rand) string {
+	info := GetLanguageInfo(lang)
+	alphabet := []rune(info.Alphabet)
+	if len(alphabet) == 0 {
+		alphabet = []rune(word)
+	}
+
+	typo := word
+	for attempt := 0; attempt < 10; attempt++ {
+		runes := []rune(word)
+		i := random.Intn(len(runes))
+		switch random.Intn(4) {
+		case 0: // Deletion
+			runes = append(runes[:i], runes[i+1:]...)
+		case 1: // Insertion
+			letter := alphabet[random.Intn(len(alphabet))]
+			runes = append(runes[:i], append([]rune{letter}, runes[i:]...)...)
+		case 2: // Substitution, by an adjacent key when the layout has one
+			if neighbors := info.KeyboardLayout[runes[i]]; len(neighbors) > 0 {
+				runes[i] = neighbors[random.Intn(len(neighbors))]
+			} else {
+				runes[i] = alphabet[random.Intn(len(alphabet))]
+			}
+		case 3: // Transposition
+			if i == len(runes)-1 {
+				i--
+			}
+			runes[i], runes[i+1] = runes[i+1], runes[i]
+		}
+
+		typo = string(runes)
+		if typo != word && !dym.IsCorrectForLanguage(typo, lang) {
+			return typo
+		}
+	}
+	return typo
+}
@@ -0,0 +1,120 @@
+package dymean
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+//go:embed testdata/quality_corpus.tsv
+var goldenQualityCorpus string
+
+// QualityCase is a misspelling and the suggestion expected first for it
+type QualityCase struct {
+	Language    Language
+	Misspelling string
+	Expected    string
+}
+
+// QualitySuiteResult is the result of RunQualitySuite for one language
+type QualitySuiteResult struct {
+	Language  Language
+	Cases     int              // Cases of the language run
+	Passed    int              // Cases whose first suggestion was the expected word
+	Recovered int              // Cases with the expected word among the suggestions
+	Failures  []QualityFailure // Every case not passed
+}
+
+// Accuracy returns the fraction of cases passed
+func (r QualitySuiteResult) Accuracy() float64 {
+	if r.Cases == 0 {
+		return 0
+	}
+	return float64(r.Passed) / float64(r.Cases)
+}
+
+// GoldenQualityCases returns the curated multilingual corpus of misspellings
+// shipped with the package, written for its embedded dictionaries and common
+// words of the other languages
+func GoldenQualityCases() []QualityCase {
+	cases, err := ParseQualityCases(strings.NewReader(goldenQualityCorpus))
+	if err != nil {
+		panic(err) // The embedded corpus is checked by the tests
+	}
+	return cases
+}
+
+// ParseQualityCases reads cases from r, one per line as a language code, the
+// misspelling and the expected suggestion separated by tabs. Empty lines and
+// lines starting with '#' are skipped.
+func ParseQualityCases(r io.Reader) ([]QualityCase, error) {
+	scanner := bufio.NewScanner(r)
+	cases := make([]QualityCase, 0)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("quality case on line %d: expected 3 tab-separated fields, got %d", line, len(fields))
+		}
+		cases = append(cases, QualityCase{Language: Language(fields[0]), Misspelling: fields[1], Expected: fields[2]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read quality cases: %w", err)
+	}
+	return cases, nil
+}
+
+// RunQualitySuite checks that each case's misspelling gets its expected word
+// as first suggestion, with the language's SuggestionTuning, and reports the
+// results per language in code order. Cases of languages without a loaded
+// dictionary are skipped, so a suite can hold languages an instance lacks.
+// Run it with GoldenQualityCases after changing normalizers, tuning or
+// strategies to catch regressions in other languages; the golden cases all
+// pass with WithAutomatonSearch. Like ValidateDictionary it never consults
+// the fallback provider.
+func (dym *DidYouMean) RunQualitySuite(cases []QualityCase) []QualitySuiteResult {
+	results := make(map[Language]*QualitySuiteResult)
+	for _, c := range cases {
+		if !dym.hasDictionary(c.Language) {
+			continue
+		}
+		result := results[c.Language]
+		if result == nil {
+			result = &QualitySuiteResult{Language: c.Language, Failures: make([]QualityFailure, 0)}
+			results[c.Language] = result
+		}
+		result.Cases++
+
+		tuning := GetLanguageInfo(c.Language).SuggestionTuning
+		suggestions := dym.suggestions(context.Background(), c.Misspelling, tuning.MaxSuggestions, tuning.MaxEditDistance, c.Language, false)
+		expected := dym.normalize(c.Expected, c.Language)
+		failure := QualityFailure{Word: c.Expected, Typo: c.Misspelling, Suggestions: make([]string, 0, len(suggestions))}
+		for i, suggestion := range suggestions {
+			failure.Suggestions = append(failure.Suggestions, suggestion.Word)
+			if suggestion.Word != expected {
+				continue
+			}
+			result.Recovered++
+			if i == 0 {
+				result.Passed++
+			}
+		}
+		if len(suggestions) == 0 || suggestions[0].Word != expected {
+			result.Failures = append(result.Failures, failure)
+		}
+	}
+
+	report := make([]QualitySuiteResult, 0, len(results))
+	for _, result := range results {
+		report = append(report, *result)
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].Language < report[j].Language })
+	return report
+}
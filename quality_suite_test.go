@@ -0,0 +1,52 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"strings"
+	"testing"
+)
+
+// TestGoldenQualityCases tests that every golden case passes
+func TestGoldenQualityCases(t *testing.T) {
+	dym := dymean.NewDidYouMean(100000, 7, dymean.WithAutomatonSearch())
+	dym.LoadDefaultDictionary(dymean.English)
+	dym.LoadDefaultDictionary(dymean.Persian)
+	cases := dymean.GoldenQualityCases()
+	for _, c := range cases {
+		if c.Language != dymean.English && c.Language != dymean.Persian {
+			dym.AddWordsForLanguage([]string{c.Expected}, c.Language)
+		}
+	}
+
+	results := dym.RunQualitySuite(cases)
+	if len(results) < 5 {
+		t.Fatalf("Expected results for at least 5 languages, got %d", len(results))
+	}
+	for _, result := range results {
+		if result.Accuracy() != 1 {
+			t.Errorf("%s: %d of %d cases passed, failures: %v", result.Language, result.Passed, result.Cases, result.Failures)
+		}
+	}
+}
+
+// TestRunQualitySuite tests failures and skipped languages
+func TestRunQualitySuite(t *testing.T) {
+	cases, err := dymean.ParseQualityCases(strings.NewReader("# comment\nen\thelo\thello\nen\tzzz\tworld\n\nde\tstrase\tstraße\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello", "world"})
+
+	results := dym.RunQualitySuite(cases)
+	if len(results) != 1 || results[0].Language != dymean.English {
+		t.Fatalf("Expected only English results, got %v", results)
+	}
+	if result := results[0]; result.Cases != 2 || result.Passed != 1 || len(result.Failures) != 1 || result.Failures[0].Typo != "zzz" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	if _, err := dymean.ParseQualityCases(strings.NewReader("en\thelo\n")); err == nil {
+		t.Error("Expected an error for a line without 3 fields")
+	}
+}
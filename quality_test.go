@@ -0,0 +1,38 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestValidateDictionary tests the quality self-test on the embedded dictionaries
+func TestValidateDictionary(t *testing.T) {
+	dym := dymean.NewDidYouMean(100000, 7, dymean.WithAutomatonSearch())
+	for _, lang := range []dymean.Language{dymean.English, dymean.Persian} {
+		if err := dym.LoadDefaultDictionary(lang); err != nil {
+			t.Fatal(err)
+		}
+		report, err := dym.ValidateDictionary(lang)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report.Sampled == 0 || report.NotFound != 0 || report.Accuracy < 0.9 || report.Top1 > report.Recovered {
+			t.Errorf("Unexpected %s report %+v", lang, report)
+		}
+		if missed := report.Sampled - report.Recovered; len(report.Failures) > 10 || (missed <= 10 && len(report.Failures) != missed) {
+			t.Errorf("Expected up to 10 failures as examples, got %+v", report.Failures)
+		}
+	}
+}
+
+// TestValidateDictionaryWithoutWords tests the self-test without a dictionary
+func TestValidateDictionaryWithoutWords(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	if _, err := dym.ValidateDictionary(dymean.English); err == nil {
+		t.Error("Expected an error without a dictionary")
+	}
+	dym.AddWords([]string{"a", "an"})
+	if _, err := dym.ValidateDictionary(dymean.English); err == nil {
+		t.Error("Expected an error without words long enough to sample")
+	}
+}
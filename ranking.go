@@ -0,0 +1,152 @@
+package dymean
+
+import (
+	"context"
+	"math"
+)
+
+// RankQuery is the lookup a Ranker scores suggestions for
+type RankQuery struct {
+	Input    string   // Normalized input
+	Language Language // Language of the lookup
+	Previous string   // Normalized word before the input, empty when unknown
+
+	dym *DidYouMean
+}
+
+// WordFrequency returns the frequency recorded for a word of the query's
+// language, or 0
+func (q RankQuery) WordFrequency(word string) int {
+	return q.dym.WordFrequency(word, q.Language)
+}
+
+// BigramFrequency returns the frequency recorded for a pair of words of the
+// query's language, or 0
+func (q RankQuery) BigramFrequency(first, second string) int {
+	return q.dym.BigramFrequency(first, second, q.Language)
+}
+
+// Ranker scores the suggestions of a lookup for a ranking pipeline
+type Ranker interface {
+	// Score returns the score of a suggestion between 0 and 1, higher
+	// ranking first. The suggestion's Similarity includes the boosts of the
+	// error model, confusables, feedback and decay.
+	Score(ctx context.Context, query RankQuery, suggestion Suggestion) float64
+}
+
+// RankerFunc adapts a function to the Ranker interface
+type RankerFunc func(ctx context.Context, query RankQuery, suggestion Suggestion) float64
+
+// Score calls f
+func (f RankerFunc) Score(ctx context.Context, query RankQuery, suggestion Suggestion) float64 {
+	return f(ctx, query, suggestion)
+}
+
+// Built-in rankers
+var (
+	// SimilarityRanker scores suggestions by their similarity to the input,
+	// with the boosts of the error model, confusables, feedback and decay
+	SimilarityRanker Ranker = RankerFunc(func(_ context.Context, _ RankQuery, suggestion Suggestion) float64 {
+		return suggestion.Similarity
+	})
+	// FrequencyRanker scores suggestions by the log of their frequency set
+	// with SetWordFrequencies, relative to the most frequent word
+	FrequencyRanker Ranker = RankerFunc(func(_ context.Context, query RankQuery, suggestion Suggestion) float64 {
+		query.dym.mu.RLock()
+		frequencies := query.dym.frequencies[query.Language]
+		query.dym.mu.RUnlock()
+		if frequencies == nil || frequencies.max == 0 {
+			return 0
+		}
+		return math.Log1p(float64(frequencies.counts[suggestion.Word])) / math.Log1p(float64(frequencies.max))
+	})
+	// ContextRanker scores suggestions by how often they follow the previous
+	// word, from the frequencies set with SetBigramFrequencies
+	ContextRanker Ranker = RankerFunc(func(_ context.Context, query RankQuery, suggestion Suggestion) float64 {
+		if query.Previous == "" {
+			return 0
+		}
+		query.dym.mu.RLock()
+		bigrams := query.dym.bigrams[query.Language]
+		query.dym.mu.RUnlock()
+		if bigrams == nil || bigrams.firsts[query.Previous] == 0 {
+			return 0
+		}
+		return float64(bigrams.counts[[2]string{query.Previous, suggestion.Word}]) / float64(bigrams.firsts[query.Previous])
+	})
+)
+
+// RankingStage is a ranker of a pipeline with the weight of its scores
+type RankingStage struct {
+	Name   string // For logs and debugging
+	Ranker Ranker
+	Weight float64 // Stages with a weight of 0 or less are skipped
+}
+
+// DefaultRankingPipeline returns a pipeline of similarity, then frequency and
+// context as tie-breakers, to extend with business rules
+func DefaultRankingPipeline() []RankingStage {
+	return []RankingStage{
+		{Name: "similarity", Ranker: SimilarityRanker, Weight: 1},
+		{Name: "frequency", Ranker: FrequencyRanker, Weight: 0.1},
+		{Name: "context", Ranker: ContextRanker, Weight: 0.1},
+	}
+}
+
+// WithRankingPipeline ranks suggestions by the weighted mean of the scores of
+// a pipeline of rankers, so applications can add domain signals such as
+// products in stock to the ranking:
+//
+//	inStock := dymean.RankerFunc(func(ctx context.Context, q dymean.RankQuery, s dymean.Suggestion) float64 {
+//		if catalog.InStock(s.Word) {
+//			return 1
+//		}
+//		return 0
+//	})
+//	dym := dymean.NewDidYouMean(100000, 7, dymean.WithRankingPipeline(append(dymean.DefaultRankingPipeline(),
+//		dymean.RankingStage{Name: "in stock", Ranker: inStock, Weight: 0.3})...))
+//
+// Scores are clamped to [0, 1] and their mean becomes the suggestion's
+// similarity; a correct word still ranks first. The previous word of
+// ContextRanker comes from ContextWithPreviousWord, or from the text in
+// CheckText.
+func WithRankingPipeline(stages ...RankingStage) Option {
+	return func(dym *DidYouMean) {
+		dym.ranking = make([]RankingStage, 0, len(stages))
+		for _, stage := range stages {
+			if stage.Ranker != nil && stage.Weight > 0 {
+				dym.ranking = append(dym.ranking, stage)
+			}
+		}
+	}
+}
+
+// previousWordKey is the context key of ContextWithPreviousWord
+type previousWordKey struct{}
+
+// ContextWithPreviousWord returns a context giving the word before the one
+// suggestions are asked for, for ContextRanker and custom rankers
+func ContextWithPreviousWord(ctx context.Context, word string) context.Context {
+	return context.WithValue(ctx, previousWordKey{}, word)
+}
+
+// applyRanking scores suggestions with the ranking pipeline
+func (dym *DidYouMean) applyRanking(ctx context.Context, normalized string, lang Language, suggestions []Suggestion) []Suggestion {
+	if len(dym.ranking) == 0 {
+		return suggestions
+	}
+
+	query := RankQuery{Input: normalized, Language: lang, dym: dym}
+	if previous, ok := ctx.Value(previousWordKey{}).(string); ok {
+		query.Previous = dym.normalize(previous, lang)
+	}
+	for i := range suggestions {
+		score, weights := 0.0, 0.0
+		for _, stage := range dym.ranking {
+			score += stage.Weight * math.Min(math.Max(stage.Ranker.Score(ctx, query, suggestions[i]), 0), 1)
+			weights += stage.Weight
+		}
+		suggestions[i].Similarity = score / weights
+	}
+	return suggestions
+}
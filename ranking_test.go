@@ -0,0 +1,78 @@
+package dymean_test
+
+import (
+	"context"
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestRankingPipeline tests business rules in the ranking pipeline
+func TestRankingPipeline(t *testing.T) {
+	words := []string{"boots", "boats", "bolts"}
+	inStock := map[string]bool{"bolts": true}
+
+	plain := dymean.NewDidYouMean(10000, 7)
+	plain.AddWords(words)
+	if suggestions := plain.GetSuggestions("bots", 3, 1); len(suggestions) == 0 || suggestions[0].Word == "bolts" {
+		t.Fatalf("Expected another word than bolts first without the pipeline, got %v", suggestions)
+	}
+
+	var queries []dymean.RankQuery
+	stock := dymean.RankerFunc(func(ctx context.Context, query dymean.RankQuery, suggestion dymean.Suggestion) float64 {
+		queries = append(queries, query)
+		if inStock[suggestion.Word] {
+			return 1
+		}
+		return 0
+	})
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithRankingPipeline(append(dymean.DefaultRankingPipeline(),
+		dymean.RankingStage{Name: "in stock", Ranker: stock, Weight: 0.5})...))
+	dym.AddWords(words)
+
+	suggestions := dym.GetSuggestions("bots", 3, 1)
+	if len(suggestions) != 3 || suggestions[0].Word != "bolts" {
+		t.Errorf("Expected bolts first, got %v", suggestions)
+	}
+	for _, suggestion := range suggestions {
+		if suggestion.Similarity < 0 || suggestion.Similarity > 1 {
+			t.Errorf("Expected similarities in [0, 1], got %v", suggestions)
+		}
+	}
+	if len(queries) == 0 || queries[0].Input != "bots" || queries[0].Language != dymean.English {
+		t.Errorf("Expected the query of the lookup, got %v", queries)
+	}
+
+	// A correct word still ranks first
+	if suggestions := dym.GetSuggestions("boots", 3, 1); suggestions[0].Word != "boots" {
+		t.Errorf("Expected the correct word first, got %v", suggestions)
+	}
+}
+
+// TestFrequencyAndContextRankers tests the built-in rankers
+func TestFrequencyAndContextRankers(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithRankingPipeline(
+		dymean.RankingStage{Name: "similarity", Ranker: dymean.SimilarityRanker, Weight: 1},
+		dymean.RankingStage{Name: "frequency", Ranker: dymean.FrequencyRanker, Weight: 0.2},
+		dymean.RankingStage{Name: "context", Ranker: dymean.ContextRanker, Weight: 1},
+	))
+	dym.AddWords([]string{"cat", "car", "red", "fast", "the"})
+
+	// "cat" and "car" are one substitution from "caz"
+	dym.SetWordFrequencies(map[string]int{"cat": 100, "car": 10}, dymean.English)
+	if suggestions := dym.GetSuggestions("caz", 2, 1); len(suggestions) != 2 || suggestions[0].Word != "cat" {
+		t.Errorf("Expected the frequent cat first, got %v", suggestions)
+	}
+
+	dym.SetBigramFrequencies(map[string]int{"fast car": 50, "fast food": 50}, dymean.English)
+	ctx := dymean.ContextWithPreviousWord(context.Background(), "Fast")
+	if suggestions := dym.GetSuggestionsForLanguageContext(ctx, "caz", 2, 1, dymean.English); suggestions[0].Word != "car" {
+		t.Errorf("Expected car after fast, got %v", suggestions)
+	}
+
+	// CheckText gives the previous word of the text
+	corrections := dym.CheckTextForLanguage("the cat was red. a fast caz", dymean.English)
+	last := corrections[len(corrections)-1]
+	if last.Original != "caz" || last.Suggestions[0].Word != "car" {
+		t.Errorf("Expected car for caz after fast, got %v", corrections)
+	}
+}
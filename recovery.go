@@ -0,0 +1,114 @@
+package dymean
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// maxGeneratedEditDistance bounds the edit distance of candidate generation,
+// whose cost grows exponentially with it: distance 3 takes seconds for a
+// short word. The automaton search is bounded by the dictionary instead.
+const maxGeneratedEditDistance = 2
+
+// ErrPanic is matched by errors.Is for every *PanicError
+var ErrPanic = errors.New("recovered panic")
+
+// PanicError reports a panic recovered while handling an input, so a
+// malformed input fails its call instead of crashing the host
+type PanicError struct {
+	Op    string // Operation that panicked, such as "suggestions"
+	Input string // Start of the word or text being handled
+	Value any    // Value passed to panic
+	Stack []byte // Stack of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s of %q: panic: %v", e.Op, e.Input, e.Value)
+}
+
+// Is makes errors.Is(err, ErrPanic) match
+func (e *PanicError) Is(target error) bool {
+	return target == ErrPanic
+}
+
+// ErrInvalidArgument is matched by errors.Is for every *InvalidArgumentError
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// InvalidArgumentError reports an argument out of its range
+type InvalidArgumentError struct {
+	Argument string
+	Value    int
+}
+
+func (e *InvalidArgumentError) Error() string {
+	return fmt.Sprintf("invalid %s %d", e.Argument, e.Value)
+}
+
+// Is makes errors.Is(err, ErrInvalidArgument) match
+func (e *InvalidArgumentError) Is(target error) bool {
+	return target == ErrInvalidArgument
+}
+
+// WithRecovery recovers the panics of suggestions and text checks, so a
+// malformed input can never crash the host service: the call returns no
+// suggestions or corrections, the panic is logged with its stack, and
+// onPanic, if not nil, is called with it, for instance to report it. Panics
+// propagate without the option; TryGetSuggestionsForLanguage returns them as
+// errors either way.
+func WithRecovery(onPanic func(*PanicError)) Option {
+	return func(dym *DidYouMean) {
+		dym.recovery = true
+		dym.onPanic = onPanic
+	}
+}
+
+// maxPanicInput is the number of runes of the input kept in a PanicError
+const maxPanicInput = 64
+
+// newPanicError wraps a recovered panic value, keeping the start of the input
+func newPanicError(op, input string, value any) *PanicError {
+	if runes := []rune(input); len(runes) > maxPanicInput {
+		input = string(runes[:maxPanicInput])
+	}
+	return &PanicError{Op: op, Input: input, Value: value, Stack: debug.Stack()}
+}
+
+// recovered logs a recovered panic and hands it to the onPanic hook
+func (dym *DidYouMean) recovered(err *PanicError) {
+	dym.logger.Error("recovered panic", "op", err.Op, "input", err.Input, "panic", err.Value, "stack", string(err.Stack))
+	if dym.onPanic != nil {
+		dym.onPanic(err)
+	}
+}
+
+// TryGetSuggestionsForLanguage is GetSuggestionsForLanguageContext reporting
+// bad inputs as typed errors instead of empty results: an
+// *InvalidArgumentError for a negative count or distance, an
+// *InputTooLongError for an overlong word, and a *PanicError for a panic,
+// recovered whether or not WithRecovery is set.
+func (dym *DidYouMean) TryGetSuggestionsForLanguage(ctx context.Context, word string, maxSuggestions int, maxEditDistance int, lang Language) (suggestions []Suggestion, err error) {
+	if maxSuggestions < 0 {
+		return nil, &InvalidArgumentError{Argument: "maxSuggestions", Value: maxSuggestions}
+	}
+	if maxEditDistance < 0 {
+		return nil, &InvalidArgumentError{Argument: "maxEditDistance", Value: maxEditDistance}
+	}
+	if err := dym.CheckWordLength(word); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if value := recover(); value != nil {
+			panicErr := newPanicError("suggestions", word, value)
+			dym.recovered(panicErr)
+			suggestions, err = nil, panicErr
+		}
+	}()
+	suggestions = dym.collectSuggestions(ctx, word, maxSuggestions, maxEditDistance, lang, true)
+	if dym.explainSuggestions {
+		dym.explainAll(dym.normalize(word, lang), lang, suggestions)
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,75 @@
+package dymean_test
+
+import (
+	"context"
+	"errors"
+	"github.com/bi0dread/dymean"
+	"strings"
+	"testing"
+)
+
+// panickingTracer panics when a stage starts
+type panickingTracer struct{}
+
+func (panickingTracer) StartStage(context.Context, dymean.Stage, dymean.Language, int) func() {
+	panic("tracer failure")
+}
+
+// TestRecovery tests recovering panics into empty results and typed errors
+func TestRecovery(t *testing.T) {
+	var recovered []*dymean.PanicError
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithTracer(panickingTracer{}), dymean.WithRecovery(func(err *dymean.PanicError) {
+		recovered = append(recovered, err)
+	}))
+	dym.AddWords([]string{"hello", "world"})
+
+	if suggestions := dym.GetSuggestions("helo", 5, 1); len(suggestions) != 0 {
+		t.Errorf("Expected no suggestions, got %v", suggestions)
+	}
+	if corrections := dym.CheckTextForLanguage("helo world", dymean.English); len(corrections) != 1 || len(corrections[0].Suggestions) != 0 {
+		t.Errorf("Expected helo without suggestions, got %v", corrections)
+	}
+	if len(recovered) < 2 || recovered[0].Input != "helo" || recovered[0].Value != "tracer failure" || len(recovered[0].Stack) == 0 {
+		t.Errorf("Expected the panics to be reported, got %v", recovered)
+	}
+
+	// Without the option the error API still recovers
+	plain := dymean.NewDidYouMean(10000, 7, dymean.WithTracer(panickingTracer{}))
+	plain.AddWords([]string{"hello"})
+	_, err := plain.TryGetSuggestionsForLanguage(context.Background(), "helo", 5, 1, dymean.English)
+	var panicErr *dymean.PanicError
+	if !errors.Is(err, dymean.ErrPanic) || !errors.As(err, &panicErr) || panicErr.Op != "suggestions" {
+		t.Errorf("Expected a panic error, got %v", err)
+	}
+}
+
+// TestEdgeInputs tests that edge inputs give empty results or typed errors
+func TestEdgeInputs(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello", "héllo"})
+
+	for _, word := range []string{"", "\xff\xfe", "h\xffllo", "́", "hello world"} {
+		if suggestions := dym.GetSuggestions(word, -5, -1); len(suggestions) != 0 {
+			t.Errorf("Expected no suggestions for %q, got %v", word, suggestions)
+		}
+		dym.GetSuggestionsWithThreshold(word, 0.5, -1)
+		dym.GetSuggestionsForLanguages(word, -1, 1, []dymean.Language{dymean.English})
+	}
+
+	// Generation distances are capped instead of running for ever
+	if suggestions := dym.GetSuggestions("helo", 1, 1000); len(suggestions) == 0 || suggestions[0].Word != "hello" {
+		t.Errorf("Expected hello, got %v", suggestions)
+	}
+
+	ctx := context.Background()
+	if _, err := dym.TryGetSuggestionsForLanguage(ctx, "helo", -1, 1, dymean.English); !errors.Is(err, dymean.ErrInvalidArgument) {
+		t.Errorf("Expected an invalid argument error, got %v", err)
+	}
+	if _, err := dym.TryGetSuggestionsForLanguage(ctx, strings.Repeat("a", 100), 5, 1, dymean.English); !errors.Is(err, dymean.ErrInputTooLong) {
+		t.Errorf("Expected an input too long error, got %v", err)
+	}
+	suggestions, err := dym.TryGetSuggestionsForLanguage(ctx, "helo", 5, 1, dymean.English)
+	if err != nil || len(suggestions) == 0 || suggestions[0].Word != "hello" {
+		t.Errorf("Expected hello, got %v, %v", suggestions, err)
+	}
+}
@@ -0,0 +1,74 @@
+package dymean
+
+import "context"
+
+// relatedLanguage is a language whose suggestions are blended into another's
+type relatedLanguage struct {
+	lang    Language
+	penalty float64 // Factor applied to its similarities
+}
+
+// WithRelatedLanguages makes suggestions for each of the languages also draw
+// on the dictionaries of the others, such as Persian and Arabic or Spanish and
+// a registered Catalan, so borrowed words and words of the shared script are
+// found. Their similarities are multiplied by penalty, between 0 and 1, to
+// rank below equally close words of the language asked for; a word found in
+// both keeps its better score. Correct words are never blended, and penalties
+// outside (0, 1] leave the languages unrelated. The option can be given once
+// per set of related languages.
+func WithRelatedLanguages(penalty float64, langs ...Language) Option {
+	return func(dym *DidYouMean) {
+		if penalty <= 0 || penalty > 1 {
+			return
+		}
+		for _, lang := range langs {
+			for _, other := range langs {
+				if other != lang {
+					dym.related[lang] = append(dym.related[lang], relatedLanguage{lang: other, penalty: penalty})
+				}
+			}
+		}
+	}
+}
+
+// blendRelated adds the suggestions of the languages related to lang, with
+// their penalty, to the suggestions for a word misspelled in lang
+func (dym *DidYouMean) blendRelated(ctx context.Context, word string, maxSuggestions int, maxEditDistance int, lang Language, suggestions []Suggestion) []Suggestion {
+	related := dym.related[lang]
+	if len(related) == 0 || (len(suggestions) > 0 && suggestions[0].Similarity >= 1.0) {
+		return suggestions
+	}
+
+	best := make(map[string]int, len(suggestions)) // Index of each word in suggestions
+	for i, suggestion := range suggestions {
+		best[suggestion.Word] = i
+	}
+	blended := false
+	for _, other := range related {
+		if !dym.hasDictionary(other.lang) {
+			continue
+		}
+		for _, suggestion := range dym.rankSuggestions(ctx, word, maxSuggestions, maxEditDistance, other.lang, false) {
+			suggestion.Similarity *= other.penalty
+			if i, ok := best[suggestion.Word]; ok {
+				if suggestion.Similarity > suggestions[i].Similarity {
+					suggestions[i] = suggestion
+					blended = true
+				}
+				continue
+			}
+			best[suggestion.Word] = len(suggestions)
+			suggestions = append(suggestions, suggestion)
+			blended = true
+		}
+	}
+	if !blended {
+		return suggestions
+	}
+
+	sortSuggestions(suggestions, lang)
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	return suggestions
+}
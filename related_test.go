@@ -0,0 +1,48 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestRelatedLanguages tests blending suggestions of related languages with a penalty
+func TestRelatedLanguages(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithRelatedLanguages(0.9, dymean.Spanish, dymean.Italian))
+	dym.AddWordsForLanguage([]string{"casa", "perro", "gato"}, dymean.Spanish)
+	dym.AddWordsForLanguage([]string{"cane", "gatto", "pizza"}, dymean.Italian)
+
+	// A borrowed word is found in the related dictionary
+	suggestions := dym.GetSuggestionsForLanguage("piza", 5, 1, dymean.Spanish)
+	if len(suggestions) == 0 || suggestions[0].Word != "pizza" {
+		t.Fatalf("Expected pizza from Italian, got %v", suggestions)
+	}
+
+	// Equally close words of the language asked for rank first
+	suggestions = dym.GetSuggestionsForLanguage("cana", 5, 1, dymean.Italian)
+	if len(suggestions) < 2 || suggestions[0].Word != "cane" || suggestions[1].Word != "casa" {
+		t.Fatalf("Expected cane before casa, got %v", suggestions)
+	}
+	if suggestions[1].Similarity >= suggestions[0].Similarity {
+		t.Errorf("Expected the Spanish word to be penalized, got %v", suggestions)
+	}
+
+	// A word of the related language outranks misspellings of other words
+	suggestions = dym.GetSuggestionsForLanguage("gato", 5, 1, dymean.Italian)
+	if len(suggestions) == 0 || suggestions[0].Word != "gato" || suggestions[0].Similarity != 0.9 {
+		t.Errorf("Expected the Spanish gato first with the penalty, got %v", suggestions)
+	}
+
+	// Correct words are not blended
+	suggestions = dym.GetSuggestionsForLanguage("casa", 5, 1, dymean.Spanish)
+	if len(suggestions) != 1 || suggestions[0].Word != "casa" {
+		t.Errorf("Expected only casa, got %v", suggestions)
+	}
+
+	// Without the option languages stay apart
+	plain := dymean.NewDidYouMean(10000, 7)
+	plain.AddWordsForLanguage([]string{"casa"}, dymean.Spanish)
+	plain.AddWordsForLanguage([]string{"pizza"}, dymean.Italian)
+	if suggestions := plain.GetSuggestionsForLanguage("piza", 5, 1, dymean.Spanish); len(suggestions) != 0 {
+		t.Errorf("Expected no suggestions, got %v", suggestions)
+	}
+}
@@ -0,0 +1,20 @@
+module github.com/bi0dread/dymean/replication/redispubsub
+
+go 1.21
+
+require (
+	github.com/alicebob/miniredis/v2 v2.31.1
+	github.com/bi0dread/dymean v0.0.0
+	github.com/redis/go-redis/v9 v9.5.1
+)
+
+require (
+	github.com/alicebob/gopher-json v0.0.0-20200520072559-a9ecdc9d1d3a // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/yuin/gopher-lua v1.1.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The transport is versioned with the checker it replicates
+replace github.com/bi0dread/dymean => ../../
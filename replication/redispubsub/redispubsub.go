@@ -0,0 +1,56 @@
+// Package redispubsub is a replication.Transport over Redis Pub/Sub. It is a
+// separate module so the checker does not depend on a Redis client.
+//
+//	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	replicator := replication.New(dym, redispubsub.New(client, "dymean:words"), replication.Options{})
+//	go replicator.Run(ctx)
+package redispubsub
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+)
+
+// Transport publishes and receives mutations on a Redis channel
+type Transport struct {
+	client  redis.UniversalClient
+	channel string
+}
+
+// New creates a transport on a Redis channel. Every instance sharing a
+// dictionary must use the same channel.
+func New(client redis.UniversalClient, channel string) *Transport {
+	return &Transport{client: client, channel: channel}
+}
+
+// Publish sends a message on the channel
+func (t *Transport) Publish(ctx context.Context, message []byte) error {
+	return t.client.Publish(ctx, t.channel, message).Err()
+}
+
+// Subscribe handles the messages of the channel until ctx is done or the
+// connection fails for good. The client reconnects after transient failures;
+// messages published meanwhile are lost, as with any Pub/Sub subscriber.
+func (t *Transport) Subscribe(ctx context.Context, handle func(message []byte)) error {
+	pubsub := t.client.Subscribe(ctx, t.channel)
+	defer pubsub.Close()
+
+	// Wait for the subscription, so messages published after Subscribe
+	// returns control to Run are not missed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return err
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			handle([]byte(message.Payload))
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
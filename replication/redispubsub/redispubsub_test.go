@@ -0,0 +1,46 @@
+package redispubsub_test
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/bi0dread/dymean"
+	"github.com/bi0dread/dymean/replication"
+	"github.com/bi0dread/dymean/replication/redispubsub"
+	"github.com/redis/go-redis/v9"
+	"testing"
+	"time"
+)
+
+// eventually waits for a condition to hold
+func eventually(t *testing.T, condition func() bool, message string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal(message)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestRedisReplication tests that two instances converge through Redis Pub/Sub
+func TestRedisReplication(t *testing.T) {
+	server := miniredis.RunT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	instances := make([]*dymean.DidYouMean, 2)
+	for i := range instances {
+		instances[i] = dymean.NewDidYouMean(10000, 7)
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		defer client.Close()
+		go replication.New(instances[i], redispubsub.New(client, "dymean:words"), replication.Options{}).Run(ctx)
+	}
+	eventually(t, func() bool { return server.PubSubNumSub("dymean:words")["dymean:words"] == 2 }, "Expected both instances to subscribe")
+
+	instances[0].AddWords([]string{"kubernetes"})
+	eventually(t, func() bool { return instances[1].IsCorrect("kubernetes") }, "Expected the added word to replicate")
+
+	instances[1].RemoveWords([]string{"kubernetes"})
+	eventually(t, func() bool { return !instances[0].IsCorrect("kubernetes") }, "Expected the removal to replicate")
+}
@@ -0,0 +1,238 @@
+// Package replication keeps the user-added words of several DidYouMean
+// instances in sync, such as replicas behind a load balancer. Each instance
+// publishes the words added to and removed from its dictionaries through a
+// Transport and applies the mutations published by the others.
+//
+// Transports deliver messages to the instances subscribed at the time, as
+// publish/subscribe systems do, so an instance started later does not receive
+// earlier mutations: load a snapshot of the shared dictionary before Run.
+// Reloads with ReplaceDictionary are not replicated, since each instance
+// reloads from the same source.
+package replication
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/bi0dread/dymean"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// Mutation is a dictionary change published by an instance
+type Mutation struct {
+	Origin   string                     `json:"origin"` // ID of the publishing instance
+	Kind     dymean.DictionaryEventKind `json:"kind"`   // EventWordsAdded or EventWordsRemoved
+	Language dymean.Language            `json:"language"`
+	Words    []string                   `json:"words"`
+}
+
+// Transport carries encoded mutations between instances
+type Transport interface {
+	// Publish sends a message to every subscriber, including the publisher
+	Publish(ctx context.Context, message []byte) error
+
+	// Subscribe calls handle for every message published until ctx is done
+	// or the subscription fails, and returns the error that ended it.
+	// Messages are handled one at a time.
+	Subscribe(ctx context.Context, handle func(message []byte)) error
+}
+
+// Options configures a Replicator
+type Options struct {
+	// NodeID identifies the instance in its mutations. Defaults to a random ID.
+	NodeID string
+
+	// Logger receives publish and decode failures. By default nothing is logged.
+	Logger *slog.Logger
+}
+
+// Replicator publishes the local mutations of a checker and applies remote ones
+type Replicator struct {
+	dym       *dymean.DidYouMean
+	transport Transport
+	nodeID    string
+	logger    *slog.Logger
+
+	mu     sync.Mutex
+	remote map[remoteKey]int // Words being applied from remote mutations, not to publish again
+}
+
+// remoteKey identifies a word changed by a remote mutation
+type remoteKey struct {
+	kind dymean.DictionaryEventKind
+	lang dymean.Language
+	word string
+}
+
+// New creates a replicator for a checker
+func New(dym *dymean.DidYouMean, transport Transport, opts Options) *Replicator {
+	if opts.NodeID == "" {
+		id := make([]byte, 8)
+		rand.Read(id)
+		opts.NodeID = hex.EncodeToString(id)
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Replicator{
+		dym:       dym,
+		transport: transport,
+		nodeID:    opts.NodeID,
+		logger:    opts.Logger,
+		remote:    make(map[remoteKey]int),
+	}
+}
+
+// NodeID returns the ID the replicator publishes its mutations with
+func (r *Replicator) NodeID() string {
+	return r.nodeID
+}
+
+// Run publishes the words added to and removed from the checker, and applies
+// the mutations of other instances, until ctx is done or the subscription
+// fails. It returns the error that ended the subscription.
+func (r *Replicator) Run(ctx context.Context) error {
+	unregister := r.dym.OnDictionaryChange(func(event dymean.DictionaryEvent) {
+		r.publish(ctx, event)
+	})
+	defer unregister()
+
+	err := r.transport.Subscribe(ctx, r.apply)
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// publish sends a local change, leaving out the words applied from remote mutations
+func (r *Replicator) publish(ctx context.Context, event dymean.DictionaryEvent) {
+	if event.Kind != dymean.EventWordsAdded && event.Kind != dymean.EventWordsRemoved {
+		return
+	}
+
+	r.mu.Lock()
+	local := make([]string, 0, len(event.Words))
+	for _, word := range event.Words {
+		key := remoteKey{kind: event.Kind, lang: event.Language, word: word}
+		if r.remote[key] > 0 {
+			continue
+		}
+		local = append(local, word)
+	}
+	r.mu.Unlock()
+	if len(local) == 0 {
+		return
+	}
+
+	message, err := json.Marshal(Mutation{Origin: r.nodeID, Kind: event.Kind, Language: event.Language, Words: local})
+	if err == nil {
+		err = r.transport.Publish(ctx, message)
+	}
+	if err != nil {
+		r.logger.Error("publish dictionary mutation", "language", event.Language, "words", len(local), "error", err)
+	}
+}
+
+// apply applies a mutation published by another instance
+func (r *Replicator) apply(message []byte) {
+	var mutation Mutation
+	if err := json.Unmarshal(message, &mutation); err != nil {
+		r.logger.Warn("skipped invalid dictionary mutation", "error", err)
+		return
+	}
+	if mutation.Origin == r.nodeID || len(mutation.Words) == 0 {
+		return
+	}
+
+	keys := r.markRemote(mutation)
+	defer r.unmarkRemote(keys)
+
+	switch mutation.Kind {
+	case dymean.EventWordsAdded:
+		r.dym.AddWordsForLanguage(mutation.Words, mutation.Language)
+	case dymean.EventWordsRemoved:
+		r.dym.RemoveWordsForLanguage(mutation.Words, mutation.Language)
+	default:
+		r.logger.Warn("skipped dictionary mutation of unknown kind", "kind", mutation.Kind)
+	}
+}
+
+// markRemote records the words of a remote mutation so the change events
+// they cause are not published again. Words are normalized as the events
+// report them.
+func (r *Replicator) markRemote(mutation Mutation) []remoteKey {
+	keys := make([]remoteKey, len(mutation.Words))
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, word := range mutation.Words {
+		keys[i] = remoteKey{kind: mutation.Kind, lang: mutation.Language, word: word}
+		r.remote[keys[i]]++
+	}
+	return keys
+}
+
+// unmarkRemote forgets the words recorded by markRemote
+func (r *Replicator) unmarkRemote(keys []remoteKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, key := range keys {
+		if r.remote[key]--; r.remote[key] <= 0 {
+			delete(r.remote, key)
+		}
+	}
+}
+
+// MemoryTransport is a Transport connecting the instances of one process,
+// for tests and for several checkers sharing a process
+type MemoryTransport struct {
+	mu          sync.Mutex
+	subscribers map[int]chan []byte
+	next        int
+}
+
+// NewMemoryTransport creates an in-process transport
+func NewMemoryTransport() *MemoryTransport {
+	return &MemoryTransport{subscribers: make(map[int]chan []byte)}
+}
+
+// Publish delivers a message to every subscriber
+func (t *MemoryTransport) Publish(ctx context.Context, message []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, ch := range t.subscribers {
+		select {
+		case ch <- message:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Subscribe handles published messages until ctx is done
+func (t *MemoryTransport) Subscribe(ctx context.Context, handle func(message []byte)) error {
+	ch := make(chan []byte, 1024)
+	t.mu.Lock()
+	id := t.next
+	t.next++
+	t.subscribers[id] = ch
+	t.mu.Unlock()
+
+	defer func() {
+		t.mu.Lock()
+		delete(t.subscribers, id)
+		t.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case message := <-ch:
+			handle(message)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
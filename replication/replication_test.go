@@ -0,0 +1,91 @@
+package replication_test
+
+import (
+	"context"
+	"github.com/bi0dread/dymean"
+	"github.com/bi0dread/dymean/replication"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingTransport counts the messages published through a transport
+type countingTransport struct {
+	*replication.MemoryTransport
+	published *atomic.Int32
+}
+
+func (t countingTransport) Publish(ctx context.Context, message []byte) error {
+	t.published.Add(1)
+	return t.MemoryTransport.Publish(ctx, message)
+}
+
+// eventually waits for a condition to hold
+func eventually(t *testing.T, condition func() bool, message string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal(message)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestReplication tests that instances converge without echoing mutations
+func TestReplication(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bus := replication.NewMemoryTransport()
+	var published atomic.Int32
+
+	instances := make([]*dymean.DidYouMean, 3)
+	for i := range instances {
+		instances[i] = dymean.NewDidYouMean(10000, 7)
+		instances[i].AddWords([]string{"hello"})
+		replicator := replication.New(instances[i], countingTransport{bus, &published}, replication.Options{})
+		go replicator.Run(ctx)
+	}
+	time.Sleep(10 * time.Millisecond) // Let the subscriptions start
+
+	instances[0].AddWords([]string{"Kubernetes", "hello"})
+	for _, dym := range instances {
+		eventually(t, func() bool { return dym.IsCorrect("kubernetes") }, "Expected the added word on every instance")
+	}
+
+	instances[2].RemoveWords([]string{"hello"})
+	for _, dym := range instances {
+		eventually(t, func() bool { return !dym.IsCorrect("hello") }, "Expected the removal on every instance")
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if n := published.Load(); n != 2 {
+		t.Errorf("Expected one message per local change, got %d", n)
+	}
+}
+
+// TestReplicationSkipsOwnAndInvalidMessages tests that an instance ignores its own and malformed messages
+func TestReplicationSkipsOwnAndInvalidMessages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bus := replication.NewMemoryTransport()
+	dym := dymean.NewDidYouMean(10000, 7)
+	replicator := replication.New(dym, bus, replication.Options{NodeID: "a"})
+
+	done := make(chan error)
+	go func() { done <- replicator.Run(ctx) }()
+	time.Sleep(10 * time.Millisecond)
+
+	bus.Publish(ctx, []byte(`not json`))
+	bus.Publish(ctx, []byte(`{"origin": "a", "kind": "added", "language": "en", "words": ["mine"]}`))
+	bus.Publish(ctx, []byte(`{"origin": "b", "kind": "added", "language": "en", "words": ["theirs"]}`))
+	eventually(t, func() bool { return dym.IsCorrect("theirs") }, "Expected the remote word")
+	if dym.IsCorrect("mine") {
+		t.Error("Expected the instance to skip its own mutation")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Expected Run to end cleanly, got %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package dymean
+
+import (
+	"strings"
+)
+
+// maxReportSamples is the number of rejected words kept per reason in an AddReport
+const maxReportSamples = 10
+
+// RejectReason explains why a word was not added to a dictionary
+type RejectReason string
+
+// Reasons for rejecting dictionary words
+const (
+	RejectEmpty             RejectReason = "empty after normalization"
+	RejectInvalidCharacters RejectReason = "invalid characters"
+	RejectDuplicate         RejectReason = "duplicate"
+	RejectTooLong           RejectReason = "too long" // Longer than WithMaxWordLength
+)
+
+// RejectedWord is a word that was not added, with the reason
+type RejectedWord struct {
+	Word       string // Word as given
+	Normalized string // Word after the language's normalizer
+	Reason     RejectReason
+}
+
+// AddReport summarizes the outcome of adding words to a dictionary
+type AddReport struct {
+	Language Language
+	Added    int                  // Words new to the dictionary
+	Rejected map[RejectReason]int // Words not added, by reason
+	Samples  []RejectedWord       // Up to maxReportSamples rejected words per reason
+}
+
+// RejectedCount returns the total number of words not added
+func (r AddReport) RejectedCount() int {
+	total := 0
+	for _, count := range r.Rejected {
+		total += count
+	}
+	return total
+}
+
+// reject records a word that was not added
+func (r *AddReport) reject(word, normalized string, reason RejectReason) {
+	r.Rejected[reason]++
+	if r.Rejected[reason] <= maxReportSamples {
+		r.Samples = append(r.Samples, RejectedWord{Word: word, Normalized: normalized, Reason: reason})
+	}
+}
+
+// AddWordsReport adds words to a language's dictionary like
+// AddWordsForLanguage and reports the words it did not add: words that are
+// empty after normalization, longer than the maximum word length, contain
+// characters outside the language's alphabet, or duplicate another word of
+// the list or the dictionary.
+func (dym *DidYouMean) AddWordsReport(words []string, lang Language) AddReport {
+	report := AddReport{Language: lang, Rejected: make(map[RejectReason]int)}
+
+	valid := make([]string, 0, len(words))
+	sources := make(map[string]string, len(words)) // Normalized form to first input word
+	collisions := make([]string, 0)
+	for _, word := range words {
+		normalized := dym.normalize(word, lang)
+		switch {
+		case strings.TrimSpace(normalized) == "":
+			report.reject(word, normalized, RejectEmpty)
+			continue
+		case dym.isWordTooLong(normalized):
+			report.reject(word, normalized, RejectTooLong)
+			continue
+		case !dym.isValidWord(normalized, lang):
+			report.reject(word, normalized, RejectInvalidCharacters)
+			continue
+		}
+
+		if source, ok := sources[normalized]; ok {
+			if source != word {
+				collisions = append(collisions, source+" / "+word)
+			}
+			report.reject(word, normalized, RejectDuplicate)
+			continue
+		}
+		sources[normalized] = word
+		valid = append(valid, normalized)
+	}
+
+	dym.mu.Lock()
+	// Initialize Bloom filter and dictionary for this language if not exists
+	if dym.bloomFilters[lang] == nil {
+		dym.bloomFilters[lang] = NewBloomFilter(10000, 7)
+		dym.dictionaries[lang] = make(map[string]bool)
+	}
+
+	added := make([]string, 0, len(valid))
+	for _, word := range valid {
+		if dym.lookup(lang, word) {
+			report.reject(sources[word], word, RejectDuplicate)
+			continue
+		}
+		dym.bloomFilters[lang].Add(word)
+		dym.dictionaries[lang][word] = true
+		delete(dym.tombstones[lang], word)
+		dym.addPhrase(lang, word)
+		added = append(added, word)
+	}
+	report.Added = len(added)
+
+	dym.compactDictionary(lang)
+	if report.Added > 0 {
+		delete(dym.tries, lang)
+		delete(dym.neighbors, lang)
+		delete(dym.equivalences, lang)
+	}
+	dym.mu.Unlock()
+	dym.notify(DictionaryEvent{Kind: EventWordsAdded, Language: lang, Words: added})
+
+	if invalid := report.Rejected[RejectInvalidCharacters] + report.Rejected[RejectEmpty] + report.Rejected[RejectTooLong]; invalid > 0 {
+		examples := make([]string, 0)
+		for _, rejected := range report.Samples {
+			if rejected.Reason != RejectDuplicate {
+				examples = append(examples, rejected.Word)
+			}
+		}
+		dym.logger.Warn("skipped invalid dictionary words",
+			"language", lang, "count", invalid, "examples", sample(examples))
+	}
+	if len(collisions) > 0 {
+		dym.logger.Warn("dictionary words collide after normalization",
+			"language", lang, "count", len(collisions), "examples", sample(collisions))
+	}
+
+	return report
+}
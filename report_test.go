@@ -0,0 +1,47 @@
+package dymean_test
+
+import (
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"strings"
+	"testing"
+)
+
+// TestAddWordsReport tests reporting of rejected dictionary words
+func TestAddWordsReport(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello"})
+
+	report := dym.AddWordsReport([]string{"world", "World", "hello", "", "  ", "abc123", "help", strings.Repeat("a", 65)}, dymean.English)
+	if report.Added != 2 {
+		t.Errorf("Expected 2 words added, got %d", report.Added)
+	}
+	expected := map[dymean.RejectReason]int{
+		dymean.RejectDuplicate:         2, // "World" in the list, "hello" in the dictionary
+		dymean.RejectEmpty:             2,
+		dymean.RejectInvalidCharacters: 1,
+		dymean.RejectTooLong:           1, // Longer than DefaultMaxWordLength
+	}
+	for reason, count := range expected {
+		if report.Rejected[reason] != count {
+			t.Errorf("Expected %d words rejected as %q, got %d", count, reason, report.Rejected[reason])
+		}
+	}
+	if report.RejectedCount() != 6 || len(report.Samples) != 6 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+	if !dym.IsCorrect("world") || !dym.IsCorrect("help") || dym.IsCorrect(strings.Repeat("a", 65)) {
+		t.Error("Expected valid words to be added")
+	}
+
+	// Samples are bounded per reason
+	invalid := make([]string, 50)
+	for i := range invalid {
+		invalid[i] = fmt.Sprintf("x%d", i)
+	}
+	report = dym.AddWordsReport(invalid, dymean.English)
+	if report.Rejected[dymean.RejectInvalidCharacters] != 50 || len(report.Samples) != 10 {
+		t.Errorf("Expected 50 rejections with 10 samples, got %d and %d",
+			report.Rejected[dymean.RejectInvalidCharacters], len(report.Samples))
+	}
+}
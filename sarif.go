@@ -0,0 +1,174 @@
+package dymean
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// DocumentResult is the outcome of checking one document, for reports that
+// span several files
+type DocumentResult struct {
+	Path        string // Path of the document, relative paths are reported as is
+	Text        string // The checked text, used to compute lines and columns
+	Corrections []Correction
+}
+
+// sarifRules describes each correction category as a SARIF rule
+var sarifRules = []sarifRule{
+	{ID: string(CategorySpelling), ShortDescription: sarifMessage{Text: "Misspelled word"}},
+	{ID: string(CategoryWhitespace), ShortDescription: sarifMessage{Text: "Doubled or missing space"}},
+	{ID: string(CategoryGrammar), ShortDescription: sarifMessage{Text: "Grammar issue"}},
+	{ID: string(CategoryLayoutSwitch), ShortDescription: sarifMessage{Text: "Word typed with the wrong keyboard layout"}},
+}
+
+// WriteSARIF writes the corrections of documents as a SARIF 2.1.0 log, which
+// GitHub code scanning and other CI systems render as inline annotations.
+// Columns count Unicode code points, as LineColumn does, and the best
+// suggestion of each correction is included as a fix.
+func WriteSARIF(w io.Writer, results []DocumentResult) error {
+	run := sarifRun{
+		Tool:       sarifTool{Driver: sarifDriver{Name: "dymean", InformationURI: "https://github.com/bi0dread/dymean", Rules: sarifRules}},
+		ColumnKind: "unicodeCodePoints",
+		Results:    make([]sarifResult, 0),
+	}
+
+	for _, doc := range results {
+		uri := filepath.ToSlash(doc.Path)
+		for _, correction := range doc.Corrections {
+			region := sarifRegion{}
+			region.StartLine, region.StartColumn = LineColumn(doc.Text, correction.Start)
+			region.EndLine, region.EndColumn = LineColumn(doc.Text, correction.End)
+			location := sarifArtifactLocation{URI: uri}
+
+			result := sarifResult{
+				RuleID:    string(correction.Category),
+				Level:     "warning",
+				Message:   sarifMessage{Text: sarifText(correction)},
+				Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: location, Region: region}}},
+			}
+			if len(correction.Suggestions) > 0 {
+				replacement := correction.Suggestions[0].Word
+				result.Fixes = []sarifFix{{
+					Description: sarifMessage{Text: fmt.Sprintf("Replace with %q", replacement)},
+					ArtifactChanges: []sarifArtifactChange{{
+						ArtifactLocation: location,
+						Replacements:     []sarifReplacement{{DeletedRegion: region, InsertedContent: sarifContent{Text: replacement}}},
+					}},
+				}}
+			}
+			run.Results = append(run.Results, result)
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
+
+// sarifText returns the message of a correction
+func sarifText(correction Correction) string {
+	var message string
+	switch correction.Category {
+	case CategorySpelling:
+		message = fmt.Sprintf("%q is not in the dictionary", correction.Original)
+	case CategoryWhitespace:
+		message = "Whitespace issue"
+	default:
+		message = fmt.Sprintf("%s issue at %q", correction.Category, correction.Original)
+	}
+	if len(correction.Suggestions) == 0 {
+		return message
+	}
+	words := make([]string, 0, len(correction.Suggestions))
+	for _, suggestion := range correction.Suggestions {
+		words = append(words, fmt.Sprintf("%q", suggestion.Word))
+	}
+	return message + "; did you mean " + strings.Join(words, ", ") + "?"
+}
+
+// The SARIF 2.1.0 objects used by WriteSARIF
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool     `json:"tool"`
+	ColumnKind string        `json:"columnKind"`
+	Results    []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	Fixes     []sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion  `json:"deletedRegion"`
+	InsertedContent sarifContent `json:"insertedContent"`
+}
+
+type sarifContent struct {
+	Text string `json:"text"`
+}
@@ -0,0 +1,81 @@
+package dymean_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestWriteSARIF tests the SARIF log of checked documents
+func TestWriteSARIF(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello", "world"})
+
+	text := "hello\nسلام wrold"
+	results := []dymean.DocumentResult{
+		{Path: "docs/a.txt", Text: text, Corrections: dym.CheckTextForLanguage(text, dymean.English)},
+		{Path: "docs/b.txt", Text: "hello"},
+	}
+	var buf bytes.Buffer
+	if err := dymean.WriteSARIF(&buf, results); err != nil {
+		t.Fatalf("Failed to write SARIF: %v", err)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name string `json:"name"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine, StartColumn, EndLine, EndColumn int
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+				Fixes []struct {
+					ArtifactChanges []struct {
+						Replacements []struct {
+							InsertedContent struct {
+								Text string `json:"text"`
+							} `json:"insertedContent"`
+						} `json:"replacements"`
+					} `json:"artifactChanges"`
+				} `json:"fixes"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("Failed to parse SARIF: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || log.Runs[0].Tool.Driver.Name != "dymean" {
+		t.Fatalf("Unexpected SARIF log %s", buf.String())
+	}
+
+	var found bool
+	for _, result := range log.Runs[0].Results {
+		location := result.Locations[0].PhysicalLocation
+		if location.ArtifactLocation.URI != "docs/a.txt" {
+			t.Errorf("Unexpected location %+v", location)
+		}
+		region := location.Region
+		if result.RuleID == "spelling" && region.StartLine == 2 && region.StartColumn == 6 && region.EndColumn == 11 {
+			found = true
+			if result.Fixes[0].ArtifactChanges[0].Replacements[0].InsertedContent.Text != "world" {
+				t.Errorf("Expected a fix to world, got %+v", result.Fixes)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Expected wrold at 2:6-2:11, got %s", buf.String())
+	}
+}
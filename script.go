@@ -0,0 +1,82 @@
+package dymean
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// genericPrefix starts the codes of the generic script languages, after the
+// BCP 47 subtag for an undetermined language
+const genericPrefix = "und-"
+
+// rtlScripts are the scripts written right to left among those a generic
+// language can use
+var rtlScripts = map[string]bool{
+	"Adlam": true, "Arabic": true, "Hebrew": true, "Mandaic": true, "Nko": true,
+	"Samaritan": true, "Syriac": true, "Thaana": true,
+}
+
+// ScriptLanguage returns the generic language of a Unicode script, named as
+// in unicode.Scripts ("Thai", "Georgian", "Armenian"). Words of languages
+// dymean does not support are validated by membership of the script, with
+// combining marks allowed after a letter, and checked against the words added
+// for the generic language, instead of following English rules:
+//
+//	dym.AddWordsForLanguage(thaiWords, dymean.ScriptLanguage("Thai"))
+//
+// DetectLanguage returns the generic language of a script no supported
+// language uses.
+func ScriptLanguage(script string) Language {
+	return Language(genericPrefix + script)
+}
+
+// GenericScript returns the script of a generic language made with
+// ScriptLanguage, and false for other languages
+func GenericScript(lang Language) (string, bool) {
+	script, ok := strings.CutPrefix(string(lang), genericPrefix)
+	if !ok || unicode.Scripts[script] == nil {
+		return "", false
+	}
+	return script, true
+}
+
+// genericLanguageInfo describes the generic language of a script
+func genericLanguageInfo(script string) LanguageInfo {
+	direction := "ltr"
+	if rtlScripts[script] {
+		direction = "rtl"
+	}
+	return LanguageInfo{
+		Code:       ScriptLanguage(script),
+		Name:       script + " (generic)",
+		Direction:  direction,
+		Scripts:    []*unicode.RangeTable{unicode.Scripts[script]},
+		IsRTL:      rtlScripts[script],
+		Normalizer: normalizeEnglish,
+
+		SuggestionTuning: defaultSuggestionTuning,
+	}
+}
+
+// scriptLanguage returns the language of a letter outside the scripts
+// DetectLanguage checks: the first supported language using its script, or
+// the generic language of the script
+func scriptLanguage(r rune) Language {
+	for _, lang := range GetSupportedLanguages() {
+		if scripts := GetLanguageInfo(lang).Scripts; len(scripts) > 0 && unicode.In(r, scripts...) {
+			return lang
+		}
+	}
+	names := make([]string, 0, 1)
+	for name, table := range unicode.Scripts {
+		if unicode.Is(table, r) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return English
+	}
+	sort.Strings(names) // Scripts never overlap, but keep the choice stable
+	return ScriptLanguage(names[0])
+}
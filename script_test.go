@@ -0,0 +1,64 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestDetectScriptLanguage tests that unsupported scripts get their generic language
+func TestDetectScriptLanguage(t *testing.T) {
+	tests := map[string]dymean.Language{
+		"สวัสดี":    dymean.ScriptLanguage("Thai"),
+		"გამარჯობა": dymean.ScriptLanguage("Georgian"),
+		"բարեւ":     dymean.ScriptLanguage("Armenian"),
+		"カタカナ":      dymean.Japanese, // A supported language uses Katakana
+		"hello":     dymean.English,
+	}
+	for word, expected := range tests {
+		if lang := dymean.DetectLanguage(word); lang != expected {
+			t.Errorf("DetectLanguage(%q) = %s, want %s", word, lang, expected)
+		}
+	}
+
+	if script, ok := dymean.GenericScript(dymean.ScriptLanguage("Thai")); !ok || script != "Thai" {
+		t.Errorf("Expected the Thai script, got %q %v", script, ok)
+	}
+	if _, ok := dymean.GenericScript(dymean.English); ok {
+		t.Error("Expected English not to be a generic language")
+	}
+	if info := dymean.GetLanguageInfo(dymean.ScriptLanguage("Hebrew")); !info.IsRTL || info.Code != dymean.ScriptLanguage("Hebrew") {
+		t.Errorf("Expected right-to-left generic Hebrew, got %+v", info)
+	}
+}
+
+// TestScriptLanguageValidation tests validation by script membership
+func TestScriptLanguageValidation(t *testing.T) {
+	thai := dymean.ScriptLanguage("Thai")
+	if !dymean.IsValidWordForLanguage("สวัสดี", thai) { // Vowel signs are combining marks
+		t.Error("Expected a Thai word with vowel signs to be valid")
+	}
+	for _, word := range []string{"hello", "สวัสดีhello", "ัส"} {
+		if dymean.IsValidWordForLanguage(word, thai) {
+			t.Errorf("Expected %q to be invalid for generic Thai", word)
+		}
+	}
+}
+
+// TestScriptLanguageDictionary tests checking words against a user dictionary of a script
+func TestScriptLanguageDictionary(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithAutomatonSearch())
+	georgian := dymean.ScriptLanguage("Georgian")
+	dym.AddWordsForLanguage([]string{"გამარჯობა", "მადლობა"}, georgian)
+
+	word := "მადლობა"
+	if lang := dymean.DetectLanguage(word); !dym.IsCorrectForLanguage(word, lang) {
+		t.Errorf("Expected %q to be correct in %s", word, lang)
+	}
+	if dym.IsCorrectForLanguage(word, dymean.English) {
+		t.Error("Expected English not to hold Georgian words")
+	}
+	suggestions := dym.GetSuggestionsForLanguage("მადლბა", 5, 1, georgian)
+	if len(suggestions) == 0 || suggestions[0].Word != "მადლობა" {
+		t.Errorf("Expected მადლობა, got %v", suggestions)
+	}
+}
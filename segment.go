@@ -0,0 +1,143 @@
+package dymean
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Parameters of SegmentAndCorrect
+const (
+	maxSegmentRunes       = 24  // Longest word looked for in the input
+	maxSegmentInput       = 256 // Longest input segmented, in runes
+	minCorrectedRunes     = 4   // Shorter pieces are only taken as they are
+	correctionCostFactor  = 1.5 // Cost of one edit, in unseen words
+	unknownRuneCostFactor = 1.0 // Cost of each letter left unknown, in unseen words
+)
+
+// Segment is a piece of segmented input
+type Segment struct {
+	Original  string // The characters of the normalized input covered
+	Word      string // The dictionary word, or Original when unknown
+	Corrected bool   // Whether Word corrects a typo in Original
+	Known     bool   // Whether Word is a dictionary word
+}
+
+// Segmentation is the result of SegmentAndCorrect
+type Segmentation struct {
+	Segments []Segment
+	Text     string // The words separated by spaces
+}
+
+// SegmentAndCorrect splits input typed without spaces, such as
+// "wheretobuyshoes" in a search box, into words of the current language,
+// correcting typos on the way
+func (dym *DidYouMean) SegmentAndCorrect(input string) Segmentation {
+	return dym.SegmentAndCorrectForLanguage(input, dym.currentLang)
+}
+
+// SegmentAndCorrectForLanguage splits input typed without spaces into words
+// of a language. It finds the most likely sequence of words by dynamic
+// programming: each word costs its negative log probability, from the
+// frequencies set with SetWordFrequencies (all words are equally likely
+// without them, so fewer words win), a piece of at least four letters can be
+// replaced by a dictionary word one edit away at an extra cost, and letters
+// matching no word are kept at a higher cost. Spaces in the input are kept as
+// word boundaries.
+//
+// Every piece of the input is looked up and corrected, so input longer than
+// 256 runes is truncated, like overlong texts in CheckText.
+func (dym *DidYouMean) SegmentAndCorrectForLanguage(input string, lang Language) Segmentation {
+	var result Segmentation
+	cost, unseen := dym.wordCosts(lang)
+	for _, field := range strings.Fields(dym.truncateSegmentInput(dym.normalize(input, lang))) {
+		result.Segments = append(result.Segments, dym.segment([]rune(field), lang, cost, unseen)...)
+	}
+
+	words := make([]string, len(result.Segments))
+	for i, segment := range result.Segments {
+		words[i] = segment.Word
+	}
+	result.Text = strings.Join(words, " ")
+	return result
+}
+
+// truncateSegmentInput cuts input to the longest input segmented, backing off
+// to the last space so no word is segmented half-cut
+func (dym *DidYouMean) truncateSegmentInput(input string) string {
+	if utf8.RuneCountInString(input) <= maxSegmentInput {
+		return input
+	}
+
+	dym.logger.Warn("truncated overlong segmentation input", "length", utf8.RuneCountInString(input), "limit", maxSegmentInput)
+
+	end := 0
+	for i := 0; i < maxSegmentInput; i++ {
+		_, size := utf8.DecodeRuneInString(input[end:])
+		end += size
+	}
+	if boundary := strings.LastIndexFunc(input[:end], unicode.IsSpace); boundary > 0 {
+		end = boundary
+	}
+	return input[:end]
+}
+
+// segment finds the cheapest segmentation of a run of letters
+func (dym *DidYouMean) segment(runes []rune, lang Language, cost func(string) float64, unseen float64) []Segment {
+	n := len(runes)
+	best := make([]float64, n+1)   // Cost of the best segmentation of runes[:i]
+	choice := make([]Segment, n+1) // Last segment of that segmentation
+	start := make([]int, n+1)      // Where the last segment starts
+	for i := 1; i <= n; i++ {
+		best[i] = -1
+		for j := max(0, i-maxSegmentRunes); j < i; j++ {
+			piece := string(runes[j:i])
+			segment, pieceCost := dym.segmentCost(piece, i-j, lang, cost, unseen)
+			if total := best[j] + pieceCost; best[i] < 0 || total < best[i] {
+				best[i], choice[i], start[i] = total, segment, j
+			}
+		}
+	}
+
+	// Walk back from the end, merging adjacent unknown pieces
+	segments := make([]Segment, 0)
+	for i := n; i > 0; i = start[i] {
+		segment := choice[i]
+		if last := len(segments) - 1; last >= 0 && !segment.Known && !segments[last].Known {
+			segments[last].Original = segment.Original + segments[last].Original
+			segments[last].Word = segments[last].Original
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	for left, right := 0, len(segments)-1; left < right; left, right = left+1, right-1 {
+		segments[left], segments[right] = segments[right], segments[left]
+	}
+	return segments
+}
+
+// segmentCost returns the cheapest way to read one piece of input: as a
+// dictionary word, as a correction, or as unknown letters
+func (dym *DidYouMean) segmentCost(piece string, runes int, lang Language, cost func(string) float64, unseen float64) (Segment, float64) {
+	if dym.contains(lang, piece) {
+		return Segment{Original: piece, Word: piece, Known: true}, cost(piece)
+	}
+
+	unknown := Segment{Original: piece, Word: piece}
+	unknownCost := unseen * (1 + unknownRuneCostFactor*float64(runes))
+	if runes < minCorrectedRunes {
+		return unknown, unknownCost
+	}
+
+	suggestions := dym.suggestions(context.Background(), piece, 1, 1, lang, false)
+	if len(suggestions) == 0 {
+		return unknown, unknownCost
+	}
+	word := suggestions[0].Word
+	correctedCost := cost(word) + unseen*correctionCostFactor*float64(len(LevenshteinOps(piece, word)))
+	if correctedCost >= unknownCost {
+		return unknown, unknownCost
+	}
+	return Segment{Original: piece, Word: word, Corrected: true, Known: true}, correctedCost
+}
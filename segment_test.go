@@ -0,0 +1,88 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSegmentAndCorrect tests splitting and correcting concatenated input
+func TestSegmentAndCorrect(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"where", "to", "buy", "shoes", "hello", "world"})
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"wheretobuyshoes", "where to buy shoes"},
+		{"WhereToBuyShose", "where to buy shoes"},
+		{"whretobuyshoes", "where to buy shoes"},
+		{"hello world", "hello world"},
+		{"xyzhelloqq", "xyz hello qq"},
+		{"", ""},
+	}
+	for _, test := range tests {
+		if result := dym.SegmentAndCorrect(test.input); result.Text != test.expected {
+			t.Errorf("SegmentAndCorrect(%q) = %q, expected %q", test.input, result.Text, test.expected)
+		}
+	}
+
+	segments := dym.SegmentAndCorrect("xyztobuyshose").Segments
+	expected := []dymean.Segment{
+		{Original: "xyz", Word: "xyz"},
+		{Original: "to", Word: "to", Known: true},
+		{Original: "buy", Word: "buy", Known: true},
+		{Original: "shose", Word: "shoes", Corrected: true, Known: true},
+	}
+	if len(segments) != len(expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, segments)
+	}
+	for i := range expected {
+		if segments[i] != expected[i] {
+			t.Errorf("Segment %d: expected %+v, got %+v", i, expected[i], segments[i])
+		}
+	}
+}
+
+// TestSegmentWithFrequencies tests that frequencies decide between segmentations
+func TestSegmentWithFrequencies(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"pen", "island", "penis", "land", "a"})
+
+	dym.SetWordFrequencies(map[string]int{"pen": 500, "island": 300, "penis": 1, "land": 2}, dymean.English)
+	if text := dym.SegmentAndCorrect("penisland").Text; text != "pen island" {
+		t.Errorf("Expected the frequent words, got %q", text)
+	}
+	dym.SetWordFrequencies(map[string]int{"pen": 0, "penis": 5000, "land": 5000}, dymean.English)
+	if text := dym.SegmentAndCorrect("penisland").Text; text != "penis land" {
+		t.Errorf("Expected the updated frequencies to win, got %q", text)
+	}
+	if dym.WordFrequency("PEN", dymean.English) != 0 || dym.WordFrequency("island", dymean.English) != 300 {
+		t.Error("Expected frequencies to be updated, forgotten and kept")
+	}
+}
+
+// TestSegmentLongInput tests that overlong input is truncated instead of
+// segmented in full
+func TestSegmentLongInput(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"where", "to", "buy", "shoes"})
+
+	start := time.Now()
+	result := dym.SegmentAndCorrect(strings.Repeat("wheretobuyshose", 300))
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected long input to be segmented quickly, took %v", elapsed)
+	}
+	if length := len([]rune(strings.ReplaceAll(result.Text, " ", ""))); length > 256 {
+		t.Errorf("Expected the input to be truncated to 256 runes, got %d", length)
+	}
+	if !strings.HasPrefix(result.Text, "where to buy shoes where") {
+		t.Errorf("Expected the start of the input to be segmented, got %q", result.Text[:40])
+	}
+
+	if slug := dym.CorrectSlug(strings.Repeat("where-to-buy-", 500)); strings.Count(slug, "-") > 256 {
+		t.Errorf("Expected the slug to be truncated, got %d words", strings.Count(slug, "-")+1)
+	}
+}
@@ -0,0 +1,184 @@
+package server
+
+import (
+	"container/list"
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Rejection reasons recorded in the metrics
+const (
+	reasonRateLimit   = "rate_limit"
+	reasonConcurrency = "concurrency"
+)
+
+// maxBuckets is the number of client buckets kept. The least recently seen
+// client is evicted beyond it, so a scan from many addresses cannot grow the
+// map without bound.
+const maxBuckets = 10000
+
+// ClientIP identifies clients by the host part of the request's remote
+// address. Behind a proxy, set Config.ClientKey to read the forwarded address.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// bucket is the token bucket of one client
+type bucket struct {
+	client string
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter limits the request rate of each client with a token bucket
+type rateLimiter struct {
+	rate  float64 // Tokens added per second
+	burst float64 // Bucket capacity
+	now   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*list.Element // Elements of recent
+	recent  *list.List               // Buckets, the most recently seen first
+}
+
+// newRateLimiter creates a limiter allowing rate requests per second per
+// client, with bursts of up to burst requests
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst <= 0 {
+		burst = int(math.Ceil(rate))
+	}
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		now:     time.Now,
+		buckets: make(map[string]*list.Element),
+		recent:  list.New(),
+	}
+}
+
+// allow takes a token from the client's bucket. When it is empty, it returns
+// false and how long until a token is available.
+func (l *rateLimiter) allow(client string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var b *bucket
+	if element := l.buckets[client]; element != nil {
+		l.recent.MoveToFront(element)
+		b = element.Value.(*bucket)
+	} else {
+		if len(l.buckets) >= maxBuckets {
+			l.evict()
+		}
+		b = &bucket{client: client, tokens: l.burst, last: now}
+		l.buckets[client] = l.recent.PushFront(b)
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+// evict drops the bucket of the least recently seen client, the likeliest to
+// have refilled. Callers hold l.mu.
+func (l *rateLimiter) evict() {
+	if oldest := l.recent.Back(); oldest != nil {
+		l.recent.Remove(oldest)
+		delete(l.buckets, oldest.Value.(*bucket).client)
+	}
+}
+
+// admission caps the number of requests served at once
+type admission struct {
+	slots   chan struct{}
+	timeout time.Duration // How long a request may wait for a slot
+}
+
+// acquire takes a slot, waiting up to the queue timeout or until the request
+// is canceled. It reports whether a slot was taken.
+func (a *admission) acquire(r *http.Request) bool {
+	select {
+	case a.slots <- struct{}{}:
+		return true
+	default:
+	}
+	if a.timeout <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(a.timeout)
+	defer timer.Stop()
+	select {
+	case a.slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+// wait takes a slot, waiting as long as needed. It returns false when ctx is
+// done first.
+func (a *admission) wait(ctx context.Context) bool {
+	select {
+	case a.slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// release frees a slot taken by acquire or wait
+func (a *admission) release() {
+	<-a.slots
+}
+
+// admit applies the rate limit and the concurrency cap to an endpoint,
+// answering 429 Too Many Requests with a Retry-After header when either is
+// exceeded
+func (s *Server) admit(endpoint string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.limiter != nil {
+			if ok, wait := s.limiter.allow(s.cfg.ClientKey(r)); !ok {
+				s.metrics.Reject(endpoint, reasonRateLimit)
+				tooManyRequests(w, r, wait, "rate limit exceeded")
+				return
+			}
+		}
+		if s.admission != nil {
+			if !s.admission.acquire(r) {
+				s.metrics.Reject(endpoint, reasonConcurrency)
+				tooManyRequests(w, r, time.Second, "too many concurrent requests")
+				return
+			}
+			defer s.admission.release()
+		}
+		handler(w, r)
+	}
+}
+
+// tooManyRequests writes a 429 response asking the client to retry later
+func tooManyRequests(w http.ResponseWriter, r *http.Request, retryAfter time.Duration, message string) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	writeError(w, r, http.StatusTooManyRequests, message)
+}
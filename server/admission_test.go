@@ -0,0 +1,37 @@
+package server
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// TestRateLimiterBound tests that the buckets of many active clients stay
+// bounded, evicting the least recently seen client
+func TestRateLimiterBound(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newRateLimiter(1, 1)
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 2*maxBuckets; i++ {
+		if i == maxBuckets {
+			if ok, _ := limiter.allow("client0"); ok {
+				t.Fatal("Expected the first client to be limited") // Its bucket is still empty
+			}
+		}
+		limiter.allow("client" + strconv.Itoa(i))
+		if len(limiter.buckets) > maxBuckets || limiter.recent.Len() != len(limiter.buckets) {
+			t.Fatalf("Expected at most %d buckets, got %d (%d listed)", maxBuckets, len(limiter.buckets), limiter.recent.Len())
+		}
+	}
+
+	if _, ok := limiter.buckets["client0"]; ok {
+		t.Error("Expected the least recently seen client to be evicted")
+	}
+	if _, ok := limiter.buckets["client"+strconv.Itoa(2*maxBuckets-1)]; !ok {
+		t.Error("Expected the most recent client to be kept")
+	}
+	if ok, _ := limiter.allow("client" + strconv.Itoa(2*maxBuckets-1)); ok {
+		t.Error("Expected the most recent client to keep its empty bucket")
+	}
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/bi0dread/dymean"
+	"net/http"
+	"strings"
+)
+
+// ErrForbidden is returned by a TokenValidator for a valid token that may not
+// mutate dictionaries, answered with 403 Forbidden instead of 401
+var ErrForbidden = errors.New("forbidden")
+
+// TokenValidator checks the bearer token of a request. It returns nil to
+// allow the request, ErrForbidden to deny an authenticated client, or any
+// other error to reject the token.
+type TokenValidator func(ctx context.Context, token string) error
+
+// DictionaryRequest is the body of /dictionary/add and /dictionary/remove
+type DictionaryRequest struct {
+	Words []string `json:"words"`
+}
+
+// DictionaryResponse is the response of /dictionary/add and /dictionary/remove
+type DictionaryResponse struct {
+	Language string         `json:"language"`
+	Added    int            `json:"added,omitempty"`
+	Removed  int            `json:"removed,omitempty"`
+	Rejected map[string]int `json:"rejected,omitempty"` // Words not added, by reason
+}
+
+// RequireToken wraps a handler so it only serves requests carrying an
+// "Authorization: Bearer <token>" header accepted by validate. Requests
+// without a token get 401 Unauthorized.
+func RequireToken(validate TokenValidator, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="dymean"`)
+			writeError(w, r, http.StatusUnauthorized, "missing bearer token")
+			return
+		}
+
+		if err := validate(r.Context(), token); err != nil {
+			if errors.Is(err, ErrForbidden) {
+				writeError(w, r, http.StatusForbidden, "not allowed to modify dictionaries")
+				return
+			}
+			w.Header().Set("WWW-Authenticate", `Bearer realm="dymean", error="invalid_token"`)
+			writeError(w, r, http.StatusUnauthorized, "invalid token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// guard registers a dictionary mutation endpoint behind the token validator
+func (s *Server) guard(pattern, endpoint string, handler handlerFunc) {
+	s.mux.Handle(pattern, RequireToken(s.cfg.ValidateToken, s.instrument(endpoint, handler)))
+}
+
+// dictionaryRequest reads the language parameter and the words of a
+// dictionary mutation, writing an error response when they are invalid
+func (s *Server) dictionaryRequest(w http.ResponseWriter, r *http.Request) ([]string, dymean.Language, bool) {
+	lang := dymean.Language(r.URL.Query().Get("lang"))
+	if lang == "" {
+		lang = s.dym.GetCurrentLanguage()
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, r, http.StatusMethodNotAllowed, "use POST")
+		return nil, lang, false
+	}
+
+	var request DictionaryRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&request); err != nil {
+		writeError(w, r, http.StatusBadRequest, "body must be a JSON object with a words array")
+		return nil, lang, false
+	}
+	if len(request.Words) == 0 {
+		writeError(w, r, http.StatusBadRequest, "no words given")
+		return nil, lang, false
+	}
+	for _, word := range request.Words {
+		if err := s.dym.CheckWordLength(word); err != nil {
+			writeError(w, r, http.StatusRequestEntityTooLarge, err.Error())
+			return nil, lang, false
+		}
+	}
+	return request.Words, lang, true
+}
+
+// handleDictionaryAdd adds the words of the request body to a dictionary
+func (s *Server) handleDictionaryAdd(w http.ResponseWriter, r *http.Request) dymean.Language {
+	words, lang, ok := s.dictionaryRequest(w, r)
+	if !ok {
+		return lang
+	}
+
+	report := s.dym.AddWordsReport(words, lang)
+	response := DictionaryResponse{Language: string(lang), Added: report.Added}
+	if len(report.Rejected) > 0 {
+		response.Rejected = make(map[string]int, len(report.Rejected))
+		for reason, count := range report.Rejected {
+			response.Rejected[string(reason)] = count
+		}
+	}
+	writeJSON(w, http.StatusOK, response)
+	return lang
+}
+
+// handleDictionaryRemove removes the words of the request body from a dictionary
+func (s *Server) handleDictionaryRemove(w http.ResponseWriter, r *http.Request) dymean.Language {
+	words, lang, ok := s.dictionaryRequest(w, r)
+	if !ok {
+		return lang
+	}
+
+	before := s.dym.GetWordCount(lang)
+	s.dym.RemoveWordsForLanguage(words, lang)
+	writeJSON(w, http.StatusOK, DictionaryResponse{Language: string(lang), Removed: before - s.dym.GetWordCount(lang)})
+	return lang
+}
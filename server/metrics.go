@@ -0,0 +1,225 @@
+package server
+
+import (
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// latencyBuckets are the upper bounds, in seconds, of the latency histogram
+var latencyBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5}
+
+// otherLanguage labels requests for languages without a dictionary, so user
+// input cannot create unbounded label values
+const otherLanguage = "other"
+
+// requestKey identifies a request counter
+type requestKey struct {
+	endpoint string
+	language string
+}
+
+// histogram is a cumulative latency histogram
+type histogram struct {
+	counts []uint64 // One per bucket, non-cumulative
+	sum    float64
+	count  uint64
+}
+
+// rejectionKey identifies a rejected request counter
+type rejectionKey struct {
+	endpoint string
+	reason   string
+}
+
+// Metrics collects request counts, latencies, rejections and dictionary sizes
+type Metrics struct {
+	dym *dymean.DidYouMean
+
+	mu         sync.Mutex
+	requests   map[requestKey]uint64
+	latencies  map[string]*histogram
+	rejections map[rejectionKey]uint64
+}
+
+// NewMetrics creates metrics reporting the dictionary sizes of a checker
+func NewMetrics(dym *dymean.DidYouMean) *Metrics {
+	return &Metrics{
+		dym:        dym,
+		requests:   make(map[requestKey]uint64),
+		latencies:  make(map[string]*histogram),
+		rejections: make(map[rejectionKey]uint64),
+	}
+}
+
+// Observe records a request to an endpoint and its latency
+func (m *Metrics) Observe(endpoint string, lang dymean.Language, latency time.Duration) {
+	language := otherLanguage
+	if m.dym.GetWordCount(lang) > 0 {
+		language = string(lang)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestKey{endpoint: endpoint, language: language}]++
+
+	h := m.latencies[endpoint]
+	if h == nil {
+		h = &histogram{counts: make([]uint64, len(latencyBuckets))}
+		m.latencies[endpoint] = h
+	}
+	seconds := latency.Seconds()
+	for i, bound := range latencyBuckets {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// Requests returns the number of requests recorded for an endpoint and language
+func (m *Metrics) Requests(endpoint string, lang dymean.Language) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.requests[requestKey{endpoint: endpoint, language: string(lang)}]
+}
+
+// Reject records a request to an endpoint rejected by admission control,
+// with the reason "rate_limit" or "concurrency"
+func (m *Metrics) Reject(endpoint, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rejections[rejectionKey{endpoint: endpoint, reason: reason}]++
+}
+
+// Rejections returns the number of requests to an endpoint rejected for a reason
+func (m *Metrics) Rejections(endpoint, reason string) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rejections[rejectionKey{endpoint: endpoint, reason: reason}]
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write([]byte(m.Prometheus()))
+}
+
+// Prometheus renders the metrics in the Prometheus text exposition format
+func (m *Metrics) Prometheus() string {
+	var b strings.Builder
+
+	m.mu.Lock()
+	keys := make([]requestKey, 0, len(m.requests))
+	for key := range m.requests {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].endpoint != keys[j].endpoint {
+			return keys[i].endpoint < keys[j].endpoint
+		}
+		return keys[i].language < keys[j].language
+	})
+
+	b.WriteString("# HELP dymean_requests_total Requests by endpoint and language.\n")
+	b.WriteString("# TYPE dymean_requests_total counter\n")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "dymean_requests_total{endpoint=%q,language=%q} %d\n", key.endpoint, key.language, m.requests[key])
+	}
+
+	endpoints := make([]string, 0, len(m.latencies))
+	for endpoint := range m.latencies {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	b.WriteString("# HELP dymean_request_duration_seconds Request latency by endpoint.\n")
+	b.WriteString("# TYPE dymean_request_duration_seconds histogram\n")
+	for _, endpoint := range endpoints {
+		h := m.latencies[endpoint]
+		cumulative := uint64(0)
+		for i, bound := range latencyBuckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(&b, "dymean_request_duration_seconds_bucket{endpoint=%q,le=\"%g\"} %d\n", endpoint, bound, cumulative)
+		}
+		fmt.Fprintf(&b, "dymean_request_duration_seconds_bucket{endpoint=%q,le=\"+Inf\"} %d\n", endpoint, h.count)
+		fmt.Fprintf(&b, "dymean_request_duration_seconds_sum{endpoint=%q} %g\n", endpoint, h.sum)
+		fmt.Fprintf(&b, "dymean_request_duration_seconds_count{endpoint=%q} %d\n", endpoint, h.count)
+	}
+
+	rejections := make([]rejectionKey, 0, len(m.rejections))
+	for key := range m.rejections {
+		rejections = append(rejections, key)
+	}
+	sort.Slice(rejections, func(i, j int) bool {
+		if rejections[i].endpoint != rejections[j].endpoint {
+			return rejections[i].endpoint < rejections[j].endpoint
+		}
+		return rejections[i].reason < rejections[j].reason
+	})
+
+	b.WriteString("# HELP dymean_rejected_requests_total Requests rejected by admission control, by endpoint and reason.\n")
+	b.WriteString("# TYPE dymean_rejected_requests_total counter\n")
+	for _, key := range rejections {
+		fmt.Fprintf(&b, "dymean_rejected_requests_total{endpoint=%q,reason=%q} %d\n", key.endpoint, key.reason, m.rejections[key])
+	}
+	m.mu.Unlock()
+
+	b.WriteString("# HELP dymean_dictionary_words Words in the dictionary of each language.\n")
+	b.WriteString("# TYPE dymean_dictionary_words gauge\n")
+	for _, lang := range m.dym.GetLoadedLanguages() {
+		fmt.Fprintf(&b, "dymean_dictionary_words{language=%q} %d\n", lang, m.dym.GetWordCount(lang))
+	}
+
+	return b.String()
+}
+
+// Snapshot returns the metrics as a JSON-friendly value for expvar
+func (m *Metrics) Snapshot() any {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := make(map[string]map[string]uint64)
+	for key, count := range m.requests {
+		if requests[key.endpoint] == nil {
+			requests[key.endpoint] = make(map[string]uint64)
+		}
+		requests[key.endpoint][key.language] = count
+	}
+
+	latencies := make(map[string]map[string]float64)
+	for endpoint, h := range m.latencies {
+		mean := 0.0
+		if h.count > 0 {
+			mean = h.sum / float64(h.count)
+		}
+		latencies[endpoint] = map[string]float64{"count": float64(h.count), "mean_seconds": mean}
+	}
+
+	rejections := make(map[string]map[string]uint64)
+	for key, count := range m.rejections {
+		if rejections[key.endpoint] == nil {
+			rejections[key.endpoint] = make(map[string]uint64)
+		}
+		rejections[key.endpoint][key.reason] = count
+	}
+
+	dictionaries := make(map[string]int)
+	for _, lang := range m.dym.GetLoadedLanguages() {
+		dictionaries[string(lang)] = m.dym.GetWordCount(lang)
+	}
+
+	return map[string]any{
+		"requests":     requests,
+		"latencies":    latencies,
+		"rejections":   rejections,
+		"dictionaries": dictionaries,
+	}
+}
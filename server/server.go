@@ -0,0 +1,363 @@
+// Package server exposes a DidYouMean instance over HTTP as a JSON API, with
+// Prometheus-compatible metrics and expvar for monitoring.
+//
+// Endpoints:
+//
+//	GET  /check?word=...&lang=...                   whether a word is correct, with suggestions
+//	GET  /suggest?word=...&lang=...&max=N&offset=K  a page of ranked suggestions for a word
+//	POST /check-text?lang=...                       corrections for the request body
+//	POST /check-stream?lang=...&completions=N       corrections of a document edited as the user types
+//	POST /dictionary/add?lang=...                   adds {"words": [...]} to a dictionary
+//	POST /dictionary/remove?lang=...                removes {"words": [...]} from a dictionary
+//	POST /dictionary/upload?lang=...&min_freq=N     streams a word list into the dictionaries
+//	GET  /healthz                                   200 unless the checker is broken
+//	GET  /readyz                                    200 once the dictionaries are loaded
+//	GET  /metrics                                   metrics in the Prometheus text format
+//	GET  /debug/vars                                expvar variables
+//
+// The lang parameter defaults to the checker's current language; "auto"
+// detects the language of each word.
+//
+// Suggestions are CPU-heavy, so the checking endpoints can be protected with
+// a per-client rate limit (Config.RateLimit) and a cap on the requests served
+// at once (Config.MaxConcurrent). Requests over either limit get 429 Too Many
+// Requests with a Retry-After header; the probes and monitoring endpoints are
+// never limited. Messages of a /check-stream stream wait for both instead.
+//
+// The dictionary endpoints mutate the dictionaries shared by every client, so
+// they are only served when Config.ValidateToken is set, and only to requests
+// with a bearer token it accepts. Checking stays open.
+package server
+
+import (
+	"encoding/json"
+	"expvar"
+	"github.com/bi0dread/dymean"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxBodySize bounds the request body accepted by /check-text
+const maxBodySize = 1 << 20
+
+// Config configures a Server
+type Config struct {
+	// ExpvarName publishes the server's metrics as an expvar variable of this
+	// name. Names are process-wide, so leave it empty for additional servers.
+	ExpvarName string
+
+	// MaxSuggestions is the default number of suggestions returned. Defaults to 5.
+	MaxSuggestions int
+
+	// RateLimit is the number of checking requests per second allowed for
+	// each client, and RateBurst the number it may make at once. RateBurst
+	// defaults to RateLimit rounded up. Zero disables rate limiting.
+	RateLimit float64
+	RateBurst int
+
+	// ClientKey identifies the client of a request for rate limiting.
+	// Defaults to ClientIP.
+	ClientKey func(r *http.Request) string
+
+	// MaxConcurrent caps the checking requests served at once. Requests over
+	// the cap wait up to QueueTimeout for a slot. Zero disables the cap.
+	MaxConcurrent int
+	QueueTimeout  time.Duration
+
+	// ValidateToken authorizes the dictionary mutation endpoints, which are
+	// not served without it
+	ValidateToken TokenValidator
+
+	// MaxUploadSize bounds the decompressed body of /dictionary/upload.
+	// Defaults to 256 MiB.
+	MaxUploadSize int64
+}
+
+// Server serves a DidYouMean instance over HTTP
+type Server struct {
+	dym     *dymean.DidYouMean
+	cfg     Config
+	mux     *http.ServeMux
+	metrics *Metrics
+
+	limiter   *rateLimiter // Nil without a rate limit
+	admission *admission   // Nil without a concurrency cap
+}
+
+// New creates a server for a checker
+func New(dym *dymean.DidYouMean, cfg Config) *Server {
+	if cfg.MaxSuggestions <= 0 {
+		cfg.MaxSuggestions = 5
+	}
+	if cfg.ClientKey == nil {
+		cfg.ClientKey = ClientIP
+	}
+
+	s := &Server{
+		dym:     dym,
+		cfg:     cfg,
+		mux:     http.NewServeMux(),
+		metrics: NewMetrics(dym),
+	}
+	if cfg.RateLimit > 0 {
+		s.limiter = newRateLimiter(cfg.RateLimit, cfg.RateBurst)
+	}
+	if cfg.MaxConcurrent > 0 {
+		s.admission = &admission{slots: make(chan struct{}, cfg.MaxConcurrent), timeout: cfg.QueueTimeout}
+	}
+
+	s.mux.HandleFunc("/check", s.instrument("check", s.handleCheck))
+	s.mux.HandleFunc("/suggest", s.instrument("suggest", s.handleSuggest))
+	s.mux.HandleFunc("/check-text", s.instrument("check-text", s.handleCheckText))
+	s.mux.HandleFunc("/check-stream", s.handleCheckStream)
+	if cfg.ValidateToken != nil {
+		s.guard("/dictionary/add", "dictionary-add", s.handleDictionaryAdd)
+		s.guard("/dictionary/remove", "dictionary-remove", s.handleDictionaryRemove)
+		s.guard("/dictionary/upload", "dictionary-upload", s.handleDictionaryUpload)
+	}
+	s.mux.HandleFunc("/healthz", probe(dym.Healthy))
+	s.mux.HandleFunc("/readyz", probe(dym.Ready))
+	s.mux.Handle("/metrics", s.metrics)
+	s.mux.Handle("/debug/vars", expvar.Handler())
+
+	if cfg.ExpvarName != "" {
+		expvar.Publish(cfg.ExpvarName, expvar.Func(s.metrics.Snapshot))
+	}
+
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Metrics returns the server's metrics
+func (s *Server) Metrics() *Metrics {
+	return s.metrics
+}
+
+// probe serves a health check: 200 "ok" when check returns nil, 503 with
+// the error otherwise
+func probe(check func() error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if err := check(); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(err.Error() + "\n"))
+			return
+		}
+		w.Write([]byte("ok\n"))
+	}
+}
+
+// handlerFunc is an endpoint handler returning the language it served
+type handlerFunc func(w http.ResponseWriter, r *http.Request) dymean.Language
+
+// instrument admits requests to an endpoint and records their count and latency
+func (s *Server) instrument(endpoint string, handler handlerFunc) http.HandlerFunc {
+	return s.admit(endpoint, func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		lang := handler(w, r)
+		s.metrics.Observe(endpoint, lang, time.Since(start))
+	})
+}
+
+// Suggestion is a suggestion in API responses
+type Suggestion struct {
+	Word       string  `json:"word"`
+	Similarity float64 `json:"similarity"`
+	Reason     string  `json:"reason,omitempty"`
+}
+
+// CheckResponse is the response of /check and /suggest
+type CheckResponse struct {
+	Word        string       `json:"word"`
+	Language    string       `json:"language"`
+	Correct     bool         `json:"correct"`
+	Suggestions []Suggestion `json:"suggestions"`
+	Total       int          `json:"total,omitempty"`
+	HasMore     bool         `json:"has_more,omitempty"`
+}
+
+// Correction is a correction in /check-text responses
+type Correction struct {
+	Start       int          `json:"start"`
+	End         int          `json:"end"`
+	Original    string       `json:"original"`
+	Category    string       `json:"category"`
+	Language    string       `json:"language"`
+	Suggestions []Suggestion `json:"suggestions"`
+}
+
+// CheckTextResponse is the response of /check-text
+type CheckTextResponse struct {
+	Corrections []Correction `json:"corrections"`
+}
+
+// handleCheck reports whether a word is correct
+func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) dymean.Language {
+	word, lang, ok := s.wordRequest(w, r)
+	if !ok {
+		return lang
+	}
+
+	correct, suggestions := s.dym.CheckAndSuggestForLanguage(word, lang)
+	writeJSON(w, http.StatusOK, CheckResponse{
+		Word:        word,
+		Language:    string(lang),
+		Correct:     correct,
+		Suggestions: toSuggestions(suggestions),
+	})
+	return lang
+}
+
+// handleSuggest returns ranked suggestions for a word
+func (s *Server) handleSuggest(w http.ResponseWriter, r *http.Request) dymean.Language {
+	word, lang, ok := s.wordRequest(w, r)
+	if !ok {
+		return lang
+	}
+
+	maxSuggestions := s.cfg.MaxSuggestions
+	if value := r.URL.Query().Get("max"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			writeError(w, r, http.StatusBadRequest, "max must be a positive integer")
+			return lang
+		}
+		maxSuggestions = n
+	}
+
+	offset := 0
+	if value := r.URL.Query().Get("offset"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			writeError(w, r, http.StatusBadRequest, "offset must be a non-negative integer")
+			return lang
+		}
+		offset = n
+	}
+
+	distance := s.dym.SuggestionTuning(lang).MaxEditDistance
+	page := s.dym.GetSuggestionsPageForLanguage(word, offset, maxSuggestions, distance, lang)
+	writeJSON(w, http.StatusOK, CheckResponse{
+		Word:        word,
+		Language:    string(lang),
+		Correct:     s.dym.IsCorrectForLanguage(word, lang),
+		Suggestions: toSuggestions(page.Suggestions),
+		Total:       page.Total,
+		HasMore:     page.HasMore,
+	})
+	return lang
+}
+
+// handleCheckText checks the text in the request body
+func (s *Server) handleCheckText(w http.ResponseWriter, r *http.Request) dymean.Language {
+	lang := dymean.Language(r.URL.Query().Get("lang"))
+	if r.Method != http.MethodPost {
+		writeError(w, r, http.StatusMethodNotAllowed, "use POST")
+		return lang
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		writeError(w, r, http.StatusRequestEntityTooLarge, "request body too large")
+		return lang
+	}
+
+	var corrections []dymean.Correction
+	switch lang {
+	case "auto":
+		corrections = s.dym.CheckText(string(body))
+	case "":
+		lang = s.dym.GetCurrentLanguage()
+		corrections = s.dym.CheckTextForLanguage(string(body), lang)
+	default:
+		corrections = s.dym.CheckTextForLanguage(string(body), lang)
+	}
+
+	response := CheckTextResponse{Corrections: toCorrections(corrections)}
+	writeJSON(w, http.StatusOK, response)
+	return lang
+}
+
+// wordRequest reads the word and language parameters, writing an error
+// response when the word is missing or too long
+func (s *Server) wordRequest(w http.ResponseWriter, r *http.Request) (string, dymean.Language, bool) {
+	query := r.URL.Query()
+	word := query.Get("word")
+	lang := dymean.Language(query.Get("lang"))
+	switch lang {
+	case "":
+		lang = s.dym.GetCurrentLanguage()
+	case "auto":
+		lang = dymean.DetectLanguage(word)
+	}
+
+	if word == "" {
+		writeError(w, r, http.StatusBadRequest, "missing word parameter")
+		return word, lang, false
+	}
+	if err := s.dym.CheckWordLength(word); err != nil {
+		writeError(w, r, http.StatusRequestEntityTooLarge, err.Error())
+		return word, lang, false
+	}
+	return word, lang, true
+}
+
+// toSuggestions converts suggestions to their API form
+func toSuggestions(suggestions []dymean.Suggestion) []Suggestion {
+	result := make([]Suggestion, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		result = append(result, Suggestion{Word: suggestion.Word, Similarity: suggestion.Similarity, Reason: suggestion.Reason})
+	}
+	return result
+}
+
+// toCorrections converts corrections to their API form
+func toCorrections(corrections []dymean.Correction) []Correction {
+	result := make([]Correction, 0, len(corrections))
+	for _, correction := range corrections {
+		result = append(result, Correction{
+			Start:       correction.Start,
+			End:         correction.End,
+			Original:    correction.Original,
+			Category:    string(correction.Category),
+			Language:    string(correction.Language),
+			Suggestions: toSuggestions(correction.Suggestions),
+		})
+	}
+	return result
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// writeError writes a JSON error response, translated to the language of
+// the request
+func writeError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": dymean.Translate(messageLanguage(r), message)})
+}
+
+// messageLanguage returns the language to write messages for a request in:
+// the first supported language of its Accept-Language header, else its lang
+// parameter, else English
+func messageLanguage(r *http.Request) dymean.Language {
+	for _, locale := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		if lang, ok := dymean.ParseLocale(locale); ok {
+			return lang
+		}
+	}
+	if lang, ok := dymean.ParseLocale(r.URL.Query().Get("lang")); ok {
+		return lang
+	}
+	return dymean.English
+}
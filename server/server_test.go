@@ -0,0 +1,496 @@
+package server_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"github.com/bi0dread/dymean/server"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newServer creates a server with a small English dictionary
+func newServer() *server.Server {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello", "world", "help"})
+	return server.New(dym, server.Config{})
+}
+
+// do performs a request against the server and decodes the JSON response
+func do(t *testing.T, s *server.Server, method, target, body string, response any) int {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	s.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+	if response != nil {
+		if err := json.Unmarshal(recorder.Body.Bytes(), response); err != nil {
+			t.Fatalf("Failed to decode response %q: %v", recorder.Body.String(), err)
+		}
+	}
+	return recorder.Code
+}
+
+// TestLocalizedErrors tests that errors follow the language of the request
+func TestLocalizedErrors(t *testing.T) {
+	s := newServer()
+	for _, test := range []struct {
+		target, acceptLanguage, expected string
+	}{
+		{"/check", "", "missing word parameter"},
+		{"/check", "fa-IR,fa;q=0.9,en;q=0.8", "پارامتر word فرستاده نشده"},
+		{"/check?lang=fa", "", "پارامتر word فرستاده نشده"},
+		{"/check?lang=fa", "xx, en-US", "missing word parameter"},
+	} {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, test.target, nil)
+		request.Header.Set("Accept-Language", test.acceptLanguage)
+		s.ServeHTTP(recorder, request)
+
+		var response map[string]string
+		json.Unmarshal(recorder.Body.Bytes(), &response)
+		if response["error"] != test.expected {
+			t.Errorf("%s with %q: expected %q, got %q", test.target, test.acceptLanguage, test.expected, response["error"])
+		}
+	}
+}
+
+// TestEndpoints tests the JSON endpoints
+func TestEndpoints(t *testing.T) {
+	s := newServer()
+
+	var check server.CheckResponse
+	if code := do(t, s, http.MethodGet, "/check?word=helo", "", &check); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	if check.Correct || len(check.Suggestions) == 0 || check.Suggestions[0].Word != "hello" || check.Language != "en" {
+		t.Errorf("Unexpected check response %+v", check)
+	}
+
+	var suggest server.CheckResponse
+	do(t, s, http.MethodGet, "/suggest?word=wrold&max=1", "", &suggest)
+	if len(suggest.Suggestions) != 1 || suggest.Suggestions[0].Word != "world" {
+		t.Errorf("Unexpected suggest response %+v", suggest)
+	}
+
+	var next server.CheckResponse
+	do(t, s, http.MethodGet, "/suggest?word=wrold&max=1&offset=1", "", &next)
+	if next.Total != suggest.Total || len(next.Suggestions) > 1 || (len(next.Suggestions) == 1 && next.Suggestions[0].Word == "world") {
+		t.Errorf("Unexpected second page %+v", next)
+	}
+	if code := do(t, s, http.MethodGet, "/suggest?word=wrold&offset=-1", "", nil); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a negative offset, got %d", code)
+	}
+
+	var text server.CheckTextResponse
+	do(t, s, http.MethodPost, "/check-text", "helo wrold", &text)
+	if len(text.Corrections) != 2 || text.Corrections[1].Original != "wrold" || text.Corrections[1].Category != "spelling" {
+		t.Errorf("Unexpected check-text response %+v", text)
+	}
+
+	if code := do(t, s, http.MethodGet, "/check", "", nil); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a missing word, got %d", code)
+	}
+	if code := do(t, s, http.MethodGet, "/check?word="+strings.Repeat("a", 1000), "", nil); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413 for an overlong word, got %d", code)
+	}
+	if code := do(t, s, http.MethodGet, "/check-text", "", nil); code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for GET /check-text, got %d", code)
+	}
+}
+
+// TestMetrics tests request counting and the Prometheus output
+func TestMetrics(t *testing.T) {
+	s := newServer()
+	do(t, s, http.MethodGet, "/check?word=helo", "", nil)
+	do(t, s, http.MethodGet, "/check?word=helo&lang=en", "", nil)
+	do(t, s, http.MethodGet, "/suggest?word=helo&lang=xx", "", nil)
+
+	if count := s.Metrics().Requests("check", dymean.English); count != 2 {
+		t.Errorf("Expected 2 check requests, got %d", count)
+	}
+	if count := s.Metrics().Requests("suggest", "other"); count != 1 {
+		t.Errorf("Expected unknown languages to be counted as 'other', got %d", count)
+	}
+
+	recorder := httptest.NewRecorder()
+	s.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := recorder.Body.String()
+	for _, expected := range []string{
+		`dymean_requests_total{endpoint="check",language="en"} 2`,
+		`dymean_request_duration_seconds_count{endpoint="check"} 2`,
+		`dymean_request_duration_seconds_bucket{endpoint="check",le="+Inf"} 2`,
+		`dymean_dictionary_words{language="en"} 3`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", expected, body)
+		}
+	}
+}
+
+// TestExpvar tests publishing metrics through expvar
+func TestExpvar(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello"})
+	s := server.New(dym, server.Config{ExpvarName: "dymean_test"})
+	do(t, s, http.MethodGet, "/check?word=hello", "", nil)
+
+	var vars map[string]json.RawMessage
+	do(t, s, http.MethodGet, "/debug/vars", "", &vars)
+
+	var metrics struct {
+		Requests     map[string]map[string]int `json:"requests"`
+		Dictionaries map[string]int            `json:"dictionaries"`
+	}
+	if err := json.Unmarshal(vars["dymean_test"], &metrics); err != nil {
+		t.Fatalf("Failed to decode expvar metrics: %v", err)
+	}
+	if metrics.Requests["check"]["en"] != 1 || metrics.Dictionaries["en"] != 1 {
+		t.Errorf("Unexpected expvar metrics %+v", metrics)
+	}
+}
+
+// TestProbes tests the health and readiness endpoints
+func TestProbes(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	s := server.New(dym, server.Config{})
+
+	if code := do(t, s, http.MethodGet, "/healthz", "", nil); code != http.StatusOK {
+		t.Errorf("Expected /healthz to return 200, got %d", code)
+	}
+	if code := do(t, s, http.MethodGet, "/readyz", "", nil); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected /readyz to return 503 before loading, got %d", code)
+	}
+
+	dym.AddWords([]string{"hello"})
+	if code := do(t, s, http.MethodGet, "/readyz", "", nil); code != http.StatusOK {
+		t.Errorf("Expected /readyz to return 200 after loading, got %d", code)
+	}
+}
+
+// TestRateLimit tests the per-client rate limit
+func TestRateLimit(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello"})
+	s := server.New(dym, server.Config{RateLimit: 0.01, RateBurst: 2})
+
+	request := func(target, remote string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		r.RemoteAddr = remote
+		s.ServeHTTP(recorder, r)
+		return recorder
+	}
+
+	for i := 0; i < 2; i++ {
+		if code := request("/check?word=hello", "10.0.0.1:1234").Code; code != http.StatusOK {
+			t.Fatalf("Expected request %d within the burst to succeed, got %d", i+1, code)
+		}
+	}
+	limited := request("/check?word=hello", "10.0.0.1:5678")
+	if limited.Code != http.StatusTooManyRequests || limited.Header().Get("Retry-After") == "" {
+		t.Errorf("Expected 429 with Retry-After, got %d %v", limited.Code, limited.Header())
+	}
+	if code := request("/check?word=hello", "10.0.0.2:1234").Code; code != http.StatusOK {
+		t.Errorf("Expected another client to be served, got %d", code)
+	}
+	if code := request("/healthz", "10.0.0.1:1234").Code; code != http.StatusOK {
+		t.Errorf("Expected probes not to be limited, got %d", code)
+	}
+	if count := s.Metrics().Rejections("check", "rate_limit"); count != 1 {
+		t.Errorf("Expected 1 rate-limited request, got %d", count)
+	}
+}
+
+// TestMaxConcurrent tests admission control of concurrent requests
+func TestMaxConcurrent(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello"})
+	s := server.New(dym, server.Config{MaxConcurrent: 1})
+
+	// A request whose body is still being sent holds the only slot
+	body, writer := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/check-text", body))
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for do(t, s, http.MethodGet, "/check?word=hello", "", nil) != http.StatusTooManyRequests {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected 429 while the slot is taken")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	writer.Close()
+	<-done
+	if code := do(t, s, http.MethodGet, "/check?word=hello", "", nil); code != http.StatusOK {
+		t.Errorf("Expected 200 once the slot is free, got %d", code)
+	}
+	if s.Metrics().Rejections("check", "concurrency") == 0 {
+		t.Error("Expected concurrency rejections to be counted")
+	}
+	if !strings.Contains(s.Metrics().Prometheus(), `dymean_rejected_requests_total{endpoint="check",reason="concurrency"}`) {
+		t.Error("Expected rejections in the Prometheus output")
+	}
+}
+
+// TestDictionaryEndpoints tests that dictionary mutations require an accepted token
+func TestDictionaryEndpoints(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello"})
+	s := server.New(dym, server.Config{ValidateToken: func(ctx context.Context, token string) error {
+		switch token {
+		case "admin":
+			return nil
+		case "reader":
+			return server.ErrForbidden
+		}
+		return errors.New("unknown token")
+	}})
+
+	mutate := func(target, token, body string, response any) int {
+		recorder := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		s.ServeHTTP(recorder, r)
+		if response != nil {
+			json.Unmarshal(recorder.Body.Bytes(), response)
+		}
+		return recorder.Code
+	}
+
+	body := `{"words": ["world", "wor1d", "hello"]}`
+	for token, want := range map[string]int{"": http.StatusUnauthorized, "guest": http.StatusUnauthorized, "reader": http.StatusForbidden} {
+		if code := mutate("/dictionary/add", token, body, nil); code != want {
+			t.Errorf("Expected %d for token %q, got %d", want, token, code)
+		}
+	}
+	if dym.IsCorrect("world") {
+		t.Fatal("Expected unauthorized requests not to add words")
+	}
+
+	var added server.DictionaryResponse
+	if code := mutate("/dictionary/add?lang=en", "admin", body, &added); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	if added.Added != 1 || added.Rejected["duplicate"] != 1 || added.Rejected["invalid characters"] != 1 || !dym.IsCorrect("world") {
+		t.Errorf("Unexpected add response %+v", added)
+	}
+
+	var removed server.DictionaryResponse
+	mutate("/dictionary/remove", "admin", `{"words": ["world"]}`, &removed)
+	if removed.Removed != 1 || dym.IsCorrect("world") {
+		t.Errorf("Unexpected remove response %+v", removed)
+	}
+	if code := mutate("/dictionary/add", "admin", `{"words": []}`, nil); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for no words, got %d", code)
+	}
+
+	if code := do(t, s, http.MethodGet, "/check?word=hello", "", nil); code != http.StatusOK {
+		t.Errorf("Expected checking to stay open, got %d", code)
+	}
+	if code := do(t, newServer(), http.MethodPost, "/dictionary/add", body, nil); code != http.StatusNotFound {
+		t.Errorf("Expected no dictionary endpoints without a validator, got %d", code)
+	}
+}
+
+// TestDictionaryUpload tests streaming word lists into the dictionaries
+func TestDictionaryUpload(t *testing.T) {
+	dym := dymean.NewDidYouMean(100000, 7)
+	s := server.New(dym, server.Config{
+		ValidateToken: func(ctx context.Context, token string) error { return nil },
+		MaxUploadSize: 1 << 10,
+	})
+
+	upload := func(target, contentType string, gzipped bool, body string) []server.UploadProgress {
+		var buf bytes.Buffer
+		if gzipped {
+			gz := gzip.NewWriter(&buf)
+			gz.Write([]byte(body))
+			gz.Close()
+		} else {
+			buf.WriteString(body)
+		}
+		r := httptest.NewRequest(http.MethodPost, target, &buf)
+		r.Header.Set("Authorization", "Bearer token")
+		r.Header.Set("Content-Type", contentType)
+		if gzipped {
+			r.Header.Set("Content-Encoding", "gzip")
+		}
+		recorder := httptest.NewRecorder()
+		s.ServeHTTP(recorder, r)
+
+		var lines []server.UploadProgress
+		decoder := json.NewDecoder(recorder.Body)
+		for decoder.More() {
+			var line server.UploadProgress
+			if err := decoder.Decode(&line); err != nil {
+				t.Fatalf("Failed to decode progress: %v", err)
+			}
+			lines = append(lines, line)
+		}
+		if len(lines) == 0 {
+			t.Fatalf("Expected progress lines, got %q", recorder.Body.String())
+		}
+		return lines
+	}
+
+	last := upload("/dictionary/upload?lang=en", "text/plain", false, "# words\nhello\nworld\n\nwor1d\n")
+	final := last[len(last)-1]
+	if !final.Done || final.Added["en"] != 2 || final.Rejected["en"]["invalid characters"] != 1 || !dym.IsCorrect("world") {
+		t.Errorf("Unexpected plain upload result %+v", final)
+	}
+
+	ndjson := `{"word": "bonjour", "lang": "fr", "freq": 900}
+{"word": "maison", "lang": "fr", "freq": 3}
+{"word": "help", "freq": 50}
+`
+	last = upload("/dictionary/upload?lang=en&min_freq=10", "application/x-ndjson", true, ndjson)
+	final = last[len(last)-1]
+	if !final.Done || final.Added["fr"] != 1 || final.Added["en"] != 1 || final.Skipped != 1 {
+		t.Errorf("Unexpected NDJSON upload result %+v", final)
+	}
+	if !dym.IsCorrectForLanguage("bonjour", dymean.French) || dym.IsCorrectForLanguage("maison", dymean.French) {
+		t.Error("Expected frequent French words only")
+	}
+
+	final = upload("/dictionary/upload", "application/x-ndjson", false, "{\"word\": \"ok\"}\nnot json\n")[0]
+	if final.Done || !strings.Contains(final.Error, "line 2") {
+		t.Errorf("Expected an error on line 2, got %+v", final)
+	}
+	final = upload("/dictionary/upload", "text/plain", true, strings.Repeat("word\n", 1000))[0]
+	if final.Done || !strings.Contains(final.Error, "maximum size") {
+		t.Errorf("Expected the upload to exceed the maximum size, got %+v", final)
+	}
+}
+
+// TestCheckStream tests that a stream answers each edit as it is sent, with
+// the corrections it changed and completions of the typed word
+func TestCheckStream(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithAutomatonSearch())
+	dym.AddWords([]string{"hello", "help", "helper", "world"})
+	s := server.New(dym, server.Config{RateLimit: 1000, RateBurst: 1, MaxConcurrent: 1})
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	body, requests := io.Pipe()
+	defer requests.Close()
+	r, _ := http.NewRequest(http.MethodPost, ts.URL+"/check-stream?lang=en&completions=2", body)
+	response, err := ts.Client().Do(r)
+	if err != nil {
+		t.Fatalf("Failed to open the stream: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", response.StatusCode)
+	}
+
+	encoder := json.NewEncoder(requests)
+	decoder := json.NewDecoder(response.Body)
+	send := func(request server.StreamRequest) server.StreamResponse {
+		t.Helper()
+		if err := encoder.Encode(request); err != nil {
+			t.Fatalf("Failed to send %+v: %v", request, err)
+		}
+		var answer server.StreamResponse
+		if err := decoder.Decode(&answer); err != nil {
+			t.Fatalf("Failed to read the answer to %+v: %v", request, err)
+		}
+		if answer.ID != request.ID {
+			t.Fatalf("Expected the answer to %d, got %+v", request.ID, answer)
+		}
+		return answer
+	}
+
+	answer := send(server.StreamRequest{ID: 1, Op: server.StreamSet, Text: "helo world"})
+	if len(answer.Added) != 1 || answer.Added[0].Original != "helo" || answer.Added[0].Suggestions[0].Word != "hello" {
+		t.Errorf("Unexpected first check %+v", answer)
+	}
+
+	answer = send(server.StreamRequest{ID: 2, Op: server.StreamEdit, Start: 10, End: 10, Text: " hel"})
+	if len(answer.Removed) != 0 || len(answer.Added) != 1 || answer.Added[0].Start != 11 {
+		t.Errorf("Expected only the new word to be reported, got %+v", answer)
+	}
+	if answer.Prefix != "hel" || !reflect.DeepEqual(answer.Completions, []string{"help", "hello"}) {
+		t.Errorf("Unexpected completions %q %v", answer.Prefix, answer.Completions)
+	}
+
+	answer = send(server.StreamRequest{ID: 3, Op: server.StreamEdit, Start: 0, End: 4, Text: "hello"})
+	if len(answer.Removed) != 1 || answer.Removed[0].Start != 0 || len(answer.Added) != 0 || answer.Completions != nil {
+		t.Errorf("Expected the fixed word to be removed, got %+v", answer)
+	}
+
+	answer = send(server.StreamRequest{ID: 4, Op: server.StreamIgnore, Word: "hel"})
+	if len(answer.Removed) != 1 || answer.Removed[0].Start != 12 {
+		t.Errorf("Expected the ignored word to be removed, got %+v", answer)
+	}
+
+	for _, request := range []server.StreamRequest{
+		{ID: 5, Op: server.StreamEdit, Start: 5, End: 100},
+		{ID: 6, Op: "undo"},
+		{ID: 7, Op: server.StreamSet, Text: strings.Repeat("a", 1<<20+1)},
+	} {
+		if answer := send(request); answer.Error == "" {
+			t.Errorf("Expected an error for %s, got %+v", request.Op, answer)
+		}
+	}
+
+	requests.Close()
+	if decoder.More() {
+		t.Error("Expected the stream to end with the request body")
+	}
+	if got := s.Metrics().Requests("check-stream", dymean.English); got != 7 {
+		t.Errorf("Expected 7 messages to be recorded, got %d", got)
+	}
+}
+
+// TestDictionaryUploadCompact tests that uploads reject overlong words like
+// /dictionary/add and leave compact dictionaries compacted
+func TestDictionaryUploadCompact(t *testing.T) {
+	dym := dymean.NewDidYouMean(100000, 7, dymean.WithCompactStorage(dymean.English))
+	s := server.New(dym, server.Config{ValidateToken: func(ctx context.Context, token string) error { return nil }})
+
+	upload := func(body string) server.UploadProgress {
+		r := httptest.NewRequest(http.MethodPost, "/dictionary/upload?lang=en", strings.NewReader(body))
+		r.Header.Set("Authorization", "Bearer token")
+		recorder := httptest.NewRecorder()
+		s.ServeHTTP(recorder, r)
+		lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+		var final server.UploadProgress
+		if err := json.Unmarshal([]byte(lines[len(lines)-1]), &final); err != nil {
+			t.Fatalf("Failed to decode progress: %v", err)
+		}
+		return final
+	}
+
+	var words strings.Builder
+	for i := 0; i < 25000; i++ {
+		fmt.Fprintf(&words, "w%c%c%c%c\n", 'a'+i%26, 'a'+i/26%26, 'a'+i/676%26, 'a'+i/17576%26)
+	}
+	if final := upload(words.String()); !final.Done || final.Added["en"] != 25000 {
+		t.Errorf("Unexpected upload result %+v", final)
+	}
+	if stats := dym.Stats().Languages[dymean.English]; !stats.Compact || stats.Words != 25000 || dym.GetWordCount(dymean.English) != 25000 {
+		t.Errorf("Expected 25000 compact words, got %+v", stats)
+	}
+
+	final := upload("hello\n" + strings.Repeat("a", 100) + "\nworld\n")
+	if final.Done || !strings.Contains(final.Error, "line 2") {
+		t.Errorf("Expected the overlong word to fail the upload, got %+v", final)
+	}
+	if !dym.IsCorrect("hello") || dym.IsCorrect("world") {
+		t.Error("Expected the words before the error to stay")
+	}
+}
@@ -0,0 +1,209 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/bi0dread/dymean"
+	"net/http"
+	"strconv"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Operations of /check-stream messages
+const (
+	StreamSet    = "set"    // Replace the whole text
+	StreamEdit   = "edit"   // Replace a byte range of the text
+	StreamIgnore = "ignore" // Ignore every occurrence of a word
+)
+
+// StreamRequest is a line of the /check-stream request body
+type StreamRequest struct {
+	ID    int    `json:"id,omitempty"`    // Echoed in the response
+	Op    string `json:"op"`              // StreamSet, StreamEdit or StreamIgnore
+	Text  string `json:"text,omitempty"`  // Text set, or inserted by an edit
+	Start int    `json:"start,omitempty"` // Byte range replaced by an edit
+	End   int    `json:"end,omitempty"`
+	Word  string `json:"word,omitempty"` // Word ignored
+}
+
+// StreamResponse is a line of the /check-stream response, answering the
+// request of the same ID. Removed corrections have their offsets before the
+// update and added ones after it; the other corrections stay, shifted by the
+// length change of an edit when they follow it.
+type StreamResponse struct {
+	ID          int          `json:"id,omitempty"`
+	Removed     []Correction `json:"removed"`
+	Added       []Correction `json:"added"`
+	Prefix      string       `json:"prefix,omitempty"`      // Word being typed at the end of an edit
+	Completions []string     `json:"completions,omitempty"` // Dictionary words completing Prefix
+	Error       string       `json:"error,omitempty"`
+}
+
+// handleCheckStream checks a document edited as the user types. The request
+// body is a stream of NDJSON StreamRequest lines and the response a stream
+// of NDJSON StreamResponse lines, one per request, with the corrections each
+// update added or removed and, with completions=N, up to N completions of
+// the word typed by an edit. Streams are full duplex, over HTTP/2 or HTTP/1.1.
+//
+// A stream occupies no slot of the concurrency cap while idle: each message
+// waits for the rate limit and a slot instead of being rejected.
+func (s *Server) handleCheckStream(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	lang := dymean.Language(query.Get("lang"))
+	if r.Method != http.MethodPost {
+		writeError(w, r, http.StatusMethodNotAllowed, "use POST")
+		return
+	}
+	completions := 0
+	if value := query.Get("completions"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			writeError(w, r, http.StatusBadRequest, "completions must be a non-negative integer")
+			return
+		}
+		completions = n
+	}
+
+	docLang := lang
+	switch lang {
+	case "auto":
+		docLang = "" // Detected per word
+	case "":
+		lang = s.dym.GetCurrentLanguage()
+		docLang = lang
+	}
+	doc := dymean.NewDocument(dymean.NewSession(s.dym), docLang)
+
+	controller := http.NewResponseController(w)
+	controller.EnableFullDuplex() // HTTP/2 streams are always full duplex
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	controller.Flush()
+
+	client := s.cfg.ClientKey(r)
+	encoder := json.NewEncoder(w)
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 0, 64<<10), 2*maxBodySize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		release, ok := s.throttle(r.Context(), client)
+		if !ok {
+			return
+		}
+		start := time.Now()
+		response := s.streamMessage(r, doc, scanner.Bytes(), completions)
+		release()
+		s.metrics.Observe("check-stream", lang, time.Since(start))
+
+		if encoder.Encode(response) != nil || controller.Flush() != nil {
+			return // The client is gone
+		}
+	}
+	if errors.Is(scanner.Err(), bufio.ErrTooLong) {
+		encoder.Encode(StreamResponse{Error: dymean.Translate(messageLanguage(r), "stream line too long")})
+	}
+}
+
+// streamMessage applies a message of a stream to its document
+func (s *Server) streamMessage(r *http.Request, doc *dymean.Document, line []byte, completions int) StreamResponse {
+	var request StreamRequest
+	if err := json.Unmarshal(line, &request); err != nil {
+		return streamError(r, 0, "invalid stream message")
+	}
+
+	var change dymean.DocumentChange
+	switch request.Op {
+	case StreamSet:
+		if len(request.Text) > maxBodySize {
+			return streamError(r, request.ID, "text too large")
+		}
+		change = doc.SetText(request.Text)
+	case StreamEdit:
+		if len(doc.Text())+len(request.Text)-(request.End-request.Start) > maxBodySize {
+			return streamError(r, request.ID, "text too large")
+		}
+		var err error
+		if change, err = doc.Edit(request.Start, request.End, request.Text); err != nil {
+			return streamError(r, request.ID, "invalid edit range")
+		}
+	case StreamIgnore:
+		change = doc.IgnoreAll(request.Word)
+	default:
+		return streamError(r, request.ID, "unknown operation")
+	}
+
+	response := StreamResponse{ID: request.ID, Removed: toCorrections(change.Removed), Added: toCorrections(change.Added)}
+	if request.Op == StreamEdit && request.Text != "" && completions > 0 {
+		if prefix := typedWord(doc.Text(), request.Start+len(request.Text)); prefix != "" {
+			lang := doc.Language()
+			if lang == "" {
+				lang = dymean.DetectLanguage(prefix)
+			}
+			response.Prefix = prefix
+			response.Completions = s.dym.Complete(prefix, lang, completions)
+		}
+	}
+	return response
+}
+
+// throttle waits until a client may send another stream message and a slot
+// of the concurrency cap is free. It returns the release of the slot, or
+// false when ctx is done first.
+func (s *Server) throttle(ctx context.Context, client string) (func(), bool) {
+	if s.limiter != nil {
+		for {
+			ok, wait := s.limiter.allow(client)
+			if ok {
+				break
+			}
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, false
+			}
+		}
+	}
+	if s.admission == nil {
+		return func() {}, true
+	}
+	if !s.admission.wait(ctx) {
+		return nil, false
+	}
+	return s.admission.release, true
+}
+
+// typedWord returns the word ending at the cursor of a text, or "" when the
+// cursor is not at the end of a word
+func typedWord(text string, cursor int) string {
+	if next, _ := utf8.DecodeRuneInString(text[cursor:]); cursor < len(text) && isWordRune(next) {
+		return "" // Inside a word
+	}
+	start := cursor
+	for start > 0 {
+		r, size := utf8.DecodeLastRuneInString(text[:start])
+		if !isWordRune(r) {
+			break
+		}
+		start -= size
+	}
+	return text[start:cursor]
+}
+
+// isWordRune checks if a rune belongs to a word being typed
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || r == '‌'
+}
+
+// streamError returns a stream response reporting an error, translated to
+// the language of the request
+func streamError(r *http.Request, id int, message string) StreamResponse {
+	return StreamResponse{ID: id, Removed: []Correction{}, Added: []Correction{}, Error: dymean.Translate(messageLanguage(r), message)}
+}
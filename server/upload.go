@@ -0,0 +1,222 @@
+package server
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// uploadBatchSize is the number of words of a language added at once by
+// /dictionary/upload, so lookups keep being served during an upload
+const uploadBatchSize = 10000
+
+// defaultMaxUploadSize bounds the decompressed body of /dictionary/upload
+const defaultMaxUploadSize = 256 << 20
+
+// UploadEntry is a line of an NDJSON upload
+type UploadEntry struct {
+	Word      string `json:"word"`
+	Language  string `json:"lang,omitempty"` // Defaults to the lang parameter
+	Frequency int64  `json:"freq,omitempty"` // Compared with the min_freq parameter
+}
+
+// UploadProgress is a line of the /dictionary/upload response. A progress
+// line is streamed after every batch; the last line has Done or Error set.
+type UploadProgress struct {
+	Lines    int                       `json:"lines"`              // Input lines read so far
+	Loaded   map[string]int            `json:"loaded"`             // Words submitted by language
+	Added    map[string]int            `json:"added,omitempty"`    // Words new to the dictionaries, by language
+	Rejected map[string]map[string]int `json:"rejected,omitempty"` // Words not added, by language and reason
+	Skipped  int                       `json:"skipped,omitempty"`  // Entries below min_freq
+	Done     bool                      `json:"done,omitempty"`
+	Error    string                    `json:"error,omitempty"`
+}
+
+// upload feeds the entries of an upload to the dictionaries in batches
+type upload struct {
+	dym      *dymean.DidYouMean
+	progress UploadProgress
+	batches  map[dymean.Language][]string
+	compact  map[dymean.Language]func() // Compacts a language once the upload ends
+	w        http.ResponseWriter
+}
+
+// handleDictionaryUpload streams a word list into the dictionaries: plain
+// text with one word per line, or NDJSON UploadEntry lines when the content
+// type is application/x-ndjson, optionally gzip-compressed. Progress is
+// streamed back as NDJSON UploadProgress lines. Words read before an error
+// stay in the dictionaries.
+func (s *Server) handleDictionaryUpload(w http.ResponseWriter, r *http.Request) dymean.Language {
+	query := r.URL.Query()
+	lang := dymean.Language(query.Get("lang"))
+	if lang == "" {
+		lang = s.dym.GetCurrentLanguage()
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, r, http.StatusMethodNotAllowed, "use POST")
+		return lang
+	}
+
+	minFrequency := int64(0)
+	if value := query.Get("min_freq"); value != "" {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < 0 {
+			writeError(w, r, http.StatusBadRequest, "min_freq must be a non-negative integer")
+			return lang
+		}
+		minFrequency = n
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	ndjson := mediaType == "application/x-ndjson" || mediaType == "application/jsonl"
+
+	var body io.Reader = r.Body
+	if strings.EqualFold(r.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "invalid gzip body")
+			return lang
+		}
+		defer gz.Close()
+		body = gz
+	}
+	limit := s.cfg.MaxUploadSize
+	if limit <= 0 {
+		limit = defaultMaxUploadSize
+	}
+	body = &limitedReader{r: body, n: limit}
+
+	u := &upload{
+		dym:      s.dym,
+		progress: UploadProgress{Loaded: make(map[string]int), Added: make(map[string]int), Rejected: make(map[string]map[string]int)},
+		batches:  make(map[dymean.Language][]string),
+		compact:  make(map[dymean.Language]func()),
+		w:        w,
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	err := u.read(body, lang, ndjson, minFrequency)
+	for batchLang := range u.batches {
+		u.flush(batchLang)
+	}
+	for _, compact := range u.compact {
+		compact()
+	}
+	if err != nil {
+		u.progress.Error = err.Error()
+	} else {
+		u.progress.Done = true
+	}
+	u.report()
+	return lang
+}
+
+// read parses the upload line by line, adding each full batch
+func (u *upload) read(r io.Reader, lang dymean.Language, ndjson bool, minFrequency int64) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		u.progress.Lines++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || (!ndjson && strings.HasPrefix(line, "#")) {
+			continue
+		}
+
+		entry := UploadEntry{Word: line}
+		if ndjson {
+			entry = UploadEntry{}
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				return fmt.Errorf("line %d: %w", u.progress.Lines, err)
+			}
+			if entry.Word == "" {
+				continue
+			}
+			if entry.Frequency < minFrequency {
+				u.progress.Skipped++
+				continue
+			}
+		}
+
+		if err := u.dym.CheckWordLength(entry.Word); err != nil {
+			return fmt.Errorf("line %d: %w", u.progress.Lines, err)
+		}
+
+		entryLang := lang
+		if entry.Language != "" {
+			entryLang = dymean.Language(entry.Language)
+		}
+		if u.compact[entryLang] == nil {
+			// Compact storage is rebuilt once, not after every batch
+			u.compact[entryLang] = u.dym.DeferCompaction(entryLang)
+		}
+		u.batches[entryLang] = append(u.batches[entryLang], entry.Word)
+		if len(u.batches[entryLang]) == uploadBatchSize {
+			u.flush(entryLang)
+			u.report()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read upload: %w", err)
+	}
+	return nil
+}
+
+// flush adds the pending words of a language to its dictionary
+func (u *upload) flush(lang dymean.Language) {
+	words := u.batches[lang]
+	if len(words) == 0 {
+		return
+	}
+	report := u.dym.AddWordsReport(words, lang)
+	u.progress.Loaded[string(lang)] += len(words)
+	u.progress.Added[string(lang)] += report.Added
+	for reason, count := range report.Rejected {
+		if u.progress.Rejected[string(lang)] == nil {
+			u.progress.Rejected[string(lang)] = make(map[string]int)
+		}
+		u.progress.Rejected[string(lang)][string(reason)] += count
+	}
+	u.batches[lang] = words[:0]
+}
+
+// report streams the current progress to the client
+func (u *upload) report() {
+	json.NewEncoder(u.w).Encode(u.progress)
+	if flusher, ok := u.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// errUploadTooLarge is reported when an upload exceeds Config.MaxUploadSize
+var errUploadTooLarge = errors.New("upload exceeds the maximum size")
+
+// limitedReader fails reads past n bytes, unlike io.LimitReader which ends
+// silently and would load a truncated list
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		var probe [1]byte // A body of exactly n bytes is still accepted
+		if n, err := l.r.Read(probe[:]); n == 0 && err != nil {
+			return 0, err
+		}
+		return 0, errUploadTooLarge
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
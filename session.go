@@ -0,0 +1,84 @@
+package dymean
+
+// Session scopes "ignore" decisions to a single document on top of a shared
+// DidYouMean, without mutating the shared instance
+type Session struct {
+	dym       *DidYouMean
+	ignoreAll map[string]bool            // Normalized words ignored in the whole document
+	ignored   map[ignoredOccurrence]bool // Single occurrences ignored by the user
+}
+
+// ignoredOccurrence identifies a single occurrence of a word in a document
+type ignoredOccurrence struct {
+	start int
+	word  string
+}
+
+// NewSession creates a new session for a document checked with dym
+func NewSession(dym *DidYouMean) *Session {
+	return &Session{
+		dym:       dym,
+		ignoreAll: make(map[string]bool),
+		ignored:   make(map[ignoredOccurrence]bool),
+	}
+}
+
+// Ignore ignores a single reported occurrence of a misspelled word
+func (s *Session) Ignore(correction Correction) {
+	s.ignored[ignoredOccurrence{start: correction.Start, word: correction.Original}] = true
+}
+
+// IgnoreAll ignores every occurrence of a word in this document
+func (s *Session) IgnoreAll(word string) {
+	s.ignoreAll[sessionKey(word)] = true
+}
+
+// IsIgnored checks if every occurrence of a word is ignored in this document
+func (s *Session) IsIgnored(word string) bool {
+	return s.ignoreAll[sessionKey(word)]
+}
+
+// Reset forgets all ignore decisions made in this session
+func (s *Session) Reset() {
+	s.ignoreAll = make(map[string]bool)
+	s.ignored = make(map[ignoredOccurrence]bool)
+}
+
+// IsCorrect checks if a word is correct in the current language or ignored in this document
+func (s *Session) IsCorrect(word string) bool {
+	return s.IsIgnored(word) || s.dym.IsCorrect(word)
+}
+
+// CheckText checks a text like DidYouMean.CheckText, leaving out spelling
+// corrections the user ignored in this session
+func (s *Session) CheckText(text string) []Correction {
+	return s.filter(s.dym.CheckText(text))
+}
+
+// CheckTextForLanguage checks a text like DidYouMean.CheckTextForLanguage,
+// leaving out spelling corrections the user ignored in this session
+func (s *Session) CheckTextForLanguage(text string, lang Language) []Correction {
+	return s.filter(s.dym.CheckTextForLanguage(text, lang))
+}
+
+// filter removes ignored spelling corrections
+func (s *Session) filter(corrections []Correction) []Correction {
+	filtered := make([]Correction, 0, len(corrections))
+	for _, correction := range corrections {
+		if correction.Category == CategorySpelling {
+			if s.IsIgnored(correction.Original) {
+				continue
+			}
+			if s.ignored[ignoredOccurrence{start: correction.Start, word: correction.Original}] {
+				continue
+			}
+		}
+		filtered = append(filtered, correction)
+	}
+	return filtered
+}
+
+// sessionKey normalizes a word for the language it is written in
+func sessionKey(word string) string {
+	return GetLanguageInfo(DetectLanguage(word)).Normalizer(word)
+}
@@ -0,0 +1,49 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestSessionIgnores tests that session ignores don't leak into the shared instance
+func TestSessionIgnores(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.LoadDefaultDictionary(dymean.English)
+
+	text := "helo wrld helo"
+	session := dymean.NewSession(dym)
+
+	corrections := session.CheckText(text)
+	if len(corrections) != 3 {
+		t.Fatalf("Expected 3 corrections, got %d: %v", len(corrections), corrections)
+	}
+
+	// Ignore the first "helo" only
+	session.Ignore(corrections[0])
+	corrections = session.CheckText(text)
+	if len(corrections) != 2 || corrections[0].Original != "wrld" || corrections[1].Start != 10 {
+		t.Errorf("Expected the first 'helo' to be ignored, got %v", corrections)
+	}
+
+	// Ignore every "wrld"
+	session.IgnoreAll("WRLD")
+	if !session.IsCorrect("wrld") {
+		t.Error("Expected 'wrld' to be correct in the session")
+	}
+	if corrections = session.CheckText(text); len(corrections) != 1 {
+		t.Errorf("Expected 1 correction after ignoring 'wrld', got %v", corrections)
+	}
+
+	// The shared instance is not affected
+	if dym.IsCorrect("wrld") {
+		t.Error("Expected 'wrld' to remain incorrect in the shared instance")
+	}
+	if other := dymean.NewSession(dym); len(other.CheckText(text)) != 3 {
+		t.Error("Expected ignores not to leak into other sessions")
+	}
+
+	session.Reset()
+	if corrections = session.CheckText(text); len(corrections) != 3 {
+		t.Errorf("Expected 3 corrections after reset, got %v", corrections)
+	}
+}
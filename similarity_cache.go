@@ -0,0 +1,130 @@
+package dymean
+
+import (
+	"container/list"
+	"sync"
+)
+
+// maxMemoPairs bounds the similarities remembered during a single suggestion
+// call; pairs past it are computed without being stored
+const maxMemoPairs = 1024
+
+// SimilarityCache remembers the CalculateSimilarity scores of recent word
+// pairs, evicting the least recently used beyond its capacity. It is safe for
+// concurrent use and can be shared by instances with WithSimilarityCache, so
+// misspellings repeated across texts are not scored again.
+type SimilarityCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[[2]string]*list.Element
+	order    *list.List // Most recently used first
+	hits     uint64
+	misses   uint64
+}
+
+// similarityEntry is a cached score
+type similarityEntry struct {
+	key   [2]string
+	score float64
+}
+
+// NewSimilarityCache creates a cache holding up to capacity pairs, at least one
+func NewSimilarityCache(capacity int) *SimilarityCache {
+	return &SimilarityCache{
+		capacity: max(capacity, 1),
+		entries:  make(map[[2]string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// similarityKey orders a pair, as similarity is symmetric
+func similarityKey(a, b string) [2]string {
+	if b < a {
+		a, b = b, a
+	}
+	return [2]string{a, b}
+}
+
+// Similarity returns CalculateSimilarity(a, b), from the cache when the pair
+// was scored recently
+func (c *SimilarityCache) Similarity(a, b string) float64 {
+	key := similarityKey(a, b)
+	c.mu.Lock()
+	if element, ok := c.entries[key]; ok {
+		c.order.MoveToFront(element)
+		c.hits++
+		score := element.Value.(*similarityEntry).score
+		c.mu.Unlock()
+		return score
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	// Scored outside the lock; concurrent misses of a pair store the same score
+	score := CalculateSimilarity(a, b)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok {
+		c.entries[key] = c.order.PushFront(&similarityEntry{key: key, score: score})
+		if c.order.Len() > c.capacity {
+			oldest := c.order.Back()
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*similarityEntry).key)
+		}
+	}
+	return score
+}
+
+// Len returns the number of cached pairs
+func (c *SimilarityCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Stats returns the lookups answered from the cache and those computed
+func (c *SimilarityCache) Stats() (hits, misses uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+// Clear empties the cache and resets its statistics
+func (c *SimilarityCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[[2]string]*list.Element)
+	c.order.Init()
+	c.hits, c.misses = 0, 0
+}
+
+// similarityMemo remembers the scores computed during one suggestion call,
+// where error model, confusable and re-ranking steps may score a pair again
+type similarityMemo struct {
+	cache  *SimilarityCache
+	scores map[[2]string]float64
+}
+
+// newSimilarityMemo creates a memo backed by the instance's shared cache, if any
+func (dym *DidYouMean) newSimilarityMemo() *similarityMemo {
+	return &similarityMemo{cache: dym.similarityCache, scores: make(map[[2]string]float64)}
+}
+
+// similarity returns CalculateSimilarity(a, b), computing each pair once
+func (m *similarityMemo) similarity(a, b string) float64 {
+	key := similarityKey(a, b)
+	if score, ok := m.scores[key]; ok {
+		return score
+	}
+	var score float64
+	if m.cache != nil {
+		score = m.cache.Similarity(a, b)
+	} else {
+		score = CalculateSimilarity(a, b)
+	}
+	if len(m.scores) < maxMemoPairs {
+		m.scores[key] = score
+	}
+	return score
+}
@@ -0,0 +1,55 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"testing"
+)
+
+// TestSimilarityCache tests cached scores, statistics and eviction
+func TestSimilarityCache(t *testing.T) {
+	cache := dymean.NewSimilarityCache(2)
+
+	if score := cache.Similarity("hello", "helo"); score != dymean.CalculateSimilarity("hello", "helo") {
+		t.Errorf("Unexpected score %v", score)
+	}
+	cache.Similarity("helo", "hello") // Same pair, reversed
+	if hits, misses := cache.Stats(); hits != 1 || misses != 1 {
+		t.Errorf("Expected 1 hit and 1 miss, got %d and %d", hits, misses)
+	}
+
+	cache.Similarity("cat", "cart")
+	cache.Similarity("dog", "dig") // Evicts hello/helo, the least recently used
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 cached pairs, got %d", cache.Len())
+	}
+	cache.Similarity("hello", "helo")
+	if _, misses := cache.Stats(); misses != 4 {
+		t.Errorf("Expected the evicted pair to be scored again, got %d misses", misses)
+	}
+
+	cache.Clear()
+	if hits, misses := cache.Stats(); cache.Len() != 0 || hits != 0 || misses != 0 {
+		t.Error("Expected an empty cache after Clear")
+	}
+}
+
+// TestWithSimilarityCache tests that a shared cache keeps suggestions unchanged
+func TestWithSimilarityCache(t *testing.T) {
+	words := []string{"hello", "help", "world", "word"}
+	plain := dymean.NewDidYouMean(10000, 7)
+	plain.AddWords(words)
+	cache := dymean.NewSimilarityCache(100)
+	cached := dymean.NewDidYouMean(10000, 7, dymean.WithSimilarityCache(cache))
+	cached.AddWords(words)
+
+	expected := plain.GetSuggestions("helo", 5, 2)
+	for i := 0; i < 2; i++ {
+		if suggestions := cached.GetSuggestions("helo", 5, 2); !reflect.DeepEqual(suggestions, expected) {
+			t.Errorf("Expected %v, got %v", expected, suggestions)
+		}
+	}
+	if hits, misses := cache.Stats(); misses == 0 || hits != misses {
+		t.Errorf("Expected the second call to be answered from the cache, got %d hits and %d misses", hits, misses)
+	}
+}
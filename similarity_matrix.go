@@ -0,0 +1,144 @@
+package dymean
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// Neighbor is a word similar to another word in a list
+type Neighbor struct {
+	Index      int // Position of the word in the input list
+	Word       string
+	Similarity float64
+}
+
+// CalculateSimilarityMatrix returns the pairwise similarities of words, where
+// matrix[i][j] is CalculateSimilarity(words[i], words[j]). Rows are computed
+// in parallel and each pair is only scored once.
+func CalculateSimilarityMatrix(words []string) [][]float64 {
+	matrix := make([][]float64, len(words))
+	for i := range matrix {
+		matrix[i] = make([]float64, len(words))
+		matrix[i][i] = 1.0
+	}
+
+	parallelFor(len(words), runtime.GOMAXPROCS(0), func(i int) {
+		for j := i + 1; j < len(words); j++ {
+			similarity := CalculateSimilarity(words[i], words[j])
+			// Each goroutine owns row i above the diagonal and column i below it
+			matrix[i][j] = similarity
+			matrix[j][i] = similarity
+		}
+	})
+
+	return matrix
+}
+
+// NearestNeighbors returns, for every word, up to k other words of the list with
+// a similarity of at least minSimilarity, most similar first. Pairs whose
+// lengths differ too much to reach minSimilarity are skipped without scoring.
+func NearestNeighbors(words []string, k int, minSimilarity float64) [][]Neighbor {
+	byLength := sortedByLength(words)
+	neighbors := make([][]Neighbor, len(words))
+
+	parallelFor(len(words), runtime.GOMAXPROCS(0), func(i int) {
+		neighbors[i] = nearestInBuckets(words[i], i, words, byLength, k, minSimilarity)
+	})
+
+	return neighbors
+}
+
+// FindNearestNeighbors returns up to k words of the list with a similarity of
+// at least minSimilarity to word, most similar first
+func FindNearestNeighbors(word string, words []string, k int, minSimilarity float64) []Neighbor {
+	return nearestInBuckets(word, -1, words, sortedByLength(words), k, minSimilarity)
+}
+
+// sortedByLength returns the indexes of words ordered by byte length
+func sortedByLength(words []string) []int {
+	indexes := make([]int, len(words))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(a, b int) bool {
+		return len(words[indexes[a]]) < len(words[indexes[b]])
+	})
+	return indexes
+}
+
+// nearestInBuckets scores word against the words whose length can reach
+// minSimilarity, skipping the word at position self
+func nearestInBuckets(word string, self int, words []string, byLength []int, k int, minSimilarity float64) []Neighbor {
+	// Similarity is at most shorter/longer length, so only a window of lengths can qualify
+	minLen, maxLen := 0, int(^uint(0)>>1)
+	if minSimilarity > 0 {
+		minLen = int(float64(len(word)) * minSimilarity)
+		maxLen = int(float64(len(word))/minSimilarity + 1e-9)
+	}
+
+	start := sort.Search(len(byLength), func(i int) bool {
+		return len(words[byLength[i]]) >= minLen
+	})
+
+	neighbors := make([]Neighbor, 0)
+	for _, index := range byLength[start:] {
+		if len(words[index]) > maxLen {
+			break
+		}
+		if index == self {
+			continue
+		}
+
+		similarity := CalculateSimilarity(word, words[index])
+		if similarity >= minSimilarity {
+			neighbors = append(neighbors, Neighbor{Index: index, Word: words[index], Similarity: similarity})
+		}
+	}
+
+	sort.Slice(neighbors, func(a, b int) bool {
+		if neighbors[a].Similarity != neighbors[b].Similarity {
+			return neighbors[a].Similarity > neighbors[b].Similarity
+		}
+		return neighbors[a].Index < neighbors[b].Index
+	})
+
+	if k >= 0 && len(neighbors) > k {
+		neighbors = neighbors[:k]
+	}
+
+	return neighbors
+}
+
+// parallelFor calls fn for every index in [0, n) using up to workers
+// goroutines. Indexes are handed out over an unbuffered channel, so work is
+// only dispatched as fast as the workers complete it.
+func parallelFor(n, workers int, fn func(i int)) {
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
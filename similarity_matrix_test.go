@@ -0,0 +1,64 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestCalculateSimilarityMatrix tests pairwise similarity computation
+func TestCalculateSimilarityMatrix(t *testing.T) {
+	words := []string{"hello", "helo", "world", "word"}
+	matrix := dymean.CalculateSimilarityMatrix(words)
+
+	for i := range words {
+		for j := range words {
+			expected := dymean.CalculateSimilarity(words[i], words[j])
+			if matrix[i][j] != expected {
+				t.Errorf("matrix[%d][%d] = %.2f, expected %.2f", i, j, matrix[i][j], expected)
+			}
+		}
+	}
+}
+
+// TestNearestNeighbors tests finding similar words in a list
+func TestNearestNeighbors(t *testing.T) {
+	words := []string{"hello", "helo", "hallo", "world", "programming"}
+	neighbors := dymean.NearestNeighbors(words, 2, 0.7)
+
+	if len(neighbors[0]) != 2 || neighbors[0][0].Word != "helo" || neighbors[0][1].Word != "hallo" {
+		t.Errorf("Unexpected neighbors for 'hello': %v", neighbors[0])
+	}
+	if len(neighbors[4]) != 0 {
+		t.Errorf("Expected no neighbors for 'programming', got %v", neighbors[4])
+	}
+
+	nearest := dymean.FindNearestNeighbors("wrld", words, 1, 0.5)
+	if len(nearest) != 1 || nearest[0].Word != "world" || nearest[0].Index != 3 {
+		t.Errorf("Expected 'world' to be nearest to 'wrld', got %v", nearest)
+	}
+}
+
+// TestFilterByLengthWindow tests skipping candidates too long or short to match
+func TestFilterByLengthWindow(t *testing.T) {
+	candidates := []string{"cat", "cart", "c", "carts", "ca"}
+	filtered := dymean.FilterByLengthWindow("cat", candidates, 1)
+
+	expected := []string{"cat", "cart", "ca"}
+	if len(filtered) != len(expected) {
+		t.Fatalf("FilterByLengthWindow = %v, expected %v", filtered, expected)
+	}
+	for i := range expected {
+		if filtered[i] != expected[i] {
+			t.Errorf("FilterByLengthWindow = %v, expected %v", filtered, expected)
+		}
+	}
+	for _, candidate := range candidates {
+		kept := false
+		for _, word := range filtered {
+			kept = kept || word == candidate
+		}
+		if !kept && dymean.LevenshteinDistance("cat", candidate) <= 1 {
+			t.Errorf("Expected %q within one edit to be kept", candidate)
+		}
+	}
+}
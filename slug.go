@@ -0,0 +1,105 @@
+package dymean
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// slugSeparators split the words of slugs and hashtags
+const slugSeparators = "-_.+/#"
+
+// SplitSlug splits a URL slug or hashtag into its words: "#WhereToBuy" and
+// "where-to_buy" both give "Where", "To", "Buy" (in the input's case). Words
+// are separated by dashes, underscores, dots, plus signs and slashes, by
+// camelCase ("HTMLParser" gives "HTML", "Parser"), and between letters and
+// digits ("iphone15" gives "iphone", "15"). Parts run together without any
+// of these are returned whole; SegmentAndCorrect splits them further.
+func SplitSlug(slug string) []string {
+	parts := make([]string, 0)
+	runes := []rune(slug)
+	start := 0
+	flush := func(end int) {
+		if end > start {
+			parts = append(parts, string(runes[start:end]))
+		}
+		start = end
+	}
+
+	for i, r := range runes {
+		if strings.ContainsRune(slugSeparators, r) || unicode.IsSpace(r) {
+			flush(i)
+			start = i + 1
+			continue
+		}
+		if i == start {
+			continue
+		}
+		prev := runes[i-1]
+		switch {
+		case unicode.IsDigit(r) != unicode.IsDigit(prev):
+			flush(i)
+		case unicode.IsUpper(r) && unicode.IsLower(prev):
+			flush(i) // camelCase
+		case unicode.IsUpper(r) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			flush(i) // The last capital of an acronym starts the next word
+		}
+	}
+	flush(len(runes))
+	return parts
+}
+
+// slugWords splits a slug or hashtag and segments and corrects its words.
+// Slugs are truncated like the input of SegmentAndCorrect.
+func (dym *DidYouMean) slugWords(slug string, lang Language) []string {
+	words := make([]string, 0)
+	for _, part := range SplitSlug(dym.truncateSegmentInput(slug)) {
+		if r, _ := utf8.DecodeRuneInString(part); unicode.IsDigit(r) {
+			words = append(words, part)
+			continue
+		}
+		for _, segment := range dym.SegmentAndCorrectForLanguage(part, lang).Segments {
+			words = append(words, segment.Word)
+		}
+	}
+	return words
+}
+
+// CorrectSlug corrects a URL slug in the current language
+func (dym *DidYouMean) CorrectSlug(slug string) string {
+	return dym.CorrectSlugForLanguage(slug, dym.currentLang)
+}
+
+// CorrectSlugForLanguage splits a URL slug into words with SplitSlug,
+// segments and corrects them with SegmentAndCorrectForLanguage, and returns
+// them lowercase and joined by dashes: "WhereToBuy-shose" gives
+// "where-to-buy-shoes". Numbers are kept as they are.
+func (dym *DidYouMean) CorrectSlugForLanguage(slug string, lang Language) string {
+	return strings.Join(dym.slugWords(slug, lang), "-")
+}
+
+// CorrectHashtag corrects a hashtag in the current language
+func (dym *DidYouMean) CorrectHashtag(tag string) string {
+	return dym.CorrectHashtagForLanguage(tag, dym.currentLang)
+}
+
+// CorrectHashtagForLanguage splits, segments and corrects a hashtag like
+// CorrectSlugForLanguage and returns it with a leading '#'. Words are joined
+// in CamelCase, which screen readers and readers alike split back into
+// words ("#wheretobuyshose" gives "#WhereToBuyShoes"), or with underscores
+// in scripts without capitals ("#سلام_دنیا").
+func (dym *DidYouMean) CorrectHashtagForLanguage(tag string, lang Language) string {
+	words := dym.slugWords(tag, lang)
+	cased := false
+	for i, word := range words {
+		r, size := utf8.DecodeRuneInString(word)
+		if upper := unicode.ToUpper(r); upper != r {
+			words[i] = string(upper) + word[size:]
+			cased = true
+		}
+	}
+	if cased {
+		return "#" + strings.Join(words, "")
+	}
+	return "#" + strings.Join(words, "_")
+}
@@ -0,0 +1,47 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"testing"
+)
+
+// TestSplitSlug tests splitting slugs and hashtags into words
+func TestSplitSlug(t *testing.T) {
+	tests := []struct {
+		slug     string
+		expected []string
+	}{
+		{"#WhereToBuy", []string{"Where", "To", "Buy"}},
+		{"where-to_buy", []string{"where", "to", "buy"}},
+		{"/blog/HTMLParser.v2/", []string{"blog", "HTML", "Parser", "v", "2"}},
+		{"summer2024sale", []string{"summer", "2024", "sale"}},
+		{"#سلام_دنیا", []string{"سلام", "دنیا"}},
+		{"--", []string{}},
+	}
+	for _, test := range tests {
+		if parts := dymean.SplitSlug(test.slug); !reflect.DeepEqual(parts, test.expected) {
+			t.Errorf("SplitSlug(%q) = %q, expected %q", test.slug, parts, test.expected)
+		}
+	}
+}
+
+// TestCorrectSlugAndHashtag tests correcting slugs and hashtags
+func TestCorrectSlugAndHashtag(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"where", "to", "buy", "shoes", "summer", "sale"})
+	dym.AddWordsForLanguage([]string{"سلام", "دنیا"}, dymean.Persian)
+
+	if slug := dym.CorrectSlug("WhereToBuy-shose"); slug != "where-to-buy-shoes" {
+		t.Errorf("Expected a corrected slug, got %q", slug)
+	}
+	if slug := dym.CorrectSlug("summer2024-sael"); slug != "summer-2024-sale" {
+		t.Errorf("Expected numbers to be kept, got %q", slug)
+	}
+	if tag := dym.CorrectHashtag("#wheretobuyshose"); tag != "#WhereToBuyShoes" {
+		t.Errorf("Expected a CamelCase hashtag, got %q", tag)
+	}
+	if tag := dym.CorrectHashtagForLanguage("#سلامدنیا", dymean.Persian); tag != "#سلام_دنیا" {
+		t.Errorf("Expected underscores without capitals, got %q", tag)
+	}
+}
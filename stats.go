@@ -0,0 +1,167 @@
+package dymean
+
+import "unsafe"
+
+// Sizes used to estimate the memory of the dictionaries and indexes. The
+// estimates leave out allocator rounding and the spare capacity of maps, so
+// they are for capacity planning rather than exact accounting.
+const (
+	stringHeaderBytes = int(unsafe.Sizeof(""))
+	sliceHeaderBytes  = int(unsafe.Sizeof([]byte(nil)))
+	mapEntryBytes     = 16 // Average hash table overhead of a map entry
+)
+
+// Stats reports the size of an instance's dictionaries, indexes and caches
+type Stats struct {
+	Languages              map[Language]LanguageStats
+	Bytes                  int // Estimated memory of all the languages' dictionaries and indexes
+	SimilarityCacheEntries int // Pairs in the similarity cache, 0 without WithSimilarityCache
+	LearnedCorrections     int // Misspellings with corrections in the error model
+	UnknownWords           int // Words harvested by text checks
+}
+
+// LanguageStats reports the size of a language's dictionary and indexes.
+// Byte counts are estimates; indexes built on demand count 0 until used.
+type LanguageStats struct {
+	Words                  int
+	Compact                bool // Stored front-coded, see WithCompactStorage
+	Tombstones             int  // Removed words not yet compacted
+	BloomBits              uint
+	BloomFillRatio         float64 // Share of the Bloom filter's bits set
+	BloomFalsePositiveRate float64 // Estimated from the fill ratio
+	DictionaryBytes        int
+	BloomBytes             int
+	TrieBytes              int // Search trie of WithAutomatonSearch
+	NeighborIndexBytes     int // Set with BuildNeighborIndex
+	EquivalenceIndexBytes  int // Words by folded equivalent letters
+	FrequencyBytes         int // Set with SetWordFrequencies and SetBigramFrequencies
+}
+
+// Stats reports per-language word counts, Bloom filter fill and estimated
+// false positive rate, estimated index sizes in bytes, and cache sizes, for
+// capacity planning without profiling. Measuring the Bloom filter fill reads
+// every bit, so Stats suits a metrics scrape rather than a request path.
+func (dym *DidYouMean) Stats() Stats {
+	stats := Stats{
+		Languages:          make(map[Language]LanguageStats),
+		LearnedCorrections: dym.errorModel.misspellings(),
+	}
+	if dym.similarityCache != nil {
+		stats.SimilarityCacheEntries = dym.similarityCache.Len()
+	}
+	dym.unknown.mu.Lock()
+	stats.UnknownWords = len(dym.unknown.words)
+	dym.unknown.mu.Unlock()
+
+	dym.mu.RLock()
+	defer dym.mu.RUnlock()
+	for lang, bf := range dym.bloomFilters {
+		language := LanguageStats{
+			Compact:                dym.compact[lang] != nil,
+			Tombstones:             len(dym.tombstones[lang]),
+			BloomBits:              bf.Size(),
+			BloomFillRatio:         bf.FillRatio(),
+			BloomFalsePositiveRate: bf.FalsePositiveRate(),
+			BloomBytes:             int(bf.Size()), // One bool per bit
+			TrieBytes:              dym.tries[lang].bytes(),
+			NeighborIndexBytes:     dym.neighbors[lang].bytes(),
+			EquivalenceIndexBytes:  stringSlicesMapBytes(dym.equivalences[lang]),
+			FrequencyBytes:         dym.frequencies[lang].bytes() + dym.bigrams[lang].bytes(),
+		}
+		if set := dym.compact[lang]; set != nil {
+			language.Words = set.len()
+			language.DictionaryBytes = len(set.data) + 4*len(set.offsets)
+		}
+		language.Words += len(dym.dictionaries[lang]) // Compact languages while compaction is deferred
+		for word := range dym.dictionaries[lang] {
+			language.DictionaryBytes += len(word) + stringHeaderBytes + 1 + mapEntryBytes
+		}
+
+		stats.Bytes += language.DictionaryBytes + language.BloomBytes + language.TrieBytes +
+			language.NeighborIndexBytes + language.EquivalenceIndexBytes + language.FrequencyBytes
+		stats.Languages[lang] = language
+	}
+	return stats
+}
+
+// misspellings returns the number of misspellings with learned corrections,
+// 0 for nil
+func (em *ErrorModel) misspellings() int {
+	if em == nil {
+		return 0
+	}
+	em.mu.RLock()
+	defer em.mu.RUnlock()
+	count := 0
+	for _, corrections := range em.corrections {
+		count += len(corrections)
+	}
+	return count
+}
+
+// bytes estimates the memory of a trie, 0 for nil
+func (t *Trie) bytes() int {
+	if t == nil {
+		return 0
+	}
+	total := 0
+	var walk func(node *trieNode)
+	walk = func(node *trieNode) {
+		total += int(unsafe.Sizeof(*node)) + 4*cap(node.keys) + 8*cap(node.children)
+		for _, child := range node.children {
+			walk(child)
+		}
+	}
+	walk(&t.root)
+	return total
+}
+
+// bytes estimates the memory of a neighbor index, 0 for nil
+func (n *NeighborIndex) bytes() int {
+	if n == nil {
+		return 0
+	}
+	total := 4*(len(n.offsets)+len(n.targets)) + len(n.distances)
+	for _, word := range n.words {
+		// The word, and its entry in ids sharing its bytes
+		total += len(word) + 2*stringHeaderBytes + 4 + mapEntryBytes
+	}
+	return total
+}
+
+// bytes estimates the memory of word frequencies, 0 for nil
+func (f *wordFrequencies) bytes() int {
+	if f == nil {
+		return 0
+	}
+	total := 0
+	for word := range f.counts {
+		total += len(word) + stringHeaderBytes + 8 + mapEntryBytes
+	}
+	return total
+}
+
+// bytes estimates the memory of word pair frequencies, 0 for nil
+func (b *bigramFrequencies) bytes() int {
+	if b == nil {
+		return 0
+	}
+	total := 0
+	for pair := range b.counts {
+		total += len(pair[0]) + len(pair[1]) + 2*stringHeaderBytes + 8 + mapEntryBytes
+	}
+	for word := range b.firsts {
+		total += len(word) + stringHeaderBytes + 8 + mapEntryBytes
+	}
+	return total
+}
+
+// stringSlicesMapBytes estimates the memory of a map of words to words whose
+// values share the bytes of the dictionary
+func stringSlicesMapBytes(m map[string][]string) int {
+	total := 0
+	for key, values := range m {
+		total += len(key) + stringHeaderBytes + sliceHeaderBytes + mapEntryBytes + stringHeaderBytes*cap(values)
+	}
+	return total
+}
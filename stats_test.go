@@ -0,0 +1,42 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestStats tests reporting the sizes of dictionaries, indexes and caches
+func TestStats(t *testing.T) {
+	cache := dymean.NewSimilarityCache(100)
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithCompactStorage(dymean.German), dymean.WithAutomatonSearch(), dymean.WithSimilarityCache(cache))
+	dym.AddWords([]string{"hello", "world", "help"})
+	dym.AddWordsForLanguage([]string{"hallo", "welt"}, dymean.German)
+
+	stats := dym.Stats()
+	english := stats.Languages[dymean.English]
+	if english.Words != 3 || english.Compact || english.DictionaryBytes == 0 || english.TrieBytes != 0 {
+		t.Errorf("Unexpected English stats %+v", english)
+	}
+	if english.BloomBits != 10000 || english.BloomFillRatio <= 0 || english.BloomFillRatio > 21.0/10000 {
+		t.Errorf("Expected at most 21 of 10000 bits set, got %+v", english)
+	}
+	if english.BloomFalsePositiveRate <= 0 || english.BloomFalsePositiveRate >= 1e-12 {
+		t.Errorf("Expected a tiny false positive rate, got %v", english.BloomFalsePositiveRate)
+	}
+	if german := stats.Languages[dymean.German]; german.Words != 2 || !german.Compact || german.DictionaryBytes == 0 {
+		t.Errorf("Unexpected German stats %+v", german)
+	}
+
+	// Indexes and caches grow with use
+	dym.GetSuggestions("helo", 5, 1)
+	dym.BuildNeighborIndex(dymean.English, 1)
+	dym.LearnCorrection("helo", "hello")
+	after := dym.Stats()
+	english = after.Languages[dymean.English]
+	if english.TrieBytes == 0 || english.NeighborIndexBytes == 0 {
+		t.Errorf("Expected the trie and neighbor index to be counted, got %+v", english)
+	}
+	if after.Bytes <= stats.Bytes || after.SimilarityCacheEntries == 0 || after.LearnedCorrections != 1 {
+		t.Errorf("Unexpected stats %+v", after)
+	}
+}
@@ -0,0 +1,163 @@
+package dymean
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldCorrection is an issue found in a field of structured data. Offsets of
+// the correction are relative to the field's value.
+type FieldCorrection struct {
+	Row   int    // 1-based data row of a CSV file, or value of a JSON stream
+	Field string // CSV column name, or JSON path such as "items[2].title"
+	Correction
+}
+
+// CheckCSV checks the values of the selected columns of CSV data whose first
+// record is a header, or of every column when columns is empty, and reports
+// the issues in row order. Each value is checked like CheckText.
+func (dym *DidYouMean) CheckCSV(r io.Reader, columns []string) ([]FieldCorrection, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+
+	indexes := make([]int, 0, len(header))
+	if len(columns) == 0 {
+		for i := range header {
+			indexes = append(indexes, i)
+		}
+	}
+	for _, column := range columns {
+		i := indexOf(header, column)
+		if i < 0 {
+			return nil, fmt.Errorf("column %q not in CSV header", column)
+		}
+		indexes = append(indexes, i)
+	}
+
+	corrections := make([]FieldCorrection, 0)
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return corrections, nil
+		}
+		if err != nil {
+			return corrections, fmt.Errorf("read CSV row %d: %w", row, err)
+		}
+		for _, i := range indexes {
+			if i < len(record) {
+				corrections = dym.appendFieldCorrections(corrections, row, header[i], record[i])
+			}
+		}
+	}
+}
+
+// indexOf returns the index of a string in a slice, or -1
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// CheckJSON checks the string values selected by dot-separated paths in a
+// stream of JSON values, such as a document or JSON lines, and reports the
+// issues in stream order with the paths of their fields. A path segment is an
+// object key, an array index, or "*" for every key or element, so
+// "items.*.title" selects the title of every item. Without selectors every
+// string value is checked.
+func (dym *DidYouMean) CheckJSON(r io.Reader, fieldSelectors []string) ([]FieldCorrection, error) {
+	selectors := make([][]string, 0, len(fieldSelectors))
+	for _, selector := range fieldSelectors {
+		selectors = append(selectors, strings.Split(selector, "."))
+	}
+
+	decoder := json.NewDecoder(r)
+	corrections := make([]FieldCorrection, 0)
+	for row := 1; ; row++ {
+		var value any
+		err := decoder.Decode(&value)
+		if errors.Is(err, io.EOF) {
+			return corrections, nil
+		}
+		if err != nil {
+			return corrections, fmt.Errorf("decode JSON value %d: %w", row, err)
+		}
+		dym.walkJSON(value, make([]string, 0), "", selectors, func(path, text string) {
+			corrections = dym.appendFieldCorrections(corrections, row, path, text)
+		})
+	}
+}
+
+// walkJSON calls visit with the path and text of the selected string values
+// under a JSON value, in document order with object keys sorted. segments
+// are the keys and indexes leading to value, and path their rendering.
+func (dym *DidYouMean) walkJSON(value any, segments []string, path string, selectors [][]string, visit func(path, text string)) {
+	switch v := value.(type) {
+	case string:
+		if selectedPath(segments, selectors) {
+			visit(path, v)
+		}
+	case []any:
+		for i, element := range v {
+			index := strconv.Itoa(i)
+			dym.walkJSON(element, append(segments, index), path+"["+index+"]", selectors, visit)
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child := key
+			if path != "" {
+				child = path + "." + key
+			}
+			dym.walkJSON(v[key], append(segments, key), child, selectors, visit)
+		}
+	}
+}
+
+// selectedPath reports whether a path matches one of the selectors, every
+// path when there are none
+func selectedPath(segments []string, selectors [][]string) bool {
+	if len(selectors) == 0 {
+		return true
+	}
+	for _, selector := range selectors {
+		if len(selector) != len(segments) {
+			continue
+		}
+		matched := true
+		for i, part := range selector {
+			if part != "*" && part != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// appendFieldCorrections checks the value of a field and appends its issues
+func (dym *DidYouMean) appendFieldCorrections(corrections []FieldCorrection, row int, field, value string) []FieldCorrection {
+	for _, correction := range dym.CheckText(value) {
+		corrections = append(corrections, FieldCorrection{Row: row, Field: field, Correction: correction})
+	}
+	return corrections
+}
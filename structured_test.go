@@ -0,0 +1,64 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fieldIssues renders field corrections as "row field original"
+func fieldIssues(corrections []dymean.FieldCorrection) []string {
+	issues := make([]string, 0, len(corrections))
+	for _, correction := range corrections {
+		issues = append(issues, strings.Join([]string{strconv.Itoa(correction.Row), correction.Field, correction.Original}, " "))
+	}
+	return issues
+}
+
+// TestCheckCSV tests checking selected CSV columns
+func TestCheckCSV(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"red", "apple", "green", "pear"})
+
+	input := "id,name,color\nx1,red appel,gren\nx2,pear,green\n"
+	corrections, err := dym.CheckCSV(strings.NewReader(input), []string{"name", "color"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(fieldIssues(corrections), "|"); got != "1 name appel|1 color gren" {
+		t.Errorf("Unexpected issues %q", got)
+	}
+	if corrections[0].Start != 4 || corrections[0].Suggestions[0].Word != "apple" {
+		t.Errorf("Expected apple at offset 4 of the field, got %+v", corrections[0])
+	}
+
+	if _, err := dym.CheckCSV(strings.NewReader(input), []string{"size"}); err == nil {
+		t.Error("Expected an error for an unknown column")
+	}
+}
+
+// TestCheckJSON tests checking selected JSON fields
+func TestCheckJSON(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"red", "apple", "green", "pear"})
+
+	input := `{"id": "zzz", "items": [{"title": "red appel", "sku": "qqq"}, {"title": "gren pear"}]}
+{"items": [{"title": "pear"}], "note": "gren"}`
+	corrections, err := dym.CheckJSON(strings.NewReader(input), []string{"items.*.title"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(fieldIssues(corrections), "|"); got != "1 items[0].title appel|1 items[1].title gren" {
+		t.Errorf("Unexpected issues %q", got)
+	}
+
+	all, err := dym.CheckJSON(strings.NewReader(input), nil)
+	if err != nil || len(all) != 5 {
+		t.Errorf("Expected every string to be checked, got %v, %v", fieldIssues(all), err)
+	}
+
+	if _, err := dym.CheckJSON(strings.NewReader(`{"a": `), nil); err == nil {
+		t.Error("Expected an error for malformed JSON")
+	}
+}
@@ -0,0 +1,119 @@
+package dymean
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TaggedWord is a word of a multilingual dictionary with the languages it
+// belongs to, such as a product name used in several markets
+type TaggedWord struct {
+	Word      string
+	Languages []Language
+}
+
+// AddTaggedWords adds words to the dictionaries of each of their languages,
+// so a single multilingual word list, such as a product catalog, needs no
+// splitting by language. Lookups filter by a set of tags with
+// IsCorrectForLanguages and GetSuggestionsForLanguages.
+func (dym *DidYouMean) AddTaggedWords(words []TaggedWord) {
+	byLanguage := make(map[Language][]string)
+	order := make([]Language, 0)
+	for _, word := range words {
+		for _, lang := range word.Languages {
+			if _, ok := byLanguage[lang]; !ok {
+				order = append(order, lang)
+			}
+			byLanguage[lang] = append(byLanguage[lang], word.Word)
+		}
+	}
+	for _, lang := range order {
+		dym.AddWordsForLanguage(byLanguage[lang], lang)
+	}
+}
+
+// LoadTaggedWordsFromReader adds the tagged words read from r. The input has
+// one word per line followed by a tab and its comma-separated language codes,
+// as in "pizza\tit,en"; empty lines and lines starting with '#' are skipped.
+func (dym *DidYouMean) LoadTaggedWordsFromReader(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	words := make([]TaggedWord, 0)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		word, tags, ok := strings.Cut(text, "\t")
+		if !ok || strings.TrimSpace(tags) == "" {
+			return fmt.Errorf("tagged word on line %d: expected a word, a tab and language codes", line)
+		}
+		tagged := TaggedWord{Word: word, Languages: make([]Language, 0)}
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tagged.Languages = append(tagged.Languages, Language(tag))
+			}
+		}
+		words = append(words, tagged)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read tagged words: %w", err)
+	}
+
+	dym.AddTaggedWords(words)
+	return nil
+}
+
+// WordLanguages returns the loaded languages whose dictionary holds a word,
+// in sorted order: the tags of a word added with AddTaggedWords
+func (dym *DidYouMean) WordLanguages(word string) []Language {
+	languages := make([]Language, 0)
+	for _, lang := range dym.GetLoadedLanguages() {
+		if dym.IsCorrectForLanguage(word, lang) {
+			languages = append(languages, lang)
+		}
+	}
+	return languages
+}
+
+// IsCorrectForLanguages checks if a word is in the dictionary of any of the
+// languages
+func (dym *DidYouMean) IsCorrectForLanguages(word string, langs ...Language) bool {
+	for _, lang := range langs {
+		if dym.IsCorrectForLanguage(word, lang) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSuggestionsForLanguages returns suggestions for a misspelled word from
+// the dictionaries of all the languages, ranked together. A word found in
+// several languages is suggested once, with its best similarity.
+func (dym *DidYouMean) GetSuggestionsForLanguages(word string, maxSuggestions int, maxEditDistance int, langs []Language) []Suggestion {
+	maxSuggestions = max(maxSuggestions, 0)
+	merged := make([]Suggestion, 0)
+	index := make(map[string]int)
+	for _, lang := range langs {
+		for _, suggestion := range dym.GetSuggestionsForLanguageContext(context.Background(), word, maxSuggestions, maxEditDistance, lang) {
+			if i, ok := index[suggestion.Word]; ok {
+				if suggestion.Similarity > merged[i].Similarity {
+					merged[i] = suggestion
+				}
+				continue
+			}
+			index[suggestion.Word] = len(merged)
+			merged = append(merged, suggestion)
+		}
+	}
+
+	if len(langs) > 0 {
+		sortSuggestions(merged, langs[0]) // Ties in the order of the first language
+	}
+	if len(merged) > maxSuggestions {
+		merged = merged[:maxSuggestions]
+	}
+	return merged
+}
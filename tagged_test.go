@@ -0,0 +1,54 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestTaggedWords tests a multilingual dictionary filtered by language tags
+func TestTaggedWords(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddTaggedWords([]dymean.TaggedWord{
+		{Word: "espresso", Languages: []dymean.Language{dymean.Italian, dymean.English}},
+		{Word: "kaffee", Languages: []dymean.Language{dymean.German}},
+		{Word: "coffee", Languages: []dymean.Language{dymean.English}},
+	})
+
+	if got := dym.WordLanguages("espresso"); !reflect.DeepEqual(got, []dymean.Language{dymean.English, dymean.Italian}) {
+		t.Errorf("Expected espresso in en and it, got %v", got)
+	}
+	if !dym.IsCorrectForLanguages("kaffee", dymean.Italian, dymean.German) {
+		t.Error("Expected kaffee to be correct for it or de")
+	}
+	if dym.IsCorrectForLanguages("kaffee", dymean.Italian, dymean.English) {
+		t.Error("Expected kaffee not to be correct for it or en")
+	}
+
+	suggestions := dym.GetSuggestionsForLanguages("kafee", 5, 2, []dymean.Language{dymean.English, dymean.German})
+	if len(suggestions) == 0 || suggestions[0].Word != "kaffee" {
+		t.Errorf("Expected kaffee first, got %v", suggestions)
+	}
+	for _, suggestion := range dym.GetSuggestionsForLanguages("kafee", 5, 2, []dymean.Language{dymean.English}) {
+		if suggestion.Word == "kaffee" {
+			t.Error("Expected German words to be filtered out")
+		}
+	}
+}
+
+// TestLoadTaggedWordsFromReader tests reading a tagged word list
+func TestLoadTaggedWordsFromReader(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	input := "# catalog\npizza\tit, en\n\nbratwurst\tde\n"
+	if err := dym.LoadTaggedWordsFromReader(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+	if !dym.IsCorrectForLanguage("pizza", dymean.English) || !dym.IsCorrectForLanguage("bratwurst", dymean.German) {
+		t.Error("Expected the tagged words to be loaded")
+	}
+
+	if err := dym.LoadTaggedWordsFromReader(strings.NewReader("pizza\n")); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("Expected an error for a word without tags, got %v", err)
+	}
+}
@@ -0,0 +1,359 @@
+package dymean
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CorrectionCategory describes the kind of issue reported by a Correction
+type CorrectionCategory string
+
+const (
+	CategorySpelling   CorrectionCategory = "spelling"
+	CategoryWhitespace CorrectionCategory = "whitespace"
+)
+
+// Correction represents an issue found in a text with its suggested replacements
+type Correction struct {
+	Start       int    // Byte offset where the issue starts
+	End         int    // Byte offset just after the issue
+	Original    string // Text found between Start and End
+	Suggestions []Suggestion
+	Category    CorrectionCategory
+	Language    Language
+}
+
+// Token represents a word found in a text with its byte offsets
+type Token struct {
+	Text  string
+	Start int
+	End   int
+}
+
+// Tokenize splits a text into word tokens made of letters, marks and digits.
+// A zero-width non-joiner between letters, bidi controls and tatweel inside a
+// word are kept in the token, while those at its edges are left out. A token
+// also ends where the text switches between right-to-left and left-to-right
+// letters. Offsets always refer to the original text.
+func Tokenize(text string) []Token {
+	tokens := make([]Token, 0)
+	start, end := -1, -1 // Current token and the end of its last word rune
+	direction := dirNeutral
+
+	flush := func() {
+		if start >= 0 {
+			tokens = append(tokens, Token{Text: text[start:end], Start: start, End: end})
+		}
+		start, direction = -1, dirNeutral
+	}
+
+	for i, r := range text {
+		_, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case isWordRune(r) && !isIgnorable(r):
+			if d := letterDirection(r); d != dirNeutral {
+				if direction != dirNeutral && d != direction {
+					flush()
+				}
+				direction = d
+			}
+			if start < 0 {
+				start = i
+			}
+			end = i + size
+		case start >= 0 && (isIgnorable(r) || r == zwnj && joinsWord(text[i+size:])):
+			// Part of the word only if more letters follow
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+// isWordRune checks if a rune can be part of a word token
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r)
+}
+
+// joinsWord reports whether the text after a zero-width non-joiner continues
+// the word, as in the Persian compound "می‌روم"
+func joinsWord(rest string) bool {
+	r, _ := utf8.DecodeRuneInString(rest)
+	return unicode.IsLetter(r)
+}
+
+// CheckText checks every word of a text, detecting the language of each word,
+// and reports spelling and whitespace issues in the order they appear.
+// Grammar issues are included when enabled with SetGrammarChecks. Regions
+// between dymean:off and dymean:on directives are skipped, and so are
+// versions, model numbers and IDs (see WithImmutablePatterns).
+func (dym *DidYouMean) CheckText(text string) []Correction {
+	return dym.checkText(text, nil, func(word string) Language {
+		return DetectLanguage(word)
+	})
+}
+
+// CheckTextForLanguage checks every word of a text against a specific language
+// and reports spelling and whitespace issues in the order they appear
+func (dym *DidYouMean) CheckTextForLanguage(text string, lang Language) []Correction {
+	return dym.checkText(text, nil, func(string) Language {
+		return lang
+	})
+}
+
+// checkText runs the spelling and whitespace checks over a text, reporting
+// nothing that overlaps an ignored range, an immutable token such as a version
+// or model number, or a region switched off by directives. Panics are
+// recovered, with no corrections, under WithRecovery.
+func (dym *DidYouMean) checkText(text string, ignore []TextRange, languageOf func(string) Language) (corrections []Correction) {
+	if dym.recovery {
+		defer func() {
+			if value := recover(); value != nil {
+				dym.recovered(newPanicError("text check", text, value))
+				corrections = make([]Correction, 0)
+			}
+		}()
+	}
+
+	corrections = make([]Correction, 0)
+	text = dym.truncateText(text)
+	tokens := Tokenize(text)
+	corrected := make(map[int]string)
+	ignore = mergeRanges(append(append(DirectiveRanges(text), dym.ImmutableRanges(text)...), ignore...))
+
+	// Dictionary phrases are matched greedily before single words
+	covered := dym.matchPhrases(text, tokens, languageOf)
+	for i, token := range tokens {
+		if overlapsRanges(ignore, token.Start, token.End) {
+			covered[i] = true // Ignored words are never reported
+		}
+	}
+
+	// Words are checked in parallel; results are collected in token order
+	spelling := make([]*Correction, len(tokens))
+	parallelFor(len(tokens), dym.concurrency(), func(i int) {
+		token := tokens[i]
+		if covered[i] {
+			return // Part of a correct phrase
+		}
+		word := stripIgnorable(token.Text) // Offsets still cover the original text
+		if dym.isWordTooLong(word) {
+			return // Not a word a human would type
+		}
+
+		lang := languageOf(word)
+		langInfo := GetLanguageInfo(lang)
+		normalized := dym.normalize(word, lang)
+		if normalized == "" || strings.IndexFunc(normalized, unicode.IsDigit) >= 0 {
+			return // Numbers and identifiers are not spell checked
+		}
+		if langInfo.isStopword(normalized) {
+			return // Function words are always accepted
+		}
+		if dym.textRules.skips(text, token, word) {
+			return // Left alone by the text rules
+		}
+		if dym.layoutDetection {
+			if switched, ok := dym.DetectLayoutSwitch(word); ok {
+				spelling[i] = &Correction{
+					Start:       token.Start,
+					End:         token.End,
+					Original:    token.Text,
+					Suggestions: []Suggestion{{Word: switched.Word, Similarity: 1.0}},
+					Category:    CategoryLayoutSwitch,
+					Language:    switched.To,
+				}
+				return
+			}
+		}
+		if !dym.hasDictionary(lang) {
+			return // Nothing to check against
+		}
+		if dym.IsCorrectForLanguage(word, lang) {
+			return
+		}
+		if dym.textRules.CollapseElongation {
+			collapsed, correct := dym.collapseElongation(word, lang)
+			if correct {
+				return // "soooo" for "so"
+			}
+			word = collapsed
+		}
+
+		tuning := dym.SuggestionTuning(lang)
+		ctx := context.Background()
+		if i > 0 && strings.TrimSpace(text[tokens[i-1].End:token.Start]) == "" {
+			ctx = ContextWithPreviousWord(ctx, tokens[i-1].Text) // For ContextRanker
+		}
+		suggestions := dym.GetSuggestionsForLanguageContext(ctx, word, tuning.MaxSuggestions, tuning.MaxEditDistance, lang)
+		dym.harvest(text, token, word, lang)
+		if !dym.textRules.confident(suggestions) {
+			return // Likely a name rather than a misspelling
+		}
+		spelling[i] = &Correction{
+			Start:       token.Start,
+			End:         token.End,
+			Original:    token.Text,
+			Suggestions: suggestions,
+			Category:    CategorySpelling,
+			Language:    lang,
+		}
+	})
+
+	dym.correctPhrases(text, tokens, covered, spelling)
+
+	for _, correction := range spelling {
+		if correction == nil {
+			continue
+		}
+		if len(correction.Suggestions) > 0 {
+			corrected[correction.Start] = correction.Suggestions[0].Word
+		}
+		corrections = append(corrections, *correction)
+	}
+
+	corrections = append(corrections, CheckWhitespace(text)...)
+	if dym.grammarChecks {
+		corrections = append(corrections, checkGrammarTokens(text, tokens, corrected)...)
+	}
+
+	if len(ignore) > 0 {
+		kept := corrections[:0]
+		for _, correction := range corrections {
+			if !overlapsRanges(ignore, correction.Start, correction.End) {
+				kept = append(kept, correction)
+			}
+		}
+		corrections = kept
+	}
+
+	sort.SliceStable(corrections, func(i, j int) bool {
+		return corrections[i].Start < corrections[j].Start
+	})
+
+	return corrections
+}
+
+// Correct returns the text with every misspelled word replaced by its best
+// suggestion, keeping the capitalization of the original word. Versions,
+// model numbers and IDs matched by the immutable patterns are never rewritten.
+func (dym *DidYouMean) Correct(text string) string {
+	var builder strings.Builder
+	last := 0
+
+	for _, correction := range dym.CheckText(text) {
+		if correction.Category != CategorySpelling && correction.Category != CategoryLayoutSwitch {
+			continue
+		}
+		if len(correction.Suggestions) == 0 {
+			continue
+		}
+		builder.WriteString(text[last:correction.Start])
+		builder.WriteString(matchCase(correction.Original, correction.Suggestions[0].Word))
+		last = correction.End
+	}
+	builder.WriteString(text[last:])
+
+	return builder.String()
+}
+
+// LineColumn converts a byte offset of a text to a 1-based line and a 1-based
+// column counted in runes, as editors and CI annotations expect
+func LineColumn(text string, offset int) (line, column int) {
+	if offset > len(text) {
+		offset = len(text)
+	}
+	lineStart := strings.LastIndexByte(text[:offset], '\n') + 1
+	line = strings.Count(text[:lineStart], "\n") + 1
+	column = utf8.RuneCountInString(text[lineStart:offset]) + 1
+	return line, column
+}
+
+// matchCase applies the capitalization of original to replacement
+func matchCase(original, replacement string) string {
+	if looksLikeAcronym(original) && !strings.Contains(original, ".") {
+		return strings.ToUpper(replacement)
+	}
+
+	runes := []rune(replacement)
+	if first := []rune(original); len(first) > 0 && len(runes) > 0 && unicode.IsUpper(first[0]) {
+		runes[0] = unicode.ToUpper(runes[0])
+	}
+	return string(runes)
+}
+
+// sentencePunctuation contains punctuation marks that should be followed by a space
+const sentencePunctuation = ",.;:!?،؛؟"
+
+// CheckWhitespace reports doubled spaces and missing spaces after punctuation
+func CheckWhitespace(text string) []Correction {
+	corrections := make([]Correction, 0)
+	lineStart := true
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+
+		switch {
+		case r == '\n':
+			lineStart = true
+			i += size
+			continue
+
+		case r == ' ':
+			end := i
+			for end < len(text) && text[end] == ' ' {
+				end++
+			}
+			// Indentation and trailing spaces are left alone
+			if !lineStart && end-i > 1 && end < len(text) && text[end] != '\n' {
+				corrections = append(corrections, Correction{
+					Start:       i,
+					End:         end,
+					Original:    text[i:end],
+					Suggestions: []Suggestion{{Word: " ", Similarity: 1.0}},
+					Category:    CategoryWhitespace,
+				})
+			}
+			i = end
+			continue
+
+		case strings.ContainsRune(sentencePunctuation, r) && i > 0 && i+size < len(text):
+			prev, _ := utf8.DecodeLastRuneInString(text[:i])
+			next, _ := utf8.DecodeRuneInString(text[i+size:])
+			if missingSpaceAfter(r, prev, next) {
+				corrections = append(corrections, Correction{
+					Start:       i,
+					End:         i + size,
+					Original:    text[i : i+size],
+					Suggestions: []Suggestion{{Word: string(r) + " ", Similarity: 1.0}},
+					Category:    CategoryWhitespace,
+				})
+			}
+		}
+
+		lineStart = false
+		i += size
+	}
+
+	return corrections
+}
+
+// missingSpaceAfter checks if a punctuation mark between two runes needs a space after it
+func missingSpaceAfter(punct, prev, next rune) bool {
+	if !unicode.IsLetter(prev) || !unicode.IsLetter(next) {
+		return false
+	}
+
+	// A period followed by a lowercase letter is most likely an abbreviation
+	// ("e.g.") or a domain name ("example.com"), not the end of a sentence
+	if punct == '.' {
+		return unicode.IsUpper(next)
+	}
+
+	return true
+}
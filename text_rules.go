@@ -0,0 +1,129 @@
+package dymean
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TextRules tune which words text checks report, for texts where prose
+// defaults over-flag, such as subject lines or chat. The zero value reports
+// every unknown word.
+type TextRules struct {
+	MinWordLength           int     // Words of fewer runes are not checked
+	SkipAllCaps             bool    // Words in capitals, such as "FREE", are not checked
+	SkipCapitalized         bool    // Capitalized words not starting a sentence, mostly names and brands, are not checked
+	MinSuggestionSimilarity float64 // Unknown words are reported only with a suggestion at least this similar
+	CollapseElongation      bool    // Letters repeated three times or more, as in "soooo", are collapsed before checking
+}
+
+// WithTextRules sets the rules of the words text checks report
+func WithTextRules(rules TextRules) Option {
+	return func(dym *DidYouMean) {
+		dym.textRules = rules
+	}
+}
+
+// skips reports whether the rules leave a word of a text unchecked
+func (rules TextRules) skips(text string, token Token, word string) bool {
+	if rules.MinWordLength > 0 && utf8.RuneCountInString(word) < rules.MinWordLength {
+		return true
+	}
+	first, size := utf8.DecodeRuneInString(word)
+	if !unicode.IsUpper(first) {
+		return false
+	}
+	if rules.SkipAllCaps && utf8.RuneCountInString(word) > 1 && strings.ToUpper(word) == word {
+		return true
+	}
+	return rules.SkipCapitalized && strings.ToLower(word[size:]) == word[size:] && !startsSentence(text, token.Start)
+}
+
+// startsSentence reports whether the word at an offset of a text is the first
+// of a sentence: nothing but spaces and opening punctuation since the start
+// of the text, a line, or the end of the previous sentence
+func startsSentence(text string, offset int) bool {
+	for offset > 0 {
+		r, size := utf8.DecodeLastRuneInString(text[:offset])
+		switch {
+		case r == '\n' || r == '.' || r == '!' || r == '?' || r == '…' || r == ':':
+			return true
+		case unicode.IsSpace(r) || unicode.Is(unicode.Ps, r) || unicode.Is(unicode.Pi, r) || r == '"' || r == '\'':
+			offset -= size
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// confident reports whether suggestions for an unknown word are close enough
+// for it to be reported
+func (rules TextRules) confident(suggestions []Suggestion) bool {
+	if rules.MinSuggestionSimilarity <= 0 {
+		return true
+	}
+	return len(suggestions) > 0 && suggestions[0].Similarity >= rules.MinSuggestionSimilarity
+}
+
+// maxElongatedRuns caps the runs of repeated letters whose collapsed forms
+// are tried, as each run doubles them
+const maxElongatedRuns = 4
+
+// collapseElongation returns the first correct form of an elongated word with
+// each run of three or more repeated letters collapsed to two or one letters
+// ("cooool" -> "cool", "soooo" -> "so"), trying longer runs first, and true.
+// Without a correct form it returns the word with its runs collapsed to two
+// letters, the likelier spelling to suggest for, and false.
+func (dym *DidYouMean) collapseElongation(word string, lang Language) (string, bool) {
+	type run struct {
+		r     rune
+		count int
+	}
+	runs := make([]run, 0, len(word))
+	elongated := 0
+	for _, r := range word {
+		if len(runs) > 0 && unicode.IsLetter(r) && runs[len(runs)-1].r == r {
+			runs[len(runs)-1].count++
+			if runs[len(runs)-1].count == 3 {
+				elongated++
+			}
+			continue
+		}
+		runs = append(runs, run{r: r, count: 1})
+	}
+	if elongated == 0 {
+		return word, false
+	}
+
+	// Bit i of a form's mask collapses the ith elongated run to one letter
+	likeliest := word
+	forms := 1 << elongated
+	if elongated > maxElongatedRuns {
+		forms = 1
+	}
+	for mask := 0; mask < forms; mask++ {
+		var builder strings.Builder
+		builder.Grow(len(word))
+		i := 0
+		for _, run := range runs {
+			count := run.count
+			if count >= 3 {
+				count = 2
+				if mask&(1<<i) != 0 {
+					count = 1
+				}
+				i++
+			}
+			builder.WriteString(strings.Repeat(string(run.r), count))
+		}
+		collapsed := builder.String()
+		if dym.IsCorrectForLanguage(collapsed, lang) {
+			return collapsed, true
+		}
+		if mask == 0 {
+			likeliest = collapsed
+		}
+	}
+	return likeliest, false
+}
@@ -0,0 +1,168 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"strings"
+	"testing"
+)
+
+// TestTokenize tests splitting text into tokens with offsets
+func TestTokenize(t *testing.T) {
+	text := "hello, wrld سلام"
+	tokens := dymean.Tokenize(text)
+
+	expected := []string{"hello", "wrld", "سلام"}
+	if len(tokens) != len(expected) {
+		t.Fatalf("Expected %d tokens, got %d", len(expected), len(tokens))
+	}
+
+	for i, token := range tokens {
+		if token.Text != expected[i] {
+			t.Errorf("Expected token '%s', got '%s'", expected[i], token.Text)
+		}
+		if text[token.Start:token.End] != token.Text {
+			t.Errorf("Token '%s' has misaligned offsets %d-%d", token.Text, token.Start, token.End)
+		}
+	}
+}
+
+// TestTokenizeBidi tests tokens of RTL text with bidi controls, tatweel and mixed runs
+func TestTokenizeBidi(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"\u200fسلام\u200f دنیا", []string{"سلام", "دنیا"}},
+		{"سل\u200eام", []string{"سل\u200eام"}},
+		{"سـلام\u0640", []string{"سـلام"}},
+		{"\u2067سلام\u2069 world", []string{"سلام", "world"}},
+		{"سلامhello", []string{"سلام", "hello"}},
+		{"hello۱۲سلام", []string{"hello۱۲", "سلام"}},
+		{"\u061c١٢٣", []string{"١٢٣"}},
+	}
+	for _, tc := range tests {
+		tokens := dymean.Tokenize(tc.text)
+		if len(tokens) != len(tc.expected) {
+			t.Errorf("Expected tokens %q in %q, got %+v", tc.expected, tc.text, tokens)
+			continue
+		}
+		for i, token := range tokens {
+			if token.Text != tc.expected[i] || tc.text[token.Start:token.End] != token.Text {
+				t.Errorf("Expected token %q in %q, got %+v", tc.expected[i], tc.text, token)
+			}
+		}
+	}
+}
+
+// TestCheckTextBidi tests that corrections of RTL text with bidi controls line up
+func TestCheckTextBidi(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.LoadDefaultDictionary(dymean.English)
+	dym.LoadDefaultDictionary(dymean.Persian)
+
+	text := "\u200fسـلام\u200f \u2067برنام\u2069 helo"
+	corrections := dym.CheckText(text)
+	if len(corrections) != 2 {
+		t.Fatalf("Expected 2 corrections, got %+v", corrections)
+	}
+	if corrections[0].Original != "برنام" || text[corrections[0].Start:corrections[0].End] != "برنام" {
+		t.Errorf("Expected an aligned correction of برنام, got %+v", corrections[0])
+	}
+	if corrections[1].Original != "helo" || text[corrections[1].Start:corrections[1].End] != "helo" {
+		t.Errorf("Expected a correction of helo, got %+v", corrections[1])
+	}
+}
+
+// TestCheckText tests spelling corrections in a text
+func TestCheckText(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.LoadDefaultDictionary(dymean.English)
+	dym.LoadDefaultDictionary(dymean.Persian)
+
+	text := "helo world سلام 123"
+	corrections := dym.CheckText(text)
+
+	if len(corrections) != 1 {
+		t.Fatalf("Expected 1 correction, got %d: %v", len(corrections), corrections)
+	}
+
+	correction := corrections[0]
+	if correction.Original != "helo" || correction.Category != dymean.CategorySpelling {
+		t.Errorf("Expected spelling correction for 'helo', got %+v", correction)
+	}
+	if text[correction.Start:correction.End] != "helo" {
+		t.Errorf("Correction has misaligned offsets %d-%d", correction.Start, correction.End)
+	}
+	if len(correction.Suggestions) == 0 || correction.Suggestions[0].Word != "hello" {
+		t.Errorf("Expected 'hello' suggestion, got %v", correction.Suggestions)
+	}
+}
+
+// TestCorrect tests replacing misspelled words with their best suggestion
+func TestCorrect(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.LoadDefaultDictionary(dymean.English)
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"helo world", "hello world"},
+		{"Helo, wrld!", "Hello, world!"},
+		{"hello world", "hello world"},
+	}
+
+	for _, tc := range testCases {
+		if result := dym.Correct(tc.input); result != tc.expected {
+			t.Errorf("Expected '%s' for '%s', got '%s'", tc.expected, tc.input, result)
+		}
+	}
+}
+
+// TestCheckWhitespace tests whitespace and punctuation corrections
+func TestCheckWhitespace(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected []string
+	}{
+		{"hello  world", []string{"  "}},
+		{"hello,world", []string{","}},
+		{"Done.Next one", []string{"."}},
+		{"see e.g. example.com", nil},
+		{"  indented line", nil},
+		{"pi is 3.14, ok", nil},
+		{"سلام،دنیا", []string{"،"}},
+	}
+
+	for _, tc := range testCases {
+		corrections := dymean.CheckWhitespace(tc.text)
+		if len(corrections) != len(tc.expected) {
+			t.Errorf("Expected %d corrections for '%s', got %d: %v", len(tc.expected), tc.text, len(corrections), corrections)
+			continue
+		}
+		for i, correction := range corrections {
+			if correction.Original != tc.expected[i] || correction.Category != dymean.CategoryWhitespace {
+				t.Errorf("Expected whitespace correction '%s' for '%s', got %+v", tc.expected[i], tc.text, correction)
+			}
+		}
+	}
+}
+
+// TestLineColumn tests converting byte offsets to lines and columns
+func TestLineColumn(t *testing.T) {
+	text := "hello\nسلام wrold\n"
+	tests := []struct {
+		offset, line, column int
+	}{
+		{0, 1, 1},
+		{5, 1, 6},
+		{6, 2, 1},
+		{strings.Index(text, "wrold"), 2, 6},
+		{len(text), 3, 1},
+	}
+	for _, tc := range tests {
+		if line, column := dymean.LineColumn(text, tc.offset); line != tc.line || column != tc.column {
+			t.Errorf("Expected offset %d at %d:%d, got %d:%d", tc.offset, tc.line, tc.column, line, column)
+		}
+	}
+}
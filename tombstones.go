@@ -0,0 +1,137 @@
+package dymean
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Bloom filters rebuilt by Compact get bloomBitsPerWord bits per live word,
+// and never fewer than minBloomBits
+const (
+	bloomBitsPerWord = 10
+	minBloomBits     = 10000
+	bloomHashFuncs   = 7
+)
+
+// CompactionStats reports the removed words still set in the Bloom filters
+// and the compactions that cleared them
+type CompactionStats struct {
+	Tombstones     map[Language]int // Removed words per language not yet compacted
+	Compactions    int              // Compactions that rebuilt at least one filter
+	Reclaimed      int              // Tombstones cleared by compactions
+	LastCompaction time.Time        // Zero before the first compaction
+	LastDuration   time.Duration
+}
+
+// compactionState holds the counters behind CompactionStats. Guarded by dym.mu.
+type compactionState struct {
+	compactions int
+	reclaimed   int
+	last        time.Time
+	duration    time.Duration
+}
+
+// addTombstone records a word removed from a language's dictionary. The
+// caller must hold the write lock.
+func (dym *DidYouMean) addTombstone(lang Language, word string) {
+	if dym.tombstones[lang] == nil {
+		dym.tombstones[lang] = make(map[string]bool)
+	}
+	dym.tombstones[lang][word] = true
+}
+
+// isTombstone reports whether a word was removed since the last compaction.
+// The caller must hold the lock.
+func (dym *DidYouMean) isTombstone(lang Language, word string) bool {
+	tombstones := dym.tombstones[lang]
+	return len(tombstones) > 0 && tombstones[word]
+}
+
+// Compact rebuilds the Bloom filters of the languages with removed words from
+// their live words, sized for the current word count, and clears the
+// tombstones. Removed words are never reported as correct, but until a
+// compaction their bits keep raising the filter's false positive rate, so
+// more misses fall through to the dictionary. It returns the number of
+// tombstones cleared.
+func (dym *DidYouMean) Compact() int {
+	start := time.Now()
+	dym.mu.Lock()
+	defer dym.mu.Unlock()
+
+	langs := make([]Language, 0, len(dym.tombstones))
+	for lang, tombstones := range dym.tombstones {
+		if len(tombstones) > 0 {
+			langs = append(langs, lang)
+		}
+	}
+	if len(langs) == 0 {
+		return 0
+	}
+	sort.Slice(langs, func(i, j int) bool { return langs[i] < langs[j] })
+
+	cleared := 0
+	for _, lang := range langs {
+		words := dym.dictionaryWords(lang)
+		size := uint(len(words) * bloomBitsPerWord)
+		if size < minBloomBits {
+			size = minBloomBits
+		}
+		bf := NewBloomFilter(size, bloomHashFuncs)
+		bf.AddWords(words)
+		dym.bloomFilters[lang] = bf
+
+		cleared += len(dym.tombstones[lang])
+		delete(dym.tombstones, lang)
+	}
+
+	dym.compaction.compactions++
+	dym.compaction.reclaimed += cleared
+	dym.compaction.last = start
+	dym.compaction.duration = time.Since(start)
+	dym.logger.Info("compacted dictionaries", "languages", langs, "tombstones", cleared, "duration", dym.compaction.duration)
+	return cleared
+}
+
+// CompactionStats returns the pending tombstones and compaction counters
+func (dym *DidYouMean) CompactionStats() CompactionStats {
+	dym.mu.RLock()
+	defer dym.mu.RUnlock()
+
+	stats := CompactionStats{
+		Tombstones:     make(map[Language]int, len(dym.tombstones)),
+		Compactions:    dym.compaction.compactions,
+		Reclaimed:      dym.compaction.reclaimed,
+		LastCompaction: dym.compaction.last,
+		LastDuration:   dym.compaction.duration,
+	}
+	for lang, tombstones := range dym.tombstones {
+		if len(tombstones) > 0 {
+			stats.Tombstones[lang] = len(tombstones)
+		}
+	}
+	return stats
+}
+
+// StartCompaction compacts in the background every interval once at least
+// minTombstones words were removed in total, until ctx is done
+func (dym *DidYouMean) StartCompaction(ctx context.Context, interval time.Duration, minTombstones int) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				pending := 0
+				for _, count := range dym.CompactionStats().Tombstones {
+					pending += count
+				}
+				if pending > 0 && pending >= minTombstones {
+					dym.Compact()
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,84 @@
+package dymean_test
+
+import (
+	"context"
+	"github.com/bi0dread/dymean"
+	"testing"
+	"time"
+)
+
+// TestTombstones tests that removed words are tracked until compaction
+func TestTombstones(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello", "world", "help"})
+	dym.RemoveWords([]string{"world", "missing"})
+
+	if dym.IsCorrect("world") {
+		t.Error("Expected a removed word to be incorrect immediately")
+	}
+	stats := dym.CompactionStats()
+	if stats.Tombstones[dymean.English] != 1 || stats.Compactions != 0 {
+		t.Errorf("Expected one tombstone for the word that was present, got %+v", stats)
+	}
+
+	dym.AddWords([]string{"world"})
+	if !dym.IsCorrect("world") || dym.CompactionStats().Tombstones[dymean.English] != 0 {
+		t.Error("Expected adding a word back to clear its tombstone")
+	}
+
+	dym.RemoveWords([]string{"world", "help"})
+	if cleared := dym.Compact(); cleared != 2 {
+		t.Errorf("Expected 2 tombstones cleared, got %d", cleared)
+	}
+	stats = dym.CompactionStats()
+	if len(stats.Tombstones) != 0 || stats.Compactions != 1 || stats.Reclaimed != 2 || stats.LastCompaction.IsZero() {
+		t.Errorf("Unexpected stats after compaction %+v", stats)
+	}
+	if !dym.IsCorrect("hello") || dym.IsCorrect("help") {
+		t.Error("Expected compaction to keep live words only")
+	}
+	if suggestions := dym.GetSuggestions("helo", 1, 2); len(suggestions) != 1 || suggestions[0].Word != "hello" {
+		t.Errorf("Expected hello after compaction, got %v", suggestions)
+	}
+	if dym.Compact() != 0 || dym.CompactionStats().Compactions != 1 {
+		t.Error("Expected nothing to compact without tombstones")
+	}
+}
+
+// TestCompactStorageTombstones tests tombstones with front-coded storage
+func TestCompactStorageTombstones(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithCompactStorage(dymean.English))
+	dym.AddWords([]string{"hello", "world"})
+	dym.RemoveWords([]string{"world"})
+	if dym.IsCorrect("world") || dym.CompactionStats().Tombstones[dymean.English] != 1 {
+		t.Error("Expected a tombstone for the removed word")
+	}
+	dym.Compact()
+	if dym.IsCorrect("world") || !dym.IsCorrect("hello") {
+		t.Error("Expected compaction to keep live words only")
+	}
+}
+
+// TestStartCompaction tests background compaction
+func TestStartCompaction(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWords([]string{"hello", "world", "help"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dym.StartCompaction(ctx, time.Millisecond, 2)
+
+	dym.RemoveWords([]string{"world"})
+	time.Sleep(20 * time.Millisecond)
+	if dym.CompactionStats().Compactions != 0 {
+		t.Error("Expected no compaction below the tombstone threshold")
+	}
+
+	dym.RemoveWords([]string{"help"})
+	deadline := time.Now().Add(5 * time.Second)
+	for dym.CompactionStats().Compactions == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected a background compaction")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
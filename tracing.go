@@ -0,0 +1,71 @@
+package dymean
+
+import (
+	"context"
+	"runtime/pprof"
+	"runtime/trace"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Stage is a step of finding suggestions, traced by a Tracer
+type Stage string
+
+// Stages of finding suggestions
+const (
+	StageGenerate Stage = "generate" // Generating edits, or searching the trie or neighbor index
+	StageFilter   Stage = "filter"   // Keeping the generated edits found in the dictionary
+	StageRank     Stage = "rank"     // Scoring, boosting and sorting the candidates
+)
+
+// Tracer observes the stages of suggestions, so latency can be attributed to
+// them. StartStage is called as a stage starts, with the context of the call,
+// and the returned function as it ends, on the same goroutine. An OpenTelemetry
+// tracer adapts in a few lines by starting a span with the language and word
+// length as attributes and returning its End.
+type Tracer interface {
+	StartStage(ctx context.Context, stage Stage, lang Language, wordLength int) (end func())
+}
+
+// WithTracer traces the stages of suggestions with a tracer, such as
+// RuntimeTracer
+func WithTracer(tracer Tracer) Option {
+	return func(dym *DidYouMean) {
+		dym.tracer = tracer
+	}
+}
+
+// RuntimeTracer traces stages with the standard library: each stage is a
+// runtime/trace region named "dymean.<stage>", logging the language and word
+// length, and runs with the pprof labels dymean_stage, dymean_language and
+// dymean_word_length, so CPU profiles split by stage.
+type RuntimeTracer struct{}
+
+// StartStage starts the region and sets the labels of a stage, restoring the
+// labels of ctx when it ends
+func (RuntimeTracer) StartStage(ctx context.Context, stage Stage, lang Language, wordLength int) func() {
+	length := strconv.Itoa(wordLength)
+	pprof.SetGoroutineLabels(pprof.WithLabels(ctx, pprof.Labels(
+		"dymean_stage", string(stage),
+		"dymean_language", string(lang),
+		"dymean_word_length", length,
+	)))
+	region := trace.StartRegion(ctx, "dymean."+string(stage))
+	if trace.IsEnabled() {
+		trace.Log(ctx, "dymean.language", string(lang))
+		trace.Log(ctx, "dymean.word_length", length)
+	}
+	return func() {
+		region.End()
+		pprof.SetGoroutineLabels(ctx)
+	}
+}
+
+// startStage starts tracing a stage of the suggestions for a normalized word,
+// returning the function ending it; without a tracer it does nothing
+func (dym *DidYouMean) startStage(ctx context.Context, stage Stage, lang Language, normalized string) func() {
+	if dym.tracer == nil {
+		return func() {}
+	}
+	return dym.tracer.StartStage(ctx, stage, lang, utf8.RuneCountInString(normalized))
+}
@@ -0,0 +1,64 @@
+package dymean_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/bi0dread/dymean"
+	"reflect"
+	"runtime/trace"
+	"sync"
+	"testing"
+)
+
+// recordingTracer records the stages it traces
+type recordingTracer struct {
+	mu     sync.Mutex
+	stages []string
+}
+
+func (r *recordingTracer) StartStage(ctx context.Context, stage dymean.Stage, lang dymean.Language, wordLength int) func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stages = append(r.stages, fmt.Sprintf("%s %s %d", stage, lang, wordLength))
+	return func() {}
+}
+
+// TestTracer tests tracing the stages of suggestions
+func TestTracer(t *testing.T) {
+	tracer := &recordingTracer{}
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithTracer(tracer))
+	dym.AddWords([]string{"hello", "world"})
+
+	dym.GetSuggestions("helo", 5, 1)
+	want := []string{"generate en 4", "filter en 4", "rank en 4"}
+	if !reflect.DeepEqual(tracer.stages, want) {
+		t.Errorf("Expected %v, got %v", want, tracer.stages)
+	}
+
+	// Correct words are answered without stages
+	tracer.stages = nil
+	dym.GetSuggestions("hello", 5, 1)
+	if len(tracer.stages) != 0 {
+		t.Errorf("Expected no stages, got %v", tracer.stages)
+	}
+}
+
+// TestRuntimeTracer tests tracing with runtime/trace regions and pprof labels
+func TestRuntimeTracer(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithTracer(dymean.RuntimeTracer{}), dymean.WithAutomatonSearch())
+	dym.AddWords([]string{"hello", "world"})
+
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Skipf("Tracing unavailable: %v", err)
+	}
+	suggestions := dym.GetSuggestions("helo", 5, 1)
+	trace.Stop()
+	if len(suggestions) == 0 || suggestions[0].Word != "hello" {
+		t.Errorf("Expected hello, got %v", suggestions)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("dymean.rank")) {
+		t.Error("Expected the rank region in the trace")
+	}
+}
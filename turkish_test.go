@@ -0,0 +1,48 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestTurkishCasing tests that Turkish words are lowercased with Turkish rules
+func TestTurkishCasing(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWordsForLanguage([]string{"KIRMIZI", "İstanbul"}, dymean.Turkish)
+
+	for _, word := range []string{"kırmızı", "Kırmızı", "istanbul", "İSTANBUL"} {
+		if !dym.IsCorrectForLanguage(word, dymean.Turkish) {
+			t.Errorf("Expected %q to be correct", word)
+		}
+	}
+	if dym.IsCorrectForLanguage("kirmizi", dymean.Turkish) {
+		t.Error("Expected dotted kirmizi not to match kırmızı")
+	}
+}
+
+// TestTurkishDeasciification tests suggestions for words typed without Turkish letters
+func TestTurkishDeasciification(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWordsForLanguage([]string{"çağrı", "öğrenci", "şehir", "güzel", "cari"}, dymean.Turkish)
+
+	tests := map[string]string{"cagri": "çağrı", "ogrenci": "öğrenci", "sehir": "şehir", "guzel": "güzel"}
+	for ascii, expected := range tests {
+		suggestions := dym.GetSuggestionsForLanguage(ascii, 5, 1, dymean.Turkish)
+		if len(suggestions) == 0 || suggestions[0].Word != expected {
+			t.Errorf("Expected %q first for %q, got %v", expected, ascii, suggestions)
+		}
+	}
+
+	// Adjacent keys follow the Turkish Q layout, where "ı" sits next to "u" and "o"
+	if suggestions := dym.GetSuggestionsForLanguage("çağru", 5, 1, dymean.Turkish); len(suggestions) == 0 || suggestions[0].Word != "çağrı" {
+		t.Errorf("Expected çağrı for a keyboard typo, got %v", suggestions)
+	}
+}
+
+// TestDetectTurkish tests that Turkish letters identify Turkish
+func TestDetectTurkish(t *testing.T) {
+	candidates := dymean.DetectLanguageCandidates("öğrenci")
+	if len(candidates) == 0 || candidates[0].Language != dymean.Turkish {
+		t.Errorf("Expected Turkish first, got %v", candidates)
+	}
+}
@@ -0,0 +1,94 @@
+package dymean
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ValidationMode controls which characters words may contain when they are
+// added to a dictionary or looked up
+type ValidationMode int
+
+const (
+	// ValidationStrict accepts only the language's alphabet and extended
+	// characters. This is the default.
+	ValidationStrict ValidationMode = iota
+	// ValidationLenient accepts any letter or combining mark of the
+	// language's scripts, plus a middle dot between letters as in Catalan
+	// "col·lecció"
+	ValidationLenient
+)
+
+// middleDot joins letters in some Latin-script spellings
+const middleDot = '·'
+
+// IsValidWordForLanguageMode checks if a word contains only valid characters
+// for a language under the given validation mode
+func IsValidWordForLanguageMode(word string, lang Language, mode ValidationMode) bool {
+	if mode != ValidationLenient {
+		return IsValidWordForLanguage(word, lang)
+	}
+	if len(word) == 0 || startsWithMark(word) {
+		return false
+	}
+
+	langInfo := GetLanguageInfo(lang)
+	runes := []rune(word)
+	for i, r := range runes {
+		switch {
+		case unicode.IsSpace(r), unicode.IsMark(r):
+		case strings.ContainsRune(langInfo.Alphabet, r), strings.ContainsRune(langInfo.Extended, r):
+		case isJoinerAt(runes, i, langInfo.Joiners):
+		case unicode.IsLetter(r):
+			if len(langInfo.Scripts) > 0 && !unicode.In(r, langInfo.Scripts...) {
+				return false
+			}
+		case r == middleDot:
+			if !isJoinerAt(runes, i, string(middleDot)) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isValidWord validates a word with the instance's validation mode
+func (dym *DidYouMean) isValidWord(word string, lang Language) bool {
+	return IsValidWordForLanguageMode(word, lang, dym.validationMode)
+}
+
+// NormalizeWord detects the language of a word and normalizes it for that
+// language as lookups do, with the instance's digit policy
+func (dym *DidYouMean) NormalizeWord(word string) (string, Language) {
+	lang := DetectLanguage(word)
+	return dym.normalize(word, lang), lang
+}
+
+// ValidateWord detects the language of a word, normalizes it and checks the
+// normalized word as AddWordsReport and lookups do: it is rejected when empty,
+// longer than the maximum word length, or with characters the validation
+// mode does not accept for the language. The reason is empty for valid words.
+func (dym *DidYouMean) ValidateWord(word string) (Language, bool, RejectReason) {
+	normalized, lang := dym.NormalizeWord(word)
+	switch {
+	case strings.TrimSpace(normalized) == "":
+		return lang, false, RejectEmpty
+	case dym.isWordTooLong(normalized):
+		return lang, false, RejectTooLong
+	case !dym.isValidWord(normalized, lang):
+		return lang, false, RejectInvalidCharacters
+	}
+	return lang, true, ""
+}
+
+// isJoinerAt reports whether runes[i] is one of joiners with a letter or
+// combining mark on both sides
+func isJoinerAt(runes []rune, i int, joiners string) bool {
+	if i == 0 || i == len(runes)-1 || !strings.ContainsRune(joiners, runes[i]) {
+		return false
+	}
+	before, after := runes[i-1], runes[i+1]
+	return (unicode.IsLetter(before) || unicode.IsMark(before)) && unicode.IsLetter(after)
+}
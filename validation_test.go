@@ -0,0 +1,111 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestExtendedCharacters tests that extended characters pass strict validation
+func TestExtendedCharacters(t *testing.T) {
+	valid := []struct {
+		word string
+		lang dymean.Language
+	}{
+		{"آب", dymean.Persian},
+		{"پائیز", dymean.Persian},
+		{"جزء", dymean.Persian},
+		{"مدرسة", dymean.Arabic},
+		{"œuvre", dymean.French},
+		{"cœur", dymean.French},
+	}
+	for _, tc := range valid {
+		if !dymean.IsValidWordForLanguage(tc.word, tc.lang) {
+			t.Errorf("Expected %q to be valid for %s", tc.word, tc.lang)
+		}
+	}
+	if dymean.IsValidWordForLanguage("œuvre", dymean.English) {
+		t.Error("Expected extended characters to be per language")
+	}
+}
+
+// TestValidationModes tests strict and lenient validation
+func TestValidationModes(t *testing.T) {
+	tests := []struct {
+		word    string
+		lang    dymean.Language
+		strict  bool
+		lenient bool
+	}{
+		{"hello", dymean.English, true, true},
+		{"naïve", dymean.English, false, true},
+		{"col·lecció", dymean.Spanish, false, true},
+		{"·hola", dymean.Spanish, false, false},
+		{"hola·", dymean.Spanish, false, false},
+		{"ğüzel", dymean.German, false, true},
+		{"hello123", dymean.English, false, false},
+		{"hello!", dymean.English, false, false},
+		{"привет", dymean.English, false, false},
+		{"ٱلله", dymean.Arabic, false, true},
+		{"hello", dymean.Russian, false, false},
+	}
+	for _, tc := range tests {
+		if got := dymean.IsValidWordForLanguageMode(tc.word, tc.lang, dymean.ValidationStrict); got != tc.strict {
+			t.Errorf("Strict validation of %q for %s: expected %v, got %v", tc.word, tc.lang, tc.strict, got)
+		}
+		if got := dymean.IsValidWordForLanguageMode(tc.word, tc.lang, dymean.ValidationLenient); got != tc.lenient {
+			t.Errorf("Lenient validation of %q for %s: expected %v, got %v", tc.word, tc.lang, tc.lenient, got)
+		}
+	}
+}
+
+// TestWithValidationMode tests that the instance validates words with its mode
+func TestWithValidationMode(t *testing.T) {
+	words := []string{"café", "naïve", "hello"}
+
+	strict := dymean.NewDidYouMean(10000, 7)
+	if report := strict.AddWordsReport(words, dymean.English); report.Added != 1 {
+		t.Errorf("Expected 1 word added in strict mode, got %d", report.Added)
+	}
+
+	lenient := dymean.NewDidYouMean(10000, 7, dymean.WithValidationMode(dymean.ValidationLenient))
+	if report := lenient.AddWordsReport(words, dymean.English); report.Added != 3 {
+		t.Errorf("Expected 3 words added in lenient mode, got %d", report.Added)
+	}
+	if !lenient.IsCorrect("café") {
+		t.Error("Expected café to be in the dictionary")
+	}
+	if suggestions := lenient.GetSuggestions("caféé", 5, 2); len(suggestions) == 0 || suggestions[0].Word != "café" {
+		t.Errorf("Expected café to be suggested, got %v", suggestions)
+	}
+}
+
+// TestNormalizeAndValidateWord tests detection, normalization and validation in one call
+func TestNormalizeAndValidateWord(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7, dymean.WithMaxWordLength(10))
+
+	if normalized, lang := dym.NormalizeWord("  Hello "); normalized != "hello" || lang != dymean.English {
+		t.Errorf("NormalizeWord = %q, %s, expected hello, en", normalized, lang)
+	}
+	if normalized, lang := dym.NormalizeWord("كتاب"); normalized != "کتاب" || lang != dymean.Persian {
+		t.Errorf("NormalizeWord = %q, %s, expected the Persian kaf, fa", normalized, lang)
+	}
+
+	tests := []struct {
+		word   string
+		lang   dymean.Language
+		valid  bool
+		reason dymean.RejectReason
+	}{
+		{"Hello", dymean.English, true, ""},
+		{"سلام", dymean.Persian, true, ""},
+		{"   ", dymean.English, false, dymean.RejectEmpty},
+		{"supercalifragilistic", dymean.English, false, dymean.RejectTooLong},
+		{"he!!o", dymean.English, false, dymean.RejectInvalidCharacters},
+	}
+	for _, tc := range tests {
+		lang, valid, reason := dym.ValidateWord(tc.word)
+		if lang != tc.lang || valid != tc.valid || reason != tc.reason {
+			t.Errorf("ValidateWord(%q) = %s, %v, %q, expected %s, %v, %q", tc.word, lang, valid, reason, tc.lang, tc.valid, tc.reason)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package dymean
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// vietnameseVowels lists each Vietnamese vowel in its six tones: ngang
+// (none), huyền, sắc, hỏi, ngã and nặng
+var vietnameseVowels = []string{
+	"aàáảãạ", "ăằắẳẵặ", "âầấẩẫậ", "eèéẻẽẹ", "êềếểễệ", "iìíỉĩị",
+	"oòóỏõọ", "ôồốổỗộ", "ơờớởỡợ", "uùúủũụ", "ưừứửữự", "yỳýỷỹỵ",
+}
+
+// vietnameseModified maps the letters with a modifier (breve, circumflex,
+// horn or stroke) to their plain letter
+var vietnameseModified = map[rune]rune{'ă': 'a', 'â': 'a', 'ê': 'e', 'ô': 'o', 'ơ': 'o', 'ư': 'u', 'đ': 'd'}
+
+// Combining marks of decomposed Vietnamese text, by tone and by modifier
+var (
+	vietnameseCombiningTones = map[rune]int{'\u0300': 1, '\u0301': 2, '\u0309': 3, '\u0303': 4, '\u0323': 5}
+	vietnameseCombiningMods  = map[[2]rune]rune{
+		{'a', '\u0306'}: 'ă', {'a', '\u0302'}: 'â', {'e', '\u0302'}: 'ê', {'o', '\u0302'}: 'ô',
+		{'o', '\u031B'}: 'ơ', {'u', '\u031B'}: 'ư',
+	}
+)
+
+// Tone keys of the Telex and VNI input methods, by tone
+var (
+	telexTones = map[rune]int{'f': 1, 's': 2, 'r': 3, 'x': 4, 'j': 5, 'z': 0}
+	vniTones   = map[rune]int{'2': 1, '1': 2, '3': 3, '4': 4, '5': 5, '0': 0}
+)
+
+// omittedMarkCost is the VietnameseMarkDistance of a diacritic left out, far
+// below the cost of a wrong one
+const omittedMarkCost = 0.1
+
+// vietnameseTones maps each toned vowel to its toneless vowel and tone
+var vietnameseTones = make(map[rune]vietnameseLetter)
+
+// vietnameseLetter is a vowel split into its toneless form and tone
+type vietnameseLetter struct {
+	vowel rune
+	tone  int
+}
+
+func init() {
+	for _, series := range vietnameseVowels {
+		runes := []rune(series)
+		for tone, r := range runes {
+			vietnameseTones[r] = vietnameseLetter{vowel: runes[0], tone: tone}
+		}
+	}
+}
+
+// splitVietnamese returns the toneless form and tone of a letter
+func splitVietnamese(r rune) (rune, int) {
+	if letter, ok := vietnameseTones[r]; ok {
+		return letter.vowel, letter.tone
+	}
+	return r, 0
+}
+
+// toneVietnamese returns a toneless vowel with a tone, or r if it takes none
+func toneVietnamese(r rune, tone int) rune {
+	for _, series := range vietnameseVowels {
+		runes := []rune(series)
+		if runes[0] == r {
+			return runes[tone]
+		}
+	}
+	return r
+}
+
+// plainVietnamese returns a toneless letter without its modifier
+func plainVietnamese(r rune) rune {
+	if plain, ok := vietnameseModified[r]; ok {
+		return plain
+	}
+	return r
+}
+
+// normalizeVietnamese lowercases a word and composes the combining tone and
+// modifier marks of decomposed text
+func normalizeVietnamese(word string) string {
+	runes := []rune(strings.ToLower(strings.TrimSpace(word)))
+	composed := make([]rune, 0, len(runes))
+	for _, r := range runes {
+		if len(composed) > 0 {
+			last := composed[len(composed)-1]
+			if modified, ok := vietnameseCombiningMods[[2]rune{last, r}]; ok {
+				composed[len(composed)-1] = modified
+				continue
+			}
+			if tone, ok := vietnameseCombiningTones[r]; ok {
+				if vowel, current := splitVietnamese(last); current == 0 && toneVietnamese(vowel, tone) != vowel {
+					composed[len(composed)-1] = toneVietnamese(vowel, tone)
+					continue
+				}
+			}
+		}
+		composed = append(composed, r)
+	}
+	return string(composed)
+}
+
+// VietnameseMarkDistance compares a word typed with missing or partial
+// diacritics to a word of the same letters: each modifier (ă, â, ê, ô, ơ, ư,
+// đ) and the tone count omittedMarkCost when the typed word leaves them out,
+// and 1 when it has another one. The tone is compared per word, wherever it
+// was placed ("hoà", "hòa"). Words of different letters are as far as their
+// LevenshteinDistance.
+func VietnameseMarkDistance(typed, word string) float64 {
+	a, b := []rune(typed), []rune(word)
+	if len(a) != len(b) {
+		return float64(LevenshteinDistance(typed, word))
+	}
+
+	distance := 0.0
+	typedTone, wordTone := 0, 0
+	for i := range a {
+		x, xTone := splitVietnamese(a[i])
+		y, yTone := splitVietnamese(b[i])
+		typedTone, wordTone = max(typedTone, xTone), max(wordTone, yTone)
+		switch {
+		case x == y:
+		case plainVietnamese(x) != plainVietnamese(y):
+			return float64(LevenshteinDistance(typed, word))
+		case x == plainVietnamese(x):
+			distance += omittedMarkCost
+		default:
+			distance++
+		}
+	}
+	switch {
+	case typedTone == wordTone:
+	case typedTone == 0:
+		distance += omittedMarkCost
+	default:
+		distance++
+	}
+	return distance
+}
+
+// vietnameseSimilarity scores a dictionary word spelled like a typed word
+// up to diacritics, from 1 when the typed marks all fit it
+func vietnameseSimilarity(typed, word string) float64 {
+	return math.Max(0, 1-VietnameseMarkDistance(typed, word)/float64(utf8.RuneCountInString(word)+1))
+}
+
+// DecodeTelex converts a word typed with the Telex input method to
+// Vietnamese: "aa", "ee", "oo" and "dd" double letters, "w" adds a breve or
+// horn ("uow" is "ươ"), and a final "s", "f", "r", "x" or "j" sets the tone,
+// so "truowngf" is "trường". Words without Telex keys are returned unchanged.
+func DecodeTelex(word string) string {
+	runes := []rune(word)
+	letters := make([]rune, 0, len(runes))
+	tone := -1
+	for i, r := range runes {
+		previous := rune(0)
+		if len(letters) > 0 {
+			previous = letters[len(letters)-1]
+		}
+		if t, ok := telexTones[r]; ok && i > 0 && hasVietnameseVowel(letters) && (r != 'r' && r != 's' && r != 'x' || isVietnameseVowel(previous) || i == len(runes)-1) {
+			tone = t
+			continue
+		}
+		switch {
+		case r == 'd' && previous == 'd':
+			letters[len(letters)-1] = 'đ'
+		case r == previous && (r == 'a' || r == 'e' || r == 'o'):
+			letters[len(letters)-1] = map[rune]rune{'a': 'â', 'e': 'ê', 'o': 'ô'}[r]
+		case r == 'w':
+			letters = addHorn(letters)
+		default:
+			letters = append(letters, r)
+		}
+	}
+	return placeVietnameseTone(letters, tone)
+}
+
+// DecodeVNI converts a word typed with the VNI input method to Vietnamese:
+// digits after a letter add a circumflex (6), horn (7), breve (8) or stroke
+// (9), and 1 to 5 set the tone, so "truong72" is "trường". Words without VNI
+// digits are returned unchanged.
+func DecodeVNI(word string) string {
+	letters := make([]rune, 0, len(word))
+	tone := -1
+	for _, r := range word {
+		if t, ok := vniTones[r]; ok && hasVietnameseVowel(letters) {
+			tone = t
+			continue
+		}
+		switch r {
+		case '6':
+			letters = modifyLast(letters, map[rune]rune{'a': 'â', 'e': 'ê', 'o': 'ô'})
+		case '7':
+			letters = addHorn(letters)
+		case '8':
+			letters = modifyLast(letters, map[rune]rune{'a': 'ă'})
+		case '9':
+			letters = modifyLast(letters, map[rune]rune{'d': 'đ'})
+		default:
+			letters = append(letters, r)
+		}
+	}
+	return placeVietnameseTone(letters, tone)
+}
+
+// modifyLast replaces the last letter found in modified
+func modifyLast(letters []rune, modified map[rune]rune) []rune {
+	for i := len(letters) - 1; i >= 0; i-- {
+		if r, ok := modified[letters[i]]; ok {
+			letters[i] = r
+			break
+		}
+	}
+	return letters
+}
+
+// addHorn adds a horn or breve to the last vowel that takes one, both vowels
+// of "uo", or appends "ư" when there is none
+func addHorn(letters []rune) []rune {
+	for i := len(letters) - 1; i >= 0; i-- {
+		switch letters[i] {
+		case 'o':
+			letters[i] = 'ơ'
+			if i > 0 && letters[i-1] == 'u' {
+				letters[i-1] = 'ư'
+			}
+			return letters
+		case 'u':
+			letters[i] = 'ư'
+			return letters
+		case 'a':
+			letters[i] = 'ă'
+			return letters
+		}
+	}
+	return append(letters, 'ư')
+}
+
+// isVietnameseVowel checks if a toneless letter is a vowel
+func isVietnameseVowel(r rune) bool {
+	_, ok := vietnameseTones[r]
+	return ok
+}
+
+// hasVietnameseVowel checks if letters hold a vowel
+func hasVietnameseVowel(letters []rune) bool {
+	for _, r := range letters {
+		if isVietnameseVowel(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// placeVietnameseTone puts a tone on the vowel that carries it: the last
+// vowel with a modifier, else the last vowel of the nucleus before a final
+// consonant, else the first vowel of a final pair ("mùa") or the middle one
+// of three. A negative tone leaves the letters as they are.
+func placeVietnameseTone(letters []rune, tone int) string {
+	if tone < 0 {
+		return string(letters)
+	}
+	start, end := -1, -1 // Vowel nucleus, skipping the "u" of "qu" and "i" of "gi"
+	for i, r := range letters {
+		if !isVietnameseVowel(r) {
+			if start >= 0 {
+				break
+			}
+			continue
+		}
+		if start < 0 && i > 0 && ((r == 'u' && letters[i-1] == 'q') || (r == 'i' && letters[i-1] == 'g' && i+1 < len(letters) && isVietnameseVowel(letters[i+1]))) {
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+		end = i + 1
+	}
+	if start < 0 {
+		return string(letters)
+	}
+
+	target := -1
+	for i := start; i < end; i++ {
+		if plainVietnamese(letters[i]) != letters[i] {
+			target = i
+		}
+	}
+	switch {
+	case target >= 0:
+	case end < len(letters):
+		target = end - 1
+	case end-start == 3:
+		target = start + 1
+	default:
+		target = start
+	}
+	vowel, _ := splitVietnamese(letters[target])
+	letters[target] = toneVietnamese(vowel, tone)
+	return string(letters)
+}
+
+// decodeVietnameseTyping decodes a word typed with Telex or VNI keys when it
+// is not a correct word as typed
+func (dym *DidYouMean) decodeVietnameseTyping(normalized string) string {
+	if dym.IsCorrectForLanguage(normalized, Vietnamese) {
+		return normalized
+	}
+	decoded := DecodeTelex(normalized)
+	if strings.ContainsAny(normalized, "0123456789") {
+		decoded = DecodeVNI(normalized)
+	}
+	if decoded != normalized && dym.isValidWord(decoded, Vietnamese) {
+		return decoded
+	}
+	return normalized
+}
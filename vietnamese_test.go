@@ -0,0 +1,86 @@
+package dymean_test
+
+import (
+	"github.com/bi0dread/dymean"
+	"testing"
+)
+
+// TestVietnameseDiacriticRestoration tests that words typed without
+// diacritics get their diacriticized dictionary forms, best fitting first
+func TestVietnameseDiacriticRestoration(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWordsForLanguage([]string{"trường", "trưởng", "tiếng", "việt", "người"}, dymean.Vietnamese)
+
+	suggestions := dym.GetSuggestionsForLanguage("truong", 5, 1, dymean.Vietnamese)
+	found := make(map[string]bool)
+	for _, suggestion := range suggestions {
+		found[suggestion.Word] = true
+	}
+	if !found["trường"] || !found["trưởng"] {
+		t.Errorf("Expected trường and trưởng for truong, got %v", suggestions)
+	}
+
+	// The marks typed pick among the forms
+	tests := map[string]string{"trưởng": "trưởng", "trương": "trường", "tieng": "tiếng", "viet": "việt", "nguoi": "người"}
+	for typed, expected := range tests {
+		suggestions := dym.GetSuggestionsForLanguage(typed, 5, 1, dymean.Vietnamese)
+		if len(suggestions) == 0 || suggestions[0].Word != expected {
+			t.Errorf("Expected %q first for %q, got %v", expected, typed, suggestions)
+		}
+	}
+}
+
+// TestVietnameseMarkDistance tests that omitted marks cost less than wrong ones
+func TestVietnameseMarkDistance(t *testing.T) {
+	if d := dymean.VietnameseMarkDistance("trường", "trường"); d != 0 {
+		t.Errorf("Expected 0 for the same word, got %v", d)
+	}
+	omitted := dymean.VietnameseMarkDistance("truong", "trường")
+	wrong := dymean.VietnameseMarkDistance("trưởng", "trường")
+	if omitted >= wrong {
+		t.Errorf("Expected omitted marks (%v) to cost less than a wrong tone (%v)", omitted, wrong)
+	}
+	if d := dymean.VietnameseMarkDistance("hoà", "hòa"); d != 0 {
+		t.Errorf("Expected the tone to be compared per word, got %v", d)
+	}
+}
+
+// TestVietnameseInputMethods tests Telex and VNI decoding
+func TestVietnameseInputMethods(t *testing.T) {
+	telex := map[string]string{
+		"truowngf": "trường", "tieengs": "tiếng", "vieetj": "việt", "nguwowif": "người",
+		"ddaats": "đất", "muaf": "mùa", "hoaf": "hòa", "quar": "quả",
+	}
+	for typed, expected := range telex {
+		if decoded := dymean.DecodeTelex(typed); decoded != expected {
+			t.Errorf("DecodeTelex(%q) = %q, want %q", typed, decoded, expected)
+		}
+	}
+	vni := map[string]string{"truong72": "trường", "tie6ng1": "tiếng", "d9a6t1": "đất"}
+	for typed, expected := range vni {
+		if decoded := dymean.DecodeVNI(typed); decoded != expected {
+			t.Errorf("DecodeVNI(%q) = %q, want %q", typed, decoded, expected)
+		}
+	}
+
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWordsForLanguage([]string{"trường", "tiếng"}, dymean.Vietnamese)
+	for _, typed := range []string{"truowngf", "truong72"} {
+		suggestions := dym.GetSuggestionsForLanguage(typed, 5, 1, dymean.Vietnamese)
+		if len(suggestions) == 0 || suggestions[0].Word != "trường" {
+			t.Errorf("Expected trường for %q, got %v", typed, suggestions)
+		}
+	}
+}
+
+// TestVietnameseCombiningMarks tests that decomposed text matches composed words
+func TestVietnameseCombiningMarks(t *testing.T) {
+	dym := dymean.NewDidYouMean(10000, 7)
+	dym.AddWordsForLanguage([]string{"trường", "Việt"}, dymean.Vietnamese)
+
+	for _, word := range []string{"tru\u031Bo\u031B\u0300ng", "vie\u0302\u0323t", "VIE\u0302\u0323T"} {
+		if !dym.IsCorrectForLanguage(word, dymean.Vietnamese) {
+			t.Errorf("Expected %q to be correct", word)
+		}
+	}
+}